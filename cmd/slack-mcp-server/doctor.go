@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// doctorTimeout bounds all Slack API checks of a doctor run
+const doctorTimeout = 30 * time.Second
+
+const (
+	doctorPass = "PASS"
+	doctorFail = "FAIL"
+	doctorSkip = "SKIP"
+)
+
+type doctorCheck struct {
+	Name   string
+	Status string
+	Detail string
+}
+
+// doctor collects the results of a one-shot diagnostic run, see -doctor
+type doctor struct {
+	checks []doctorCheck
+}
+
+func (d *doctor) add(name, status, detail string) {
+	d.checks = append(d.checks, doctorCheck{Name: name, Status: status, Detail: detail})
+}
+
+func (d *doctor) failed() bool {
+	for _, c := range d.checks {
+		if c.Status == doctorFail {
+			return true
+		}
+	}
+	return false
+}
+
+// report prints one line per check followed by a summary
+func (d *doctor) report(w io.Writer) {
+	counts := make(map[string]int)
+	for _, c := range d.checks {
+		counts[c.Status]++
+		fmt.Fprintf(w, "[%s] %-18s %s\n", c.Status, c.Name, c.Detail)
+	}
+	fmt.Fprintf(w, "\n%d passed, %d failed, %d skipped\n", counts[doctorPass], counts[doctorFail], counts[doctorSkip])
+}
+
+// runDoctor validates configuration, credentials, Slack API access and cache files without
+// starting the server, prints the report to w and returns the process exit code
+func runDoctor(ctx context.Context, w io.Writer) int {
+	d := &doctor{}
+
+	checkDoctorConfig(d)
+	tokenOK := checkDoctorToken(d)
+	checkDoctorCacheFiles(d)
+
+	switch {
+	case !tokenOK:
+		for _, name := range []string{"auth.test", "conversations.list", "users.list"} {
+			d.add(name, doctorSkip, "no valid credentials")
+		}
+	case provider.IsDemoMode():
+		for _, name := range []string{"auth.test", "conversations.list", "users.list"} {
+			d.add(name, doctorSkip, "demo credentials")
+		}
+	default:
+		ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+		defer cancel()
+		checkDoctorSlackAPI(ctx, d)
+	}
+
+	d.report(w)
+	if d.failed() {
+		return 1
+	}
+	return 0
+}
+
+func checkDoctorConfig(d *doctor) {
+	config, err := loadServerConfig()
+	if err == nil {
+		err = validateServerConfig(config)
+	}
+	if err == nil {
+		err = server.ValidateAddMessageTool(os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL"))
+	}
+	if err == nil {
+		if err = server.ValidateReadChannels(os.Getenv("SLACK_MCP_READ_CHANNELS")); err != nil {
+			err = fmt.Errorf("invalid SLACK_MCP_READ_CHANNELS: %w", err)
+		}
+	}
+	if err == nil {
+		if err = server.ValidateDefaultChannel(os.Getenv("SLACK_MCP_DEFAULT_CHANNEL")); err != nil {
+			err = fmt.Errorf("invalid SLACK_MCP_DEFAULT_CHANNEL: %w", err)
+		}
+	}
+	if err == nil {
+		if err = server.ValidateAppToken(os.Getenv("SLACK_MCP_APP_TOKEN")); err != nil {
+			err = fmt.Errorf("invalid SLACK_MCP_APP_TOKEN: %w", err)
+		}
+	}
+	if err == nil {
+		_, err = server.ParseEnabledTools(os.Getenv("SLACK_MCP_ENABLED_TOOLS"))
+	}
+	if err != nil {
+		d.add("config", doctorFail, err.Error())
+		return
+	}
+	d.add("config", doctorPass, fmt.Sprintf("port %s, rate limit %s, burst %d", config.Port, config.RateLimit, config.RateLimitBurst))
+}
+
+// checkDoctorToken reports which credentials are configured and whether their prefixes look right
+func checkDoctorToken(d *doctor) bool {
+	if provider.IsDemoMode() {
+		d.add("token", doctorPass, "demo credentials")
+		return true
+	}
+
+	if xoxp := os.Getenv("SLACK_MCP_XOXP_TOKEN"); xoxp != "" {
+		switch {
+		case strings.HasPrefix(xoxp, "xoxp-"):
+			d.add("token", doctorPass, "user OAuth token (xoxp)")
+		case strings.HasPrefix(xoxp, "xoxb-"):
+			d.add("token", doctorPass, "bot token (xoxb), user-only tools will be unavailable")
+		default:
+			d.add("token", doctorFail, "SLACK_MCP_XOXP_TOKEN must start with xoxp- or xoxb-")
+			return false
+		}
+		return true
+	}
+
+	xoxc, xoxd := os.Getenv("SLACK_MCP_XOXC_TOKEN"), os.Getenv("SLACK_MCP_XOXD_TOKEN")
+	switch {
+	case xoxc == "" && xoxd == "":
+		d.add("token", doctorFail, "set SLACK_MCP_XOXP_TOKEN or both SLACK_MCP_XOXC_TOKEN and SLACK_MCP_XOXD_TOKEN")
+		return false
+	case xoxc == "" || xoxd == "":
+		d.add("token", doctorFail, "SLACK_MCP_XOXC_TOKEN and SLACK_MCP_XOXD_TOKEN must be set together")
+		return false
+	case !strings.HasPrefix(xoxc, "xoxc-"):
+		d.add("token", doctorFail, "SLACK_MCP_XOXC_TOKEN must start with xoxc-")
+		return false
+	case !strings.HasPrefix(xoxd, "xoxd-"):
+		d.add("token", doctorFail, "SLACK_MCP_XOXD_TOKEN must start with xoxd-")
+		return false
+	}
+	d.add("token", doctorPass, "browser session tokens (xoxc/xoxd)")
+	return true
+}
+
+// checkDoctorCacheFiles verifies the directory of every cache file accepts new files
+func checkDoctorCacheFiles(d *doctor) {
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, file := range provider.CacheFiles() {
+		dir := filepath.Dir(file)
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+
+		f, err := os.CreateTemp(dir, ".slack-mcp-doctor-*")
+		if err != nil {
+			d.add("cache_dir", doctorFail, fmt.Sprintf("%s is not writable: %v", dir, err))
+			return
+		}
+		f.Close()
+		os.Remove(f.Name())
+		dirs = append(dirs, dir)
+	}
+	d.add("cache_dir", doctorPass, "writable: "+strings.Join(dirs, ", "))
+}
+
+// checkDoctorSlackAPI runs auth.test and requests a single page of channels and users
+func checkDoctorSlackAPI(ctx context.Context, d *doctor) {
+	authProvider, err := provider.AuthFromEnv()
+	if err == nil {
+		var client *provider.MCPSlackClient
+		client, err = provider.NewMCPSlackClient(authProvider, nil, nil, zap.NewNop())
+		if err == nil {
+			resp := client.AuthResponse()
+			d.add("auth.test", doctorPass, fmt.Sprintf("team %s (%s), user %s (%s)", resp.Team, resp.TeamID, resp.User, resp.UserID))
+
+			_, _, err = client.GetConversationsContext(ctx, &slack.GetConversationsParameters{
+				Types:           []string{provider.PubChanType},
+				Limit:           1,
+				ExcludeArchived: true,
+			})
+			addDoctorResult(d, "conversations.list", err)
+
+			_, err = client.Raw().Slack.GetUsersPaginated(slack.GetUsersOptionLimit(1)).Next(ctx)
+			addDoctorResult(d, "users.list", err)
+			return
+		}
+	}
+
+	d.add("auth.test", doctorFail, err.Error())
+	d.add("conversations.list", doctorSkip, "auth.test failed")
+	d.add("users.list", doctorSkip, "auth.test failed")
+}
+
+func addDoctorResult(d *doctor, name string, err error) {
+	if err != nil {
+		d.add(name, doctorFail, err.Error())
+		return
+	}
+	d.add(name, doctorPass, "ok")
+}
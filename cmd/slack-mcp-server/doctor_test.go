@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDoctorWithoutCredentials(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("SLACK_MCP_XOXP_TOKEN", "")
+	t.Setenv("SLACK_MCP_XOXC_TOKEN", "")
+	t.Setenv("SLACK_MCP_XOXD_TOKEN", "")
+	t.Setenv("SLACK_MCP_USERS_CACHE", filepath.Join(dir, "users.json"))
+	t.Setenv("SLACK_MCP_CHANNELS_CACHE", filepath.Join(dir, "channels.json"))
+	t.Setenv("SLACK_MCP_EMOJI_CACHE", filepath.Join(dir, "emoji.json"))
+
+	var out bytes.Buffer
+	if code := runDoctor(context.Background(), &out); code != 1 {
+		t.Errorf("Expected exit code 1 without credentials, got %d", code)
+	}
+
+	report := out.String()
+	for _, want := range []string{
+		"[PASS] config",
+		"[FAIL] token",
+		"[PASS] cache_dir",
+		"[SKIP] auth.test",
+		"[SKIP] users.list",
+		"2 passed, 1 failed, 3 skipped",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("Expected cache dir check to clean up, found %d files", len(entries))
+	}
+}
+
+func TestDoctorTokenChecks(t *testing.T) {
+	tests := []struct {
+		name string
+		xoxp string
+		xoxc string
+		xoxd string
+		ok   bool
+	}{
+		{"user token", "xoxp-1", "", "", true},
+		{"bot token", "xoxb-1", "", "", true},
+		{"unknown prefix", "xapp-1", "", "", false},
+		{"session tokens", "", "xoxc-1", "xoxd-1", true},
+		{"missing cookie", "", "xoxc-1", "", false},
+		{"swapped session tokens", "", "xoxd-1", "xoxc-1", false},
+		{"demo", "demo", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SLACK_MCP_XOXP_TOKEN", tt.xoxp)
+			t.Setenv("SLACK_MCP_XOXC_TOKEN", tt.xoxc)
+			t.Setenv("SLACK_MCP_XOXD_TOKEN", tt.xoxd)
+
+			d := &doctor{}
+			if got := checkDoctorToken(d); got != tt.ok {
+				t.Errorf("Expected %v, got %v (%s)", tt.ok, got, d.checks[0].Detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"go.uber.org/zap"
+)
+
+// shutdownTimeout bounds how long open requests may take to finish once a shutdown signal is received
+const shutdownTimeout = 20 * time.Second
+
+// shutdownSignals delivers SIGINT and SIGTERM
+func shutdownSignals() <-chan os.Signal {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	return sig
+}
+
+// drainOnSignal moves the lifecycle to draining on the first signal from sig and calls shutdown, if any, to
+// finish open requests within shutdownTimeout
+func drainOnSignal(sig <-chan os.Signal, lifecycle *provider.Lifecycle, shutdown func(ctx context.Context) error, logger *zap.Logger) {
+	received := <-sig
+	lifecycle.Transition(provider.PhaseDraining, "received "+received.String())
+	if shutdown == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := shutdown(ctx); err != nil {
+		logger.Error("Shutdown failed",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+}
+
+// serveAndDrain runs serve until a signal from sig drains it with shutdown. serve returns as soon as shutdown
+// stops accepting connections, so serveAndDrain waits for shutdown to finish the open requests before it returns.
+func serveAndDrain(serve func() error, shutdown func(ctx context.Context) error, sig <-chan os.Signal, lifecycle *provider.Lifecycle, logger *zap.Logger) error {
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		drainOnSignal(sig, lifecycle, shutdown, logger)
+	}()
+
+	if err := serve(); err != nil {
+		return err
+	}
+	<-drained
+	return nil
+}
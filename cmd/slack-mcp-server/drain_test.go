@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"go.uber.org/zap"
+)
+
+func TestServeAndDrainWaitsForOpenRequests(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	entered, release := make(chan struct{}), make(chan struct{})
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-release
+		_, _ = io.WriteString(w, "done")
+	})}
+	// like EnhancedSSEServer.Start, a closed server is not an error
+	serve := func() error {
+		if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+
+	sig := make(chan os.Signal, 1)
+	lifecycle := provider.NewLifecycle(zap.NewNop())
+	served := make(chan error, 1)
+	go func() { served <- serveAndDrain(serve, srv.Shutdown, sig, lifecycle, zap.NewNop()) }()
+
+	type result struct {
+		body string
+		err  error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			responses <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		responses <- result{body: string(body), err: err}
+	}()
+
+	select {
+	case <-entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the request to reach the handler")
+	}
+	sig <- syscall.SIGTERM
+
+	select {
+	case err := <-served:
+		t.Fatalf("Expected serveAndDrain to wait for the open request, returned %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if phase, _ := lifecycle.Phase(); phase != provider.PhaseDraining {
+		t.Errorf("Expected the lifecycle to be draining, got %s", phase)
+	}
+
+	close(release)
+	res := <-responses
+	if res.err != nil || res.body != "done" {
+		t.Errorf("Expected the open request to complete during the drain, got %q (%v)", res.body, res.err)
+	}
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected serveAndDrain to return once the drain finished")
+	}
+}
@@ -5,13 +5,17 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
 	"github.com/korotovsky/slack-mcp-server/pkg/server"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/middleware"
+	"github.com/korotovsky/slack-mcp-server/pkg/transport"
 	"github.com/mattn/go-isatty"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -20,28 +24,43 @@ import (
 var defaultSseHost = "127.0.0.1"
 var defaultSsePort = 13080
 
-// ServerConfig holds all server configuration from environment variables
+// ServerConfig holds all server configuration from environment variables, -print-config writes it as JSON
 type ServerConfig struct {
 	// Network configuration
-	Host    string
-	Port    string
-	BaseURL string
+	Host    string `json:"host"`
+	Port    string `json:"port"`
+	BaseURL string `json:"base_url"`
 
 	// Railway-specific configuration
-	RailwayEnvironment string
-	RailwayPort        string
+	RailwayEnvironment string `json:"railway_environment"`
+	RailwayPort        string `json:"railway_port"`
 
 	// Security configuration
-	CORSOrigins     []string
-	RateLimit       time.Duration
-	SecurityHeaders bool
-	HealthEnabled   bool
-	PrivateNetwork  bool
+	CORSOrigins     []string      `json:"cors_origins"`
+	RateLimit       time.Duration `json:"rate_limit_interval"`
+	RateLimitBurst  int           `json:"rate_limit_burst"`
+	SecurityHeaders bool          `json:"security_headers"`
+	HealthEnabled   bool          `json:"health_enabled"`
+	PrivateNetwork  bool          `json:"private_network"`
+
+	// Health check timeouts of /health and /ready
+	HealthTimeout    time.Duration `json:"health_timeout"`
+	ReadinessTimeout time.Duration `json:"readiness_timeout"`
+	// DegradedLatency is the auth.test latency above which /ready reports Slack as degraded
+	DegradedLatency time.Duration `json:"degraded_latency"`
+
+	// ProxyURL is the proxy of outgoing Slack requests with its password redacted, empty for direct connections
+	ProxyURL string `json:"proxy_url"`
 
 	// Logging configuration
-	LogLevel  string
-	LogFormat string
-	LogColor  bool
+	LogLevel              string `json:"log_level"`
+	LogFormat             string `json:"log_format"`
+	LogColor              bool   `json:"log_color"`
+	LogSamplingInitial    int    `json:"log_sampling_initial"`
+	LogSamplingThereafter int    `json:"log_sampling_thereafter"`
+
+	// Cache configuration
+	UsersRefreshInterval time.Duration `json:"users_refresh_interval"`
 }
 
 // loadServerConfig loads and validates server configuration from environment variables
@@ -90,21 +109,18 @@ func loadServerConfig() (*ServerConfig, error) {
 	}
 
 	// Rate limiting configuration
-	rateLimitStr := os.Getenv("SLACK_MCP_RATE_LIMIT")
-	if rateLimitStr == "" {
-		config.RateLimit = time.Minute // Default: 60 requests per minute
-	} else {
-		rateLimitInt, err := strconv.Atoi(rateLimitStr)
-		if err != nil || rateLimitInt < 0 {
-			return nil, fmt.Errorf("invalid SLACK_MCP_RATE_LIMIT value '%s': must be a non-negative integer", rateLimitStr)
-		}
-
-		// Handle special case: 0 means no rate limiting
-		if rateLimitInt == 0 {
-			config.RateLimit = 0 // Disabled
-		} else {
-			config.RateLimit = time.Minute / time.Duration(rateLimitInt)
-		}
+	rateLimit, err := middleware.RateLimitInterval(os.Getenv("SLACK_MCP_RATE_LIMIT"))
+	if err != nil {
+		return nil, err
+	}
+	config.RateLimit = rateLimit
+	rateLimitBurst, err := middleware.RateLimitBurst(os.Getenv("SLACK_MCP_RATE_LIMIT_BURST"))
+	if err != nil {
+		return nil, err
+	}
+	config.RateLimitBurst = rateLimitBurst
+	if _, err := middleware.TrustedProxies(os.Getenv("SLACK_MCP_TRUSTED_PROXIES")); err != nil {
+		return nil, err
 	}
 
 	// Security headers configuration
@@ -115,6 +131,25 @@ func loadServerConfig() (*ServerConfig, error) {
 	healthEnabledStr := os.Getenv("SLACK_MCP_HEALTH_ENABLED")
 	config.HealthEnabled = healthEnabledStr == "" || healthEnabledStr == "true" || healthEnabledStr == "1"
 
+	healthTimeout, readinessTimeout, err := server.ParseHealthTimeouts()
+	if err != nil {
+		return nil, err
+	}
+	config.HealthTimeout = healthTimeout
+	config.ReadinessTimeout = readinessTimeout
+
+	config.DegradedLatency, err = server.ParseDegradedLatency()
+	if err != nil {
+		return nil, err
+	}
+
+	// Fail before connecting to Slack rather than on the first request
+	proxyURL, err := transport.ParseProxyURL()
+	if err != nil {
+		return nil, err
+	}
+	config.ProxyURL = transport.RedactProxyURL(proxyURL)
+
 	// Private network deployment detection
 	privateNetworkStr := os.Getenv("SLACK_MCP_PRIVATE_NETWORK")
 	config.PrivateNetwork = privateNetworkStr == "true" || privateNetworkStr == "1" ||
@@ -126,9 +161,49 @@ func loadServerConfig() (*ServerConfig, error) {
 	logColorStr := os.Getenv("SLACK_MCP_LOG_COLOR")
 	config.LogColor = logColorStr == "true" || logColorStr == "1"
 
+	// Log sampling configuration in format "initial/thereafter", disabled by default
+	logSamplingStr := os.Getenv("SLACK_MCP_LOG_SAMPLING")
+	if logSamplingStr != "" && logSamplingStr != "0" && logSamplingStr != "false" {
+		initial, thereafter, err := parseLogSampling(logSamplingStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SLACK_MCP_LOG_SAMPLING value '%s': %w", logSamplingStr, err)
+		}
+		config.LogSamplingInitial = initial
+		config.LogSamplingThereafter = thereafter
+	}
+
+	// Scheduled users cache refresh, disabled by default
+	if usersRefreshStr := os.Getenv("SLACK_MCP_USERS_REFRESH_INTERVAL"); usersRefreshStr != "" {
+		interval, err := time.ParseDuration(usersRefreshStr)
+		if err != nil || interval < 0 {
+			return nil, fmt.Errorf("invalid SLACK_MCP_USERS_REFRESH_INTERVAL value '%s': must be a non-negative duration, e.g. '1h'", usersRefreshStr)
+		}
+		config.UsersRefreshInterval = interval
+	}
+
 	return config, nil
 }
 
+// parseLogSampling parses sampling counts in format "initial/thereafter"
+func parseLogSampling(value string) (int, int, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("must be in format 'initial/thereafter', e.g. '100/100'")
+	}
+
+	initial, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || initial <= 0 {
+		return 0, 0, fmt.Errorf("initial count must be a positive integer")
+	}
+
+	thereafter, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil || thereafter < 0 {
+		return 0, 0, fmt.Errorf("thereafter count must be a non-negative integer")
+	}
+
+	return initial, thereafter, nil
+}
+
 // validateServerConfig validates the server configuration
 func validateServerConfig(config *ServerConfig) error {
 	// Validate port
@@ -153,10 +228,21 @@ func validateServerConfig(config *ServerConfig) error {
 
 func main() {
 	var transport string
+	var doctorMode bool
+	var printConfig bool
 	flag.StringVar(&transport, "t", "stdio", "Transport type (stdio or sse)")
 	flag.StringVar(&transport, "transport", "stdio", "Transport type (stdio or sse)")
+	flag.BoolVar(&doctorMode, "doctor", false, "Check configuration, credentials and Slack API access, print a report and exit without starting the server")
+	flag.BoolVar(&printConfig, "print-config", false, "Print the configuration resolved from the environment as JSON with secrets masked and exit without starting the server")
 	flag.Parse()
 
+	if doctorMode {
+		os.Exit(runDoctor(context.Background(), os.Stdout))
+	}
+	if printConfig {
+		os.Exit(runPrintConfig(os.Stdout))
+	}
+
 	// Load and validate server configuration
 	config, err := loadServerConfig()
 	if err != nil {
@@ -184,12 +270,20 @@ func main() {
 		zap.String("railway_environment", config.RailwayEnvironment),
 		zap.Strings("cors_origins", config.CORSOrigins),
 		zap.Duration("rate_limit_interval", config.RateLimit),
+		zap.Int("rate_limit_burst", config.RateLimitBurst),
 		zap.Bool("security_headers", config.SecurityHeaders),
 		zap.Bool("health_enabled", config.HealthEnabled),
+		zap.Duration("health_timeout", config.HealthTimeout),
+		zap.Duration("readiness_timeout", config.ReadinessTimeout),
+		zap.Duration("degraded_latency", config.DegradedLatency),
+		zap.String("proxy_url", config.ProxyURL),
 		zap.Bool("private_network", config.PrivateNetwork),
+		zap.Int("log_sampling_initial", config.LogSamplingInitial),
+		zap.Int("log_sampling_thereafter", config.LogSamplingThereafter),
+		zap.Duration("users_refresh_interval", config.UsersRefreshInterval),
 	)
 
-	err = validateToolConfig(os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL"))
+	err = server.ValidateAddMessageTool(os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL"))
 	if err != nil {
 		logger.Fatal("error in SLACK_MCP_ADD_MESSAGE_TOOL",
 			zap.String("context", "console"),
@@ -197,24 +291,85 @@ func main() {
 		)
 	}
 
+	if err := server.ValidateReadChannels(os.Getenv("SLACK_MCP_READ_CHANNELS")); err != nil {
+		logger.Fatal("error in SLACK_MCP_READ_CHANNELS",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
+	if err := server.ValidateDefaultChannel(os.Getenv("SLACK_MCP_DEFAULT_CHANNEL")); err != nil {
+		logger.Fatal("error in SLACK_MCP_DEFAULT_CHANNEL",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
+	appToken := strings.TrimSpace(os.Getenv("SLACK_MCP_APP_TOKEN"))
+	if err := server.ValidateAppToken(appToken); err != nil {
+		logger.Fatal("error in SLACK_MCP_APP_TOKEN",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
+	if _, err := server.ParseEnabledTools(os.Getenv("SLACK_MCP_ENABLED_TOOLS")); err != nil {
+		logger.Fatal("error in SLACK_MCP_ENABLED_TOOLS",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
 	p := provider.New(transport, logger)
 	s := server.NewMCPServer(p, logger)
+	if p.WebhookEnabled() && appToken == "" {
+		logger.Warn("SLACK_MCP_WEBHOOK_URL is set without SLACK_MCP_APP_TOKEN, only messages posted by this server are forwarded",
+			zap.String("context", "console"),
+		)
+	}
+	go reloadOnSignal(s, logger)
+
+	// the watchers and Socket Mode stop once the server has drained its open requests
+	ctx, stopWatchers := context.WithCancel(context.Background())
+	defer stopWatchers()
 
 	go func() {
 		var once sync.Once
+		supervisor := newWatcherSupervisor(logger)
+
+		// clicks on interactive messages and webhook events arrive while the caches still warm up
+		if appToken != "" {
+			go supervisor.Run(ctx, "socket_mode", func() error {
+				return p.ListenInteractions(ctx, appToken)
+			})
+		}
+
+		p.Lifecycle().Transition(provider.PhaseCacheWarming, "loading users and channels caches")
+		warmup := newBootWarmup(supervisor, provider.ParseBootRetry(logger), config.HealthEnabled, p.Lifecycle(), logger)
+		warmup.Run(ctx, "users", newUsersWatcher(ctx, p, &once, logger))
+		warmup.Run(ctx, "channels", newChannelsWatcher(ctx, p, &once, logger))
+		supervisor.Run(ctx, "emoji", newEmojiWatcher(ctx, p, logger))
 
-		newUsersWatcher(p, &once, logger)()
-		newChannelsWatcher(p, &once, logger)()
+		if config.UsersRefreshInterval > 0 {
+			supervisor.Run(ctx, "users_refresh", newUsersMergeRefresher(ctx, p, config.UsersRefreshInterval, logger))
+		}
 	}()
 
 	switch transport {
 	case "stdio":
+		// the stdio server stops on the same signals itself
+		go drainOnSignal(shutdownSignals(), p.Lifecycle(), func(context.Context) error {
+			stopWatchers()
+			return nil
+		}, logger)
 		if err := s.ServeStdio(); err != nil {
 			logger.Fatal("Server error",
 				zap.String("context", "console"),
 				zap.Error(err),
 			)
 		}
+		stopWatchers()
+		stopped(p.Lifecycle(), "stdio server stopped")
 	case "sse":
 		// Determine bind address for dual-stack or IPv4-only
 		var bindAddr string
@@ -247,7 +402,7 @@ func main() {
 				zap.String("host", displayHost),
 				zap.String("port", config.Port),
 				zap.String("bind_address", bindAddr),
-				zap.String("server_url", fmt.Sprintf("http://%s:%s/sse", displayHost, config.Port)),
+				zap.String("server_url", fmt.Sprintf("http://%s:%s%s/sse", displayHost, config.Port, server.SSEPathPrefix())),
 			)
 		}
 
@@ -256,6 +411,7 @@ func main() {
 			zap.String("context", "console"),
 			zap.Strings("cors_origins", config.CORSOrigins),
 			zap.Duration("rate_limit_interval", config.RateLimit),
+			zap.Int("rate_limit_burst", config.RateLimitBurst),
 			zap.Bool("security_headers_enabled", config.SecurityHeaders),
 			zap.Bool("health_checks_enabled", config.HealthEnabled),
 			zap.Bool("private_network_mode", config.PrivateNetwork),
@@ -267,12 +423,19 @@ func main() {
 			)
 		}
 
-		if err := sseServer.Start(bindAddr); err != nil {
+		serve := func() error { return sseServer.Start(bindAddr) }
+		shutdown := func(ctx context.Context) error {
+			defer stopWatchers()
+			return sseServer.Shutdown(ctx)
+		}
+		if err := serveAndDrain(serve, shutdown, shutdownSignals(), p.Lifecycle(), logger); err != nil {
 			logger.Fatal("Server error",
 				zap.String("context", "console"),
 				zap.Error(err),
 			)
 		}
+		stopWatchers()
+		stopped(p.Lifecycle(), "HTTP server stopped")
 	default:
 		logger.Fatal("Invalid transport type",
 			zap.String("context", "console"),
@@ -282,25 +445,32 @@ func main() {
 	}
 }
 
-func newUsersWatcher(p *provider.ApiProvider, once *sync.Once, logger *zap.Logger) func() {
-	return func() {
-		logger.Info("Caching users collection...",
+// reloadOnSignal reloads the settings of SLACK_MCP_RELOAD_FILE on every SIGHUP, Reload logs the outcome
+func reloadOnSignal(s *server.MCPServer, logger *zap.Logger) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for range hup {
+		logger.Info("Received SIGHUP, reloading configuration",
 			zap.String("context", "console"),
 		)
+		_, _ = s.Reload()
+	}
+}
 
-		if os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo") {
-			logger.Info("Demo credentials are set, skip",
-				zap.String("context", "console"),
-			)
-			return
-		}
+// stopped records the end of serving, the transport returns without a signal too, e.g. when stdin is closed
+func stopped(lifecycle *provider.Lifecycle, reason string) {
+	lifecycle.Transition(provider.PhaseDraining, reason)
+	lifecycle.Transition(provider.PhaseStopped, reason)
+}
 
-		err := p.RefreshUsers(context.Background())
-		if err != nil {
-			logger.Fatal("Error booting provider",
-				zap.String("context", "console"),
-				zap.Error(err),
-			)
+func newUsersWatcher(ctx context.Context, p *provider.ApiProvider, once *sync.Once, logger *zap.Logger) func() error {
+	return func() error {
+		logger.Info("Caching users collection...",
+			zap.String("context", "console"),
+		)
+
+		if err := p.RefreshUsers(ctx); err != nil {
+			return fmt.Errorf("caching users: %w", err)
 		}
 
 		ready, _ := p.IsReady()
@@ -309,30 +479,22 @@ func newUsersWatcher(p *provider.ApiProvider, once *sync.Once, logger *zap.Logge
 				logger.Info("Slack MCP Server is fully ready",
 					zap.String("context", "console"),
 				)
+				p.Lifecycle().Transition(provider.PhaseReady, "users and channels caches loaded")
 			})
 		}
+		return nil
 	}
 }
 
-func newChannelsWatcher(p *provider.ApiProvider, once *sync.Once, logger *zap.Logger) func() {
-	return func() {
+// newChannelsWatcher loads the channels cache, the server lists the channels as resources once it is loaded
+func newChannelsWatcher(ctx context.Context, p *provider.ApiProvider, once *sync.Once, logger *zap.Logger) func() error {
+	return func() error {
 		logger.Info("Caching channels collection...",
 			zap.String("context", "console"),
 		)
 
-		if os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo") {
-			logger.Info("Demo credentials are set, skip.",
-				zap.String("context", "console"),
-			)
-			return
-		}
-
-		err := p.RefreshChannels(context.Background())
-		if err != nil {
-			logger.Fatal("Error booting provider",
-				zap.String("context", "console"),
-				zap.Error(err),
-			)
+		if err := p.RefreshChannels(ctx); err != nil {
+			return fmt.Errorf("caching channels: %w", err)
 		}
 
 		ready, _ := p.IsReady()
@@ -341,37 +503,71 @@ func newChannelsWatcher(p *provider.ApiProvider, once *sync.Once, logger *zap.Lo
 				logger.Info("Slack MCP Server is fully ready.",
 					zap.String("context", "console"),
 				)
+				p.Lifecycle().Transition(provider.PhaseReady, "users and channels caches loaded")
 			})
 		}
+		return nil
 	}
 }
 
-func validateToolConfig(config string) error {
-	if config == "" || config == "true" || config == "1" {
+func newEmojiWatcher(ctx context.Context, p *provider.ApiProvider, logger *zap.Logger) func() error {
+	return func() error {
+		logger.Info("Caching emoji collection...",
+			zap.String("context", "console"),
+		)
+
+		// emoji are optional, emoji_list retries on demand when this fails
+		if err := p.RefreshEmoji(ctx); err != nil {
+			logger.Warn("Failed to cache emoji collection",
+				zap.String("context", "console"),
+				zap.Error(err),
+			)
+		}
 		return nil
 	}
+}
 
-	items := strings.Split(config, ",")
-	hasNegated := false
-	hasPositive := false
-
-	for _, item := range items {
-		item = strings.TrimSpace(item)
-		if item == "" {
-			continue
+// newUsersMergeRefresher refreshes the users cache every interval until ctx is done
+func newUsersMergeRefresher(ctx context.Context, p *provider.ApiProvider, interval time.Duration, logger *zap.Logger) func() error {
+	return func() error {
+		if provider.IsDemoMode() {
+			return nil
 		}
-		if strings.HasPrefix(item, "!") {
-			hasNegated = true
-		} else {
-			hasPositive = true
-		}
-	}
 
-	if hasNegated && hasPositive {
-		return fmt.Errorf("cannot mix allowed and disallowed (! prefixed) channels")
-	}
+		logger.Info("Scheduling users cache refresh",
+			zap.String("context", "console"),
+			zap.Duration("interval", interval),
+		)
 
-	return nil
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			startTime := time.Now()
+			if err := p.RefreshUsersMerge(ctx); err != nil {
+				logger.Error("Scheduled users cache refresh failed",
+					zap.String("context", "console"),
+					zap.Error(err),
+				)
+				continue
+			}
+
+			stats := p.CacheStats()
+			logger.Info("Scheduled users cache refresh finished",
+				zap.String("context", "console"),
+				zap.String("mode", stats.UsersRefreshMode),
+				zap.Int("changed", stats.UsersLastDeltaSize),
+				zap.Int("users", stats.Users),
+				zap.Duration("duration", time.Since(startTime)),
+			)
+		}
+	}
 }
 
 func newLogger(transport string, config *ServerConfig) (*zap.Logger, error) {
@@ -383,7 +579,7 @@ func newLogger(transport string, config *ServerConfig) (*zap.Logger, error) {
 	}
 
 	useJSON := shouldUseJSONFormat(config)
-	useColors := shouldUseColors(config) && !useJSON
+	useColors := shouldUseColors(config)
 
 	outputPath := "stdout"
 	if transport == "stdio" {
@@ -431,6 +627,14 @@ func newLogger(transport string, config *ServerConfig) (*zap.Logger, error) {
 		}
 	}
 
+	// Throttle repeated identical messages, e.g. per-request debug logs under load
+	if config.LogSamplingInitial > 0 {
+		zapConfig.Sampling = &zap.SamplingConfig{
+			Initial:    config.LogSamplingInitial,
+			Thereafter: config.LogSamplingThereafter,
+		}
+	}
+
 	logger, err := zapConfig.Build(zap.AddCaller())
 	if err != nil {
 		return nil, err
@@ -441,7 +645,13 @@ func newLogger(transport string, config *ServerConfig) (*zap.Logger, error) {
 	return logger, err
 }
 
-// shouldUseJSONFormat determines if JSON format should be used
+// isStdoutTerminal reports whether stdout is a TTY, tests replace it to simulate both environments
+var isStdoutTerminal = func() bool {
+	return isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// shouldUseJSONFormat determines if JSON format should be used, an explicit
+// SLACK_MCP_LOG_FORMAT always wins over environment and TTY detection
 func shouldUseJSONFormat(config *ServerConfig) bool {
 	if config.LogFormat != "" {
 		return strings.ToLower(config.LogFormat) == "json"
@@ -467,14 +677,21 @@ func shouldUseJSONFormat(config *ServerConfig) bool {
 		return true
 	}
 
-	if !isatty.IsTerminal(os.Stdout.Fd()) {
+	if !isStdoutTerminal() {
 		return true
 	}
 
 	return false
 }
 
+// shouldUseColors determines if console level names are colored. JSON output never uses colors,
+// then SLACK_MCP_LOG_COLOR, NO_COLOR and FORCE_COLOR apply in this order. Otherwise colors are
+// off on Railway and when stdout is not a TTY, e.g. console format forced in a container.
 func shouldUseColors(config *ServerConfig) bool {
+	if shouldUseJSONFormat(config) {
+		return false
+	}
+
 	if config.LogColor {
 		return true
 	}
@@ -492,11 +709,7 @@ func shouldUseColors(config *ServerConfig) bool {
 		return false
 	}
 
-	if env := os.Getenv("ENVIRONMENT"); env == "development" || env == "dev" {
-		return isatty.IsTerminal(os.Stdout.Fd())
-	}
-
-	return isatty.IsTerminal(os.Stdout.Fd())
+	return isStdoutTerminal()
 }
 
 func getConsoleLevelEncoder(useColors bool) zapcore.LevelEncoder {
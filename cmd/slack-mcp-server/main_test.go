@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"net"
 	"os"
 	"strings"
@@ -253,6 +254,117 @@ func TestLoadServerConfig(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "custom rate limit burst",
+			envVars: map[string]string{
+				"SLACK_MCP_RATE_LIMIT_BURST": "5",
+			},
+			expectError: false,
+			validate: func(t *testing.T, config *ServerConfig) {
+				if config.RateLimitBurst != 5 {
+					t.Errorf("Expected rate limit burst 5, got %d", config.RateLimitBurst)
+				}
+			},
+		},
+		{
+			name: "invalid rate limit burst",
+			envVars: map[string]string{
+				"SLACK_MCP_RATE_LIMIT_BURST": "0",
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid trusted proxy",
+			envVars: map[string]string{
+				"SLACK_MCP_TRUSTED_PROXIES": "10.0.0.0/8, proxy.internal",
+			},
+			expectError: true,
+		},
+		{
+			name:        "log sampling disabled by default",
+			envVars:     map[string]string{},
+			expectError: false,
+			validate: func(t *testing.T, config *ServerConfig) {
+				if config.LogSamplingInitial != 0 || config.LogSamplingThereafter != 0 {
+					t.Errorf("Expected log sampling disabled, got %d/%d", config.LogSamplingInitial, config.LogSamplingThereafter)
+				}
+			},
+		},
+		{
+			name: "custom log sampling",
+			envVars: map[string]string{
+				"SLACK_MCP_LOG_SAMPLING": "100/10",
+			},
+			expectError: false,
+			validate: func(t *testing.T, config *ServerConfig) {
+				if config.LogSamplingInitial != 100 || config.LogSamplingThereafter != 10 {
+					t.Errorf("Expected log sampling 100/10, got %d/%d", config.LogSamplingInitial, config.LogSamplingThereafter)
+				}
+			},
+		},
+		{
+			name: "invalid log sampling",
+			envVars: map[string]string{
+				"SLACK_MCP_LOG_SAMPLING": "100",
+			},
+			expectError: true,
+		},
+		{
+			name: "users refresh interval",
+			envVars: map[string]string{
+				"SLACK_MCP_USERS_REFRESH_INTERVAL": "30m",
+			},
+			expectError: false,
+			validate: func(t *testing.T, config *ServerConfig) {
+				if config.UsersRefreshInterval != 30*time.Minute {
+					t.Errorf("Expected users refresh interval 30m, got %v", config.UsersRefreshInterval)
+				}
+			},
+		},
+		{
+			name: "invalid users refresh interval",
+			envVars: map[string]string{
+				"SLACK_MCP_USERS_REFRESH_INTERVAL": "hourly",
+			},
+			expectError: true,
+		},
+		{
+			name:        "default health timeouts",
+			envVars:     map[string]string{},
+			expectError: false,
+			validate: func(t *testing.T, config *ServerConfig) {
+				if config.HealthTimeout != 10*time.Second || config.ReadinessTimeout != 15*time.Second {
+					t.Errorf("Expected health timeouts 10s/15s, got %v/%v", config.HealthTimeout, config.ReadinessTimeout)
+				}
+			},
+		},
+		{
+			name: "custom health timeouts",
+			envVars: map[string]string{
+				"SLACK_MCP_HEALTH_TIMEOUT":    "30s",
+				"SLACK_MCP_READINESS_TIMEOUT": "1m",
+			},
+			expectError: false,
+			validate: func(t *testing.T, config *ServerConfig) {
+				if config.HealthTimeout != 30*time.Second || config.ReadinessTimeout != time.Minute {
+					t.Errorf("Expected health timeouts 30s/1m, got %v/%v", config.HealthTimeout, config.ReadinessTimeout)
+				}
+			},
+		},
+		{
+			name: "invalid health timeout",
+			envVars: map[string]string{
+				"SLACK_MCP_HEALTH_TIMEOUT": "ten seconds",
+			},
+			expectError: true,
+		},
+		{
+			name: "non-positive readiness timeout",
+			envVars: map[string]string{
+				"SLACK_MCP_READINESS_TIMEOUT": "0s",
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -260,8 +372,10 @@ func TestLoadServerConfig(t *testing.T) {
 			// Clear all relevant environment variables
 			envVarsToClean := []string{
 				"PORT", "RAILWAY_ENVIRONMENT", "SLACK_MCP_HOST", "SLACK_MCP_PORT",
-				"SLACK_MCP_BASE_URL", "SLACK_MCP_CORS_ORIGINS", "SLACK_MCP_RATE_LIMIT",
-				"SLACK_MCP_SECURITY_HEADERS", "SLACK_MCP_HEALTH_ENABLED", "SLACK_MCP_PRIVATE_NETWORK",
+				"SLACK_MCP_BASE_URL", "SLACK_MCP_CORS_ORIGINS", "SLACK_MCP_RATE_LIMIT", "SLACK_MCP_RATE_LIMIT_BURST",
+				"SLACK_MCP_TRUSTED_PROXIES", "SLACK_MCP_SECURITY_HEADERS", "SLACK_MCP_HEALTH_ENABLED", "SLACK_MCP_PRIVATE_NETWORK",
+				"SLACK_MCP_LOG_SAMPLING", "SLACK_MCP_USERS_REFRESH_INTERVAL",
+				"SLACK_MCP_HEALTH_TIMEOUT", "SLACK_MCP_READINESS_TIMEOUT",
 			}
 			for _, envVar := range envVarsToClean {
 				os.Unsetenv(envVar)
@@ -501,4 +615,67 @@ func TestNetworkBindingFallback(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestLogFormatAndColorPrecedence(t *testing.T) {
+	tests := []struct {
+		format    string
+		tty       bool
+		colorFlag string
+		wantJSON  bool
+		wantColor bool
+	}{
+		{"", true, "none", false, true},
+		{"", true, "SLACK_MCP_LOG_COLOR", false, true},
+		{"", true, "NO_COLOR", false, false},
+		{"", true, "FORCE_COLOR", false, true},
+		{"", false, "none", true, false},
+		{"", false, "SLACK_MCP_LOG_COLOR", true, false},
+		{"", false, "NO_COLOR", true, false},
+		{"", false, "FORCE_COLOR", true, false},
+		{"json", true, "none", true, false},
+		{"json", true, "SLACK_MCP_LOG_COLOR", true, false},
+		{"json", true, "NO_COLOR", true, false},
+		{"json", true, "FORCE_COLOR", true, false},
+		{"json", false, "none", true, false},
+		{"json", false, "SLACK_MCP_LOG_COLOR", true, false},
+		{"json", false, "NO_COLOR", true, false},
+		{"json", false, "FORCE_COLOR", true, false},
+		{"console", true, "none", false, true},
+		{"console", true, "SLACK_MCP_LOG_COLOR", false, true},
+		{"console", true, "NO_COLOR", false, false},
+		{"console", true, "FORCE_COLOR", false, true},
+		{"console", false, "none", false, false},
+		{"console", false, "SLACK_MCP_LOG_COLOR", false, true},
+		{"console", false, "NO_COLOR", false, false},
+		{"console", false, "FORCE_COLOR", false, true},
+	}
+
+	origIsStdoutTerminal := isStdoutTerminal
+	defer func() { isStdoutTerminal = origIsStdoutTerminal }()
+
+	for _, tt := range tests {
+		name := fmt.Sprintf("format=%q/tty=%v/%s", tt.format, tt.tty, tt.colorFlag)
+		t.Run(name, func(t *testing.T) {
+			for _, env := range []string{"ENVIRONMENT", "KUBERNETES_SERVICE_HOST", "DOCKER_CONTAINER", "container", "NO_COLOR", "FORCE_COLOR"} {
+				t.Setenv(env, "")
+			}
+			tty := tt.tty
+			isStdoutTerminal = func() bool { return tty }
+
+			config := &ServerConfig{LogFormat: tt.format}
+			switch tt.colorFlag {
+			case "SLACK_MCP_LOG_COLOR":
+				config.LogColor = true
+			case "NO_COLOR", "FORCE_COLOR":
+				t.Setenv(tt.colorFlag, "1")
+			}
+
+			if got := shouldUseJSONFormat(config); got != tt.wantJSON {
+				t.Errorf("shouldUseJSONFormat() = %v, want %v", got, tt.wantJSON)
+			}
+			if got := shouldUseColors(config); got != tt.wantColor {
+				t.Errorf("shouldUseColors() = %v, want %v", got, tt.wantColor)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server"
+	"go.uber.org/zap"
+)
+
+// secretEnvVars are printed by -print-config masked to a prefix and their length
+var secretEnvVars = []string{
+	"SLACK_MCP_XOXP_TOKEN",
+	"SLACK_MCP_XOXC_TOKEN",
+	"SLACK_MCP_XOXD_TOKEN",
+	"SLACK_MCP_APP_TOKEN",
+	"SLACK_MCP_SSE_API_KEY",
+	"SLACK_MCP_PROXY_PASSWORD",
+	"SLACK_MCP_WEBHOOK_SECRET",
+	"SLACK_MCP_WEBHOOK_URL",
+}
+
+// secretPrefixLen is the number of leading characters of a secret kept by maskSecret, enough for
+// token prefixes such as xoxp-
+const secretPrefixLen = 5
+
+// configSnapshot is the document written by -print-config
+type configSnapshot struct {
+	Server   *ServerConfig     `json:"server"`
+	Provider provider.Settings `json:"provider"`
+	Tools    toolsSnapshot     `json:"tools"`
+	// Secrets holds the configured secrets masked by maskSecret, keyed by environment variable
+	Secrets map[string]string `json:"secrets"`
+}
+
+type toolsSnapshot struct {
+	Enabled        []string `json:"enabled"`
+	ReadOnly       bool     `json:"read_only"`
+	AddMessageTool string   `json:"add_message_tool"`
+	DefaultChannel string   `json:"default_channel"`
+	DebugRaw       bool     `json:"debug_raw"`
+}
+
+// MarshalJSON writes the config with durations formatted as Go durations, e.g. 1m0s
+func (c ServerConfig) MarshalJSON() ([]byte, error) {
+	type plain ServerConfig
+	return json.Marshal(struct {
+		plain
+		RateLimit            string `json:"rate_limit_interval"`
+		HealthTimeout        string `json:"health_timeout"`
+		ReadinessTimeout     string `json:"readiness_timeout"`
+		DegradedLatency      string `json:"degraded_latency"`
+		UsersRefreshInterval string `json:"users_refresh_interval"`
+	}{
+		plain:                plain(c),
+		RateLimit:            c.RateLimit.String(),
+		HealthTimeout:        c.HealthTimeout.String(),
+		ReadinessTimeout:     c.ReadinessTimeout.String(),
+		DegradedLatency:      c.DegradedLatency.String(),
+		UsersRefreshInterval: c.UsersRefreshInterval.String(),
+	})
+}
+
+// runPrintConfig resolves the configuration from the environment like the server does at startup,
+// writes it to w as JSON with secrets masked and returns the process exit code. Warnings about
+// invalid values that fall back to defaults are logged to stderr.
+func runPrintConfig(w io.Writer) int {
+	config, err := loadServerConfig()
+	if err == nil {
+		err = validateServerConfig(config)
+	}
+	if err == nil {
+		err = server.ValidateAddMessageTool(os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL"))
+	}
+	if err == nil {
+		if err = server.ValidateReadChannels(os.Getenv("SLACK_MCP_READ_CHANNELS")); err != nil {
+			err = fmt.Errorf("invalid SLACK_MCP_READ_CHANNELS: %w", err)
+		}
+	}
+	if err == nil {
+		if err = server.ValidateDefaultChannel(os.Getenv("SLACK_MCP_DEFAULT_CHANNEL")); err != nil {
+			err = fmt.Errorf("invalid SLACK_MCP_DEFAULT_CHANNEL: %w", err)
+		}
+	}
+	if err == nil {
+		if err = server.ValidateAppToken(os.Getenv("SLACK_MCP_APP_TOKEN")); err != nil {
+			err = fmt.Errorf("invalid SLACK_MCP_APP_TOKEN: %w", err)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		return 1
+	}
+
+	logger, err := newLogger("stdio", config)
+	if err != nil {
+		logger = zap.NewNop()
+	}
+	defer logger.Sync()
+
+	enabled, err := server.ParseEnabledTools(os.Getenv("SLACK_MCP_ENABLED_TOOLS"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		return 1
+	}
+	settings, err := provider.ResolveSettings(logger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		return 1
+	}
+
+	snapshot := configSnapshot{
+		Server:   config,
+		Provider: settings,
+		Tools: toolsSnapshot{
+			Enabled:        make([]string, 0, len(server.ToolNames)),
+			ReadOnly:       server.IsReadOnlyEnabled(),
+			AddMessageTool: os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL"),
+			DefaultChannel: strings.TrimSpace(os.Getenv("SLACK_MCP_DEFAULT_CHANNEL")),
+			DebugRaw:       server.IsDebugRawEnabled(),
+		},
+		Secrets: make(map[string]string),
+	}
+	for _, name := range server.ToolNames {
+		if enabled == nil || enabled[name] {
+			snapshot.Tools.Enabled = append(snapshot.Tools.Enabled, name)
+		}
+	}
+	for _, name := range secretEnvVars {
+		if value := os.Getenv(name); value != "" {
+			snapshot.Secrets[name] = maskSecret(value)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(snapshot); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write configuration: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// maskSecret keeps the first characters of a secret and its length, e.g. xoxp-***(57 chars).
+// Short secrets are masked entirely so that the prefix never reveals most of them.
+func maskSecret(value string) string {
+	prefix := ""
+	if len(value) > 2*secretPrefixLen {
+		prefix = value[:secretPrefixLen]
+	}
+	return fmt.Sprintf("%s***(%d chars)", prefix, len(value))
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWatcherBackoff    = time.Second
+	defaultWatcherMaxBackoff = 5 * time.Minute
+)
+
+// watcherSupervisor keeps background watchers running: a watcher that fails or panics is
+// logged and started again after a backoff instead of taking down the server
+type watcherSupervisor struct {
+	backoff    time.Duration
+	maxBackoff time.Duration
+	logger     *zap.Logger
+}
+
+func newWatcherSupervisor(logger *zap.Logger) *watcherSupervisor {
+	return &watcherSupervisor{
+		backoff:    defaultWatcherBackoff,
+		maxBackoff: defaultWatcherMaxBackoff,
+		logger:     logger,
+	}
+}
+
+// Run calls fn until it returns nil or ctx is done. The backoff doubles with every failure up to
+// maxBackoff and starts over once a run lasted longer than maxBackoff, e.g. a long running refresh loop.
+func (s *watcherSupervisor) Run(ctx context.Context, name string, fn func() error) error {
+	return s.RunWithRetries(ctx, name, -1, fn)
+}
+
+// RunWithRetries is Run giving up once fn failed retries times after its first run, it then returns
+// the last error. A negative retries never gives up.
+func (s *watcherSupervisor) RunWithRetries(ctx context.Context, name string, retries int, fn func() error) error {
+	backoff := s.backoff
+	for attempt := 1; ; attempt++ {
+		started := time.Now()
+		err := s.runProtected(name, fn)
+		if err == nil {
+			return nil
+		}
+		// a watcher stopped by the shutdown is not a failure
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if retries >= 0 && attempt > retries {
+			return fmt.Errorf("%s failed %d times: %w", name, attempt, err)
+		}
+		if time.Since(started) >= s.maxBackoff {
+			backoff = s.backoff
+		}
+
+		s.logger.Error("Background watcher failed, restarting",
+			zap.String("context", "console"),
+			zap.String("watcher", name),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+	}
+}
+
+// runProtected turns a panic of fn into an error and logs it with the stack trace
+func (s *watcherSupervisor) runProtected(name string, fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("Background watcher panicked",
+				zap.String("context", "console"),
+				zap.String("watcher", name),
+				zap.Any("panic", r),
+				zap.ByteString("stack", debug.Stack()),
+			)
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn()
+}
+
+// bootWarmup runs the initial cache warmup with the bounded retries of SLACK_MCP_BOOT_MAX_RETRIES
+type bootWarmup struct {
+	supervisor *watcherSupervisor
+	retries    int
+	// keepRunning leaves the server up but not ready once retries are exhausted, probes of the
+	// health checks report it, otherwise the process exits
+	keepRunning bool
+	// lifecycle turns degraded when the server stays up without a collection
+	lifecycle *provider.Lifecycle
+	logger    *zap.Logger
+	fatal     func(msg string, fields ...zap.Field)
+}
+
+func newBootWarmup(supervisor *watcherSupervisor, retry provider.BootRetry, healthEnabled bool, lifecycle *provider.Lifecycle, logger *zap.Logger) *bootWarmup {
+	warmupSupervisor := *supervisor
+	warmupSupervisor.backoff = retry.Backoff
+	return &bootWarmup{
+		supervisor:  &warmupSupervisor,
+		retries:     retry.MaxRetries,
+		keepRunning: healthEnabled,
+		lifecycle:   lifecycle,
+		logger:      logger,
+		fatal:       logger.Fatal,
+	}
+}
+
+// Run blocks until fn succeeded or its retries are exhausted. A collection that keeps failing is then
+// retried in the background without a bound if the server stays up, so the other collections still load.
+func (w *bootWarmup) Run(ctx context.Context, name string, fn func() error) {
+	err := w.supervisor.RunWithRetries(ctx, name, w.retries, fn)
+	if err == nil || ctx.Err() != nil {
+		return
+	}
+
+	if !w.keepRunning {
+		w.fatal("Cache warmup failed, giving up",
+			zap.String("context", "console"),
+			zap.String("watcher", name),
+			zap.Int("max_retries", w.retries),
+			zap.Error(err),
+		)
+		return
+	}
+
+	w.logger.Error("Cache warmup failed, the server stays up but not ready and keeps retrying in the background",
+		zap.String("context", "console"),
+		zap.String("watcher", name),
+		zap.Int("max_retries", w.retries),
+		zap.Error(err),
+	)
+	w.lifecycle.Transition(provider.PhaseDegraded, "caching "+name+" failed after all boot retries")
+	go w.supervisor.Run(ctx, name, fn)
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newTestSupervisor() (*watcherSupervisor, *observer.ObservedLogs) {
+	core, logs := observer.New(zap.InfoLevel)
+	return &watcherSupervisor{
+		backoff:    time.Millisecond,
+		maxBackoff: 4 * time.Millisecond,
+		logger:     zap.New(core),
+	}, logs
+}
+
+func TestWatcherSupervisorRecoversFromPanic(t *testing.T) {
+	s, logs := newTestSupervisor()
+
+	calls := 0
+	err := s.Run(context.Background(), "users", func() error {
+		calls++
+		if calls == 1 {
+			var users map[string]string
+			users["U1"] = "alice" // assignment to a nil map panics like a bug in RefreshUsers would
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Expected the watcher to recover, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected the watcher to be restarted once, got %d calls", calls)
+	}
+
+	panics := logs.FilterMessage("Background watcher panicked").All()
+	if len(panics) != 1 {
+		t.Fatalf("Expected one panic to be logged, got %d", len(panics))
+	}
+	fields := panics[0].ContextMap()
+	if fields["watcher"] != "users" {
+		t.Errorf("Expected the watcher name to be logged, got %v", fields["watcher"])
+	}
+	if stack, _ := fields["stack"].(string); !strings.Contains(stack, "runProtected") {
+		t.Errorf("Expected the stack trace to be logged, got %q", stack)
+	}
+	if logs.FilterMessage("Background watcher failed, restarting").Len() != 1 {
+		t.Error("Expected the restart to be logged")
+	}
+}
+
+func TestWatcherSupervisorRetriesErrorsWithBackoff(t *testing.T) {
+	s, logs := newTestSupervisor()
+
+	calls := 0
+	err := s.Run(context.Background(), "channels", func() error {
+		calls++
+		if calls < 5 {
+			return errors.New("ratelimited")
+		}
+		return nil
+	})
+
+	if err != nil || calls != 5 {
+		t.Fatalf("Expected success after 5 calls, got %d calls (%v)", calls, err)
+	}
+
+	var backoffs []time.Duration
+	for _, entry := range logs.FilterMessage("Background watcher failed, restarting").All() {
+		backoffs = append(backoffs, entry.ContextMap()["backoff"].(time.Duration))
+	}
+	expected := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond}
+	if len(backoffs) != len(expected) {
+		t.Fatalf("Expected %d restarts, got %v", len(expected), backoffs)
+	}
+	for i := range expected {
+		if backoffs[i] != expected[i] {
+			t.Errorf("Expected backoff %v for restart %d, got %v", expected[i], i+1, backoffs[i])
+		}
+	}
+}
+
+func TestWatcherSupervisorStopsOnShutdown(t *testing.T) {
+	s, _ := newTestSupervisor()
+	s.backoff, s.maxBackoff = time.Hour, time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(ctx, "emoji", func() error { return errors.New("boom") })
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the supervisor to stop waiting when the context is cancelled")
+	}
+}
+
+func TestWatcherSupervisorStopsQuietlyWhenWatcherIsCancelled(t *testing.T) {
+	s, logs := newTestSupervisor()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := s.Run(ctx, "socket_mode", func() error {
+		cancel()
+		return ctx.Err()
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("Expected no failure to be logged, got %v", logs.All())
+	}
+}
+
+func TestWatcherSupervisorRunWithRetries(t *testing.T) {
+	s, _ := newTestSupervisor()
+
+	calls := 0
+	err := s.RunWithRetries(context.Background(), "users", 2, func() error {
+		calls++
+		return errors.New("connection reset")
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Fatalf("Expected the last error once retries are exhausted, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected the first run and 2 retries, got %d calls", calls)
+	}
+}
+
+func TestBootWarmupExhausted(t *testing.T) {
+	tests := []struct {
+		name          string
+		healthEnabled bool
+		wantFatal     bool
+	}{
+		{"health checks disabled", false, true},
+		{"health checks enabled", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, logs := newTestSupervisor()
+			lifecycle := provider.NewLifecycle(s.logger)
+			w := newBootWarmup(s, provider.BootRetry{MaxRetries: 1, Backoff: time.Millisecond}, tt.healthEnabled, lifecycle, s.logger)
+			fatal := false
+			w.fatal = func(msg string, fields ...zap.Field) { fatal = true }
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var calls atomic.Int32
+			w.Run(ctx, "users", func() error {
+				if calls.Add(1) > 3 {
+					return nil
+				}
+				return errors.New("connection reset")
+			})
+
+			if fatal != tt.wantFatal {
+				t.Errorf("Expected fatal %v, got %v", tt.wantFatal, fatal)
+			}
+			if tt.wantFatal {
+				return
+			}
+			if logs.FilterMessage("Cache warmup failed, the server stays up but not ready and keeps retrying in the background").Len() != 1 {
+				t.Error("Expected the not ready state to be logged")
+			}
+			if phase, _ := lifecycle.Phase(); phase != provider.PhaseDegraded {
+				t.Errorf("Expected the degraded phase, got %s", phase)
+			}
+			deadline := time.Now().Add(time.Second)
+			for calls.Load() < 4 && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			if calls.Load() < 4 {
+				t.Errorf("Expected the warmup to keep retrying in the background, got %d calls", calls.Load())
+			}
+		})
+	}
+}
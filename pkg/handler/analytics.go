@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultAnalyticsDays = 30
+	// maxAnalyticsDays bounds the date range of one conversations_analytics call
+	maxAnalyticsDays         = 90
+	defaultAnalyticsMessages = 5000
+	// maxAnalyticsMessages bounds the history pages fetched for one conversations_analytics call
+	maxAnalyticsMessages = 20000
+	// analyticsCacheTTL is how long a result is reused for the same channel, range and limit
+	analyticsCacheTTL = 5 * time.Minute
+	// analyticsCacheSize bounds the number of cached results
+	analyticsCacheSize = 100
+	// AnalyticsTotalDate is the date of the row totalling the whole range
+	AnalyticsTotalDate = "total"
+)
+
+// AnalyticsRow is a day of conversations_analytics, or the totals of the range when Date is AnalyticsTotalDate.
+// Complete is false when the message limit was reached before the start of the day was scanned.
+type AnalyticsRow struct {
+	Date        string `json:"date"`
+	Messages    int    `json:"messages"`
+	ActiveUsers int    `json:"activeUsers"`
+	Reactions   int    `json:"reactions"`
+	Threads     int    `json:"threads"`
+	Replies     int    `json:"replies"`
+	Complete    bool   `json:"complete"`
+}
+
+type analyticsParams struct {
+	channel string
+	start   time.Time
+	// end is the start of the day after the last day of the range
+	end   time.Time
+	limit int
+}
+
+// analyticsCache keeps recent conversations_analytics results, a nil cache caches nothing
+type analyticsCache struct {
+	mu      sync.Mutex
+	entries map[string]analyticsCacheEntry
+}
+
+type analyticsCacheEntry struct {
+	rows    []AnalyticsRow
+	expires time.Time
+}
+
+func newAnalyticsCache() *analyticsCache {
+	return &analyticsCache{entries: make(map[string]analyticsCacheEntry)}
+}
+
+func (c *analyticsCache) get(key string, now time.Time) ([]AnalyticsRow, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expires) {
+		return nil, false
+	}
+	return entry.rows, true
+}
+
+func (c *analyticsCache) put(key string, rows []AnalyticsRow, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= analyticsCacheSize {
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	if len(c.entries) >= analyticsCacheSize {
+		var oldest string
+		for k, entry := range c.entries {
+			if oldest == "" || entry.expires.Before(c.entries[oldest].expires) {
+				oldest = k
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[key] = analyticsCacheEntry{rows: rows, expires: now.Add(analyticsCacheTTL)}
+}
+
+// ConversationsAnalyticsHandler pages through the history of a channel over a date range and returns a row per
+// day with message, active user, reaction and thread counts, followed by the totals of the range. Results are
+// cached for a few minutes since scanning a busy channel takes many history pages.
+func (ch *ConversationsHandler) ConversationsAnalyticsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsAnalyticsHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolAnalytics(request, time.Now())
+	if err != nil {
+		logger.Error("Failed to parse analytics params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	key := strings.Join([]string{params.channel, params.start.Format(time.DateOnly), params.end.Format(time.DateOnly), strconv.Itoa(params.limit)}, "|")
+	if rows, ok := ch.analytics.get(key, time.Now()); ok {
+		logger.Debug("Serving cached analytics", zap.String("channel", params.channel))
+		return marshalCSVResult(rows)
+	}
+
+	var (
+		msgs         []slack.Message
+		limitReached bool
+	)
+	err = readChannel(ctx, ch.apiProvider.Slack(), logger, "conversations_analytics", params.channel, func() (err error) {
+		msgs, limitReached, err = ch.historyRange(ctx, params.channel, params.start, params.end, params.limit)
+		return err
+	})
+	if err != nil {
+		logger.Error("GetConversationHistoryContext failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+	logger.Debug("Fetched history for analytics", zap.Int("message_count", len(msgs)), zap.Bool("limit_reached", limitReached))
+
+	rows := analyticsRows(msgs, params.start, params.end, limitReached)
+	ch.analytics.put(key, rows, time.Now())
+	return marshalCSVResult(rows)
+}
+
+// analyticsRows buckets msgs by UTC day from start to end, days without messages included. Join, leave and
+// other activity messages are not counted. When limitReached, the days up to the oldest scanned message are
+// incomplete.
+func analyticsRows(msgs []slack.Message, start, end time.Time, limitReached bool) []AnalyticsRow {
+	type bucket struct {
+		row   AnalyticsRow
+		users map[string]bool
+	}
+
+	var buckets []*bucket
+	index := make(map[string]*bucket)
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		b := &bucket{row: AnalyticsRow{Date: day.Format(time.DateOnly), Complete: true}, users: make(map[string]bool)}
+		buckets = append(buckets, b)
+		index[b.row.Date] = b
+	}
+
+	total := &bucket{row: AnalyticsRow{Date: AnalyticsTotalDate, Complete: !limitReached}, users: make(map[string]bool)}
+	var oldest time.Time
+	for _, msg := range msgs {
+		ts, err := slackTimestampTime(msg.Timestamp)
+		if err != nil {
+			continue
+		}
+		if oldest.IsZero() || ts.Before(oldest) {
+			oldest = ts
+		}
+		if msg.SubType != "" && msg.SubType != "bot_message" {
+			continue
+		}
+		b, ok := index[ts.UTC().Format(time.DateOnly)]
+		if !ok {
+			continue
+		}
+
+		author := msg.User
+		if author == "" {
+			author = msg.BotID
+		}
+		for _, target := range []*bucket{b, total} {
+			target.row.Messages++
+			for _, reaction := range msg.Reactions {
+				target.row.Reactions += reaction.Count
+			}
+			if msg.ReplyCount > 0 {
+				target.row.Threads++
+				target.row.Replies += msg.ReplyCount
+			}
+			if author != "" {
+				target.users[author] = true
+			}
+		}
+	}
+
+	rows := make([]AnalyticsRow, 0, len(buckets)+1)
+	for _, b := range buckets {
+		b.row.ActiveUsers = len(b.users)
+		if day, _ := time.Parse(time.DateOnly, b.row.Date); limitReached && (oldest.IsZero() || day.Before(oldest)) {
+			b.row.Complete = false
+		}
+		rows = append(rows, b.row)
+	}
+	total.row.ActiveUsers = len(total.users)
+	return append(rows, total.row)
+}
+
+// slackTimestampTime parses a message timestamp such as 1751328000.000100
+func slackTimestampTime(ts string) (time.Time, error) {
+	seconds, _, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid message timestamp %q", ts)
+	}
+	return time.Unix(sec, 0).UTC(), nil
+}
+
+// parseParamsToolAnalytics reads the channel, checked against SLACK_MCP_READ_CHANNELS, the UTC date range, by default
+// the last 30 days including today, and the message limit
+func (ch *ConversationsHandler) parseParamsToolAnalytics(request mcp.CallToolRequest, now time.Time) (*analyticsParams, error) {
+	channel, err := ch.paramReadableChannel(request, "conversations_analytics")
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	last := today
+	if raw := strings.TrimSpace(request.GetString("end_date", "")); raw != "" {
+		if last, _, err = parseFlexibleDate(raw); err != nil {
+			return nil, fmt.Errorf("end_date must be a date such as 2025-07-01, got %q", raw)
+		}
+	}
+	first := last.AddDate(0, 0, -(defaultAnalyticsDays - 1))
+	if raw := strings.TrimSpace(request.GetString("start_date", "")); raw != "" {
+		if first, _, err = parseFlexibleDate(raw); err != nil {
+			return nil, fmt.Errorf("start_date must be a date such as 2025-06-01, got %q", raw)
+		}
+	}
+	if last.Before(first) {
+		return nil, fmt.Errorf("end_date %s is before start_date %s", last.Format(time.DateOnly), first.Format(time.DateOnly))
+	}
+	if days := int(last.Sub(first).Hours()/24) + 1; days > maxAnalyticsDays {
+		return nil, fmt.Errorf("the date range spans %d days, at most %d are allowed", days, maxAnalyticsDays)
+	}
+
+	limit := request.GetInt("limit", defaultAnalyticsMessages)
+	if limit <= 0 || limit > maxAnalyticsMessages {
+		return nil, fmt.Errorf("limit must be between 1 and %d, got %d", maxAnalyticsMessages, limit)
+	}
+
+	return &analyticsParams{
+		channel: channel,
+		start:   first,
+		end:     last.AddDate(0, 0, 1),
+		limit:   limit,
+	}, nil
+}
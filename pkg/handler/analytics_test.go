@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitAnalyticsRows(t *testing.T) {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 3)
+	msgs := []slack.Message{
+		{Msg: slack.Msg{Timestamp: "1751500800.000100", User: "U1", ReplyCount: 2, Reactions: []slack.ItemReaction{{Name: "tada", Count: 3}}}},
+		{Msg: slack.Msg{Timestamp: "1751414500.000100", User: "U2"}},
+		{Msg: slack.Msg{Timestamp: "1751414400.000100", User: "U1"}},
+		{Msg: slack.Msg{Timestamp: "1751414300.000100", User: "U3", SubType: "channel_join"}},
+	}
+
+	rows := analyticsRows(msgs, start, end, false)
+	require.Len(t, rows, 4)
+	assert.Equal(t, AnalyticsRow{Date: "2025-07-01", Complete: true}, rows[0])
+	assert.Equal(t, AnalyticsRow{Date: "2025-07-02", Messages: 2, ActiveUsers: 2, Complete: true}, rows[1])
+	assert.Equal(t, AnalyticsRow{Date: "2025-07-03", Messages: 1, ActiveUsers: 1, Reactions: 3, Threads: 1, Replies: 2, Complete: true}, rows[2])
+	assert.Equal(t, AnalyticsRow{Date: AnalyticsTotalDate, Messages: 3, ActiveUsers: 2, Reactions: 3, Threads: 1, Replies: 2, Complete: true}, rows[3])
+
+	rows = analyticsRows(msgs, start, end, true)
+	assert.False(t, rows[0].Complete, "the day of the oldest scanned message, a join, is incomplete")
+	assert.True(t, rows[1].Complete)
+	assert.True(t, rows[2].Complete)
+	assert.False(t, rows[3].Complete)
+}
+
+func TestUnitParseParamsToolAnalytics(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "")
+	ch := &ConversationsHandler{apiProvider: &provider.ApiProvider{}, logger: zap.NewNop()}
+	now := time.Date(2025, 7, 15, 18, 30, 0, 0, time.UTC)
+
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = args
+		return r
+	}
+
+	params, err := ch.parseParamsToolAnalytics(request(map[string]any{"channel_id": "C1"}), now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC), params.start, "30 days including today")
+	assert.Equal(t, time.Date(2025, 7, 16, 0, 0, 0, 0, time.UTC), params.end)
+	assert.Equal(t, defaultAnalyticsMessages, params.limit)
+
+	params, err = ch.parseParamsToolAnalytics(request(map[string]any{"channel_id": "C1", "start_date": "2025-07-01", "end_date": "2025-07-01"}), now)
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, params.end.Sub(params.start))
+
+	for _, args := range []map[string]any{
+		{"channel_id": "C1", "start_date": "2025-07-02", "end_date": "2025-07-01"},
+		{"channel_id": "C1", "start_date": "2025-01-01", "end_date": "2025-07-01"},
+		{"channel_id": "C1", "start_date": "yesterday"},
+		{"channel_id": "C1", "limit": 50000},
+	} {
+		_, err := ch.parseParamsToolAnalytics(request(args), now)
+		assert.Error(t, err, "%v", args)
+	}
+
+	t.Setenv("SLACK_MCP_READ_CHANNELS", "!C1")
+	_, err = ch.parseParamsToolAnalytics(request(map[string]any{"channel_id": "C1"}), now)
+	assert.Error(t, err, "denied channels are rejected")
+}
+
+func TestUnitAnalyticsCache(t *testing.T) {
+	now := time.Now()
+	cache := newAnalyticsCache()
+	rows := []AnalyticsRow{{Date: AnalyticsTotalDate, Messages: 1}}
+
+	cache.put("C1", rows, now)
+	got, ok := cache.get("C1", now.Add(time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, rows, got)
+
+	_, ok = cache.get("C1", now.Add(analyticsCacheTTL+time.Second))
+	assert.False(t, ok, "results expire")
+
+	var disabled *analyticsCache
+	disabled.put("C1", rows, now)
+	_, ok = disabled.get("C1", now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// channelJoiner is the part of the Slack API readChannel calls to join a channel
+type channelJoiner interface {
+	JoinConversationContext(ctx context.Context, channelID string) (*slack.Channel, string, []string, error)
+}
+
+// isAutoJoinEnabled returns true if SLACK_MCP_AUTO_JOIN is set to true or 1. Joining changes the channel
+// membership, so it is never done in read-only mode.
+func isAutoJoinEnabled() bool {
+	enabled := os.Getenv("SLACK_MCP_AUTO_JOIN")
+	return (enabled == "true" || enabled == "1") && !IsReadOnlyEnabled()
+}
+
+// readChannel runs read, a Slack call reading channel. When it fails with not_in_channel and SLACK_MCP_AUTO_JOIN
+// is enabled, channel is joined with conversations.join and read runs once more. Otherwise, or when the channel
+// can't be joined, the error says how to get access. Other errors are returned unchanged.
+func readChannel(ctx context.Context, api channelJoiner, logger *zap.Logger, tool, channel string, read func() error) error {
+	err := read()
+	if provider.SlackErrorCode(err) != "not_in_channel" {
+		return err
+	}
+
+	if !isAutoJoinEnabled() {
+		return notInChannelError(tool, channel, err, "invite the bot to the channel, or set SLACK_MCP_AUTO_JOIN=true to join public channels automatically")
+	}
+	if !isChannelAllowed(channel) {
+		logger.Warn("Auto-join is not allowed for channel by policy", zap.String("tool", tool), zap.String("channel", channel))
+		return notInChannelError(tool, channel, err, "joining it is not allowed by SLACK_MCP_ADD_MESSAGE_TOOL, invite the bot to the channel instead")
+	}
+
+	if _, _, _, joinErr := api.JoinConversationContext(ctx, channel); joinErr != nil {
+		logger.Warn("Auto-join failed", zap.String("tool", tool), zap.String("channel", channel), zap.Error(joinErr))
+		return notInChannelError(tool, channel, err, fmt.Sprintf("joining it failed with %v, private channels can't be joined, invite the bot to the channel instead", joinErr))
+	}
+	logger.Info("Joined channel to read it", zap.String("tool", tool), zap.String("channel", channel))
+
+	return read()
+}
+
+// notInChannelError reports a read of a channel the token is not a member of, with the way to fix it
+func notInChannelError(tool, channel string, err error, hint string) error {
+	e := NewToolError(ErrCodePermissionDenied, fmt.Sprintf("%s can't read channel %q, the authenticated user is not a member of it: %s", tool, channel, hint), err)
+	e.Details = "not_in_channel"
+	return e
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeChannelJoiner struct {
+	joinErr error
+	joined  []string
+}
+
+func (f *fakeChannelJoiner) JoinConversationContext(ctx context.Context, channelID string) (*slack.Channel, string, []string, error) {
+	if f.joinErr != nil {
+		return nil, "", nil, f.joinErr
+	}
+	f.joined = append(f.joined, channelID)
+	return &slack.Channel{}, "", nil, nil
+}
+
+// readUntilJoined fails with not_in_channel until api joined the channel
+func readUntilJoined(api *fakeChannelJoiner, calls *int) func() error {
+	return func() error {
+		*calls++
+		if len(api.joined) == 0 {
+			return slack.SlackErrorResponse{Err: "not_in_channel"}
+		}
+		return nil
+	}
+}
+
+func TestUnitReadChannelAutoJoin(t *testing.T) {
+	t.Setenv("SLACK_MCP_AUTO_JOIN", "true")
+	t.Setenv("SLACK_MCP_READ_ONLY", "")
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "")
+
+	api := &fakeChannelJoiner{}
+	calls := 0
+	err := readChannel(context.Background(), api, zap.NewNop(), "conversations_history", "C0GENERAL", readUntilJoined(api, &calls))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C0GENERAL"}, api.joined)
+	assert.Equal(t, 2, calls, "the read is retried once after joining")
+
+	// other errors are returned without joining
+	api = &fakeChannelJoiner{}
+	err = readChannel(context.Background(), api, zap.NewNop(), "conversations_history", "C0GENERAL", func() error {
+		return slack.SlackErrorResponse{Err: "channel_not_found"}
+	})
+	assert.Equal(t, "channel_not_found", err.Error())
+	assert.Empty(t, api.joined)
+
+	// the join failing, e.g. for a private channel, keeps the not_in_channel error
+	api = &fakeChannelJoiner{joinErr: slack.SlackErrorResponse{Err: "method_not_supported_for_channel_type"}}
+	calls = 0
+	err = readChannel(context.Background(), api, zap.NewNop(), "conversations_history", "G0SECRET", readUntilJoined(api, &calls))
+	te := AsToolError(err)
+	assert.Equal(t, ErrCodePermissionDenied, te.Code)
+	assert.Contains(t, te.Message, "method_not_supported_for_channel_type")
+	assert.Equal(t, 1, calls)
+}
+
+func TestUnitReadChannelAutoJoinDisabled(t *testing.T) {
+	t.Setenv("SLACK_MCP_AUTO_JOIN", "")
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "")
+
+	api := &fakeChannelJoiner{}
+	calls := 0
+	err := readChannel(context.Background(), api, zap.NewNop(), "conversations_history", "C0GENERAL", readUntilJoined(api, &calls))
+	te := AsToolError(err)
+	assert.Equal(t, ErrCodePermissionDenied, te.Code)
+	assert.Equal(t, "not_in_channel", te.Details)
+	assert.Contains(t, te.Message, "invite the bot")
+	assert.Contains(t, te.Message, "SLACK_MCP_AUTO_JOIN")
+	assert.True(t, errors.As(err, new(slack.SlackErrorResponse)), "the Slack error stays wrapped")
+	assert.Empty(t, api.joined)
+	assert.Equal(t, 1, calls)
+
+	// read-only mode never joins
+	t.Setenv("SLACK_MCP_AUTO_JOIN", "true")
+	t.Setenv("SLACK_MCP_READ_ONLY", "true")
+	err = readChannel(context.Background(), api, zap.NewNop(), "conversations_history", "C0GENERAL", readUntilJoined(api, &calls))
+	assert.Error(t, err)
+	assert.Empty(t, api.joined)
+
+	// channels denied by SLACK_MCP_ADD_MESSAGE_TOOL are not joined
+	t.Setenv("SLACK_MCP_READ_ONLY", "")
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "!C0GENERAL")
+	err = readChannel(context.Background(), api, zap.NewNop(), "conversations_history", "C0GENERAL", readUntilJoined(api, &calls))
+	assert.ErrorContains(t, err, "SLACK_MCP_ADD_MESSAGE_TOOL")
+	assert.Empty(t, api.joined)
+}
@@ -0,0 +1,329 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultAwaitTimeout      = 5 * time.Minute
+	defaultAwaitPollInterval = 10 * time.Second
+	minAwaitPollInterval     = time.Second
+)
+
+// Statuses of a conversations_await_response result
+const (
+	AwaitStatusReaction = "reaction"
+	AwaitStatusReply    = "reply"
+	AwaitStatusTimeout  = "timeout"
+)
+
+// Accepted kinds of response of conversations_await_response
+const (
+	awaitAcceptAny      = "any"
+	awaitAcceptReaction = "reaction"
+	awaitAcceptReply    = "reply"
+)
+
+type AwaitResponse struct {
+	Status    string `json:"status"`
+	ChannelID string `json:"channelID"`
+	MessageTs string `json:"messageTs"`
+	UserID    string `json:"userID"`
+	UserName  string `json:"userName"`
+	Reaction  string `json:"reaction"`
+	Text      string `json:"text"`
+	ReplyTs   string `json:"replyTs"`
+	Waited    string `json:"waited"`
+}
+
+type awaitParams struct {
+	channel   string
+	threadTs  string
+	text      string
+	accept    string
+	users     map[string]bool
+	reactions map[string]bool
+	timeout   time.Duration
+}
+
+// awaitFilter decides which reactions and replies answer the posted message
+type awaitFilter struct {
+	accept    string
+	users     map[string]bool
+	reactions map[string]bool
+	// self and selfBot identify the token, its own reactions and replies never count
+	self    string
+	selfBot string
+}
+
+// ConversationsAwaitResponseHandler posts a message and polls its reactions and thread replies until an allowed
+// user responds or the timeout passes. A timeout is a regular result, the posted message stays in Slack.
+func (ch *ConversationsHandler) ConversationsAwaitResponseHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsAwaitResponseHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolAwaitResponse(request)
+	if err != nil {
+		logger.Error("Failed to parse await-response params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	ar, err := ch.apiProvider.Slack().AuthTest()
+	if err != nil {
+		logger.Error("Slack AuthTest failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	options := []slack.MsgOption{slack.MsgOptionText(params.text, false)}
+	if params.threadTs != "" {
+		options = append(options, slack.MsgOptionTS(params.threadTs))
+	}
+	if !text.IsUnfurlingEnabled(params.text, os.Getenv("SLACK_MCP_ADD_MESSAGE_UNFURLING"), logger) {
+		options = append(options, slack.MsgOptionDisableLinkUnfurl(), slack.MsgOptionDisableMediaUnfurl())
+	}
+
+	channel, ts, err := ch.apiProvider.Slack().PostMessageContext(ctx, params.channel, options...)
+	if err != nil {
+		logger.Error("Slack PostMessageContext failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, channelAPIError("conversations_await_response", params.channel, err)
+	}
+
+	filter := awaitFilter{
+		accept:    params.accept,
+		users:     params.users,
+		reactions: params.reactions,
+		self:      ar.UserID,
+		selfBot:   ar.BotID,
+	}
+	threadTs := params.threadTs
+	if threadTs == "" {
+		threadTs = ts
+	}
+
+	interval := awaitPollInterval()
+	logger.Debug("Waiting for a response",
+		zap.String("channel", channel),
+		zap.String("ts", ts),
+		zap.Duration("timeout", params.timeout),
+		zap.Duration("poll_interval", interval),
+	)
+
+	started := time.Now()
+	deadline := time.NewTimer(params.timeout)
+	defer deadline.Stop()
+
+	for {
+		wait := interval
+		msgs, err := ch.awaitThread(ctx, channel, threadTs, ts)
+		if err != nil {
+			// a rate limited poll is retried, anything else ends the wait
+			var rle *slack.RateLimitedError
+			if !errors.As(err, &rle) {
+				logger.Error("Slack GetConversationRepliesContext failed", zap.String("channel", channel), zap.String("ts", ts), zap.Error(err))
+				return nil, slackAPIError(err)
+			}
+			if rle.RetryAfter > wait {
+				wait = rle.RetryAfter
+			}
+		} else if res := matchAwaitResponse(msgs, ts, filter); res != nil {
+			res.ChannelID = channel
+			res.Waited = time.Since(started).Round(time.Second).String()
+			ch.fillAwaitUserName(res)
+			return marshalCSVResult([]AwaitResponse{*res})
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Debug("Waiting for a response cancelled", zap.String("channel", channel), zap.String("ts", ts))
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return marshalCSVResult([]AwaitResponse{{
+				Status:    AwaitStatusTimeout,
+				ChannelID: channel,
+				MessageTs: ts,
+				Waited:    time.Since(started).Round(time.Second).String(),
+			}})
+		case <-time.After(wait):
+		}
+	}
+}
+
+// awaitThread returns the thread of threadTs from ts on, the posted message included
+func (ch *ConversationsHandler) awaitThread(ctx context.Context, channel, threadTs, ts string) ([]slack.Message, error) {
+	var (
+		msgs   []slack.Message
+		cursor string
+	)
+	for {
+		page, hasMore, nextCursor, err := ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: channel,
+			Timestamp: threadTs,
+			Oldest:    ts,
+			Inclusive: true,
+			Limit:     200,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, page...)
+		if !hasMore || nextCursor == "" {
+			return msgs, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+func (ch *ConversationsHandler) fillAwaitUserName(res *AwaitResponse) {
+	if u, ok := ch.apiProvider.User(res.UserID); ok {
+		res.UserName = u.Name
+	}
+}
+
+// matchAwaitResponse looks for an accepted reaction on the message ts, then for an accepted reply posted after it
+func matchAwaitResponse(msgs []slack.Message, ts string, filter awaitFilter) *AwaitResponse {
+	if filter.accept != awaitAcceptReply {
+		for _, msg := range msgs {
+			if msg.Timestamp != ts {
+				continue
+			}
+			for _, reaction := range msg.Reactions {
+				if len(filter.reactions) > 0 && !filter.reactions[reaction.Name] {
+					continue
+				}
+				for _, user := range reaction.Users {
+					if filter.allows(user) {
+						return &AwaitResponse{Status: AwaitStatusReaction, MessageTs: ts, UserID: user, Reaction: reaction.Name}
+					}
+				}
+			}
+		}
+	}
+
+	if filter.accept != awaitAcceptReaction {
+		for _, msg := range msgs {
+			if msg.Timestamp <= ts || (filter.selfBot != "" && msg.BotID == filter.selfBot) || !filter.allows(msg.User) {
+				continue
+			}
+			return &AwaitResponse{Status: AwaitStatusReply, MessageTs: ts, UserID: msg.User, Text: msg.Text, ReplyTs: msg.Timestamp}
+		}
+	}
+	return nil
+}
+
+func (f awaitFilter) allows(user string) bool {
+	if user == "" || user == f.self {
+		return false
+	}
+	return len(f.users) == 0 || f.users[user]
+}
+
+// parseParamsToolAwaitResponse reads the message to post and who may answer it how, within which timeout
+func (ch *ConversationsHandler) parseParamsToolAwaitResponse(request mcp.CallToolRequest) (*awaitParams, error) {
+	channel, err := paramChannelID(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWritePolicy("conversations_await_response", channel); err != nil {
+		return nil, err
+	}
+
+	threadTs := strings.TrimSpace(request.GetString("thread_ts", ""))
+	if threadTs != "" && !isMessageTimestamp(threadTs) {
+		return nil, fmt.Errorf("thread_ts must be a message timestamp in format 1234567890.123456, got %q", threadTs)
+	}
+
+	msgText := request.GetString("text", "")
+	if strings.TrimSpace(msgText) == "" {
+		return nil, errors.New("text must be a non-empty string")
+	}
+
+	accept := request.GetString("accept", awaitAcceptAny)
+	if accept != awaitAcceptAny && accept != awaitAcceptReaction && accept != awaitAcceptReply {
+		return nil, fmt.Errorf("accept must be one of any, reaction, reply, got %q", accept)
+	}
+
+	users := make(map[string]bool)
+	for _, raw := range strings.Split(request.GetString("users", ""), ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		id, err := resolveUserID(ch.apiProvider, raw, "")
+		if err != nil {
+			return nil, err
+		}
+		users[id] = true
+	}
+
+	reactions := make(map[string]bool)
+	for _, raw := range strings.Split(request.GetString("reactions", ""), ",") {
+		if name := strings.Trim(strings.TrimSpace(raw), ":"); name != "" {
+			reactions[name] = true
+		}
+	}
+
+	timeout, err := parseAwaitTimeout(request.GetString("timeout", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	return &awaitParams{
+		channel:   channel,
+		threadTs:  threadTs,
+		text:      escapeMessageText(msgText, false),
+		accept:    accept,
+		users:     users,
+		reactions: reactions,
+		timeout:   timeout,
+	}, nil
+}
+
+// parseAwaitTimeout reads a duration such as 90s or 10m, empty means SLACK_MCP_AWAIT_TIMEOUT which also caps it
+func parseAwaitTimeout(raw string) (time.Duration, error) {
+	max := awaitMaxTimeout()
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return max, nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("timeout must be a positive duration such as 90s or 10m, got %q", raw)
+	}
+	if d > max {
+		return max, nil
+	}
+	return d, nil
+}
+
+// awaitMaxTimeout returns the default and maximum wait of conversations_await_response, see SLACK_MCP_AWAIT_TIMEOUT
+func awaitMaxTimeout() time.Duration {
+	d, err := time.ParseDuration(os.Getenv("SLACK_MCP_AWAIT_TIMEOUT"))
+	if err != nil || d <= 0 {
+		return defaultAwaitTimeout
+	}
+	return d
+}
+
+// awaitPollInterval returns how often conversations_await_response checks for a response, see SLACK_MCP_AWAIT_POLL_INTERVAL
+func awaitPollInterval() time.Duration {
+	d, err := time.ParseDuration(os.Getenv("SLACK_MCP_AWAIT_POLL_INTERVAL"))
+	if err != nil || d <= 0 {
+		return defaultAwaitPollInterval
+	}
+	if d < minAwaitPollInterval {
+		return minAwaitPollInterval
+	}
+	return d
+}
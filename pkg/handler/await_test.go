@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitMatchAwaitResponse(t *testing.T) {
+	const ts = "1700000100.000100"
+	posted := slack.Message{}
+	posted.Timestamp, posted.User = ts, "U0SELF"
+	posted.Reactions = []slack.ItemReaction{
+		{Name: "eyes", Users: []string{"U0BOB"}},
+		{Name: "white_check_mark", Users: []string{"U0SELF", "U0ALICE"}},
+	}
+	earlier := slack.Message{}
+	earlier.Timestamp, earlier.User, earlier.Text = "1700000000.000100", "U0BOB", "before the question"
+	reply := slack.Message{}
+	reply.Timestamp, reply.User, reply.Text = "1700000200.000100", "U0BOB", "approved"
+	msgs := []slack.Message{earlier, posted, reply}
+
+	res := matchAwaitResponse(msgs, ts, awaitFilter{accept: awaitAcceptAny, self: "U0SELF"})
+	require.NotNil(t, res)
+	assert.Equal(t, AwaitResponse{Status: AwaitStatusReaction, MessageTs: ts, UserID: "U0BOB", Reaction: "eyes"}, *res)
+
+	res = matchAwaitResponse(msgs, ts, awaitFilter{accept: awaitAcceptAny, self: "U0SELF", reactions: map[string]bool{"white_check_mark": true}})
+	require.NotNil(t, res)
+	assert.Equal(t, "U0ALICE", res.UserID, "the own reaction of the token is skipped")
+
+	res = matchAwaitResponse(msgs, ts, awaitFilter{accept: awaitAcceptReply, self: "U0SELF"})
+	require.NotNil(t, res)
+	assert.Equal(t, AwaitResponse{Status: AwaitStatusReply, MessageTs: ts, UserID: "U0BOB", Text: "approved", ReplyTs: reply.Timestamp}, *res)
+
+	res = matchAwaitResponse(msgs, ts, awaitFilter{accept: awaitAcceptAny, self: "U0SELF", users: map[string]bool{"U0CAROL": true}})
+	assert.Nil(t, res, "only allowed users respond")
+
+	res = matchAwaitResponse(msgs[:2], ts, awaitFilter{accept: awaitAcceptReply, self: "U0SELF"})
+	assert.Nil(t, res, "messages before the question are not replies")
+}
+
+func TestUnitParseParamsToolAwaitResponse(t *testing.T) {
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = map[string]any{"channel_id": "C1234567890", "text": "Ship it?"}
+		for k, v := range args {
+			r.Params.Arguments.(map[string]any)[k] = v
+		}
+		return r
+	}
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "")
+	_, err := ch.parseParamsToolAwaitResponse(request(nil))
+	assert.Equal(t, ErrCodeCapabilityUnsupported, AsToolError(err).Code, "writes are disabled by default")
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+	t.Setenv("SLACK_MCP_AWAIT_TIMEOUT", "")
+	params, err := ch.parseParamsToolAwaitResponse(request(map[string]any{"users": "U0BOB, W0ALICE", "reactions": ":white_check_mark:,x"}))
+	require.NoError(t, err)
+	assert.Equal(t, &awaitParams{
+		channel:   "C1234567890",
+		text:      "Ship it?",
+		accept:    awaitAcceptAny,
+		users:     map[string]bool{"U0BOB": true, "W0ALICE": true},
+		reactions: map[string]bool{"white_check_mark": true, "x": true},
+		timeout:   defaultAwaitTimeout,
+	}, params)
+
+	_, err = ch.parseParamsToolAwaitResponse(request(map[string]any{"accept": "emoji"}))
+	assert.Error(t, err)
+
+	_, err = ch.parseParamsToolAwaitResponse(request(map[string]any{"thread_ts": "yesterday"}))
+	assert.Error(t, err)
+}
+
+func TestUnitParseAwaitTimeout(t *testing.T) {
+	t.Setenv("SLACK_MCP_AWAIT_TIMEOUT", "2m")
+
+	d, err := parseAwaitTimeout("")
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, d)
+
+	d, err = parseAwaitTimeout("90s")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, d)
+
+	d, err = parseAwaitTimeout("1h")
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, d, "capped at SLACK_MCP_AWAIT_TIMEOUT")
+
+	_, err = parseAwaitTimeout("soon")
+	assert.Error(t, err)
+
+	t.Setenv("SLACK_MCP_AWAIT_POLL_INTERVAL", "10ms")
+	assert.Equal(t, minAwaitPollInterval, awaitPollInterval())
+	t.Setenv("SLACK_MCP_AWAIT_POLL_INTERVAL", "")
+	assert.Equal(t, defaultAwaitPollInterval, awaitPollInterval())
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+)
+
+// maxBlocks is the number of blocks Slack accepts in a single message
+const maxBlocks = 50
+
+// blockSpec lists the fields a block type requires. Other fields are passed through to Slack, which adds
+// new ones over time.
+type blockSpec struct {
+	required []string
+	// oneOf requires at least one of the fields, e.g. text or fields of a section
+	oneOf []string
+}
+
+// blockSpecs covers the known block types, blocks of other types are only checked for a type
+var blockSpecs = map[string]blockSpec{
+	"section":   {oneOf: []string{"text", "fields"}},
+	"divider":   {},
+	"image":     {required: []string{"alt_text"}, oneOf: []string{"image_url", "slack_file"}},
+	"actions":   {required: []string{"elements"}},
+	"context":   {required: []string{"elements"}},
+	"header":    {required: []string{"text"}},
+	"input":     {required: []string{"label", "element"}},
+	"rich_text": {required: []string{"elements"}},
+	"video":     {required: []string{"alt_text", "title", "thumbnail_url", "video_url"}},
+	"file":      {required: []string{"external_id", "source"}},
+}
+
+// maxBlockElements bounds the elements array of blocks that have one
+var maxBlockElements = map[string]int{
+	"actions": 25,
+	"context": 10,
+}
+
+// IsBlockValidationEnabled returns false if SLACK_MCP_VALIDATE_BLOCKS turns off the client-side Block Kit checks
+func IsBlockValidationEnabled() bool {
+	switch strings.ToLower(os.Getenv("SLACK_MCP_VALIDATE_BLOCKS")) {
+	case "false", "0", "no":
+		return false
+	}
+	return true
+}
+
+// escapeBlockMentions applies SLACK_MCP_ESCAPE_MENTIONS to Block Kit JSON like escapeMessageText does to
+// message text: the text of mrkdwn text objects is escaped and rich text broadcast elements become plain
+// text. Plain text objects and rich text are shown literally, so they can't notify a channel.
+func escapeBlockMentions(raw []byte, allowBroadcast bool) ([]byte, error) {
+	if allowBroadcast || !isEscapeMentionsEnabled() {
+		return raw, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var blocks any
+	if err := decoder.Decode(&blocks); err != nil {
+		return nil, fmt.Errorf("blocks must be a JSON array of Block Kit blocks: %v", err)
+	}
+	return json.Marshal(escapeBlockValue(blocks))
+}
+
+func escapeBlockValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		switch v["type"] {
+		case "mrkdwn":
+			if s, ok := v["text"].(string); ok {
+				v["text"] = text.EscapeMentions(s)
+			}
+		case "broadcast":
+			if r, ok := v["range"].(string); ok {
+				return map[string]any{"type": "text", "text": text.EscapeMentions("@" + r)}
+			}
+		}
+		for key, child := range v {
+			v[key] = escapeBlockValue(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = escapeBlockValue(child)
+		}
+	}
+	return value
+}
+
+// validateBlocks checks the structure Block Kit JSON requires, e.g. a type on every block or the text of a
+// header, and names the offending block and field. Unknown block types and fields are passed through to Slack,
+// elements inside blocks are only checked for a type.
+func validateBlocks(raw []byte) error {
+	var blocks []map[string]any
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return fmt.Errorf("blocks must be a JSON array of Block Kit blocks: %v", err)
+	}
+	if len(blocks) > maxBlocks {
+		return fmt.Errorf("blocks: at most %d blocks are allowed, got %d", maxBlocks, len(blocks))
+	}
+
+	for i, block := range blocks {
+		path := fmt.Sprintf("blocks[%d]", i)
+		if block == nil {
+			return fmt.Errorf("%s: block must be a JSON object", path)
+		}
+		blockType, ok := block["type"].(string)
+		if !ok || blockType == "" {
+			return fmt.Errorf("%s: missing \"type\"", path)
+		}
+		spec, ok := blockSpecs[blockType]
+		if !ok {
+			continue
+		}
+		if err := validateBlockFields(path, blockType, block, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateBlockFields(path, blockType string, block map[string]any, spec blockSpec) error {
+	for _, field := range spec.required {
+		if _, ok := block[field]; !ok {
+			return fmt.Errorf("%s: %s block requires %q", path, blockType, field)
+		}
+	}
+	if len(spec.oneOf) > 0 {
+		found := false
+		for _, field := range spec.oneOf {
+			if _, ok := block[field]; ok {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %s block requires one of \"%s\"", path, blockType, strings.Join(spec.oneOf, `", "`))
+		}
+	}
+
+	switch blockType {
+	case "section":
+		if text, ok := block["text"]; ok {
+			if err := validateTextObject(path+".text", text, false); err != nil {
+				return err
+			}
+		}
+		if fields, ok := block["fields"]; ok {
+			items, ok := fields.([]any)
+			if !ok || len(items) == 0 || len(items) > 10 {
+				return fmt.Errorf("%s.fields: must be an array of 1 to 10 text objects", path)
+			}
+			for j, item := range items {
+				if err := validateTextObject(fmt.Sprintf("%s.fields[%d]", path, j), item, false); err != nil {
+					return err
+				}
+			}
+		}
+	case "header":
+		return validateTextObject(path+".text", block["text"], true)
+	case "input":
+		if err := validateTextObject(path+".label", block["label"], true); err != nil {
+			return err
+		}
+		if _, ok := block["element"].(map[string]any); !ok {
+			return fmt.Errorf("%s.element: must be a block element object", path)
+		}
+	case "image":
+		if _, ok := block["alt_text"].(string); !ok {
+			return fmt.Errorf("%s.alt_text: must be a string", path)
+		}
+	case "actions", "context", "rich_text":
+		elements, ok := block["elements"].([]any)
+		if !ok || len(elements) == 0 {
+			return fmt.Errorf("%s.elements: must be a non-empty array", path)
+		}
+		if max, ok := maxBlockElements[blockType]; ok && len(elements) > max {
+			return fmt.Errorf("%s.elements: %s block allows at most %d elements, got %d", path, blockType, max, len(elements))
+		}
+		for j, element := range elements {
+			obj, ok := element.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s.elements[%d]: must be a JSON object", path, j)
+			}
+			if t, ok := obj["type"].(string); !ok || t == "" {
+				return fmt.Errorf("%s.elements[%d]: missing \"type\"", path, j)
+			}
+		}
+	}
+	return nil
+}
+
+// validateTextObject checks a composition text object, plainOnly rejects mrkdwn where Slack requires plain_text
+func validateTextObject(path string, value any, plainOnly bool) error {
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s: must be a text object such as {\"type\": \"mrkdwn\", \"text\": \"...\"}", path)
+	}
+	switch obj["type"] {
+	case "plain_text":
+	case "mrkdwn":
+		if plainOnly {
+			return fmt.Errorf("%s.type: must be \"plain_text\"", path)
+		}
+	default:
+		return fmt.Errorf("%s.type: must be \"plain_text\" or \"mrkdwn\", got %v", path, obj["type"])
+	}
+	if text, ok := obj["text"].(string); !ok || text == "" {
+		return fmt.Errorf("%s.text: must be a non-empty string", path)
+	}
+	return nil
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitValidateBlocks(t *testing.T) {
+	valid := []string{
+		`[]`,
+		`[{"type":"divider"}]`,
+		`[{"type":"section","block_id":"b1","text":{"type":"mrkdwn","text":"*hi*"}}]`,
+		`[{"type":"section","fields":[{"type":"plain_text","text":"a"},{"type":"mrkdwn","text":"b"}]}]`,
+		`[{"type":"header","text":{"type":"plain_text","text":"Title","emoji":true}}]`,
+		`[{"type":"image","image_url":"https://example.com/a.png","alt_text":"a"}]`,
+		`[{"type":"actions","elements":[{"type":"button","text":{"type":"plain_text","text":"Go"}}]}]`,
+		`[{"type":"context","elements":[{"type":"mrkdwn","text":"note"}]}]`,
+		// block types and fields Slack adds over time are passed through
+		`[{"type":"markdown","text":"**hi**"}]`,
+		`[{"type":"section","text":{"type":"mrkdwn","text":"hi"},"new_field":true}]`,
+		`[{"type":"header","text":{"type":"plain_text","text":"Title","new_flag":true}}]`,
+	}
+	for _, raw := range valid {
+		assert.NoError(t, validateBlocks([]byte(raw)), raw)
+	}
+
+	invalid := map[string]string{
+		`{"type":"divider"}`:                                         "JSON array",
+		`[{"text":{"type":"mrkdwn","text":"hi"}}]`:                   `blocks[0]: missing "type"`,
+		`[{"type":"divider"},{"type":""}]`:                           `blocks[1]: missing "type"`,
+		`[{"type":"section","txt":{"type":"mrkdwn","text":"hi"}}]`:   `blocks[0]: section block requires one of "text", "fields"`,
+		`[{"type":"section"}]`:                                       `blocks[0]: section block requires one of "text", "fields"`,
+		`[{"type":"section","text":"hi"}]`:                           `blocks[0].text: must be a text object`,
+		`[{"type":"section","text":{"type":"markdown","text":"x"}}]`: `blocks[0].text.type: must be "plain_text" or "mrkdwn"`,
+		`[{"type":"section","text":{"type":"mrkdwn","text":""}}]`:    `blocks[0].text.text: must be a non-empty string`,
+		`[{"type":"header","text":{"type":"mrkdwn","text":"x"}}]`:    `blocks[0].text.type: must be "plain_text"`,
+		`[{"type":"image","image_url":"https://example.com/a.png"}]`: `blocks[0]: image block requires "alt_text"`,
+		`[{"type":"actions","elements":[{"text":"x"}]}]`:             `blocks[0].elements[0]: missing "type"`,
+	}
+	for raw, want := range invalid {
+		err := validateBlocks([]byte(raw))
+		require.Error(t, err, raw)
+		assert.Contains(t, err.Error(), want, raw)
+	}
+}
+
+func TestUnitParseBlocksValidation(t *testing.T) {
+	raw := `[{"type":"section","txt":{"type":"mrkdwn","text":"hi"}}]`
+
+	t.Setenv("SLACK_MCP_VALIDATE_BLOCKS", "")
+	_, err := parseBlocks(raw, false)
+	assert.ErrorContains(t, err, `section block requires one of "text", "fields"`)
+
+	t.Setenv("SLACK_MCP_VALIDATE_BLOCKS", "false")
+	_, err = parseBlocks(raw, false)
+	assert.NoError(t, err, "validation can be bypassed")
+}
+
+func TestUnitParseBlocksEscapesMentions(t *testing.T) {
+	raw := `[
+		{"type":"section","text":{"type":"mrkdwn","text":"<!channel> deploy, @here"},"fields":[{"type":"mrkdwn","text":"<!subteam^S1>"}]},
+		{"type":"header","text":{"type":"plain_text","text":"@everyone"}},
+		{"type":"rich_text","elements":[{"type":"rich_text_section","elements":[{"type":"broadcast","range":"here"},{"type":"text","text":" hi"}]}]}
+	]`
+	encode := func(allowBroadcast bool) string {
+		blocks, err := parseBlocks(raw, allowBroadcast)
+		require.NoError(t, err)
+		data, err := json.Marshal(blocks)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	t.Setenv("SLACK_MCP_ESCAPE_MENTIONS", "")
+	escaped := encode(false)
+	assert.Contains(t, escaped, `"text":"\u0026lt;!channel\u0026gt; deploy, @`+"\u200b"+`here"`)
+	assert.Contains(t, escaped, `"text":"\u0026lt;!subteam^S1\u0026gt;"`)
+	assert.Contains(t, escaped, `"text":"@everyone"`, "plain text can't notify and is kept")
+	assert.NotContains(t, escaped, `"broadcast"`)
+	assert.Contains(t, escaped, `"text":"@`+"\u200b"+`here"`)
+
+	kept := encode(true)
+	assert.Contains(t, kept, `"text":"\u003c!channel\u003e deploy, @here"`, "allow_broadcast keeps the mentions")
+	assert.Contains(t, kept, `"broadcast"`)
+}
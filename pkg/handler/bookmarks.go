@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type Bookmark struct {
+	BookmarkID string `json:"bookmarkID"`
+	ChannelID  string `json:"channelID"`
+	Title      string `json:"title"`
+	Link       string `json:"link"`
+	Emoji      string `json:"emoji"`
+}
+
+type BookmarksHandler struct {
+	apiProvider *provider.ApiProvider
+	logger      *zap.Logger
+}
+
+func NewBookmarksHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *BookmarksHandler {
+	return &BookmarksHandler{
+		apiProvider: apiProvider,
+		logger:      logger,
+	}
+}
+
+// BookmarksListHandler lists bookmarks of a channel as CSV
+func (bh *BookmarksHandler) BookmarksListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, bh.logger)
+	logger.Debug("BookmarksListHandler called", zap.Any("params", request.Params))
+
+	channel, err := paramChannelID(request)
+	if err != nil {
+		return nil, invalidArgumentError(err)
+	}
+
+	bookmarks, err := bh.apiProvider.Slack().ListBookmarksContext(ctx, channel)
+	if err != nil {
+		logger.Error("Slack ListBookmarksContext failed", zap.String("channel", channel), zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+	logger.Debug("Fetched bookmarks", zap.Int("count", len(bookmarks)))
+
+	return marshalBookmarksToCSV(bookmarks)
+}
+
+// BookmarksAddHandler adds a link bookmark to a channel and returns it as CSV
+func (bh *BookmarksHandler) BookmarksAddHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, bh.logger)
+	logger.Debug("BookmarksAddHandler called", zap.Any("params", request.Params))
+
+	channel, err := paramChannelID(request)
+	if err != nil {
+		return nil, invalidArgumentError(err)
+	}
+	if err := checkWritePolicy("bookmarks_add", channel); err != nil {
+		logger.Warn("bookmarks_add rejected by policy", zap.String("channel", channel), zap.Error(err))
+		return nil, err
+	}
+
+	title := strings.TrimSpace(request.GetString("title", ""))
+	if title == "" {
+		return nil, invalidArgumentError(errors.New("title must be a non-empty string"))
+	}
+
+	link := strings.TrimSpace(request.GetString("link", ""))
+	if u, err := url.Parse(link); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, invalidArgumentError(fmt.Errorf("link must be an absolute http or https URL, got %q", link))
+	}
+
+	bookmark, err := bh.apiProvider.Slack().AddBookmarkContext(ctx, channel, slack.AddBookmarkParameters{
+		Title: title,
+		Type:  "link",
+		Link:  link,
+		Emoji: normalizeEmoji(request.GetString("emoji", "")),
+	})
+	if err != nil {
+		logger.Error("Slack AddBookmarkContext failed", zap.String("channel", channel), zap.Error(err))
+		return nil, channelAPIError("bookmarks_add", channel, err)
+	}
+
+	return marshalBookmarksToCSV([]slack.Bookmark{bookmark})
+}
+
+// BookmarksRemoveHandler removes a bookmark from a channel
+func (bh *BookmarksHandler) BookmarksRemoveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, bh.logger)
+	logger.Debug("BookmarksRemoveHandler called", zap.Any("params", request.Params))
+
+	channel, err := paramChannelID(request)
+	if err != nil {
+		return nil, invalidArgumentError(err)
+	}
+	if err := checkWritePolicy("bookmarks_remove", channel); err != nil {
+		logger.Warn("bookmarks_remove rejected by policy", zap.String("channel", channel), zap.Error(err))
+		return nil, err
+	}
+
+	bookmarkID := strings.TrimSpace(request.GetString("bookmark_id", ""))
+	if bookmarkID == "" {
+		return nil, invalidArgumentError(errors.New("bookmark_id must be a string"))
+	}
+
+	if err := bh.apiProvider.Slack().RemoveBookmarkContext(ctx, channel, bookmarkID); err != nil {
+		logger.Error("Slack RemoveBookmarkContext failed",
+			zap.String("channel", channel),
+			zap.String("bookmark_id", bookmarkID),
+			zap.Error(err),
+		)
+		return nil, channelAPIError("bookmarks_remove", channel, err)
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Bookmark %s removed from channel %s", bookmarkID, channel)), nil
+}
+
+// paramChannelID reads channel_id which must be a channel ID such as C1234567890
+func paramChannelID(request mcp.CallToolRequest) (string, error) {
+	channel := strings.TrimSpace(request.GetString("channel_id", ""))
+	if channel == "" {
+		return "", errors.New("channel_id must be a string")
+	}
+	if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
+		return "", fmt.Errorf("channel_id must be a channel ID such as C1234567890, got %q", channel)
+	}
+	return channel, nil
+}
+
+func marshalBookmarksToCSV(bookmarks []slack.Bookmark) (*mcp.CallToolResult, error) {
+	result := make([]Bookmark, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		result = append(result, Bookmark{
+			BookmarkID: b.ID,
+			ChannelID:  b.ChannelID,
+			Title:      b.Title,
+			Link:       b.Link,
+			Emoji:      b.Emoji,
+		})
+	}
+
+	return marshalCSVResult(result)
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitCheckWritePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		channel string
+		code    string
+	}{
+		{"disabled by default", "", "C1", ErrCodeCapabilityUnsupported},
+		{"enabled for all", "true", "C1", ""},
+		{"allowed channel", "C1,C2", "C2", ""},
+		{"not allowed channel", "C1,C2", "C3", ErrCodeChannelNotAllowed},
+		{"negated channel", "!C1", "C1", ErrCodeChannelNotAllowed},
+		{"other than negated channel", "!C1", "C2", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", tt.config)
+
+			err := checkWritePolicy("bookmarks_add", tt.channel)
+			if tt.code == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.code, AsToolError(err).Code)
+		})
+	}
+}
+
+func TestUnitParamChannelID(t *testing.T) {
+	request := func(channel string) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = map[string]any{"channel_id": channel}
+		return r
+	}
+
+	channel, err := paramChannelID(request(" C1234567890 "))
+	assert.NoError(t, err)
+	assert.Equal(t, "C1234567890", channel)
+
+	_, err = paramChannelID(request(""))
+	assert.Error(t, err)
+
+	_, err = paramChannelID(request("#general"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/korotovsky/slack-mcp-server/pkg/webhook"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	slackGoUtil "github.com/takara2314/slack-go-util"
+	"go.uber.org/zap"
+)
+
+// maxBroadcastChannels is the number of channels conversations_broadcast posts to in one call
+const maxBroadcastChannels = 50
+
+// Statuses of a conversations_broadcast result row
+const (
+	BroadcastStatusPosted  = "posted"
+	BroadcastStatusFailed  = "failed"
+	BroadcastStatusSkipped = "skipped"
+)
+
+type BroadcastResult struct {
+	ChannelID string `json:"channelID"`
+	Status    string `json:"status"`
+	Ts        string `json:"ts"`
+	ErrorCode string `json:"errorCode"`
+	Error     string `json:"error"`
+}
+
+type broadcastParams struct {
+	channels       []string
+	text           string
+	contentType    string
+	stopOnError    bool
+	allowBroadcast bool
+}
+
+// ConversationsBroadcastHandler posts the same message to several channels and returns one CSV row per channel,
+// so that partial failures are visible. Every channel is checked against SLACK_MCP_ADD_MESSAGE_TOOL on its own.
+func (ch *ConversationsHandler) ConversationsBroadcastHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsBroadcastHandler called", zap.Any("params", request.Params))
+
+	if os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL") == "" {
+		return nil, capabilityUnsupportedError("conversations_broadcast tool is disabled by default, set SLACK_MCP_ADD_MESSAGE_TOOL to true, 1, or a comma separated list of channels to enable it")
+	}
+
+	params, err := parseParamsToolBroadcast(request)
+	if err != nil {
+		logger.Error("Failed to parse broadcast params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	options := ch.broadcastOptions(params)
+	post := func(ctx context.Context, channel string) (string, error) {
+		if err := checkWritePolicy("conversations_broadcast", channel); err != nil {
+			return "", err
+		}
+		_, ts, err := ch.apiProvider.Slack().PostMessageContext(ctx, channel, options...)
+		if err != nil {
+			logger.Warn("Slack PostMessageContext failed", zap.String("channel", channel), zap.Error(err))
+			return "", channelAPIError("conversations_broadcast", channel, err)
+		}
+		ch.apiProvider.ForwardMessage(webhook.Event{Type: "message", ChannelID: channel, Text: params.text, Timestamp: ts})
+		return ts, nil
+	}
+
+	// the transport semaphore bounds the calls anyway, more workers would only queue on it
+	workers := ch.apiProvider.SlackCallsCapacity()
+	logger.Debug("Broadcasting Slack message",
+		zap.Int("channels", len(params.channels)),
+		zap.Int("workers", workers),
+		zap.Bool("stop_on_error", params.stopOnError),
+	)
+	return marshalCSVResult(broadcast(ctx, params.channels, workers, params.stopOnError, post))
+}
+
+// broadcast calls post for every channel with at most workers calls at once. With stopOnError, channels not
+// posted yet are skipped after the first failure; posts already in flight still complete.
+func broadcast(ctx context.Context, channels []string, workers int, stopOnError bool, post func(ctx context.Context, channel string) (string, error)) []BroadcastResult {
+	results := make([]BroadcastResult, len(channels))
+	if workers <= 0 || workers > len(channels) {
+		workers = len(channels)
+	}
+
+	// channels denied by policy are known upfront, with stopOnError nothing is posted then
+	var stopped atomic.Bool
+	if stopOnError {
+		for _, channel := range channels {
+			if !isChannelAllowed(channel) {
+				stopped.Store(true)
+			}
+		}
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				channel := channels[i]
+				// denied channels still report their policy error, post fails them without calling Slack
+				if (stopped.Load() && isChannelAllowed(channel)) || ctx.Err() != nil {
+					results[i] = BroadcastResult{ChannelID: channel, Status: BroadcastStatusSkipped}
+					continue
+				}
+				ts, err := post(ctx, channel)
+				if err != nil {
+					te := AsToolError(err)
+					results[i] = BroadcastResult{ChannelID: channel, Status: BroadcastStatusFailed, ErrorCode: te.Code, Error: te.Message}
+					if stopOnError {
+						stopped.Store(true)
+					}
+					continue
+				}
+				results[i] = BroadcastResult{ChannelID: channel, Status: BroadcastStatusPosted, Ts: ts}
+			}
+		}()
+	}
+	for i := range channels {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// broadcastOptions builds the message options shared by all channels, as conversations_add_message does
+func (ch *ConversationsHandler) broadcastOptions(params *broadcastParams) []slack.MsgOption {
+	var options []slack.MsgOption
+	plain := params.contentType == "text/plain"
+	if !plain {
+		if blocks, err := slackGoUtil.ConvertMarkdownTextToBlocks(params.text); err == nil {
+			options = append(options, slack.MsgOptionBlocks(blocks...))
+		} else {
+			ch.logger.Warn("Markdown parsing error", zap.Error(err))
+			plain = true
+		}
+	}
+	if plain {
+		options = append(options, slack.MsgOptionDisableMarkdown(), slack.MsgOptionText(params.text, false))
+	}
+
+	if params.allowBroadcast {
+		options = append(options, slack.MsgOptionLinkNames(true))
+	}
+	if text.IsUnfurlingEnabled(params.text, os.Getenv("SLACK_MCP_ADD_MESSAGE_UNFURLING"), ch.logger) {
+		options = append(options, slack.MsgOptionEnableLinkUnfurl())
+	} else {
+		options = append(options, slack.MsgOptionDisableLinkUnfurl(), slack.MsgOptionDisableMediaUnfurl())
+	}
+	return options
+}
+
+// parseParamsToolBroadcast reads the channel IDs, deduplicated in their given order, and the message to post
+func parseParamsToolBroadcast(request mcp.CallToolRequest) (*broadcastParams, error) {
+	var (
+		channels []string
+		seen     = make(map[string]bool)
+	)
+	for _, raw := range strings.Split(request.GetString("channel_ids", ""), ",") {
+		channel := strings.TrimSpace(raw)
+		if channel == "" || seen[channel] {
+			continue
+		}
+		if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
+			return nil, fmt.Errorf("channel_ids must be channel IDs such as C1234567890, got %q", channel)
+		}
+		seen[channel] = true
+		channels = append(channels, channel)
+	}
+	if len(channels) == 0 {
+		return nil, errors.New("channel_ids must be a comma-separated list of channel IDs")
+	}
+	if len(channels) > maxBroadcastChannels {
+		return nil, fmt.Errorf("channel_ids has %d channels, at most %d are allowed per call", len(channels), maxBroadcastChannels)
+	}
+
+	msgText, err := messageContent(request.GetString("payload", ""), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := request.GetString("content_type", "text/markdown")
+	if contentType != "text/plain" && contentType != "text/markdown" {
+		return nil, errors.New("content_type must be either 'text/plain' or 'text/markdown'")
+	}
+
+	allowBroadcast := request.GetBool("allow_broadcast", false)
+
+	return &broadcastParams{
+		channels:       channels,
+		text:           escapeMessageText(msgText, allowBroadcast),
+		contentType:    contentType,
+		stopOnError:    request.GetBool("stop_on_error", false),
+		allowBroadcast: allowBroadcast,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitBroadcast(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "!C0DENIED")
+	channels := []string{"C0GENERAL", "C0DENIED", "C0ARCHIVED", "C0RANDOM"}
+
+	var (
+		mu     sync.Mutex
+		posted []string
+	)
+	post := func(ctx context.Context, channel string) (string, error) {
+		if err := checkWritePolicy("conversations_broadcast", channel); err != nil {
+			return "", err
+		}
+		if channel == "C0ARCHIVED" {
+			return "", slackAPIError(slack.SlackErrorResponse{Err: "is_archived"})
+		}
+		mu.Lock()
+		posted = append(posted, channel)
+		mu.Unlock()
+		return "1700000000.000100", nil
+	}
+
+	results := broadcast(context.Background(), channels, 2, false, post)
+	require.Len(t, results, 4)
+	assert.Equal(t, BroadcastResult{ChannelID: "C0GENERAL", Status: BroadcastStatusPosted, Ts: "1700000000.000100"}, results[0])
+	assert.Equal(t, BroadcastStatusFailed, results[1].Status)
+	assert.Equal(t, ErrCodeChannelNotAllowed, results[1].ErrorCode)
+	assert.Equal(t, BroadcastStatusFailed, results[2].Status)
+	assert.Equal(t, ErrCodeChannelArchived, results[2].ErrorCode)
+	assert.Equal(t, BroadcastStatusPosted, results[3].Status)
+	assert.ElementsMatch(t, []string{"C0GENERAL", "C0RANDOM"}, posted)
+
+	posted = nil
+	results = broadcast(context.Background(), channels, 1, true, post)
+	assert.Empty(t, posted, "nothing is posted when a channel is denied by policy")
+	assert.Equal(t, BroadcastStatusSkipped, results[0].Status)
+	assert.Equal(t, ErrCodeChannelNotAllowed, results[1].ErrorCode)
+	assert.Equal(t, BroadcastStatusSkipped, results[3].Status)
+
+	results = broadcast(context.Background(), []string{"C0ARCHIVED", "C0GENERAL"}, 1, true, post)
+	assert.Equal(t, BroadcastStatusFailed, results[0].Status)
+	assert.Equal(t, BroadcastStatusSkipped, results[1].Status, "channels after the first failure are skipped")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results = broadcast(ctx, []string{"C0GENERAL"}, 0, false, func(context.Context, string) (string, error) {
+		return "", errors.New("must not be called")
+	})
+	assert.Equal(t, BroadcastStatusSkipped, results[0].Status)
+}
+
+func TestUnitParseParamsToolBroadcast(t *testing.T) {
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = args
+		return r
+	}
+
+	params, err := parseParamsToolBroadcast(request(map[string]any{
+		"channel_ids":   "C0GENERAL, C0RANDOM,C0GENERAL,",
+		"payload":       "Release 1.2 is out",
+		"stop_on_error": true,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, &broadcastParams{
+		channels:    []string{"C0GENERAL", "C0RANDOM"},
+		text:        "Release 1.2 is out",
+		contentType: "text/markdown",
+		stopOnError: true,
+	}, params)
+
+	t.Setenv("SLACK_MCP_ESCAPE_MENTIONS", "")
+	params, err = parseParamsToolBroadcast(request(map[string]any{"channel_ids": "C0GENERAL", "payload": "<!here> release is out"}))
+	require.NoError(t, err)
+	assert.Equal(t, "&lt;!here&gt; release is out", params.text, "broadcast mentions are escaped by default")
+
+	params, err = parseParamsToolBroadcast(request(map[string]any{"channel_ids": "C0GENERAL", "payload": "<!here> release is out", "allow_broadcast": true}))
+	require.NoError(t, err)
+	assert.Equal(t, "<!here> release is out", params.text)
+	assert.True(t, params.allowBroadcast)
+
+	t.Setenv("SLACK_MCP_ESCAPE_MENTIONS", "false")
+	params, err = parseParamsToolBroadcast(request(map[string]any{"channel_ids": "C0GENERAL", "payload": "@here release is out"}))
+	require.NoError(t, err)
+	assert.Equal(t, "@here release is out", params.text)
+
+	_, err = parseParamsToolBroadcast(request(map[string]any{"channel_ids": "#general", "payload": "hi"}))
+	assert.Error(t, err, "channel names are not resolved")
+
+	_, err = parseParamsToolBroadcast(request(map[string]any{"channel_ids": "C0GENERAL", "payload": " "}))
+	assert.Error(t, err)
+
+	_, err = parseParamsToolBroadcast(request(map[string]any{"channel_ids": "", "payload": "hi"}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// Statuses of a cache_invalidate row
+const (
+	CacheEntryUpdated = "updated"
+	CacheEntryRemoved = "removed"
+)
+
+type CacheEntry struct {
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+type CacheHandler struct {
+	apiProvider *provider.ApiProvider
+	logger      *zap.Logger
+}
+
+func NewCacheHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *CacheHandler {
+	return &CacheHandler{
+		apiProvider: apiProvider,
+		logger:      logger,
+	}
+}
+
+// CacheInvalidateHandler refetches single channels and users and updates their cache entries in place
+func (h *CacheHandler) CacheInvalidateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, h.logger)
+	logger.Debug("CacheInvalidateHandler called", zap.Any("params", request.Params))
+
+	if ready, err := h.apiProvider.IsReady(); !ready {
+		logger.Error("API provider not ready", zap.Error(err))
+		return nil, cacheNotReadyError(err)
+	}
+
+	channelID := strings.TrimSpace(request.GetString("channel_id", ""))
+	userID := strings.TrimSpace(request.GetString("user_id", ""))
+	if channelID == "" && userID == "" {
+		return nil, invalidArgumentError(errors.New("channel_id or user_id must be provided"))
+	}
+
+	var entries []CacheEntry
+	if channelID != "" {
+		channel, cached, err := h.apiProvider.InvalidateChannel(ctx, channelID)
+		if err != nil {
+			logger.Error("Failed to invalidate channel", zap.String("channel", channelID), zap.Error(err))
+			return nil, slackAPIError(err)
+		}
+		entries = append(entries, CacheEntry{Type: "channel", ID: channelID, Name: channel.Name, Status: cacheEntryStatus(cached)})
+	}
+	if userID != "" {
+		user, cached, err := h.apiProvider.InvalidateUser(ctx, userID)
+		if err != nil {
+			logger.Error("Failed to invalidate user", zap.String("user", userID), zap.Error(err))
+			return nil, slackAPIError(err)
+		}
+		entries = append(entries, CacheEntry{Type: "user", ID: userID, Name: user.Name, Status: cacheEntryStatus(cached)})
+	}
+
+	logger.Debug("Invalidated cache entries", zap.Any("entries", entries))
+	return marshalCSVResult(entries)
+}
+
+func cacheEntryStatus(cached bool) string {
+	if cached {
+		return CacheEntryUpdated
+	}
+	return CacheEntryRemoved
+}
@@ -0,0 +1,438 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+	"golang.org/x/net/html"
+)
+
+const (
+	// canvasFilesScope is the OAuth scope files.info requires to read a canvas
+	canvasFilesScope = "files:read"
+	// canvasSectionsScope is the OAuth scope canvases.sections.lookup requires
+	canvasSectionsScope = "canvases:read"
+)
+
+// canvasUnsupportedErrors are Slack errors meaning the token can't read canvases at all
+var canvasUnsupportedErrors = map[string]bool{
+	"missing_scope":          true,
+	"not_allowed_token_type": true,
+	"unknown_method":         true,
+}
+
+// canvasSectionTypes are the section types canvases.sections.lookup filters by
+var canvasSectionTypes = map[string]bool{
+	"any_header": true,
+	"h1":         true,
+	"h2":         true,
+	"h3":         true,
+}
+
+type CanvasContent struct {
+	CanvasID  string `json:"canvasID"`
+	ChannelID string `json:"channelID"`
+	Title     string `json:"title"`
+	Permalink string `json:"permalink"`
+	Updated   string `json:"updated"`
+	Truncated bool   `json:"truncated"`
+	Content   string `json:"content"`
+}
+
+type CanvasSection struct {
+	CanvasID  string `json:"canvasID"`
+	SectionID string `json:"sectionID"`
+}
+
+// ConversationsCanvasGetHandler returns the canvas of a channel, or a canvas by ID, converted to markdown as CSV
+func (ch *ConversationsHandler) ConversationsCanvasGetHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsCanvasGetHandler called", zap.Any("params", request.Params))
+
+	const tool = "conversations_canvas_get"
+	if err := requireScope(ctx, ch.apiProvider, logger, tool, canvasFilesScope); err != nil {
+		return nil, err
+	}
+
+	canvasID, channel, err := ch.paramCanvas(ctx, request, tool)
+	if err != nil {
+		return nil, err
+	}
+
+	file, _, _, err := ch.apiProvider.Slack().GetFileInfoContext(ctx, canvasID, 0, 0)
+	if err != nil {
+		logger.Error("Slack GetFileInfoContext failed", zap.String("canvas_id", canvasID), zap.Error(err))
+		return nil, canvasError(tool, canvasFilesScope, err)
+	}
+	if !isCanvasFile(*file) {
+		return nil, invalidArgumentError(fmt.Errorf("file %q is a %s file, not a canvas, use files_download to read it", canvasID, file.Filetype))
+	}
+	if err := checkFileReadPolicy(logger, tool, *file); err != nil {
+		return nil, err
+	}
+
+	page, truncated, err := downloadText(file.URLPrivateDownload, maxDownloadBytes(), func(url string, w io.Writer) error {
+		return ch.apiProvider.Slack().GetFileContext(ctx, url, w)
+	})
+	if err != nil {
+		logger.Error("Slack GetFileContext failed", zap.String("canvas_id", canvasID), zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	result := CanvasContent{
+		CanvasID:  file.ID,
+		ChannelID: channel,
+		Title:     file.Title,
+		Permalink: file.Permalink,
+		Truncated: truncated,
+		Content:   canvasMarkdown(strings.NewReader(page)),
+	}
+	if file.Timestamp > 0 {
+		result.Updated = file.Timestamp.Time().UTC().Format(time.RFC3339)
+	}
+	return marshalCSVResult([]CanvasContent{result})
+}
+
+// CanvasesSectionsListHandler returns the IDs of the sections of a canvas matching a heading type or text as CSV
+func (ch *ConversationsHandler) CanvasesSectionsListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("CanvasesSectionsListHandler called", zap.Any("params", request.Params))
+
+	const tool = "canvases_sections_list"
+	criteria, err := parseCanvasSectionsCriteria(request.GetString("section_types", ""), request.GetString("contains_text", ""))
+	if err != nil {
+		return nil, invalidArgumentError(err)
+	}
+	if err := requireScope(ctx, ch.apiProvider, logger, tool, canvasSectionsScope); err != nil {
+		return nil, err
+	}
+
+	canvasID, _, err := ch.paramCanvas(ctx, request, tool)
+	if err != nil {
+		return nil, err
+	}
+	if request.GetString("canvas_id", "") != "" {
+		// sections carry no content, but the canvas ID alone must not reveal a canvas of a denied channel
+		file, _, _, err := ch.apiProvider.Slack().GetFileInfoContext(ctx, canvasID, 0, 0)
+		if err != nil {
+			logger.Error("Slack GetFileInfoContext failed", zap.String("canvas_id", canvasID), zap.Error(err))
+			return nil, canvasError(tool, canvasFilesScope, err)
+		}
+		if err := checkFileReadPolicy(logger, tool, *file); err != nil {
+			return nil, err
+		}
+	}
+
+	sections, err := ch.apiProvider.Slack().LookupCanvasSectionsContext(ctx, slack.LookupCanvasSectionsParams{
+		CanvasID: canvasID,
+		Criteria: criteria,
+	})
+	if err != nil {
+		logger.Error("Slack LookupCanvasSectionsContext failed", zap.String("canvas_id", canvasID), zap.Error(err))
+		return nil, canvasError(tool, canvasSectionsScope, err)
+	}
+
+	rows := make([]CanvasSection, 0, len(sections))
+	for _, s := range sections {
+		rows = append(rows, CanvasSection{CanvasID: canvasID, SectionID: s.ID})
+	}
+	return marshalCSVResult(rows)
+}
+
+// paramCanvas reads canvas_id, or the canvas attached to channel_id when it is empty. The channel is only
+// returned in the latter case, the allow list is applied to it there and to the canvas file by the caller.
+func (ch *ConversationsHandler) paramCanvas(ctx context.Context, request mcp.CallToolRequest, tool string) (string, string, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	if canvasID := strings.TrimSpace(request.GetString("canvas_id", "")); canvasID != "" {
+		if !strings.HasPrefix(canvasID, "F") {
+			return "", "", invalidArgumentError(fmt.Errorf("canvas_id must be a canvas file ID such as F1234567890, got %q", canvasID))
+		}
+		return canvasID, "", nil
+	}
+
+	if strings.TrimSpace(request.GetString("channel_id", "")) == "" {
+		return "", "", invalidArgumentError(errors.New("either channel_id or canvas_id must be provided"))
+	}
+	channel, err := ch.paramReadableChannel(request, tool)
+	if err != nil {
+		return "", "", invalidArgumentError(err)
+	}
+
+	info, err := ch.apiProvider.Slack().GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{ChannelID: channel})
+	if err != nil {
+		logger.Error("Slack GetConversationInfoContext failed", zap.String("channel", channel), zap.Error(err))
+		return "", "", slackAPIError(err)
+	}
+	if info.Properties == nil || info.Properties.Canvas.FileId == "" {
+		return "", "", notFoundError("channel %q has no canvas", channel)
+	}
+	return info.Properties.Canvas.FileId, channel, nil
+}
+
+// canvasError maps Slack errors of a canvas call, a token that can't read canvases gets a capability error
+func canvasError(tool, scope string, err error) error {
+	if code := provider.SlackErrorCode(err); canvasUnsupportedErrors[code] {
+		return capabilityUnsupportedError("%s tool is not available for the current token (%s), it requires a token with the %s scope", tool, code, scope)
+	}
+	return slackAPIError(err)
+}
+
+// isCanvasFile reports whether f is a canvas, canvases are served as quip files by older workspaces
+func isCanvasFile(f slack.File) bool {
+	return f.Filetype == "canvas" || f.Filetype == "quip" || f.Mimetype == "application/vnd.slack-docs"
+}
+
+// parseCanvasSectionsCriteria reads a comma separated list of section types and the text to look for,
+// canvases.sections.lookup requires at least one of them
+func parseCanvasSectionsCriteria(sectionTypes, containsText string) (slack.LookupCanvasSectionsCriteria, error) {
+	criteria := slack.LookupCanvasSectionsCriteria{ContainsText: strings.TrimSpace(containsText)}
+	for _, t := range strings.Split(sectionTypes, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if !canvasSectionTypes[t] {
+			return criteria, fmt.Errorf("section_types must be a comma separated list of any_header, h1, h2 and h3, got %q", t)
+		}
+		criteria.SectionTypes = append(criteria.SectionTypes, t)
+	}
+	if len(criteria.SectionTypes) == 0 && criteria.ContainsText == "" {
+		return criteria, errors.New("either section_types or contains_text must be provided")
+	}
+	return criteria, nil
+}
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// canvasMarkdown converts the HTML Slack serves for a canvas to markdown: headings, paragraphs, lists and
+// checklists, quotes, code, links, images and tables. Other tags keep their text.
+func canvasMarkdown(r io.Reader) string {
+	w := &markdownWriter{}
+	var (
+		lists   []string // kinds of the open lists, innermost last: ul, ol or checklist
+		numbers []int    // next item number of each open list
+		links   []string // hrefs of the open links
+		skip    int      // depth in tags whose text is not content
+		pre     int      // depth in preformatted blocks
+		row     int      // rows of the current table written so far
+		cells   int      // cells of the first table row
+	)
+
+	z := html.NewTokenizer(r)
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return strings.TrimSpace(blankLines.ReplaceAllString(w.String(), "\n\n"))
+		case html.TextToken:
+			if skip > 0 {
+				continue
+			}
+			if pre > 0 {
+				w.write(string(z.Text()))
+			} else {
+				w.text(string(z.Text()))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			attrs := make(map[string]string)
+			for hasAttr {
+				var k, v []byte
+				k, v, hasAttr = z.TagAttr()
+				attrs[string(k)] = string(v)
+			}
+			switch tag := string(name); tag {
+			case "head", "title", "script", "style":
+				if tt == html.StartTagToken {
+					skip++
+				}
+			case "h1", "h2", "h3", "h4", "h5", "h6":
+				w.breakLines(2)
+				w.write(strings.Repeat("#", int(tag[1]-'0')) + " ")
+			case "p", "div":
+				if len(lists) == 0 {
+					w.breakLines(2)
+				}
+			case "blockquote":
+				w.breakLines(2)
+				w.write("> ")
+			case "pre":
+				w.breakLines(2)
+				w.write("```\n")
+				pre++
+			case "hr":
+				w.breakLines(2)
+				w.write("---")
+				w.breakLines(2)
+			case "br":
+				w.write("\n")
+			case "ul", "ol":
+				kind := tag
+				if strings.Contains(attrs["class"], "checklist") {
+					kind = "checklist"
+				}
+				lists = append(lists, kind)
+				numbers = append(numbers, 1)
+				w.breakLines(1)
+			case "li":
+				w.breakLines(1)
+				if len(lists) == 0 {
+					w.write("- ")
+					continue
+				}
+				depth := len(lists) - 1
+				w.write(strings.Repeat("  ", depth))
+				switch {
+				case strings.Contains(attrs["class"], "checked"):
+					w.write("- [x] ")
+				case lists[depth] == "checklist":
+					w.write("- [ ] ")
+				case lists[depth] == "ol":
+					w.write(fmt.Sprintf("%d. ", numbers[depth]))
+					numbers[depth]++
+				default:
+					w.write("- ")
+				}
+			case "b", "strong":
+				w.write("**")
+			case "i", "em":
+				w.write("_")
+			case "s", "del", "strike":
+				w.write("~~")
+			case "code":
+				if pre == 0 {
+					w.write("`")
+				}
+			case "a":
+				if tt == html.StartTagToken {
+					links = append(links, attrs["href"])
+					if attrs["href"] != "" {
+						w.write("[")
+					}
+				}
+			case "img":
+				if attrs["src"] != "" {
+					w.write(fmt.Sprintf("![%s](%s)", attrs["alt"], attrs["src"]))
+				}
+			case "table":
+				w.breakLines(2)
+				row, cells = 0, 0
+			case "tr":
+				w.breakLines(1)
+				w.write("|")
+			case "td", "th":
+				w.write(" ")
+				if row == 0 {
+					cells++
+				}
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch tag := string(name); tag {
+			case "head", "title", "script", "style":
+				if skip > 0 {
+					skip--
+				}
+			case "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "table":
+				w.breakLines(2)
+			case "p", "div":
+				if len(lists) == 0 {
+					w.breakLines(2)
+				}
+			case "pre":
+				if pre > 0 {
+					pre--
+				}
+				w.breakLines(1)
+				w.write("```")
+				w.breakLines(2)
+			case "ul", "ol":
+				if len(lists) > 0 {
+					lists = lists[:len(lists)-1]
+					numbers = numbers[:len(numbers)-1]
+				}
+				if len(lists) == 0 {
+					w.breakLines(2)
+				}
+			case "b", "strong":
+				w.write("**")
+			case "i", "em":
+				w.write("_")
+			case "s", "del", "strike":
+				w.write("~~")
+			case "code":
+				if pre == 0 {
+					w.write("`")
+				}
+			case "a":
+				if len(links) > 0 {
+					href := links[len(links)-1]
+					links = links[:len(links)-1]
+					if href != "" {
+						w.write("](" + href + ")")
+					}
+				}
+			case "td", "th":
+				w.write(" |")
+			case "tr":
+				if row == 0 && cells > 0 {
+					w.write("\n|" + strings.Repeat(" --- |", cells))
+				}
+				row++
+			}
+		}
+	}
+}
+
+// markdownWriter collects the markdown of canvasMarkdown, collapsing whitespace the way HTML renders it
+type markdownWriter struct {
+	buf []byte
+}
+
+func (w *markdownWriter) String() string {
+	return string(w.buf)
+}
+
+func (w *markdownWriter) write(s string) {
+	w.buf = append(w.buf, s...)
+}
+
+// text writes HTML text with runs of whitespace collapsed to one space, none at the start of a line
+func (w *markdownWriter) text(s string) {
+	for _, r := range s {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f' {
+			if len(w.buf) == 0 || w.buf[len(w.buf)-1] == ' ' || w.buf[len(w.buf)-1] == '\n' {
+				continue
+			}
+			r = ' '
+		}
+		w.buf = append(w.buf, string(r)...)
+	}
+}
+
+// breakLines ends the output with n line breaks unless it already does, trailing spaces are dropped
+func (w *markdownWriter) breakLines(n int) {
+	for len(w.buf) > 0 && w.buf[len(w.buf)-1] == ' ' {
+		w.buf = w.buf[:len(w.buf)-1]
+	}
+	if len(w.buf) == 0 {
+		return
+	}
+	have := 0
+	for i := len(w.buf) - 1; i >= 0 && w.buf[i] == '\n' && have < n; i-- {
+		have++
+	}
+	for ; have < n; have++ {
+		w.buf = append(w.buf, '\n')
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitCanvasMarkdown(t *testing.T) {
+	page := `<html><head><title>Team handbook</title><style>p { color: red }</style></head><body>
+<h1>Team   handbook</h1>
+<p class='line'>Welcome to the <b>platform</b> team, see <a href="https://example.com/wiki">the wiki</a>.</p>
+<h2>On-call</h2>
+<ul>
+  <li>Rotate <i>weekly</i></li>
+  <li>Page via &lt;pagerduty&gt;
+    <ol><li>Ack</li><li>Fix</li></ol>
+  </li>
+</ul>
+<ul class="checklist"><li class="checked">Write runbook</li><li>Review alerts</li></ul>
+<pre><code>make deploy
+make verify</code></pre>
+<table><tr><th>Owner</th><th>Area</th></tr><tr><td>Ana</td><td>API</td></tr></table>
+</body></html>`
+
+	want := strings.Join([]string{
+		"# Team handbook",
+		"",
+		"Welcome to the **platform** team, see [the wiki](https://example.com/wiki).",
+		"",
+		"## On-call",
+		"",
+		"- Rotate _weekly_",
+		"- Page via <pagerduty>",
+		"  1. Ack",
+		"  2. Fix",
+		"",
+		"- [x] Write runbook",
+		"- [ ] Review alerts",
+		"",
+		"```",
+		"make deploy",
+		"make verify",
+		"```",
+		"",
+		"| Owner | Area |",
+		"| --- | --- |",
+		"| Ana | API |",
+	}, "\n")
+	assert.Equal(t, want, canvasMarkdown(strings.NewReader(page)))
+
+	assert.Empty(t, canvasMarkdown(strings.NewReader("")))
+}
+
+func TestUnitParseCanvasSectionsCriteria(t *testing.T) {
+	criteria, err := parseCanvasSectionsCriteria(" H1, any_header ", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"h1", "any_header"}, criteria.SectionTypes)
+
+	criteria, err = parseCanvasSectionsCriteria("", " launch ")
+	require.NoError(t, err)
+	assert.Empty(t, criteria.SectionTypes)
+	assert.Equal(t, "launch", criteria.ContainsText)
+
+	_, err = parseCanvasSectionsCriteria("h4", "")
+	assert.ErrorContains(t, err, "section_types")
+
+	_, err = parseCanvasSectionsCriteria(" , ", "  ")
+	assert.ErrorContains(t, err, "either section_types or contains_text")
+}
+
+func TestUnitIsCanvasFile(t *testing.T) {
+	assert.True(t, isCanvasFile(slack.File{Filetype: "canvas"}))
+	assert.True(t, isCanvasFile(slack.File{Filetype: "quip"}))
+	assert.True(t, isCanvasFile(slack.File{Mimetype: "application/vnd.slack-docs"}))
+	assert.False(t, isCanvasFile(slack.File{Filetype: "text", Mimetype: "text/plain"}))
+}
+
+func TestUnitCanvasError(t *testing.T) {
+	te := AsToolError(canvasError("canvases_sections_list", canvasSectionsScope, slack.SlackErrorResponse{Err: "missing_scope"}))
+	assert.Equal(t, ErrCodeCapabilityUnsupported, te.Code)
+	assert.Contains(t, te.Message, "canvases:read")
+
+	te = AsToolError(canvasError("conversations_canvas_get", canvasFilesScope, errors.New("boom")))
+	assert.NotEqual(t, ErrCodeCapabilityUnsupported, te.Code)
+}
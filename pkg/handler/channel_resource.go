@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// channelResourceMessages is how many recent messages a channel resource renders
+const channelResourceMessages = 50
+
+// ChannelResourceURI returns the URI of the resource with the recent history of a channel
+func ChannelResourceURI(ws, channelID string) string {
+	return "slack://" + ws + "/channel/" + channelID
+}
+
+// ChannelResourceURITemplate is the URI template matching ChannelResourceURI of any channel
+func ChannelResourceURITemplate(ws string) string {
+	return "slack://" + ws + "/channel/{channel_id}"
+}
+
+// channelIDFromResourceURI returns the channel ID of a URI built by ChannelResourceURI
+func channelIDFromResourceURI(uri string) (string, bool) {
+	path, ok := strings.CutPrefix(uri, "slack://")
+	if !ok {
+		return "", false
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 || parts[1] != "channel" || idKind(strings.ToUpper(parts[2])) != ResolvedKindChannel {
+		return "", false
+	}
+	return strings.ToUpper(parts[2]), true
+}
+
+// ChannelResource renders the recent messages of a channel as CSV, it serves both the channels listed as
+// resources and any channel ID read through the URI template
+func (ch *ConversationsHandler) ChannelResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ChannelResource called", zap.Any("params", request.Params))
+
+	// mark3labs/mcp-go does not support middlewares for resources.
+	if authenticated, err := auth.IsAuthenticated(ctx, ch.apiProvider.ServerTransport(), logger); !authenticated {
+		logger.Error("Authentication failed for channel resource", zap.Error(err))
+		return nil, err
+	}
+
+	channelID, ok := channelIDFromResourceURI(request.Params.URI)
+	if !ok {
+		return nil, invalidArgumentError(errors.New("channel resource URI must look like slack://<workspace>/channel/<channel ID>, got " + request.Params.URI))
+	}
+	if err := checkReadPolicy(logger, "channel resource", channelID); err != nil {
+		return nil, err
+	}
+
+	var history *slack.GetConversationHistoryResponse
+	err := readChannel(ctx, ch.apiProvider.Slack(), logger, "channel resource", channelID, func() (err error) {
+		history, err = ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channelID,
+			Limit:     channelResourceMessages,
+		})
+		return err
+	})
+	if err != nil {
+		logger.Error("GetConversationHistoryContext failed", zap.String("channel", channelID), zap.Error(err))
+		if provider.SlackErrorCode(err) == "channel_not_found" {
+			return nil, notFoundError("channel %s not found or not accessible to the current token", channelID)
+		}
+		return nil, slackAPIError(err)
+	}
+	logger.Debug("Fetched channel resource history", zap.String("channel", channelID), zap.Int("message_count", len(history.Messages)))
+
+	if err := resolveMessageReferences(ctx, ch.apiProvider, history.Messages); err != nil {
+		logger.Error("Failed to resolve message references", zap.Error(err))
+		return nil, err
+	}
+	messages := ch.convertMessagesFromHistory(history.Messages, channelID, historyOptions{render: true, edits: true})
+
+	csvBytes, err := gocsv.MarshalBytes(&messages)
+	if err != nil {
+		logger.Error("Failed to marshal channel history to CSV", zap.Error(err))
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "text/csv",
+			Text:     string(csvBytes),
+		},
+	}, nil
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitChannelIDFromResourceURI(t *testing.T) {
+	tests := []struct {
+		uri    string
+		wantID string
+		wantOK bool
+	}{
+		{ChannelResourceURI("acme", "C0123ABCD"), "C0123ABCD", true},
+		{"slack://acme/channel/d0123abcd", "D0123ABCD", true},
+		{"slack://acme/channel/U0123ABCD", "", false},
+		{"slack://acme/channels", "", false},
+		{"slack://acme/channel/C0123ABCD/extra", "", false},
+		{"https://acme.slack.com/archives/C0123ABCD", "", false},
+	}
+
+	for _, tt := range tests {
+		id, ok := channelIDFromResourceURI(tt.uri)
+		assert.Equal(t, tt.wantOK, ok, tt.uri)
+		assert.Equal(t, tt.wantID, id, tt.uri)
+	}
+}
@@ -3,12 +3,16 @@ package handler
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gocarina/gocsv"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
 	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
 	"github.com/korotovsky/slack-mcp-server/pkg/text"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,7 +25,42 @@ type Channel struct {
 	Topic       string `json:"topic"`
 	Purpose     string `json:"purpose"`
 	MemberCount int    `json:"memberCount"`
-	Cursor      string `json:"cursor"`
+	// Created is the creation date of the channel, empty when the cache predates it
+	Created string `json:"created"`
+	Cursor  string `json:"cursor"`
+}
+
+// Sort orders of channels_list, popularity only orders the rows of each page
+const (
+	ChannelSortPopularity = "popularity"
+	ChannelSortNone       = "none"
+	ChannelSortMembers    = "members"
+	ChannelSortName       = "name"
+	ChannelSortCreated    = "created"
+)
+
+// channelFilter narrows channels_list by member count and name, zero values don't filter
+type channelFilter struct {
+	minMembers   int
+	maxMembers   int
+	nameContains string
+}
+
+// Statuses of a channels_resolve row
+const (
+	ChannelMatchFound     = "found"
+	ChannelMatchCandidate = "candidate"
+	ChannelMatchNotFound  = "not_found"
+)
+
+// maxChannelCandidates caps fuzzy suggestions returned per unknown name
+const maxChannelCandidates = 5
+
+type ChannelMatch struct {
+	Input  string `json:"input"`
+	Status string `json:"status"`
+	ID     string `json:"id"`
+	Name   string `json:"name"`
 }
 
 type ChannelsHandler struct {
@@ -44,30 +83,32 @@ func NewChannelsHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *
 }
 
 func (ch *ChannelsHandler) ChannelsResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	ch.logger.Debug("ChannelsResource called", zap.Any("params", request.Params))
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ChannelsResource called", zap.Any("params", request.Params))
 
 	// mark3labs/mcp-go does not support middlewares for resources.
-	if authenticated, err := auth.IsAuthenticated(ctx, ch.apiProvider.ServerTransport(), ch.logger); !authenticated {
-		ch.logger.Error("Authentication failed for channels resource", zap.Error(err))
+	if authenticated, err := auth.IsAuthenticated(ctx, ch.apiProvider.ServerTransport(), logger); !authenticated {
+		logger.Error("Authentication failed for channels resource", zap.Error(err))
 		return nil, err
 	}
 
 	var channelList []Channel
 
-	if ready, err := ch.apiProvider.IsReady(); !ready {
-		ch.logger.Error("API provider not ready", zap.Error(err))
+	// channels only need the channels cache, DM names fall back to user IDs while users are missing
+	if ready, err := ch.apiProvider.CollectionReady(provider.RefreshChannels); !ready {
+		logger.Error("Channels cache not ready", zap.Error(err))
 		return nil, err
 	}
 
 	ar, err := ch.apiProvider.Slack().AuthTest()
 	if err != nil {
-		ch.logger.Error("Auth test failed", zap.Error(err))
+		logger.Error("Auth test failed", zap.Error(err))
 		return nil, err
 	}
 
 	ws, err := text.Workspace(ar.URL)
 	if err != nil {
-		ch.logger.Error("Failed to parse workspace from URL",
+		logger.Error("Failed to parse workspace from URL",
 			zap.String("url", ar.URL),
 			zap.Error(err),
 		)
@@ -75,7 +116,7 @@ func (ch *ChannelsHandler) ChannelsResource(ctx context.Context, request mcp.Rea
 	}
 
 	channels := ch.apiProvider.ProvideChannelsMaps().Channels
-	ch.logger.Debug("Retrieved channels from provider", zap.Int("count", len(channels)))
+	logger.Debug("Retrieved channels from provider", zap.Int("count", len(channels)))
 
 	for _, channel := range channels {
 		channelList = append(channelList, Channel{
@@ -84,12 +125,13 @@ func (ch *ChannelsHandler) ChannelsResource(ctx context.Context, request mcp.Rea
 			Topic:       channel.Topic,
 			Purpose:     channel.Purpose,
 			MemberCount: channel.MemberCount,
+			Created:     channelCreated(channel),
 		})
 	}
 
 	csvBytes, err := gocsv.MarshalBytes(&channelList)
 	if err != nil {
-		ch.logger.Error("Failed to marshal channels to CSV", zap.Error(err))
+		logger.Error("Failed to marshal channels to CSV", zap.Error(err))
 		return nil, err
 	}
 
@@ -103,23 +145,33 @@ func (ch *ChannelsHandler) ChannelsResource(ctx context.Context, request mcp.Rea
 }
 
 func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("ChannelsHandler called")
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ChannelsHandler called")
 
-	if ready, err := ch.apiProvider.IsReady(); !ready {
-		ch.logger.Error("API provider not ready", zap.Error(err))
-		return nil, err
+	if ready, err := ch.apiProvider.CollectionReady(provider.RefreshChannels); !ready {
+		logger.Error("Channels cache not ready", zap.Error(err))
+		return nil, cacheNotReadyError(err)
 	}
 
-	sortType := request.GetString("sort", "popularity")
+	sortType := request.GetString("sort", ChannelSortPopularity)
 	types := request.GetString("channel_types", provider.PubChanType)
 	cursor := request.GetString("cursor", "")
 	limit := request.GetInt("limit", 0)
+	teamID := strings.TrimSpace(request.GetString("team_id", ""))
 
-	ch.logger.Debug("Request parameters",
+	filter, err := parseChannelFilter(request)
+	if err != nil {
+		return nil, invalidArgumentError(err)
+	}
+
+	logger.Debug("Request parameters",
 		zap.String("sort", sortType),
 		zap.String("channel_types", types),
 		zap.String("cursor", cursor),
 		zap.Int("limit", limit),
+		zap.Int("min_members", filter.minMembers),
+		zap.Int("max_members", filter.maxMembers),
+		zap.String("name_contains", filter.nameContains),
 	)
 
 	// MCP Inspector v0.14.0 has issues with Slice type
@@ -130,26 +182,27 @@ func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.Call
 		if ch.validTypes[t] {
 			channelTypes = append(channelTypes, t)
 		} else if t != "" {
-			ch.logger.Warn("Invalid channel type ignored", zap.String("type", t))
+			logger.Warn("Invalid channel type ignored", zap.String("type", t))
 		}
 	}
 
 	if len(channelTypes) == 0 {
-		ch.logger.Debug("No valid channel types provided, using defaults")
+		logger.Debug("No valid channel types provided, using defaults")
 		channelTypes = append(channelTypes, provider.PubChanType)
 		channelTypes = append(channelTypes, provider.PrivateChanType)
 	}
 
-	ch.logger.Debug("Validated channel types", zap.Strings("types", channelTypes))
+	logger.Debug("Validated channel types", zap.Strings("types", channelTypes))
 
 	if limit == 0 {
 		limit = 100
-		ch.logger.Debug("Limit not provided, using default", zap.Int("limit", limit))
+		logger.Debug("Limit not provided, using default", zap.Int("limit", limit))
 	}
 	if limit > 999 {
-		ch.logger.Warn("Limit exceeds maximum, capping to 999", zap.Int("requested", limit))
+		logger.Warn("Limit exceeds maximum, capping to 999", zap.Int("requested", limit))
 		limit = 999
 	}
+	limit = capLimit(limit)
 
 	var (
 		nextcur     string
@@ -157,20 +210,34 @@ func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.Call
 	)
 
 	allChannels := ch.apiProvider.ProvideChannelsMaps().Channels
-	ch.logger.Debug("Total channels available", zap.Int("count", len(allChannels)))
+	logger.Debug("Total channels available", zap.Int("count", len(allChannels)))
 
 	channels := filterChannelsByTypes(allChannels, channelTypes)
-	ch.logger.Debug("Channels after filtering by type", zap.Int("count", len(channels)))
+	logger.Debug("Channels after filtering by type", zap.Int("count", len(channels)))
+
+	if teamID != "" {
+		channels = filterChannelsByTeam(channels, teamID)
+		logger.Debug("Channels after filtering by team", zap.String("team_id", teamID), zap.Int("count", len(channels)))
+	}
+
+	channels = filterChannels(channels, filter)
+	matched := len(channels)
+	logger.Debug("Channels after filtering by members and name", zap.Int("count", matched))
+
+	// members, name and created order the whole result so pages follow each other, the other
+	// orders walk the channels by ID
+	sortChannels(channels, sortType)
 
 	var chans []provider.Channel
 
 	chans, nextcur = paginateChannels(
 		channels,
+		sortType,
 		cursor,
 		limit,
 	)
 
-	ch.logger.Debug("Pagination results",
+	logger.Debug("Pagination results",
 		zap.Int("returned_count", len(chans)),
 		zap.Bool("has_next_page", nextcur != ""),
 	)
@@ -182,31 +249,244 @@ func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.Call
 			Topic:       channel.Topic,
 			Purpose:     channel.Purpose,
 			MemberCount: channel.MemberCount,
+			Created:     channelCreated(channel),
 		})
 	}
 
 	switch sortType {
-	case "popularity":
-		ch.logger.Debug("Sorting channels by popularity (member count)")
+	case ChannelSortPopularity:
+		logger.Debug("Sorting channels by popularity (member count)")
 		sort.Slice(channelList, func(i, j int) bool {
 			return channelList[i].MemberCount > channelList[j].MemberCount
 		})
 	default:
-		ch.logger.Debug("No sorting applied", zap.String("sort_type", sortType))
+		logger.Debug("No sorting applied", zap.String("sort_type", sortType))
 	}
 
 	if len(channelList) > 0 && nextcur != "" {
 		channelList[len(channelList)-1].Cursor = nextcur
-		ch.logger.Debug("Added cursor to last channel", zap.String("cursor", nextcur))
+		logger.Debug("Added cursor to last channel", zap.String("cursor", nextcur))
 	}
 
-	csvBytes, err := gocsv.MarshalBytes(&channelList)
+	res, err := marshalCSVResult(channelList)
 	if err != nil {
-		ch.logger.Error("Failed to marshal channels to CSV", zap.Error(err))
 		return nil, err
 	}
+	if res.Meta == nil {
+		res.Meta = map[string]any{}
+	}
+	res.Meta["matched"] = matched
+	return res, nil
+}
+
+// parseChannelFilter reads min_members, max_members and name_contains of channels_list
+func parseChannelFilter(request mcp.CallToolRequest) (channelFilter, error) {
+	filter := channelFilter{
+		minMembers:   request.GetInt("min_members", 0),
+		maxMembers:   request.GetInt("max_members", 0),
+		nameContains: strings.ToLower(strings.TrimLeft(strings.TrimSpace(request.GetString("name_contains", "")), "#@")),
+	}
+	if filter.minMembers < 0 || filter.maxMembers < 0 {
+		return channelFilter{}, errors.New("min_members and max_members must not be negative")
+	}
+	if filter.maxMembers > 0 && filter.minMembers > filter.maxMembers {
+		return channelFilter{}, fmt.Errorf("min_members %d is greater than max_members %d", filter.minMembers, filter.maxMembers)
+	}
+	return filter, nil
+}
+
+// filterChannels keeps the channels within the member bounds whose name contains the filter text, ignoring case
+func filterChannels(channels []provider.Channel, filter channelFilter) []provider.Channel {
+	if filter == (channelFilter{}) {
+		return channels
+	}
+	var result []provider.Channel
+	for _, c := range channels {
+		if c.MemberCount < filter.minMembers || (filter.maxMembers > 0 && c.MemberCount > filter.maxMembers) {
+			continue
+		}
+		if filter.nameContains != "" && !strings.Contains(strings.ToLower(c.Name), filter.nameContains) {
+			continue
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// sortChannels orders channels by most members, name or newest first, ties and the other orders by ID
+func sortChannels(channels []provider.Channel, sortType string) {
+	sort.SliceStable(channels, func(i, j int) bool {
+		return channelLess(channels[i], channels[j], sortType)
+	})
+}
+
+// channelLess reports whether a sorts before b in the order of sortType, see sortChannels
+func channelLess(a, b provider.Channel, sortType string) bool {
+	switch sortType {
+	case ChannelSortMembers:
+		if a.MemberCount != b.MemberCount {
+			return a.MemberCount > b.MemberCount
+		}
+	case ChannelSortName:
+		if an, bn := strings.ToLower(a.Name), strings.ToLower(b.Name); an != bn {
+			return an < bn
+		}
+	case ChannelSortCreated:
+		if a.Created != b.Created {
+			return a.Created > b.Created
+		}
+	}
+	return a.ID < b.ID
+}
+
+// channelCursor encodes the position after channel c: its ID and, for the members, name and created orders,
+// its sort key as "ID|sort|key", so the next page starts at the right place even when c is gone
+func channelCursor(c provider.Channel, sortType string) string {
+	raw := c.ID
+	switch sortType {
+	case ChannelSortMembers:
+		raw += "|" + sortType + "|" + strconv.Itoa(c.MemberCount)
+	case ChannelSortName:
+		raw += "|" + sortType + "|" + c.Name
+	case ChannelSortCreated:
+		raw += "|" + sortType + "|" + strconv.FormatInt(c.Created, 10)
+	}
+	return base64.StdEncoding.EncodeToString([]byte(raw))
+}
+
+// parseChannelCursor decodes a cursor of channelCursor into the channel it points after, the sort key must
+// belong to sortType
+func parseChannelCursor(cursor, sortType string) (provider.Channel, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return provider.Channel{}, err
+	}
+
+	parts := strings.SplitN(string(decoded), "|", 3)
+	last := provider.Channel{ID: parts[0]}
+	switch sortType {
+	case ChannelSortMembers, ChannelSortName, ChannelSortCreated:
+	default:
+		return last, nil
+	}
+	if len(parts) != 3 || parts[1] != sortType {
+		return provider.Channel{}, fmt.Errorf("cursor was not issued for sort %q", sortType)
+	}
+
+	switch sortType {
+	case ChannelSortMembers:
+		last.MemberCount, err = strconv.Atoi(parts[2])
+	case ChannelSortName:
+		last.Name = parts[2]
+	case ChannelSortCreated:
+		last.Created, err = strconv.ParseInt(parts[2], 10, 64)
+	}
+	return last, err
+}
 
-	return mcp.NewToolResultText(string(csvBytes)), nil
+// channelCreated formats the creation date of a cached channel
+func channelCreated(c provider.Channel) string {
+	if c.Created == 0 {
+		return ""
+	}
+	return time.Unix(c.Created, 0).UTC().Format(time.DateOnly)
+}
+
+// ChannelsResolveHandler resolves channel names to IDs using the channels cache
+func (ch *ChannelsHandler) ChannelsResolveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ChannelsResolveHandler called", zap.Any("params", request.Params))
+
+	var names []string
+	for _, n := range strings.Split(request.GetString("names", ""), ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	if len(names) == 0 {
+		return nil, invalidArgumentError(errors.New("names must be a comma-separated list of channel names, e.g. '#general,random'"))
+	}
+
+	if ready, err := ch.apiProvider.CollectionReady(provider.RefreshChannels); !ready {
+		logger.Error("Channels cache not ready", zap.Error(err))
+		return nil, cacheNotReadyError(err)
+	}
+
+	fuzzy := request.GetBool("fuzzy", false)
+	matches := resolveChannelNames(ch.apiProvider.ProvideChannelsMaps(), names, fuzzy)
+	logger.Debug("Resolved channel names",
+		zap.Int("inputs", len(names)),
+		zap.Int("rows", len(matches)),
+		zap.Bool("fuzzy", fuzzy),
+	)
+
+	return marshalCSVResult(matches)
+}
+
+// resolveChannelNames returns one found or not_found row per name. With fuzzy enabled, names
+// without an exact match get up to maxChannelCandidates candidate rows instead, prefix matches first.
+func resolveChannelNames(cache *provider.ChannelsCache, names []string, fuzzy bool) []ChannelMatch {
+	var result []ChannelMatch
+	for _, input := range names {
+		name := normalizeChannelName(input)
+		if id, ok := cache.ChannelsInv[name]; ok {
+			result = append(result, ChannelMatch{Input: input, Status: ChannelMatchFound, ID: id, Name: name})
+			continue
+		}
+
+		var candidates []ChannelMatch
+		if fuzzy {
+			candidates = channelCandidates(cache.Channels, name)
+		}
+		if len(candidates) == 0 {
+			result = append(result, ChannelMatch{Input: input, Status: ChannelMatchNotFound})
+			continue
+		}
+		for _, c := range candidates {
+			c.Input = input
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func channelCandidates(channels map[string]provider.Channel, name string) []ChannelMatch {
+	sigil, query := name[:1], name[1:]
+
+	var prefix, contains []ChannelMatch
+	for _, c := range channels {
+		if !strings.HasPrefix(c.Name, sigil) {
+			continue
+		}
+		candidate := strings.ToLower(c.Name[1:])
+		switch {
+		case strings.HasPrefix(candidate, query):
+			prefix = append(prefix, ChannelMatch{Status: ChannelMatchCandidate, ID: c.ID, Name: c.Name})
+		case strings.Contains(candidate, query):
+			contains = append(contains, ChannelMatch{Status: ChannelMatchCandidate, ID: c.ID, Name: c.Name})
+		}
+	}
+
+	byName := func(s []ChannelMatch) {
+		sort.Slice(s, func(i, j int) bool { return s[i].Name < s[j].Name })
+	}
+	byName(prefix)
+	byName(contains)
+
+	candidates := append(prefix, contains...)
+	if len(candidates) > maxChannelCandidates {
+		candidates = candidates[:maxChannelCandidates]
+	}
+	return candidates
+}
+
+// normalizeChannelName lowercases a channel name and adds the leading # unless it is a DM name starting with @
+func normalizeChannelName(raw string) string {
+	name := strings.ToLower(strings.TrimSpace(raw))
+	if strings.HasPrefix(name, "#") || strings.HasPrefix(name, "@") {
+		return name
+	}
+	return "#" + name
 }
 
 func filterChannelsByTypes(channels map[string]provider.Channel, types []string) []provider.Channel {
@@ -255,26 +535,52 @@ func filterChannelsByTypes(channels map[string]provider.Channel, types []string)
 	return result
 }
 
-func paginateChannels(channels []provider.Channel, cursor string, limit int) ([]provider.Channel, string) {
-	logger := zap.L()
+// filterChannelsByTeam keeps the channels of one team of an Enterprise Grid org
+func filterChannelsByTeam(channels []provider.Channel, teamID string) []provider.Channel {
+	var result []provider.Channel
+	for _, c := range channels {
+		if c.TeamID == teamID {
+			result = append(result, c)
+		}
+	}
+	return result
+}
 
-	sort.Slice(channels, func(i, j int) bool {
-		return channels[i].ID < channels[j].ID
-	})
+// lookupChannelID resolves a #channel or @user DM name through the channels cache, teamID picks the
+// team when the name exists in several teams of an Enterprise Grid org
+func lookupChannelID(apiProvider *provider.ApiProvider, name, teamID string) (string, error) {
+	id, err := apiProvider.LookupChannel(name, teamID)
+	if errors.Is(err, provider.ErrAmbiguousName) {
+		return "", invalidArgumentError(fmt.Errorf("channel %q: %w", name, err))
+	}
+	return id, err
+}
+
+// channelNameReady returns CACHE_NOT_READY while a cache name is looked up in is loading: #channel names only
+// need the channels cache, @user DM names are named after their user and also need the users cache
+func channelNameReady(apiProvider *provider.ApiProvider, name string) error {
+	ready, err := apiProvider.CollectionReady(provider.RefreshChannels)
+	if ready && strings.HasPrefix(name, "@") {
+		ready, err = apiProvider.CollectionReady(provider.RefreshUsers)
+	}
+	if !ready {
+		return cacheNotReadyError(fmt.Errorf("channel %q not found in empty cache: %w", name, err))
+	}
+	return nil
+}
+
+// paginateChannels returns a page of channels sorted by sortChannels. The cursor points after the last channel
+// of the previous page, when that channel is gone the page starts at the next channel in the sortType order.
+func paginateChannels(channels []provider.Channel, sortType, cursor string, limit int) ([]provider.Channel, string) {
+	logger := zap.L()
 
 	startIndex := 0
 	if cursor != "" {
-		if decoded, err := base64.StdEncoding.DecodeString(cursor); err == nil {
-			lastID := string(decoded)
-			for i, ch := range channels {
-				if ch.ID > lastID {
-					startIndex = i
-					break
-				}
-			}
+		if last, err := parseChannelCursor(cursor, sortType); err == nil {
+			startIndex = channelIndexAfter(channels, last, sortType)
 			logger.Debug("Decoded cursor",
 				zap.String("cursor", cursor),
-				zap.String("decoded_id", lastID),
+				zap.String("decoded_id", last.ID),
 				zap.Int("start_index", startIndex),
 			)
 		} else {
@@ -294,7 +600,7 @@ func paginateChannels(channels []provider.Channel, cursor string, limit int) ([]
 
 	var nextCursor string
 	if endIndex < len(channels) {
-		nextCursor = base64.StdEncoding.EncodeToString([]byte(channels[endIndex-1].ID))
+		nextCursor = channelCursor(channels[endIndex-1], sortType)
 		logger.Debug("Generated next cursor",
 			zap.String("last_id", channels[endIndex-1].ID),
 			zap.String("next_cursor", nextCursor),
@@ -311,3 +617,19 @@ func paginateChannels(channels []provider.Channel, cursor string, limit int) ([]
 
 	return paged, nextCursor
 }
+
+// channelIndexAfter returns the index following the channel last, or of the first channel sorting after it in
+// the sortType order when last is no longer listed, len(channels) when there is none
+func channelIndexAfter(channels []provider.Channel, last provider.Channel, sortType string) int {
+	for i, c := range channels {
+		if c.ID == last.ID {
+			return i + 1
+		}
+	}
+	for i, c := range channels {
+		if channelLess(last, c, sortType) {
+			return i
+		}
+	}
+	return len(channels)
+}
@@ -12,7 +12,9 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
 	"github.com/korotovsky/slack-mcp-server/pkg/test/util"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/openai/openai-go/packages/param"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -219,3 +221,122 @@ func TestIntegrationChannelsList(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitResolveChannelNames(t *testing.T) {
+	cache := &provider.ChannelsCache{
+		Channels: map[string]provider.Channel{
+			"C1": {ID: "C1", Name: "#engineering"},
+			"C2": {ID: "C2", Name: "#eng-oncall"},
+			"C3": {ID: "C3", Name: "#platform-eng"},
+			"C4": {ID: "C4", Name: "#general"},
+			"D1": {ID: "D1", Name: "@alice"},
+		},
+		ChannelsInv: map[string]string{
+			"#engineering":  "C1",
+			"#eng-oncall":   "C2",
+			"#platform-eng": "C3",
+			"#general":      "C4",
+			"@alice":        "D1",
+		},
+	}
+
+	got := resolveChannelNames(cache, []string{"#general", "Engineering", "@alice", "eng", "missing"}, false)
+	assert.Equal(t, []ChannelMatch{
+		{Input: "#general", Status: ChannelMatchFound, ID: "C4", Name: "#general"},
+		{Input: "Engineering", Status: ChannelMatchFound, ID: "C1", Name: "#engineering"},
+		{Input: "@alice", Status: ChannelMatchFound, ID: "D1", Name: "@alice"},
+		{Input: "eng", Status: ChannelMatchNotFound},
+		{Input: "missing", Status: ChannelMatchNotFound},
+	}, got)
+
+	got = resolveChannelNames(cache, []string{"eng", "missing"}, true)
+	assert.Equal(t, []ChannelMatch{
+		{Input: "eng", Status: ChannelMatchCandidate, ID: "C2", Name: "#eng-oncall"},
+		{Input: "eng", Status: ChannelMatchCandidate, ID: "C1", Name: "#engineering"},
+		{Input: "eng", Status: ChannelMatchCandidate, ID: "C3", Name: "#platform-eng"},
+		{Input: "missing", Status: ChannelMatchNotFound},
+	}, got)
+}
+
+func TestUnitFilterAndSortChannels(t *testing.T) {
+	channels := []provider.Channel{
+		{ID: "C1", Name: "#engineering", MemberCount: 120, Created: 1600000000},
+		{ID: "C2", Name: "#eng-oncall", MemberCount: 8, Created: 1700000000},
+		{ID: "C3", Name: "#General", MemberCount: 500, Created: 1500000000},
+		{ID: "C4", Name: "#random", MemberCount: 120, Created: 1650000000},
+	}
+	ids := func(chans []provider.Channel) []string {
+		var out []string
+		for _, c := range chans {
+			out = append(out, c.ID)
+		}
+		return out
+	}
+
+	got := filterChannels(channels, channelFilter{minMembers: 100})
+	assert.Equal(t, []string{"C1", "C3", "C4"}, ids(got))
+
+	got = filterChannels(channels, channelFilter{minMembers: 10, maxMembers: 200, nameContains: "eng"})
+	assert.Equal(t, []string{"C1"}, ids(got))
+
+	got = filterChannels(channels, channelFilter{nameContains: "general"})
+	assert.Equal(t, []string{"C3"}, ids(got))
+
+	assert.Len(t, filterChannels(channels, channelFilter{}), 4)
+
+	sorted := append([]provider.Channel(nil), channels...)
+	sortChannels(sorted, ChannelSortMembers)
+	assert.Equal(t, []string{"C3", "C1", "C4", "C2"}, ids(sorted))
+
+	sortChannels(sorted, ChannelSortName)
+	assert.Equal(t, []string{"C2", "C1", "C3", "C4"}, ids(sorted))
+
+	sortChannels(sorted, ChannelSortCreated)
+	assert.Equal(t, []string{"C2", "C4", "C1", "C3"}, ids(sorted))
+
+	sortChannels(sorted, ChannelSortPopularity)
+	assert.Equal(t, []string{"C1", "C2", "C3", "C4"}, ids(sorted))
+}
+
+func TestUnitPaginateSortedChannels(t *testing.T) {
+	channels := []provider.Channel{
+		{ID: "C3", MemberCount: 500},
+		{ID: "C1", MemberCount: 120},
+		{ID: "C4", MemberCount: 120},
+		{ID: "C2", MemberCount: 8},
+	}
+
+	page, cursor := paginateChannels(channels, ChannelSortMembers, "", 2)
+	assert.Equal(t, []provider.Channel{channels[0], channels[1]}, page)
+	require.NotEmpty(t, cursor)
+
+	next, nextCursor := paginateChannels(channels, ChannelSortMembers, cursor, 2)
+	assert.Equal(t, []provider.Channel{channels[2], channels[3]}, next)
+	assert.Empty(t, nextCursor)
+
+	// C1 left the cache, the next page still starts after its member count instead of at a greater ID
+	gone := []provider.Channel{channels[0], channels[2], channels[3]}
+	next, _ = paginateChannels(gone, ChannelSortMembers, cursor, 2)
+	assert.Equal(t, []provider.Channel{channels[2], channels[3]}, next)
+
+	_, err := parseChannelCursor(cursor, ChannelSortName)
+	assert.Error(t, err)
+}
+
+func TestUnitParseChannelFilter(t *testing.T) {
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = args
+		return r
+	}
+
+	filter, err := parseChannelFilter(request(map[string]any{"min_members": 10, "max_members": 100, "name_contains": " #Eng "}))
+	require.NoError(t, err)
+	assert.Equal(t, channelFilter{minMembers: 10, maxMembers: 100, nameContains: "eng"}, filter)
+
+	_, err = parseChannelFilter(request(map[string]any{"min_members": 100, "max_members": 10}))
+	assert.Error(t, err)
+
+	_, err = parseChannelFilter(request(map[string]any{"min_members": -1}))
+	assert.Error(t, err)
+}
@@ -3,19 +3,27 @@ package handler
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gocarina/gocsv"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge/fasttime"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
 	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
 	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/korotovsky/slack-mcp-server/pkg/webhook"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/slack-go/slack"
 	slackGoUtil "github.com/takara2314/slack-go-util"
@@ -25,6 +33,35 @@ import (
 const (
 	defaultConversationsNumericLimit    = 50
 	defaultConversationsExpressionLimit = "1d"
+
+	// maxGroupDMUsers is the number of other users conversations.open accepts for a group DM
+	maxGroupDMUsers = 8
+
+	defaultMembersLimit = 100
+	// maxMembersLimit is the largest page conversations.members returns
+	maxMembersLimit = 1000
+	// usersInfoBatchSize is the number of user IDs resolved per users.info call
+	usersInfoBatchSize = 100
+
+	defaultScheduledLimit = 50
+	// maxScheduledLimit is the largest page chat.scheduledMessages.list returns
+	maxScheduledLimit = 1000
+	// scheduledPreviewChars is the length of scheduled message text previews
+	scheduledPreviewChars = 200
+
+	defaultDMsLimit = 50
+	// maxDMsLimit keeps the per-DM latest message lookups of a page bounded
+	maxDMsLimit = 200
+
+	defaultUnreadLimit = 20
+	// maxUnreadLimit bounds the conversations.history calls made to count unread messages
+	maxUnreadLimit = 100
+	// maxUnreadCount is the most unread messages counted per channel
+	maxUnreadCount = 100
+
+	// maxTopicChars and maxPurposeChars are the lengths Slack accepts for channel metadata
+	maxTopicChars   = 250
+	maxPurposeChars = 250
 )
 
 var validFilterKeys = map[string]struct{}{
@@ -38,37 +75,148 @@ var validFilterKeys = map[string]struct{}{
 	"during": {},
 }
 
+var messageTimestampRe = regexp.MustCompile(`^\d+\.\d+$`)
+
+var userIDRe = regexp.MustCompile(`^[UW][A-Z0-9]{2,}$`)
+
 type Message struct {
-	MsgID    string `json:"msgID"`
+	MsgID     string `json:"msgID"`
+	UserID    string `json:"userID"`
+	UserName  string `json:"userUser"`
+	RealName  string `json:"realName"`
+	Channel   string `json:"channelID"`
+	ThreadTs  string `json:"ThreadTs"`
+	Text      string `json:"text"`
+	Time      string `json:"time"`
+	Truncated bool   `json:"truncated"`
+	EditedBy  string `json:"editedBy"`
+	EditedTs  string `json:"editedTs"`
+	Cursor    string `json:"cursor"`
+}
+
+type User struct {
 	UserID   string `json:"userID"`
-	UserName string `json:"userUser"`
+	UserName string `json:"userName"`
 	RealName string `json:"realName"`
-	Channel  string `json:"channelID"`
-	ThreadTs string `json:"ThreadTs"`
-	Text     string `json:"text"`
-	Time     string `json:"time"`
-	Cursor   string `json:"cursor"`
 }
 
-type User struct {
+type ReadCursor struct {
+	ChannelID string `json:"channelID"`
+	LastRead  string `json:"lastRead"`
+}
+
+type OpenedConversation struct {
+	ChannelID   string `json:"channelID"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	AlreadyOpen bool   `json:"alreadyOpen"`
+}
+
+type ChannelMember struct {
 	UserID   string `json:"userID"`
 	UserName string `json:"userName"`
 	RealName string `json:"realName"`
+	Cursor   string `json:"cursor"`
+}
+
+type DirectMessage struct {
+	ChannelID    string `json:"channelID"`
+	Type         string `json:"type"`
+	Name         string `json:"name"`
+	Participants string `json:"participants"`
+	LatestTs     string `json:"latestTs"`
+	Cursor       string `json:"cursor"`
+}
+
+type UnreadCount struct {
+	ChannelID    string `json:"channelID"`
+	ChannelName  string `json:"channelName"`
+	UnreadCount  int    `json:"unreadCount"`
+	UnreadCapped bool   `json:"unreadCapped"`
+	MentionCount int    `json:"mentionCount"`
+	LastRead     string `json:"lastRead"`
+	LatestTs     string `json:"latestTs"`
+}
+
+type ScheduledMessage struct {
+	ScheduledMessageID string `json:"scheduledMessageID"`
+	ChannelID          string `json:"channelID"`
+	ChannelName        string `json:"channelName"`
+	PostAt             string `json:"postAt"`
+	Created            string `json:"created"`
+	TextPreview        string `json:"textPreview"`
+	Cursor             string `json:"cursor"`
+}
+
+type scheduledListParams struct {
+	channel string
+	oldest  string
+	latest  string
+	limit   int
+	cursor  string
+	render  bool
 }
 
 type conversationParams struct {
-	channel  string
-	limit    int
-	oldest   string
-	latest   string
-	cursor   string
+	channel string
+	limit   int
+	oldest  string
+	latest  string
+	cursor  string
+	historyOptions
+}
+
+// historyOptions select what convertMessagesFromHistory keeps and how it renders messages
+type historyOptions struct {
+	// activity keeps join/leave and other subtype messages
 	activity bool
+	// render expands Slack markup in the text
+	render bool
+	// edits fills editedBy and editedTs and marks the text of edited messages with "(edited)"
+	edits bool
+	// excludeBots drops messages posted by bots and apps, see isBotMessage
+	excludeBots bool
 }
 
 type searchParams struct {
-	query string
-	limit int
-	page  int
+	query  string
+	limit  int
+	page   int
+	render bool
+}
+
+type EphemeralMessage struct {
+	ChannelID string `json:"channelID"`
+	UserID    string `json:"userID"`
+	Delivery  string `json:"delivery"`
+}
+
+type ephemeralParams struct {
+	channel string
+	user    string
+	text    string
+	blocks  []slack.Block
+}
+
+// Statuses of a conversations_acknowledge step
+const (
+	AcknowledgeStepOK     = "ok"
+	AcknowledgeStepFailed = "failed"
+)
+
+type AcknowledgeStep struct {
+	Step      string `json:"step"`
+	Status    string `json:"status"`
+	ChannelID string `json:"channelID"`
+	Ts        string `json:"ts"`
+	Error     string `json:"error"`
+}
+
+type acknowledgeParams struct {
+	channel  string
+	ts       string
+	reaction string
+	text     string
 }
 
 type addMessageParams struct {
@@ -76,46 +224,61 @@ type addMessageParams struct {
 	threadTs    string
 	text        string
 	contentType string
+	username    string
+	iconEmoji   string
+	iconURL     string
+	blocks      []slack.Block
+	// allowBroadcast keeps @channel, @here and @everyone and lets Slack link them
+	allowBroadcast bool
+	// replyBroadcast also shows a thread reply in the channel
+	replyBroadcast bool
 }
 
 type ConversationsHandler struct {
 	apiProvider *provider.ApiProvider
 	logger      *zap.Logger
+	// analytics caches conversations_analytics results
+	analytics *analyticsCache
+	// unfurls fetches the pages chat_unfurl_preview has no stored unfurl for
+	unfurls *ogFetcher
 }
 
 func NewConversationsHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *ConversationsHandler {
 	return &ConversationsHandler{
 		apiProvider: apiProvider,
 		logger:      logger,
+		analytics:   newAnalyticsCache(),
+		unfurls:     newOGFetcher(),
 	}
 }
 
 // UsersResource streams a CSV of all users
 func (ch *ConversationsHandler) UsersResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	ch.logger.Debug("UsersResource called", zap.Any("params", request.Params))
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("UsersResource called", zap.Any("params", request.Params))
 
 	// authentication
-	if authenticated, err := auth.IsAuthenticated(ctx, ch.apiProvider.ServerTransport(), ch.logger); !authenticated {
-		ch.logger.Error("Authentication failed for users resource", zap.Error(err))
+	if authenticated, err := auth.IsAuthenticated(ctx, ch.apiProvider.ServerTransport(), logger); !authenticated {
+		logger.Error("Authentication failed for users resource", zap.Error(err))
 		return nil, err
 	}
 
 	// provider readiness
 	if ready, err := ch.apiProvider.IsReady(); !ready {
-		ch.logger.Error("API provider not ready", zap.Error(err))
+		logger.Error("API provider not ready", zap.Error(err))
 		return nil, err
 	}
 
 	// Slack auth test
 	ar, err := ch.apiProvider.Slack().AuthTest()
 	if err != nil {
-		ch.logger.Error("Slack AuthTest failed", zap.Error(err))
+		logger.Error("Slack AuthTest failed", zap.Error(err))
 		return nil, err
 	}
 
 	ws, err := text.Workspace(ar.URL)
 	if err != nil {
-		ch.logger.Error("Failed to parse workspace from URL",
+		logger.Error("Failed to parse workspace from URL",
 			zap.String("url", ar.URL),
 			zap.Error(err),
 		)
@@ -137,7 +300,7 @@ func (ch *ConversationsHandler) UsersResource(ctx context.Context, request mcp.R
 	// marshal CSV
 	csvBytes, err := gocsv.MarshalBytes(&usersList)
 	if err != nil {
-		ch.logger.Error("Failed to marshal users to CSV", zap.Error(err))
+		logger.Error("Failed to marshal users to CSV", zap.Error(err))
 		return nil, err
 	}
 
@@ -152,93 +315,868 @@ func (ch *ConversationsHandler) UsersResource(ctx context.Context, request mcp.R
 
 // ConversationsAddMessageHandler posts a message and returns it as CSV
 func (ch *ConversationsHandler) ConversationsAddMessageHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("ConversationsAddMessageHandler called", zap.Any("params", request.Params))
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsAddMessageHandler called", zap.Any("params", request.Params))
 
 	params, err := ch.parseParamsToolAddMessage(request)
 	if err != nil {
-		ch.logger.Error("Failed to parse add-message params", zap.Error(err))
-		return nil, err
+		logger.Error("Failed to parse add-message params", zap.Error(err))
+		return nil, invalidArgumentError(err)
 	}
 
 	var options []slack.MsgOption
 	if params.threadTs != "" {
+		if err := ch.checkThreadExists(ctx, params.channel, params.threadTs); err != nil {
+			return nil, err
+		}
 		options = append(options, slack.MsgOptionTS(params.threadTs))
+		if params.replyBroadcast {
+			options = append(options, slack.MsgOptionBroadcast())
+		}
+	}
+
+	if params.username != "" || params.iconEmoji != "" || params.iconURL != "" {
+		if err := requireBotToken(ch.apiProvider, logger, "username, icon_emoji and icon_url"); err != nil {
+			return nil, err
+		}
+		if params.username != "" {
+			options = append(options, slack.MsgOptionUsername(params.username))
+		}
+		if params.iconEmoji != "" {
+			options = append(options, slack.MsgOptionIconEmoji(params.iconEmoji))
+		}
+		if params.iconURL != "" {
+			options = append(options, slack.MsgOptionIconURL(params.iconURL))
+		}
 	}
 
-	switch params.contentType {
-	case "text/plain":
+	switch {
+	case len(params.blocks) > 0:
+		// the payload becomes the notification fallback of the blocks
+		options = append(options, slack.MsgOptionText(params.text, false))
+		options = append(options, slack.MsgOptionBlocks(params.blocks...))
+	case params.contentType == "text/plain":
 		options = append(options, slack.MsgOptionDisableMarkdown())
 		options = append(options, slack.MsgOptionText(params.text, false))
-	case "text/markdown":
+	case params.contentType == "text/markdown":
 		blocks, err := slackGoUtil.ConvertMarkdownTextToBlocks(params.text)
 		if err != nil {
-			ch.logger.Warn("Markdown parsing error", zap.Error(err))
+			logger.Warn("Markdown parsing error", zap.Error(err))
 			options = append(options, slack.MsgOptionDisableMarkdown())
 			options = append(options, slack.MsgOptionText(params.text, false))
 		} else {
 			options = append(options, slack.MsgOptionBlocks(blocks...))
 		}
 	default:
-		return nil, errors.New("content_type must be either 'text/plain' or 'text/markdown'")
+		return nil, invalidArgumentError(errors.New("content_type must be either 'text/plain' or 'text/markdown'"))
+	}
+
+	if params.allowBroadcast {
+		options = append(options, slack.MsgOptionLinkNames(true))
 	}
 
 	unfurlOpt := os.Getenv("SLACK_MCP_ADD_MESSAGE_UNFURLING")
-	if text.IsUnfurlingEnabled(params.text, unfurlOpt, ch.logger) {
+	if text.IsUnfurlingEnabled(params.text, unfurlOpt, logger) {
 		options = append(options, slack.MsgOptionEnableLinkUnfurl())
 	} else {
 		options = append(options, slack.MsgOptionDisableLinkUnfurl())
 		options = append(options, slack.MsgOptionDisableMediaUnfurl())
 	}
 
-	ch.logger.Debug("Posting Slack message",
+	logger.Debug("Posting Slack message",
 		zap.String("channel", params.channel),
 		zap.String("thread_ts", params.threadTs),
+		zap.Bool("reply_broadcast", params.replyBroadcast),
 		zap.String("content_type", params.contentType),
 	)
 	respChannel, respTimestamp, err := ch.apiProvider.Slack().PostMessageContext(ctx, params.channel, options...)
 	if err != nil {
-		ch.logger.Error("Slack PostMessageContext failed", zap.Error(err))
-		return nil, err
+		logger.Error("Slack PostMessageContext failed", zap.Error(err))
+		return nil, channelAPIError("conversations_add_message", params.channel, err)
 	}
 
 	toolConfig := os.Getenv("SLACK_MCP_ADD_MESSAGE_MARK")
 	if toolConfig == "1" || toolConfig == "true" || toolConfig == "yes" {
 		err := ch.apiProvider.Slack().MarkConversationContext(ctx, params.channel, respTimestamp)
 		if err != nil {
-			ch.logger.Error("Slack MarkConversationContext failed", zap.Error(err))
-			return nil, err
+			logger.Error("Slack MarkConversationContext failed", zap.Error(err))
+			return nil, channelAPIError("conversations_add_message", params.channel, err)
 		}
 	}
 
-	// fetch the single message we just posted
-	historyParams := slack.GetConversationHistoryParameters{
-		ChannelID: respChannel,
+	// fetch the single message we just posted, replies are only listed in their thread
+	posted, err := ch.fetchPostedMessage(ctx, respChannel, params.threadTs, respTimestamp)
+	if err != nil {
+		logger.Error("Failed to fetch the posted message", zap.String("channel", respChannel), zap.String("ts", respTimestamp), zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+	logger.Debug("Fetched posted message", zap.Int("message_count", len(posted)))
+	for _, msg := range posted {
+		if msg.Timestamp == respTimestamp {
+			ch.apiProvider.ForwardMessage(webhook.Event{
+				Type:      "message",
+				ChannelID: respChannel,
+				UserID:    msg.User,
+				Text:      msg.Text,
+				Timestamp: msg.Timestamp,
+				ThreadTs:  params.threadTs,
+			})
+		}
+	}
+
+	messages := ch.convertMessagesFromHistory(posted, respChannel, historyOptions{render: true, edits: true})
+	return marshalMessagesToCSV(messages)
+}
+
+// checkThreadExists looks up the message thread_ts points to before replying to it. A thread that is
+// not found is rejected, other lookup failures such as a missing history scope let the post go ahead.
+func (ch *ConversationsHandler) checkThreadExists(ctx context.Context, channel, threadTs string) error {
+	logger := requestid.Logger(ctx, ch.logger)
+	msgs, _, _, err := ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channel,
+		Timestamp: threadTs,
 		Limit:     1,
-		Oldest:    respTimestamp,
-		Latest:    respTimestamp,
+	})
+	if err != nil {
+		if code := provider.SlackErrorCode(err); code == "thread_not_found" || code == "message_not_found" {
+			logger.Warn("Thread to reply to not found", zap.String("channel", channel), zap.String("thread_ts", threadTs))
+			return notFoundError("thread_ts %s is not a message in channel %s, it may have been deleted", threadTs, channel)
+		}
+		logger.Warn("Could not check thread_ts, posting anyway", zap.String("channel", channel), zap.String("thread_ts", threadTs), zap.Error(err))
+		return nil
+	}
+	if len(msgs) == 0 {
+		return notFoundError("thread_ts %s is not a message in channel %s, it may have been deleted", threadTs, channel)
+	}
+	return nil
+}
+
+// fetchPostedMessage returns the message ts posted to the channel or, with threadTs, to that thread
+func (ch *ConversationsHandler) fetchPostedMessage(ctx context.Context, channel, threadTs, ts string) ([]slack.Message, error) {
+	if threadTs == "" {
+		history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channel,
+			Limit:     1,
+			Oldest:    ts,
+			Latest:    ts,
+			Inclusive: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return history.Messages, nil
+	}
+
+	replies, _, _, err := ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channel,
+		Timestamp: threadTs,
+		Oldest:    ts,
+		Latest:    ts,
 		Inclusive: true,
+		Limit:     1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// replies always start with the thread parent, keep the posted reply only
+	for _, msg := range replies {
+		if msg.Timestamp == ts {
+			return []slack.Message{msg}, nil
+		}
 	}
-	history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &historyParams)
+	return nil, nil
+}
+
+// ConversationsPostEphemeralHandler posts a message only the target user can see and confirms the delivery as CSV.
+// Ephemeral messages can't be fetched, edited or threaded, so no timestamp is returned.
+func (ch *ConversationsHandler) ConversationsPostEphemeralHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsPostEphemeralHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolPostEphemeral(request)
 	if err != nil {
-		ch.logger.Error("GetConversationHistoryContext failed", zap.Error(err))
+		logger.Error("Failed to parse post-ephemeral params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	if err := requireBotToken(ch.apiProvider, logger, "conversations_post_ephemeral tool"); err != nil {
 		return nil, err
 	}
-	ch.logger.Debug("Fetched conversation history", zap.Int("message_count", len(history.Messages)))
 
-	messages := ch.convertMessagesFromHistory(history.Messages, historyParams.ChannelID, false)
-	return marshalMessagesToCSV(messages)
+	options := []slack.MsgOption{slack.MsgOptionText(params.text, false)}
+	if len(params.blocks) > 0 {
+		options = append(options, slack.MsgOptionBlocks(params.blocks...))
+	}
+
+	if _, err := ch.apiProvider.Slack().PostEphemeralContext(ctx, params.channel, params.user, options...); err != nil {
+		logger.Error("Slack PostEphemeralContext failed",
+			zap.String("channel", params.channel),
+			zap.String("user", params.user),
+			zap.Error(err),
+		)
+		return nil, channelAPIError("conversations_post_ephemeral", params.channel, err)
+	}
+
+	result := []EphemeralMessage{{
+		ChannelID: params.channel,
+		UserID:    params.user,
+		Delivery:  "ephemeral",
+	}}
+	return marshalCSVResult(result)
+}
+
+// ConversationsAcknowledgeHandler reacts to a message and replies in its thread in one call. Both steps
+// are always attempted and reported as CSV rows, a failed step doesn't roll back the other one.
+func (ch *ConversationsHandler) ConversationsAcknowledgeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsAcknowledgeHandler called", zap.Any("params", request.Params))
+
+	params, err := parseParamsToolAcknowledge(request)
+	if err != nil {
+		logger.Error("Failed to parse acknowledge params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	reaction := AcknowledgeStep{Step: "reaction", Status: AcknowledgeStepOK, ChannelID: params.channel, Ts: params.ts}
+	reactionErr := ch.apiProvider.Slack().AddReactionContext(ctx, params.reaction, slack.NewRefToMessage(params.channel, params.ts))
+	if reactionErr != nil && provider.SlackErrorCode(reactionErr) != "already_reacted" {
+		logger.Error("Slack AddReactionContext failed", zap.String("channel", params.channel), zap.String("ts", params.ts), zap.Error(reactionErr))
+		reaction.Status = AcknowledgeStepFailed
+		reaction.Error = reactionErr.Error()
+	}
+
+	options := []slack.MsgOption{
+		slack.MsgOptionTS(params.ts),
+		slack.MsgOptionText(params.text, false),
+	}
+	if !text.IsUnfurlingEnabled(params.text, os.Getenv("SLACK_MCP_ADD_MESSAGE_UNFURLING"), logger) {
+		options = append(options, slack.MsgOptionDisableLinkUnfurl(), slack.MsgOptionDisableMediaUnfurl())
+	}
+
+	reply := AcknowledgeStep{Step: "reply", Status: AcknowledgeStepOK, ChannelID: params.channel}
+	_, replyTs, replyErr := ch.apiProvider.Slack().PostMessageContext(ctx, params.channel, options...)
+	if replyErr != nil {
+		logger.Error("Slack PostMessageContext failed", zap.String("channel", params.channel), zap.String("thread_ts", params.ts), zap.Error(replyErr))
+		reply.Status = AcknowledgeStepFailed
+		reply.Error = replyErr.Error()
+	} else {
+		reply.Ts = replyTs
+	}
+
+	// nothing happened in Slack, report it like any other failed call
+	if reaction.Status == AcknowledgeStepFailed && reply.Status == AcknowledgeStepFailed {
+		return nil, channelAPIError("conversations_acknowledge", params.channel, replyErr)
+	}
+
+	res, err := marshalCSVResult([]AcknowledgeStep{reaction, reply})
+	if err != nil {
+		return nil, err
+	}
+	if reaction.Status == AcknowledgeStepFailed || reply.Status == AcknowledgeStepFailed {
+		res.Meta = map[string]any{"partial": true}
+	}
+	return res, nil
+}
+
+// ConversationsSetTopicHandler sets the topic of a channel and returns the updated channel as CSV
+func (ch *ConversationsHandler) ConversationsSetTopicHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsSetTopicHandler called", zap.Any("params", request.Params))
+
+	return ch.setChannelMetadata(ctx, request, "conversations_set_topic", "topic", maxTopicChars,
+		ch.apiProvider.Slack().SetTopicOfConversationContext,
+		func(c *provider.Channel, text string) { c.Topic = text },
+	)
+}
+
+// ConversationsSetPurposeHandler sets the purpose of a channel and returns the updated channel as CSV
+func (ch *ConversationsHandler) ConversationsSetPurposeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsSetPurposeHandler called", zap.Any("params", request.Params))
+
+	return ch.setChannelMetadata(ctx, request, "conversations_set_purpose", "purpose", maxPurposeChars,
+		ch.apiProvider.Slack().SetPurposeOfConversationContext,
+		func(c *provider.Channel, text string) { c.Purpose = text },
+	)
+}
+
+// setChannelMetadata validates and writes the topic or purpose of a channel through set,
+// then updates the cached channel so channels_list reflects the change
+func (ch *ConversationsHandler) setChannelMetadata(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+	tool, field string,
+	maxChars int,
+	set func(ctx context.Context, channelID, text string) (*slack.Channel, error),
+	apply func(c *provider.Channel, text string),
+) (*mcp.CallToolResult, error) {
+	channel, text, err := parseParamsToolChannelMetadata(request, tool, field, maxChars)
+	if err != nil {
+		ch.logger.Error("Failed to parse channel metadata params", zap.String("tool", tool), zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	updated, err := set(ctx, channel, text)
+	if err != nil {
+		ch.logger.Error("Slack failed to set channel "+field, zap.String("channel", channel), zap.Error(err))
+		return nil, channelAPIError(tool, channel, err)
+	}
+
+	cached, ok := ch.apiProvider.UpdateChannel(channel, func(c *provider.Channel) { apply(c, text) })
+	if !ok {
+		cached = provider.Channel{
+			ID:          updated.ID,
+			Name:        "#" + updated.NameNormalized,
+			Topic:       updated.Topic.Value,
+			Purpose:     updated.Purpose.Value,
+			MemberCount: updated.NumMembers,
+		}
+		apply(&cached, text)
+	}
+
+	return marshalCSVResult([]Channel{{
+		ID:          cached.ID,
+		Name:        cached.Name,
+		Topic:       cached.Topic,
+		Purpose:     cached.Purpose,
+		MemberCount: cached.MemberCount,
+	}})
+}
+
+// parseParamsToolChannelMetadata reads channel_id and the new text, which may be empty to clear it
+func parseParamsToolChannelMetadata(request mcp.CallToolRequest, tool, field string, maxChars int) (string, string, error) {
+	channel, err := paramChannelID(request)
+	if err != nil {
+		return "", "", err
+	}
+	if err := checkWritePolicy(tool, channel); err != nil {
+		return "", "", err
+	}
+
+	if _, ok := request.GetArguments()[field]; !ok {
+		return "", "", fmt.Errorf("%s is required, pass an empty string to clear it", field)
+	}
+	text := strings.TrimSpace(request.GetString(field, ""))
+	if n := utf8.RuneCountInString(text); n > maxChars {
+		return "", "", fmt.Errorf("%s must be at most %d characters, got %d", field, maxChars, n)
+	}
+	return channel, text, nil
+}
+
+// ConversationsMarkHandler moves the read cursor of a channel and returns it as CSV
+func (ch *ConversationsHandler) ConversationsMarkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsMarkHandler called", zap.Any("params", request.Params))
+
+	channel, err := paramChannelID(request)
+	if err != nil {
+		return nil, invalidArgumentError(err)
+	}
+
+	ts := strings.TrimSpace(request.GetString("ts", ""))
+	if !isMessageTimestamp(ts) {
+		logger.Error("Invalid ts format", zap.String("ts", ts))
+		return nil, invalidArgumentError(errors.New("ts must be a valid timestamp in format 1234567890.123456"))
+	}
+
+	if err := checkWriteChannel("conversations_mark", channel); err != nil {
+		logger.Warn("conversations_mark is not allowed for channel by policy", zap.String("channel", channel))
+		return nil, err
+	}
+
+	if _, err := requireUserToken(ch.apiProvider, logger, "conversations_mark"); err != nil {
+		return nil, err
+	}
+
+	if err := ch.apiProvider.Slack().MarkConversationContext(ctx, channel, ts); err != nil {
+		logger.Error("Slack MarkConversationContext failed", zap.String("channel", channel), zap.String("ts", ts), zap.Error(err))
+		return nil, channelAPIError("conversations_mark", channel, err)
+	}
+
+	result := []ReadCursor{{ChannelID: channel, LastRead: ts}}
+	csvBytes, err := gocsv.MarshalBytes(&result)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// ConversationsOpenHandler opens or resumes a DM or group DM and returns its channel as CSV
+func (ch *ConversationsHandler) ConversationsOpenHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsOpenHandler called", zap.Any("params", request.Params))
+
+	users, err := ch.parseParamsToolOpen(request)
+	if err != nil {
+		logger.Error("Failed to parse open params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	channel, _, alreadyOpen, err := ch.apiProvider.Slack().OpenConversationContext(ctx, &slack.OpenConversationParameters{
+		Users:    users,
+		ReturnIM: len(users) == 1,
+	})
+	if err != nil {
+		logger.Error("Slack OpenConversationContext failed", zap.Strings("users", users), zap.Error(err))
+		var ser slack.SlackErrorResponse
+		if errors.As(err, &ser) && ser.Err == "missing_scope" {
+			return nil, capabilityUnsupportedError("conversations_open tool requires the im:write scope for DMs and mpim:write for group DMs, the current token lacks it")
+		}
+		return nil, slackAPIError(err)
+	}
+
+	// conversations.open omits members of group DMs, the requested users are the best approximation
+	if channel.IsMpIM && len(channel.Members) == 0 {
+		channel.Members = users
+	}
+	if channel.IsIM && channel.User == "" && len(users) == 1 {
+		channel.User = users[0]
+	}
+	opened := ch.apiProvider.AddChannel(*channel)
+	logger.Debug("Opened conversation",
+		zap.String("channel", opened.ID),
+		zap.String("name", opened.Name),
+		zap.Bool("already_open", alreadyOpen),
+	)
+
+	convType := "im"
+	if opened.IsMpIM {
+		convType = "mpim"
+	}
+	result := []OpenedConversation{{
+		ChannelID:   opened.ID,
+		Name:        opened.Name,
+		Type:        convType,
+		AlreadyOpen: alreadyOpen,
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&result)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// ConversationsMembersHandler lists one page of channel members as CSV, the last row carries the next cursor
+func (ch *ConversationsHandler) ConversationsMembersHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsMembersHandler called", zap.Any("params", request.Params))
+
+	channel, err := paramChannelID(request)
+	if err != nil {
+		return nil, invalidArgumentError(err)
+	}
+	if err := checkReadPolicy(logger, "conversations_members", channel); err != nil {
+		return nil, err
+	}
+
+	limit := request.GetInt("limit", defaultMembersLimit)
+	if limit <= 0 {
+		limit = defaultMembersLimit
+	}
+	if limit > maxMembersLimit {
+		logger.Warn("Limit exceeds maximum, capping", zap.Int("requested", limit), zap.Int("max", maxMembersLimit))
+		limit = maxMembersLimit
+	}
+	limit = capLimit(limit)
+
+	var (
+		memberIDs  []string
+		nextCursor string
+	)
+	err = readChannel(ctx, ch.apiProvider.Slack(), logger, "conversations_members", channel, func() (err error) {
+		memberIDs, nextCursor, err = ch.apiProvider.Slack().GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
+			ChannelID: channel,
+			Cursor:    request.GetString("cursor", ""),
+			Limit:     limit,
+		})
+		return err
+	})
+	if err != nil {
+		logger.Error("Slack GetUsersInConversationContext failed", zap.String("channel", channel), zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+	logger.Debug("Fetched channel members", zap.Int("count", len(memberIDs)), zap.Bool("has_more", nextCursor != ""))
+
+	members := resolveMembers(memberIDs, ch.apiProvider.ProvideUsersMap().Users, func(ids []string) ([]slack.User, error) {
+		users, err := ch.apiProvider.Slack().GetUsersInfoContext(ctx, ids...)
+		if err != nil {
+			return nil, err
+		}
+		return *users, nil
+	}, logger)
+
+	if len(members) > 0 && nextCursor != "" {
+		members[len(members)-1].Cursor = nextCursor
+	}
+	return marshalCSVResult(members)
+}
+
+// resolveMembers maps member IDs to names from the users cache. IDs missing from the
+// cache are looked up with fetch in batches of usersInfoBatchSize, failed batches keep bare IDs.
+func resolveMembers(ids []string, cached map[string]slack.User, fetch func(ids []string) ([]slack.User, error), logger *zap.Logger) []ChannelMember {
+	users := make(map[string]slack.User, len(ids))
+	var missing []string
+	for _, id := range ids {
+		if u, ok := cached[id]; ok {
+			users[id] = u
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	for start := 0; start < len(missing); start += usersInfoBatchSize {
+		end := start + usersInfoBatchSize
+		if end > len(missing) {
+			end = len(missing)
+		}
+		fetched, err := fetch(missing[start:end])
+		if err != nil {
+			logger.Warn("Failed to resolve channel members missing from cache", zap.Int("count", end-start), zap.Error(err))
+			continue
+		}
+		for _, u := range fetched {
+			users[u.ID] = u
+		}
+	}
+
+	members := make([]ChannelMember, 0, len(ids))
+	for _, id := range ids {
+		u := users[id]
+		members = append(members, ChannelMember{
+			UserID:   id,
+			UserName: u.Name,
+			RealName: u.RealName,
+		})
+	}
+	return members
+}
+
+// ConversationsListDMsHandler lists one page of DMs and group DMs of the authenticated user as CSV,
+// most recent first, the last row carries the next cursor. Bot tokens only see DMs with the bot.
+func (ch *ConversationsHandler) ConversationsListDMsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsListDMsHandler called", zap.Any("params", request.Params))
+
+	limit := request.GetInt("limit", defaultDMsLimit)
+	if limit <= 0 {
+		limit = defaultDMsLimit
+	}
+	if limit > maxDMsLimit {
+		logger.Warn("Limit exceeds maximum, capping", zap.Int("requested", limit), zap.Int("max", maxDMsLimit))
+		limit = maxDMsLimit
+	}
+	limit = capLimit(limit)
+
+	ar, err := ch.apiProvider.Slack().AuthTest()
+	if err != nil {
+		logger.Error("Slack AuthTest failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	channels, nextCursor, err := ch.apiProvider.Slack().GetConversationsContext(ctx, &slack.GetConversationsParameters{
+		Types:           []string{"im", "mpim"},
+		Cursor:          request.GetString("cursor", ""),
+		Limit:           limit,
+		ExcludeArchived: true,
+	})
+	if err != nil {
+		logger.Error("Slack GetConversationsContext failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+	logger.Debug("Fetched direct messages", zap.Int("count", len(channels)), zap.Bool("has_more", nextCursor != ""))
+
+	var userIDs []string
+	for _, c := range channels {
+		if c.IsIM {
+			userIDs = append(userIDs, c.User)
+		}
+		userIDs = append(userIDs, c.Members...)
+	}
+	if err := ch.apiProvider.ResolveUsers(ctx, userIDs); err != nil {
+		logger.Error("Failed to resolve direct message users", zap.Error(err))
+		return nil, cacheNotReadyError(err)
+	}
+
+	dms := make([]DirectMessage, 0, len(channels))
+	for _, c := range channels {
+		dms = append(dms, ch.directMessage(ctx, c))
+	}
+	sort.SliceStable(dms, func(i, j int) bool {
+		return dms[i].LatestTs > dms[j].LatestTs
+	})
+
+	if len(dms) > 0 && nextCursor != "" {
+		dms[len(dms)-1].Cursor = nextCursor
+	}
+	res, err := marshalCSVResult(dms)
+	if err != nil {
+		return nil, err
+	}
+	if ar.BotID != "" {
+		res.Content = append(res.Content, mcp.NewTextContent(
+			"note: called with a bot token, only DMs with the bot are listed, use a user token to see the DMs of a user"))
+	}
+	return res, nil
+}
+
+// directMessage resolves the other participants of a DM from the users cache and the
+// latest message timestamp, which conversations.list omits for most DMs
+func (ch *ConversationsHandler) directMessage(ctx context.Context, c slack.Channel) DirectMessage {
+	logger := requestid.Logger(ctx, ch.logger)
+	users := ch.apiProvider.ProvideUsersMap().Users
+	dm := DirectMessage{ChannelID: c.ID, Type: "im"}
+
+	if c.IsIM {
+		dm.Name = "@" + c.User
+		dm.Participants = c.User
+		if u, ok := users[c.User]; ok {
+			dm.Name = "@" + u.Name
+			dm.Participants = u.RealName
+		}
+	} else {
+		dm.Type = "mpim"
+		dm.Name = "@" + c.NameNormalized
+		// conversations.list omits members of group DMs, their handles are encoded in the name
+		// e.g. mpdm-alice--bob--carol-1
+		names := mpimHandles(c.Name)
+		if len(c.Members) > 0 {
+			names = names[:0]
+			for _, uid := range c.Members {
+				if u, ok := users[uid]; ok {
+					names = append(names, u.RealName)
+				} else {
+					names = append(names, uid)
+				}
+			}
+		}
+		dm.Participants = strings.Join(names, ", ")
+	}
+
+	if c.Latest != nil && c.Latest.Timestamp != "" {
+		dm.LatestTs = c.Latest.Timestamp
+		return dm
+	}
+	history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+		ChannelID: c.ID,
+		Limit:     1,
+	})
+	if err != nil {
+		logger.Warn("Failed to fetch latest DM message", zap.String("channel", c.ID), zap.Error(err))
+		return dm
+	}
+	if len(history.Messages) > 0 {
+		dm.LatestTs = history.Messages[0].Timestamp
+	}
+	return dm
+}
+
+// mpimHandles extracts user handles from a group DM name such as mpdm-alice--bob--carol-1
+func mpimHandles(name string) []string {
+	name = strings.TrimPrefix(name, "mpdm-")
+	if i := strings.LastIndex(name, "-"); i > 0 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			name = name[:i]
+		}
+	}
+	if name == "" {
+		return nil
+	}
+	return strings.Split(name, "--")
+}
+
+// ConversationsUnreadCountsHandler returns unread message counts of the authenticated user's
+// conversations as CSV, most unread first. Read state comes from client.counts, unread messages
+// are counted with conversations.history from the last read message.
+func (ch *ConversationsHandler) ConversationsUnreadCountsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsUnreadCountsHandler called", zap.Any("params", request.Params))
+
+	if err := requireBrowserSession(ch.apiProvider, logger, "conversations_unread_counts"); err != nil {
+		return nil, err
+	}
+
+	limit := request.GetInt("limit", defaultUnreadLimit)
+	if limit <= 0 {
+		limit = defaultUnreadLimit
+	}
+	if limit > maxUnreadLimit {
+		logger.Warn("Limit exceeds maximum, capping", zap.Int("requested", limit), zap.Int("max", maxUnreadLimit))
+		limit = maxUnreadLimit
+	}
+	limit = capLimit(limit)
+
+	counts, err := ch.apiProvider.Slack().ClientCounts(ctx)
+	if err != nil {
+		logger.Error("Slack ClientCounts failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	snapshots := unreadSnapshots(counts, IsChannelReadable, limit)
+	logger.Debug("Conversations with unread messages", zap.Int("count", len(snapshots)))
+
+	ids := make([]string, 0, len(snapshots))
+	for _, s := range snapshots {
+		ids = append(ids, s.ID)
+	}
+	if err := ch.apiProvider.ResolveChannels(ctx, ids); err != nil {
+		logger.Error("Failed to resolve unread conversations", zap.Error(err))
+		return nil, cacheNotReadyError(err)
+	}
+
+	channels := ch.apiProvider.ProvideChannelsMaps().Channels
+	result := make([]UnreadCount, 0, len(snapshots))
+	for _, s := range snapshots {
+		row := UnreadCount{
+			ChannelID:    s.ID,
+			ChannelName:  s.ID,
+			MentionCount: s.MentionCount,
+			LatestTs:     slackTimestamp(s.Latest),
+			LastRead:     slackTimestamp(s.LastRead),
+		}
+		if c, ok := channels[s.ID]; ok {
+			row.ChannelName = c.Name
+		}
+
+		history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: s.ID,
+			Oldest:    row.LastRead,
+			Limit:     maxUnreadCount,
+		})
+		if err != nil {
+			logger.Warn("Failed to count unread messages", zap.String("channel", s.ID), zap.Error(err))
+		} else {
+			row.UnreadCount = len(history.Messages)
+			row.UnreadCapped = history.HasMore
+		}
+		result = append(result, row)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].UnreadCount > result[j].UnreadCount
+	})
+	return marshalCSVResult(result)
+}
+
+// unreadSnapshots returns the allowed conversations with unread messages, at most limit
+// of them with the most recent activity first
+func unreadSnapshots(counts edge.ClientCountsResponse, allowed func(string) bool, limit int) []edge.ChannelSnapshot {
+	var snapshots []edge.ChannelSnapshot
+	for _, group := range [][]edge.ChannelSnapshot{counts.Channels, counts.MPIMs, counts.IMs} {
+		for _, s := range group {
+			if s.HasUnreads && allowed(s.ID) {
+				snapshots = append(snapshots, s)
+			}
+		}
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return time.Time(snapshots[i].Latest).After(time.Time(snapshots[j].Latest))
+	})
+	if len(snapshots) > limit {
+		snapshots = snapshots[:limit]
+	}
+	return snapshots
+}
+
+// slackTimestamp formats a client.counts time as a message timestamp, unset times such as
+// "0000000000.000000" are empty
+func slackTimestamp(t fasttime.Time) string {
+	if tt := time.Time(t); tt.IsZero() || tt.UnixMicro() == 0 {
+		return ""
+	}
+	return t.SlackString()
+}
+
+// ConversationsScheduledListHandler lists pending scheduled messages as CSV, the last row carries the next cursor
+func (ch *ConversationsHandler) ConversationsScheduledListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsScheduledListHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolScheduledList(request)
+	if err != nil {
+		logger.Error("Failed to parse scheduled messages params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	scheduled, nextCursor, err := ch.apiProvider.Slack().GetScheduledMessagesContext(ctx, &slack.GetScheduledMessagesParameters{
+		Channel: params.channel,
+		Oldest:  params.oldest,
+		Latest:  params.latest,
+		Limit:   params.limit,
+		Cursor:  params.cursor,
+	})
+	if err != nil {
+		logger.Error("Slack GetScheduledMessagesContext failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+	logger.Debug("Fetched scheduled messages", zap.Int("count", len(scheduled)), zap.Bool("has_more", nextCursor != ""))
+
+	var users, ids []string
+	for _, m := range scheduled {
+		if IsChannelReadable(m.Channel) {
+			mentioned, referenced := text.MarkupReferences(m.Text)
+			users = append(users, mentioned...)
+			ids = append(append(ids, m.Channel), referenced...)
+		}
+	}
+	if err := ch.apiProvider.ResolveUsers(ctx, users); err != nil {
+		logger.Error("Failed to resolve scheduled message mentions", zap.Error(err))
+		return nil, cacheNotReadyError(err)
+	}
+	if err := ch.apiProvider.ResolveChannels(ctx, ids); err != nil {
+		logger.Error("Failed to resolve scheduled message channels", zap.Error(err))
+		return nil, cacheNotReadyError(err)
+	}
+
+	channels := ch.apiProvider.ProvideChannelsMaps().Channels
+	renderer := newMarkupRenderer(ch.apiProvider)
+	result := make([]ScheduledMessage, 0, len(scheduled))
+	for _, m := range scheduled {
+		// without a channel filter Slack returns every channel, hide the ones excluded by policy
+		if !IsChannelReadable(m.Channel) {
+			continue
+		}
+
+		channelName := m.Channel
+		if c, ok := channels[m.Channel]; ok {
+			channelName = c.Name
+		}
+
+		preview := renderer.messageText(m.Text, params.render)
+		if runes := []rune(preview); len(runes) > scheduledPreviewChars {
+			preview = string(runes[:scheduledPreviewChars]) + "…"
+		}
+
+		result = append(result, ScheduledMessage{
+			ScheduledMessageID: m.ID,
+			ChannelID:          m.Channel,
+			ChannelName:        channelName,
+			PostAt:             time.Unix(int64(m.PostAt), 0).UTC().Format(time.RFC3339),
+			Created:            time.Unix(int64(m.DateCreated), 0).UTC().Format(time.RFC3339),
+			TextPreview:        preview,
+		})
+	}
+
+	if len(result) > 0 && nextCursor != "" {
+		result[len(result)-1].Cursor = nextCursor
+	}
+	return marshalCSVResult(result)
 }
 
 // ConversationsHistoryHandler streams conversation history as CSV
 func (ch *ConversationsHandler) ConversationsHistoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("ConversationsHistoryHandler called", zap.Any("params", request.Params))
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsHistoryHandler called", zap.Any("params", request.Params))
 
 	params, err := ch.parseParamsToolConversations(request)
 	if err != nil {
-		ch.logger.Error("Failed to parse history params", zap.Error(err))
+		logger.Error("Failed to parse history params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+	if err := checkReadPolicy(logger, "conversations_history", params.channel); err != nil {
 		return nil, err
 	}
-	ch.logger.Debug("History params parsed",
+	logger.Debug("History params parsed",
 		zap.String("channel", params.channel),
 		zap.Int("limit", params.limit),
 		zap.String("oldest", params.oldest),
@@ -254,35 +1192,45 @@ func (ch *ConversationsHandler) ConversationsHistoryHandler(ctx context.Context,
 		Cursor:    params.cursor,
 		Inclusive: false,
 	}
-	history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &historyParams)
+	var history *slack.GetConversationHistoryResponse
+	err = readChannel(ctx, ch.apiProvider.Slack(), logger, "conversations_history", params.channel, func() (err error) {
+		history, err = ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &historyParams)
+		return err
+	})
 	if err != nil {
-		ch.logger.Error("GetConversationHistoryContext failed", zap.Error(err))
-		return nil, err
+		logger.Error("GetConversationHistoryContext failed", zap.Error(err))
+		return nil, slackAPIError(err)
 	}
 
-	ch.logger.Debug("Fetched conversation history", zap.Int("message_count", len(history.Messages)))
-
-	messages := ch.convertMessagesFromHistory(history.Messages, params.channel, params.activity)
+	logger.Debug("Fetched conversation history", zap.Int("message_count", len(history.Messages)))
 
-	if len(messages) > 0 && history.HasMore {
-		messages[len(messages)-1].Cursor = history.ResponseMetaData.NextCursor
+	if err := resolveMessageReferences(ctx, ch.apiProvider, history.Messages); err != nil {
+		logger.Error("Failed to resolve message references", zap.Error(err))
+		return nil, err
 	}
-	return marshalMessagesToCSV(messages)
+	messages := ch.convertMessagesFromHistory(history.Messages, params.channel, params.historyOptions)
+	logger.Debug("Converted conversation history", zap.Int("fetched", len(history.Messages)), zap.Int("returned", len(messages)))
+
+	return marshalMessagesPage(messages, history.HasMore, history.ResponseMetaData.NextCursor)
 }
 
 // ConversationsRepliesHandler streams thread replies as CSV
 func (ch *ConversationsHandler) ConversationsRepliesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("ConversationsRepliesHandler called", zap.Any("params", request.Params))
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsRepliesHandler called", zap.Any("params", request.Params))
 
 	params, err := ch.parseParamsToolConversations(request)
 	if err != nil {
-		ch.logger.Error("Failed to parse replies params", zap.Error(err))
+		logger.Error("Failed to parse replies params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+	if err := checkReadPolicy(logger, "conversations_replies", params.channel); err != nil {
 		return nil, err
 	}
 	threadTs := request.GetString("thread_ts", "")
 	if threadTs == "" {
-		ch.logger.Error("thread_ts not provided for replies", zap.String("thread_ts", threadTs))
-		return nil, errors.New("thread_ts must be a string")
+		logger.Error("thread_ts not provided for replies", zap.String("thread_ts", threadTs))
+		return nil, invalidArgumentError(errors.New("thread_ts must be a string"))
 	}
 
 	repliesParams := slack.GetConversationRepliesParameters{
@@ -294,29 +1242,41 @@ func (ch *ConversationsHandler) ConversationsRepliesHandler(ctx context.Context,
 		Cursor:    params.cursor,
 		Inclusive: false,
 	}
-	replies, hasMore, nextCursor, err := ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &repliesParams)
+	var (
+		replies    []slack.Message
+		hasMore    bool
+		nextCursor string
+	)
+	err = readChannel(ctx, ch.apiProvider.Slack(), logger, "conversations_replies", params.channel, func() (err error) {
+		replies, hasMore, nextCursor, err = ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &repliesParams)
+		return err
+	})
 	if err != nil {
-		ch.logger.Error("GetConversationRepliesContext failed", zap.Error(err))
-		return nil, err
+		logger.Error("GetConversationRepliesContext failed", zap.Error(err))
+		return nil, slackAPIError(err)
 	}
-	ch.logger.Debug("Fetched conversation replies", zap.Int("count", len(replies)))
+	logger.Debug("Fetched conversation replies", zap.Int("count", len(replies)))
 
-	messages := ch.convertMessagesFromHistory(replies, params.channel, params.activity)
-	if len(messages) > 0 && hasMore {
-		messages[len(messages)-1].Cursor = nextCursor
+	if err := resolveMessageReferences(ctx, ch.apiProvider, replies); err != nil {
+		logger.Error("Failed to resolve message references", zap.Error(err))
+		return nil, err
 	}
-	return marshalMessagesToCSV(messages)
+	messages := ch.convertMessagesFromHistory(replies, params.channel, params.historyOptions)
+	logger.Debug("Converted conversation replies", zap.Int("fetched", len(replies)), zap.Int("returned", len(messages)))
+
+	return marshalMessagesPage(messages, hasMore, nextCursor)
 }
 
 func (ch *ConversationsHandler) ConversationsSearchHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("ConversationsSearchHandler called", zap.Any("params", request.Params))
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsSearchHandler called", zap.Any("params", request.Params))
 
-	params, err := ch.parseParamsToolSearch(request)
+	params, err := ch.parseParamsToolSearch(ctx, request)
 	if err != nil {
-		ch.logger.Error("Failed to parse search params", zap.Error(err))
-		return nil, err
+		logger.Error("Failed to parse search params", zap.Error(err))
+		return nil, invalidArgumentError(err)
 	}
-	ch.logger.Debug("Search params parsed", zap.String("query", params.query), zap.Int("limit", params.limit), zap.Int("page", params.page))
+	logger.Debug("Search params parsed", zap.String("query", params.query), zap.Int("limit", params.limit), zap.Int("page", params.page))
 
 	searchParams := slack.SearchParameters{
 		Sort:          slack.DEFAULT_SEARCH_SORT,
@@ -327,12 +1287,12 @@ func (ch *ConversationsHandler) ConversationsSearchHandler(ctx context.Context,
 	}
 	messagesRes, _, err := ch.apiProvider.Slack().SearchContext(ctx, params.query, searchParams)
 	if err != nil {
-		ch.logger.Error("Slack SearchContext failed", zap.Error(err))
-		return nil, err
+		logger.Error("Slack SearchContext failed", zap.Error(err))
+		return nil, slackAPIError(err)
 	}
-	ch.logger.Debug("Search completed", zap.Int("matches", len(messagesRes.Matches)))
+	logger.Debug("Search completed", zap.Int("matches", len(messagesRes.Matches)))
 
-	messages := ch.convertMessagesFromSearch(messagesRes.Matches)
+	messages := ch.convertMessagesFromSearch(messagesRes.Matches, params.render)
 	if len(messages) > 0 && ((messagesRes.Pagination.PerPage * messagesRes.Pagination.PageCount) < messagesRes.Pagination.TotalCount) {
 		nextCursor := fmt.Sprintf("page:%d", messagesRes.Pagination.PageCount+1)
 		messages[len(messages)-1].Cursor = base64.StdEncoding.EncodeToString([]byte(nextCursor))
@@ -340,35 +1300,65 @@ func (ch *ConversationsHandler) ConversationsSearchHandler(ctx context.Context,
 	return marshalMessagesToCSV(messages)
 }
 
-func isChannelAllowed(channel string) bool {
-	config := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
-	if config == "" || config == "true" || config == "1" {
-		return true
+// escapeMessageText applies SLACK_MCP_ESCAPE_MENTIONS to the text of an outgoing message: @channel, @here,
+// @everyone and <!...> sequences are escaped unless the caller opted into broadcast mentions
+func escapeMessageText(msgText string, allowBroadcast bool) string {
+	if allowBroadcast || !isEscapeMentionsEnabled() {
+		return msgText
 	}
-	items := strings.Split(config, ",")
-	isNegated := strings.HasPrefix(strings.TrimSpace(items[0]), "!")
-	for _, item := range items {
-		item = strings.TrimSpace(item)
-		if isNegated {
-			if strings.TrimPrefix(item, "!") == channel {
-				return false
-			}
-		} else {
-			if item == channel {
-				return true
-			}
+	return text.EscapeMentions(msgText)
+}
+
+// isEscapeMentionsEnabled returns true unless SLACK_MCP_ESCAPE_MENTIONS is set to false or 0
+func isEscapeMentionsEnabled() bool {
+	value := os.Getenv("SLACK_MCP_ESCAPE_MENTIONS")
+	return value != "false" && value != "0"
+}
+
+// isMessageTimestamp reports whether ts looks like a Slack message timestamp such as 1234567890.123456
+func isMessageTimestamp(ts string) bool {
+	return messageTimestampRe.MatchString(ts)
+}
+
+// messageReferences returns the authors and mentioned users of messages and the channels their text
+// references, the IDs rendering them needs, see provider.ResolveUsers
+func messageReferences(messages []slack.Message) (users, channels []string) {
+	for _, msg := range messages {
+		if msg.User != "" {
+			users = append(users, msg.User)
 		}
+		mentioned, referenced := text.MarkupReferences(msg.Text)
+		users = append(users, mentioned...)
+		channels = append(channels, referenced...)
 	}
-	return !isNegated
+	return users, channels
 }
 
-func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack.Message, channel string, includeActivity bool) []Message {
+// resolveMessageReferences looks up the users and channels of messages missing from the caches as selected
+// by SLACK_MCP_CACHE_MISS_POLICY
+func resolveMessageReferences(ctx context.Context, apiProvider *provider.ApiProvider, messages []slack.Message) error {
+	users, channels := messageReferences(messages)
+	if err := apiProvider.ResolveUsers(ctx, users); err != nil {
+		return cacheNotReadyError(err)
+	}
+	if err := apiProvider.ResolveChannels(ctx, channels); err != nil {
+		return cacheNotReadyError(err)
+	}
+	return nil
+}
+
+// convertMessagesFromHistory maps Slack messages to tool rows as selected by opts
+func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack.Message, channel string, opts historyOptions) []Message {
 	usersMap := ch.apiProvider.ProvideUsersMap()
+	renderer := newMarkupRenderer(ch.apiProvider)
 	var messages []Message
 	warn := false
 
 	for _, msg := range slackMessages {
-		if (msg.SubType != "" && msg.SubType != "bot_message") && !includeActivity {
+		if (msg.SubType != "" && msg.SubType != "bot_message") && !opts.activity {
+			continue
+		}
+		if opts.excludeBots && isBotMessage(msg) {
 			continue
 		}
 
@@ -389,16 +1379,26 @@ func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack
 		}
 
 		msgText := msg.Text + text.AttachmentsTo2CSV(msg.Text, msg.Attachments)
+		msgText, truncated := clipMessageText(renderer.messageText(msgText, opts.render))
+
+		var editedBy, editedTs string
+		if opts.edits && msg.Edited != nil {
+			editedBy, editedTs = msg.Edited.User, msg.Edited.Timestamp
+			msgText += " (edited)"
+		}
 
 		messages = append(messages, Message{
-			MsgID:    msg.Timestamp,
-			UserID:   msg.User,
-			UserName: userName,
-			RealName: realName,
-			Text:     text.ProcessText(msgText),
-			Channel:  channel,
-			ThreadTs: msg.ThreadTimestamp,
-			Time:     timestamp,
+			MsgID:     msg.Timestamp,
+			UserID:    msg.User,
+			UserName:  userName,
+			RealName:  realName,
+			Text:      msgText,
+			Channel:   channel,
+			ThreadTs:  msg.ThreadTimestamp,
+			Time:      timestamp,
+			Truncated: truncated,
+			EditedBy:  editedBy,
+			EditedTs:  editedTs,
 		})
 	}
 
@@ -413,8 +1413,9 @@ func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack
 	return messages
 }
 
-func (ch *ConversationsHandler) convertMessagesFromSearch(slackMessages []slack.SearchMessage) []Message {
+func (ch *ConversationsHandler) convertMessagesFromSearch(slackMessages []slack.SearchMessage, render bool) []Message {
 	usersMap := ch.apiProvider.ProvideUsersMap()
+	renderer := newMarkupRenderer(ch.apiProvider)
 	var messages []Message
 	warn := false
 
@@ -436,16 +1437,18 @@ func (ch *ConversationsHandler) convertMessagesFromSearch(slackMessages []slack.
 		}
 
 		msgText := msg.Text + text.AttachmentsTo2CSV(msg.Text, msg.Attachments)
+		msgText, truncated := clipMessageText(renderer.messageText(msgText, render))
 
 		messages = append(messages, Message{
-			MsgID:    msg.Timestamp,
-			UserID:   msg.User,
-			UserName: userName,
-			RealName: realName,
-			Text:     text.ProcessText(msgText),
-			Channel:  fmt.Sprintf("#%s", msg.Channel.Name),
-			ThreadTs: threadTs,
-			Time:     timestamp,
+			MsgID:     msg.Timestamp,
+			UserID:    msg.User,
+			UserName:  userName,
+			RealName:  realName,
+			Text:      msgText,
+			Channel:   fmt.Sprintf("#%s", msg.Channel.Name),
+			ThreadTs:  threadTs,
+			Time:      timestamp,
+			Truncated: truncated,
 		})
 	}
 
@@ -469,7 +1472,12 @@ func (ch *ConversationsHandler) parseParamsToolConversations(request mcp.CallToo
 
 	limit := request.GetString("limit", "")
 	cursor := request.GetString("cursor", "")
-	activity := request.GetBool("include_activity_messages", false)
+	opts := historyOptions{
+		activity:    request.GetBool("include_activity_messages", false),
+		render:      request.GetBool("render", true),
+		edits:       request.GetBool("include_edits", true),
+		excludeBots: request.GetBool("exclude_bots", false),
+	}
 
 	var (
 		paramLimit  int
@@ -490,9 +1498,10 @@ func (ch *ConversationsHandler) parseParamsToolConversations(request mcp.CallToo
 			return nil, err
 		}
 	}
+	paramLimit = capLimit(paramLimit)
 
 	if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
-		if ready, err := ch.apiProvider.IsReady(); !ready {
+		if err := channelNameReady(ch.apiProvider, channel); err != nil {
 			if errors.Is(err, provider.ErrUsersNotReady) {
 				ch.logger.Warn(
 					"WARNING: Slack users sync is not ready yet, you may experience some limited functionality and see UIDs instead of resolved names as well as unable to query users by their @handles. Users sync is part of channels sync and operations on channels depend on users collection (IM, MPIM). Please wait until users are synced and try again",
@@ -505,24 +1514,26 @@ func (ch *ConversationsHandler) parseParamsToolConversations(request mcp.CallToo
 					zap.Error(err),
 				)
 			}
-			return nil, fmt.Errorf("channel %q not found in empty cache", channel)
+			return nil, err
 		}
-		channelsMaps := ch.apiProvider.ProvideChannelsMaps()
-		chn, ok := channelsMaps.ChannelsInv[channel]
-		if !ok {
+		chn, err := lookupChannelID(ch.apiProvider, channel, request.GetString("team_id", ""))
+		if errors.Is(err, provider.ErrNameNotFound) {
 			ch.logger.Error("Channel not found in synced cache", zap.String("channel", channel))
-			return nil, fmt.Errorf("channel %q not found in synced cache. Try to remove old cache file and restart MCP Server", channel)
+			return nil, notFoundError("channel %q not found in synced cache. Try to remove old cache file and restart MCP Server", channel)
+		}
+		if err != nil {
+			return nil, err
 		}
-		channel = channelsMaps.Channels[chn].ID
+		channel = chn
 	}
 
 	return &conversationParams{
-		channel:  channel,
-		limit:    paramLimit,
-		oldest:   paramOldest,
-		latest:   paramLatest,
-		cursor:   cursor,
-		activity: activity,
+		channel:        channel,
+		limit:          paramLimit,
+		oldest:         paramOldest,
+		latest:         paramLatest,
+		cursor:         cursor,
+		historyOptions: opts,
 	}, nil
 }
 
@@ -530,7 +1541,7 @@ func (ch *ConversationsHandler) parseParamsToolAddMessage(request mcp.CallToolRe
 	toolConfig := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
 	if toolConfig == "" {
 		ch.logger.Error("Add-message tool disabled by default")
-		return nil, errors.New(
+		return nil, capabilityUnsupportedError(
 			"by default, the conversations_add_message tool is disabled to guard Slack workspaces against accidental spamming." +
 				"To enable it, set the SLACK_MCP_ADD_MESSAGE_TOOL environment variable to true, 1, or comma separated list of channels" +
 				"to limit where the MCP can post messages, e.g. 'SLACK_MCP_ADD_MESSAGE_TOOL=C1234567890,D0987654321', 'SLACK_MCP_ADD_MESSAGE_TOOL=!C1234567890'" +
@@ -538,23 +1549,33 @@ func (ch *ConversationsHandler) parseParamsToolAddMessage(request mcp.CallToolRe
 		)
 	}
 
-	channel := request.GetString("channel_id", "")
+	// an explicit channel_id wins over SLACK_MCP_DEFAULT_CHANNEL, both are checked against the policy below
+	channel := strings.TrimSpace(request.GetString("channel_id", ""))
 	if channel == "" {
-		ch.logger.Error("channel_id missing in add-message params")
-		return nil, errors.New("channel_id must be a string")
+		channel = strings.TrimSpace(os.Getenv("SLACK_MCP_DEFAULT_CHANNEL"))
+	}
+	if channel == "" {
+		ch.logger.Error("channel_id missing in add-message params and no default channel is set")
+		return nil, errors.New("channel_id is required, pass it or set SLACK_MCP_DEFAULT_CHANNEL to post to a default channel")
 	}
 	if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
-		channelsMaps := ch.apiProvider.ProvideChannelsMaps()
-		chn, ok := channelsMaps.ChannelsInv[channel]
-		if !ok {
+		if err := channelNameReady(ch.apiProvider, channel); err != nil {
+			ch.logger.Warn("Slack channels sync is not ready yet, post by Channel ID instead", zap.Error(err))
+			return nil, err
+		}
+		chn, err := lookupChannelID(ch.apiProvider, channel, request.GetString("team_id", ""))
+		if errors.Is(err, provider.ErrNameNotFound) {
 			ch.logger.Error("Channel not found", zap.String("channel", channel))
-			return nil, fmt.Errorf("channel %q not found", channel)
+			return nil, notFoundError("channel %q not found", channel)
 		}
-		channel = channelsMaps.Channels[chn].ID
+		if err != nil {
+			return nil, err
+		}
+		channel = chn
 	}
-	if !isChannelAllowed(channel) {
+	if err := checkWriteChannel("conversations_add_message", channel); err != nil {
 		ch.logger.Warn("Add-message tool not allowed for channel", zap.String("channel", channel), zap.String("policy", toolConfig))
-		return nil, fmt.Errorf("conversations_add_message tool is not allowed for channel %q, applied policy: %s", channel, toolConfig)
+		return nil, err
 	}
 
 	threadTs := request.GetString("thread_ts", "")
@@ -563,27 +1584,259 @@ func (ch *ConversationsHandler) parseParamsToolAddMessage(request mcp.CallToolRe
 		return nil, errors.New("thread_ts must be a valid timestamp in format 1234567890.123456")
 	}
 
-	msgText := request.GetString("payload", "")
-	if msgText == "" {
-		ch.logger.Error("Message text missing")
-		return nil, errors.New("text must be a string")
-	}
-
 	contentType := request.GetString("content_type", "text/markdown")
 	if contentType != "text/plain" && contentType != "text/markdown" {
 		ch.logger.Error("Invalid content_type", zap.String("content_type", contentType))
 		return nil, errors.New("content_type must be either 'text/plain' or 'text/markdown'")
 	}
 
+	username := strings.TrimSpace(request.GetString("username", ""))
+	iconEmoji := normalizeEmoji(request.GetString("icon_emoji", ""))
+	iconURL := strings.TrimSpace(request.GetString("icon_url", ""))
+	if iconEmoji != "" && iconURL != "" {
+		return nil, errors.New("icon_emoji and icon_url are mutually exclusive")
+	}
+	if iconURL != "" {
+		if u, err := url.Parse(iconURL); err != nil || u.Scheme != "https" || u.Host == "" {
+			ch.logger.Error("Invalid icon_url", zap.String("icon_url", iconURL))
+			return nil, fmt.Errorf("icon_url must be an absolute https URL, got %q", iconURL)
+		}
+	}
+
+	allowBroadcast := request.GetBool("allow_broadcast", false)
+
+	blocks, err := parseBlocks(request.GetString("blocks", ""), allowBroadcast)
+	if err != nil {
+		ch.logger.Error("Invalid blocks", zap.Error(err))
+		return nil, err
+	}
+
+	msgText, err := messageContent(request.GetString("payload", ""), blocks)
+	if err != nil {
+		ch.logger.Error("Message has no content", zap.Error(err))
+		return nil, err
+	}
+
+	replyBroadcast := request.GetBool("reply_broadcast", false)
+	if replyBroadcast && threadTs == "" {
+		return nil, errors.New("reply_broadcast requires thread_ts, only thread replies can be broadcast to the channel")
+	}
+
 	return &addMessageParams{
-		channel:     channel,
-		threadTs:    threadTs,
-		text:        msgText,
-		contentType: contentType,
+		channel:        channel,
+		threadTs:       threadTs,
+		text:           escapeMessageText(msgText, allowBroadcast),
+		allowBroadcast: allowBroadcast,
+		replyBroadcast: replyBroadcast,
+		contentType:    contentType,
+		username:       username,
+		iconEmoji:      iconEmoji,
+		iconURL:        iconURL,
+		blocks:         blocks,
 	}, nil
 }
 
-func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (*searchParams, error) {
+// parseParamsToolAcknowledge reads the message to acknowledge, the reaction name and the reply text
+func parseParamsToolAcknowledge(request mcp.CallToolRequest) (*acknowledgeParams, error) {
+	channel, err := paramChannelID(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWritePolicy("conversations_acknowledge", channel); err != nil {
+		return nil, err
+	}
+
+	ts := strings.TrimSpace(request.GetString("ts", ""))
+	if !isMessageTimestamp(ts) {
+		return nil, fmt.Errorf("ts must be a message timestamp in format 1234567890.123456, got %q", ts)
+	}
+
+	reaction := strings.Trim(strings.TrimSpace(request.GetString("reaction", "")), ":")
+	if reaction == "" {
+		return nil, errors.New("reaction must be an emoji name such as eyes or white_check_mark")
+	}
+
+	text := request.GetString("text", "")
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.New("text must be a non-empty string")
+	}
+
+	return &acknowledgeParams{
+		channel:  channel,
+		ts:       ts,
+		reaction: reaction,
+		text:     escapeMessageText(text, false),
+	}, nil
+}
+
+// parseParamsToolPostEphemeral reads the target channel and user, text and optional Block Kit JSON.
+// Text is required even with blocks, Slack shows it in notifications.
+func (ch *ConversationsHandler) parseParamsToolPostEphemeral(request mcp.CallToolRequest) (*ephemeralParams, error) {
+	channel, err := paramChannelID(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWritePolicy("conversations_post_ephemeral", channel); err != nil {
+		ch.logger.Warn("conversations_post_ephemeral rejected by policy", zap.String("channel", channel), zap.Error(err))
+		return nil, err
+	}
+
+	rawUser := strings.TrimSpace(request.GetString("user", ""))
+	if rawUser == "" {
+		return nil, errors.New("user must be a string")
+	}
+	user, err := resolveUserID(ch.apiProvider, rawUser, "")
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSpace(request.GetString("text", ""))
+	if text == "" {
+		return nil, errors.New("text must be a non-empty string")
+	}
+
+	blocks, err := parseBlocks(request.GetString("blocks", ""), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ephemeralParams{
+		channel: channel,
+		user:    user,
+		text:    escapeMessageText(text, false),
+		blocks:  blocks,
+	}, nil
+}
+
+// messageContent trims trailing whitespace off text and rejects a message with neither text nor blocks, Slack
+// would fail it with no_text or post a blank message. Text of only whitespace counts as none.
+func messageContent(text string, blocks []slack.Block) (string, error) {
+	text = strings.TrimRightFunc(text, unicode.IsSpace)
+	if strings.TrimSpace(text) != "" {
+		return text, nil
+	}
+	if len(blocks) == 0 {
+		return "", errors.New("message is empty: payload is empty or only whitespace and no blocks are given")
+	}
+	return "", nil
+}
+
+// parseBlocks decodes a JSON array of Block Kit blocks, empty input means no blocks.
+// Blocks are validated first unless SLACK_MCP_VALIDATE_BLOCKS is disabled, and their
+// broadcast mentions are escaped like message text, see escapeBlockMentions.
+func parseBlocks(raw string, allowBroadcast bool) ([]slack.Block, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	if IsBlockValidationEnabled() {
+		if err := validateBlocks([]byte(raw)); err != nil {
+			return nil, err
+		}
+	}
+	escaped, err := escapeBlockMentions([]byte(raw), allowBroadcast)
+	if err != nil {
+		return nil, err
+	}
+	var blocks slack.Blocks
+	if err := json.Unmarshal(escaped, &blocks); err != nil {
+		return nil, fmt.Errorf("blocks must be a JSON array of Block Kit blocks: %v", err)
+	}
+	return blocks.BlockSet, nil
+}
+
+// parseParamsToolOpen reads users as user IDs or @handles, one user opens a DM and up to 8 open a group DM
+func (ch *ConversationsHandler) parseParamsToolOpen(request mcp.CallToolRequest) ([]string, error) {
+	var users []string
+	seen := make(map[string]bool)
+	for _, raw := range strings.Split(request.GetString("users", ""), ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		uid, err := ch.paramFormatUserID(raw)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[uid] {
+			seen[uid] = true
+			users = append(users, uid)
+		}
+	}
+
+	if len(users) == 0 {
+		return nil, errors.New("users must be a comma-separated list of user IDs or @handles")
+	}
+	if len(users) > maxGroupDMUsers {
+		return nil, fmt.Errorf("a group DM can include at most %d users, got %d", maxGroupDMUsers, len(users))
+	}
+	return users, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolScheduledList(request mcp.CallToolRequest) (*scheduledListParams, error) {
+	channel := strings.TrimSpace(request.GetString("channel_id", ""))
+	if strings.HasPrefix(channel, "#") {
+		if err := channelNameReady(ch.apiProvider, channel); err != nil {
+			ch.logger.Warn("Slack channels sync is not ready yet, filter scheduled messages by Channel ID instead", zap.Error(err))
+			return nil, err
+		}
+		id, ok := ch.apiProvider.ProvideChannelsMaps().ChannelsInv[channel]
+		if !ok {
+			return nil, notFoundError("channel %q not found in synced cache", channel)
+		}
+		channel = id
+	}
+	if channel != "" {
+		if err := checkReadPolicy(ch.logger, "conversations_scheduled_messages_list", channel); err != nil {
+			return nil, err
+		}
+	}
+
+	var oldest, latest int64
+	if raw := request.GetString("date_from", ""); raw != "" {
+		from, _, err := parseFlexibleDate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date_from: %v", err)
+		}
+		oldest = from.Unix()
+	}
+	if raw := request.GetString("date_to", ""); raw != "" {
+		to, _, err := parseFlexibleDate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date_to: %v", err)
+		}
+		// date_to is inclusive, include the whole day
+		latest = to.AddDate(0, 0, 1).Unix() - 1
+	}
+	if oldest > 0 && latest > 0 && oldest > latest {
+		return nil, errors.New("date_from must not be after date_to")
+	}
+
+	limit := request.GetInt("limit", defaultScheduledLimit)
+	if limit <= 0 {
+		limit = defaultScheduledLimit
+	}
+	if limit > maxScheduledLimit {
+		limit = maxScheduledLimit
+	}
+
+	params := &scheduledListParams{
+		channel: channel,
+		limit:   capLimit(limit),
+		cursor:  request.GetString("cursor", ""),
+		render:  request.GetBool("render", true),
+	}
+	if oldest > 0 {
+		params.oldest = strconv.FormatInt(oldest, 10)
+	}
+	if latest > 0 {
+		params.latest = strconv.FormatInt(latest, 10)
+	}
+	return params, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolSearch(ctx context.Context, req mcp.CallToolRequest) (*searchParams, error) {
+	logger := requestid.Logger(ctx, ch.logger)
 	rawQuery := strings.TrimSpace(req.GetString("search_query", ""))
 	freeText, filters := splitQuery(rawQuery)
 
@@ -591,16 +1844,16 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 		addFilter(filters, "is", "thread")
 	}
 	if chName := req.GetString("filter_in_channel", ""); chName != "" {
-		f, err := ch.paramFormatChannel(chName)
+		f, err := ch.paramFormatChannel(ctx, chName)
 		if err != nil {
-			ch.logger.Error("Invalid channel filter", zap.String("filter", chName), zap.Error(err))
+			logger.Error("Invalid channel filter", zap.String("filter", chName), zap.Error(err))
 			return nil, err
 		}
 		addFilter(filters, "in", f)
 	} else if im := req.GetString("filter_in_im_or_mpim", ""); im != "" {
 		f, err := ch.paramFormatUser(im)
 		if err != nil {
-			ch.logger.Error("Invalid IM/MPIM filter", zap.String("filter", im), zap.Error(err))
+			logger.Error("Invalid IM/MPIM filter", zap.String("filter", im), zap.Error(err))
 			return nil, err
 		}
 		addFilter(filters, "in", f)
@@ -608,7 +1861,7 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 	if with := req.GetString("filter_users_with", ""); with != "" {
 		f, err := ch.paramFormatUser(with)
 		if err != nil {
-			ch.logger.Error("Invalid with-user filter", zap.String("filter", with), zap.Error(err))
+			logger.Error("Invalid with-user filter", zap.String("filter", with), zap.Error(err))
 			return nil, err
 		}
 		addFilter(filters, "with", f)
@@ -616,7 +1869,7 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 	if from := req.GetString("filter_users_from", ""); from != "" {
 		f, err := ch.paramFormatUser(from)
 		if err != nil {
-			ch.logger.Error("Invalid from-user filter", zap.String("filter", from), zap.Error(err))
+			logger.Error("Invalid from-user filter", zap.String("filter", from), zap.Error(err))
 			return nil, err
 		}
 		addFilter(filters, "from", f)
@@ -629,7 +1882,7 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 		req.GetString("filter_date_during", ""),
 	)
 	if err != nil {
-		ch.logger.Error("Invalid date filters", zap.Error(err))
+		logger.Error("Invalid date filters", zap.Error(err))
 		return nil, err
 	}
 	for key, val := range dateMap {
@@ -637,7 +1890,7 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 	}
 
 	finalQuery := buildQuery(freeText, filters)
-	limit := req.GetInt("limit", 100)
+	limit := capLimit(req.GetInt("limit", 100))
 	cursor := req.GetString("cursor", "")
 
 	var (
@@ -647,42 +1900,48 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 	if cursor != "" {
 		decodedCursor, err = base64.StdEncoding.DecodeString(cursor)
 		if err != nil {
-			ch.logger.Error("Invalid cursor decoding", zap.String("cursor", cursor), zap.Error(err))
+			logger.Error("Invalid cursor decoding", zap.String("cursor", cursor), zap.Error(err))
 			return nil, fmt.Errorf("invalid cursor: %v", err)
 		}
 		parts := strings.Split(string(decodedCursor), ":")
 		if len(parts) != 2 {
-			ch.logger.Error("Invalid cursor format", zap.String("cursor", cursor))
+			logger.Error("Invalid cursor format", zap.String("cursor", cursor))
 			return nil, fmt.Errorf("invalid cursor: %v", cursor)
 		}
 		page, err = strconv.Atoi(parts[1])
 		if err != nil || page < 1 {
-			ch.logger.Error("Invalid cursor page", zap.String("cursor", cursor), zap.Error(err))
+			logger.Error("Invalid cursor page", zap.String("cursor", cursor), zap.Error(err))
 			return nil, fmt.Errorf("invalid cursor page: %v", err)
 		}
 	} else {
 		page = 1
 	}
 
-	ch.logger.Debug("Search parameters built",
+	logger.Debug("Search parameters built",
 		zap.String("query", finalQuery),
 		zap.Int("limit", limit),
 		zap.Int("page", page),
 	)
 	return &searchParams{
-		query: finalQuery,
-		limit: limit,
-		page:  page,
+		query:  finalQuery,
+		limit:  limit,
+		page:   page,
+		render: req.GetBool("render", true),
 	}, nil
 }
 
+// paramFormatUserID returns a user ID as is and resolves an @handle to its ID using the users cache
+func (ch *ConversationsHandler) paramFormatUserID(raw string) (string, error) {
+	return resolveUserID(ch.apiProvider, raw, "")
+}
+
 func (ch *ConversationsHandler) paramFormatUser(raw string) (string, error) {
 	users := ch.apiProvider.ProvideUsersMap()
 	raw = strings.TrimSpace(raw)
 	if strings.HasPrefix(raw, "U") {
 		u, ok := users.Users[raw]
 		if !ok {
-			return "", fmt.Errorf("user %q not found", raw)
+			return "", notFoundError("user %q not found", raw)
 		}
 		return fmt.Sprintf("<@%s>", u.ID), nil
 	}
@@ -694,35 +1953,60 @@ func (ch *ConversationsHandler) paramFormatUser(raw string) (string, error) {
 	}
 	uid, ok := users.UsersInv[raw]
 	if !ok {
-		return "", fmt.Errorf("user %q not found", raw)
+		return "", notFoundError("user %q not found", raw)
 	}
 	return fmt.Sprintf("<@%s>", uid), nil
 }
 
-func (ch *ConversationsHandler) paramFormatChannel(raw string) (string, error) {
+func (ch *ConversationsHandler) paramFormatChannel(ctx context.Context, raw string) (string, error) {
 	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "C") {
+		if err := ch.apiProvider.ResolveChannels(ctx, []string{raw}); err != nil {
+			return "", cacheNotReadyError(err)
+		}
+	}
 	cms := ch.apiProvider.ProvideChannelsMaps()
 	if strings.HasPrefix(raw, "#") {
 		if id, ok := cms.ChannelsInv[raw]; ok {
 			return "#" + cms.Channels[id].Name, nil
 		}
-		return "", fmt.Errorf("channel %q not found", raw)
+		return "", notFoundError("channel %q not found", raw)
 	}
 	if strings.HasPrefix(raw, "C") {
 		if chn, ok := cms.Channels[raw]; ok {
 			return "#" + chn.Name, nil
 		}
-		return "", fmt.Errorf("channel %q not found", raw)
+		return "", notFoundError("channel %q not found", raw)
 	}
 	return "", fmt.Errorf("invalid channel format: %q", raw)
 }
 
 func marshalMessagesToCSV(messages []Message) (*mcp.CallToolResult, error) {
-	csvBytes, err := gocsv.MarshalBytes(&messages)
+	return marshalCSVResult(messages)
+}
+
+// marshalMessagesPage marshals a page of history with the cursor of the next page on the last row. The limit
+// counts messages before activity and bot messages are dropped, so a page may come back short or even empty,
+// an emptied page that is not the last one gets the cursor in a text line so that paging can go on.
+func marshalMessagesPage(messages []Message, hasMore bool, nextCursor string) (*mcp.CallToolResult, error) {
+	if !hasMore || nextCursor == "" {
+		return marshalMessagesToCSV(messages)
+	}
+	if len(messages) > 0 {
+		messages[len(messages)-1].Cursor = nextCursor
+		return marshalMessagesToCSV(messages)
+	}
+	res, err := marshalMessagesToCSV(messages)
 	if err != nil {
 		return nil, err
 	}
-	return mcp.NewToolResultText(string(csvBytes)), nil
+	res.Content = append(res.Content, mcp.NewTextContent("all messages on this page were filtered out, next cursor: "+nextCursor))
+	return res, nil
+}
+
+// isBotMessage reports whether msg was posted by a bot or an app rather than a person
+func isBotMessage(msg slack.Message) bool {
+	return msg.SubType == "bot_message" || msg.BotID != "" || msg.BotProfile != nil
 }
 
 func getUserInfo(userID string, usersMap map[string]slack.User) (userName, realName string, ok bool) {
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
@@ -12,13 +13,19 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge/fasttime"
 	"github.com/korotovsky/slack-mcp-server/pkg/test/util"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
 	"github.com/openai/openai-go/packages/param"
 	"github.com/openai/openai-go/responses"
+	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func TestIntegrationConversations(t *testing.T) {
@@ -590,3 +597,430 @@ func TestUnitLimitByExpression_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitIsMessageTimestamp(t *testing.T) {
+	assert.True(t, isMessageTimestamp("1234567890.123456"))
+	assert.False(t, isMessageTimestamp(""))
+	assert.False(t, isMessageTimestamp("1234567890"))
+	assert.False(t, isMessageTimestamp("p1234567890123456"))
+	assert.False(t, isMessageTimestamp("1234567890.123456 "))
+}
+
+func TestUnitIsChannelAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		channel string
+		want    bool
+	}{
+		{"unset allows every channel", "", "C1", true},
+		{"true allows every channel", "true", "C1", true},
+		{"1 allows every channel", "1", "C1", true},
+		{"allow-list allows a listed channel", "C1,C2", "C2", true},
+		{"allow-list denies an unlisted channel", "C1,C2", "C3", false},
+		{"allow-list entries are trimmed", "C1, C2", "C2", true},
+		{"single entry allow-list denies others", "C1", "C2", false},
+		{"negated list denies a listed channel", "!C1,!C2", "C2", false},
+		{"negated list allows an unlisted channel", "!C1,!C2", "C3", true},
+		{"negated list entries are trimmed", "!C1, !C2", "C2", false},
+		{"single negated entry allows others", "!C1", "C2", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", tt.config)
+			assert.Equal(t, tt.want, isChannelAllowed(tt.channel))
+		})
+	}
+}
+
+func TestUnitUserIDPattern(t *testing.T) {
+	assert.True(t, userIDRe.MatchString("U0123ABCD"))
+	assert.True(t, userIDRe.MatchString("W0123ABCD"))
+	assert.False(t, userIDRe.MatchString("@alice"))
+	assert.False(t, userIDRe.MatchString("Ursula"))
+	assert.False(t, userIDRe.MatchString("C0123ABCD"))
+}
+
+func TestUnitParseParamsToolAddMessageIdentity(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = map[string]any{"channel_id": "C1234567890", "payload": "hello"}
+		for k, v := range args {
+			r.Params.Arguments.(map[string]any)[k] = v
+		}
+		return r
+	}
+
+	params, err := ch.parseParamsToolAddMessage(request(map[string]any{
+		"username":   "Alerts",
+		"icon_emoji": "rotating_light",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "Alerts", params.username)
+	assert.Equal(t, ":rotating_light:", params.iconEmoji)
+
+	params, err = ch.parseParamsToolAddMessage(request(map[string]any{"icon_url": "https://example.com/icon.png"}))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/icon.png", params.iconURL)
+
+	_, err = ch.parseParamsToolAddMessage(request(map[string]any{"icon_url": "http://example.com/icon.png"}))
+	assert.Error(t, err, "icon_url must use https")
+
+	_, err = ch.parseParamsToolAddMessage(request(map[string]any{"icon_url": "https://example.com/icon.png", "icon_emoji": ":x:"}))
+	assert.Error(t, err, "icon_url and icon_emoji are mutually exclusive")
+}
+
+func TestUnitAddMessageEmptyBody(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+	section := `[{"type":"section","text":{"type":"mrkdwn","text":"Deploy done"}}]`
+
+	tests := []struct {
+		name     string
+		payload  string
+		blocks   string
+		wantText string
+		wantErr  bool
+	}{
+		{"empty text and no blocks", "", "", "", true},
+		{"empty text and empty blocks", "", "[]", "", true},
+		{"whitespace only", " \n\t ", "", "", true},
+		{"text only", "hello", "", "hello", false},
+		{"trailing whitespace is trimmed", "  hello \n\n", "", "  hello", false},
+		{"blocks only", "", section, "", false},
+		{"whitespace text with blocks", "  \n", section, "", false},
+		{"text with blocks", "Deploy done ", section, "Deploy done", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r mcp.CallToolRequest
+			r.Params.Arguments = map[string]any{"channel_id": "C1234567890", "payload": tt.payload, "blocks": tt.blocks}
+
+			params, err := ch.parseParamsToolAddMessage(r)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "message is empty")
+
+				// the handler rejects it before calling Slack
+				_, err = ch.ConversationsAddMessageHandler(context.Background(), r)
+				assert.Equal(t, ErrCodeInvalidArgument, AsToolError(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantText, params.text)
+		})
+	}
+}
+
+func TestUnitParseParamsToolAddMessageReplyBroadcast(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "C1234567890")
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = map[string]any{"channel_id": "C1234567890", "payload": "hello"}
+		for k, v := range args {
+			r.Params.Arguments.(map[string]any)[k] = v
+		}
+		return r
+	}
+
+	params, err := ch.parseParamsToolAddMessage(request(map[string]any{"thread_ts": "1700000000.000100", "reply_broadcast": true}))
+	require.NoError(t, err)
+	assert.Equal(t, "1700000000.000100", params.threadTs)
+	assert.True(t, params.replyBroadcast)
+
+	_, err = ch.parseParamsToolAddMessage(request(map[string]any{"reply_broadcast": true}))
+	assert.ErrorContains(t, err, "requires thread_ts")
+
+	r := request(map[string]any{"thread_ts": "1700000000.000100", "reply_broadcast": true})
+	r.Params.Arguments.(map[string]any)["channel_id"] = "C0987654321"
+	_, err = ch.parseParamsToolAddMessage(r)
+	assert.Error(t, err, "the channel policy applies to broadcast replies")
+}
+
+func TestUnitParseParamsToolAddMessageDefaultChannel(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "C1234567890,C0NOTIFY")
+	t.Setenv("SLACK_MCP_DEFAULT_CHANNEL", "")
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+
+	request := func(channel string) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = map[string]any{"channel_id": channel, "payload": "hello"}
+		return r
+	}
+
+	_, err := ch.parseParamsToolAddMessage(request(""))
+	assert.ErrorContains(t, err, "SLACK_MCP_DEFAULT_CHANNEL")
+
+	t.Setenv("SLACK_MCP_DEFAULT_CHANNEL", " C0NOTIFY ")
+	params, err := ch.parseParamsToolAddMessage(request(""))
+	require.NoError(t, err)
+	assert.Equal(t, "C0NOTIFY", params.channel)
+
+	params, err = ch.parseParamsToolAddMessage(request("C1234567890"))
+	require.NoError(t, err)
+	assert.Equal(t, "C1234567890", params.channel, "an explicit channel wins")
+
+	t.Setenv("SLACK_MCP_DEFAULT_CHANNEL", "C0DENIED")
+	_, err = ch.parseParamsToolAddMessage(request(""))
+	assert.Equal(t, ErrCodeChannelNotAllowed, AsToolError(err).Code, "the default channel is checked against the policy")
+}
+
+func TestUnitResolveMembers(t *testing.T) {
+	cached := map[string]slack.User{
+		"U1": {ID: "U1", Name: "alice", RealName: "Alice"},
+	}
+
+	ids := []string{"U1"}
+	for i := 0; i < usersInfoBatchSize+5; i++ {
+		ids = append(ids, fmt.Sprintf("U%03dX", i))
+	}
+
+	var batches []int
+	members := resolveMembers(ids, cached, func(batch []string) ([]slack.User, error) {
+		batches = append(batches, len(batch))
+		if len(batches) == 2 {
+			return nil, errors.New("users.info failed")
+		}
+		users := make([]slack.User, 0, len(batch))
+		for _, id := range batch {
+			users = append(users, slack.User{ID: id, Name: strings.ToLower(id)})
+		}
+		return users, nil
+	}, zap.NewNop())
+
+	assert.Equal(t, []int{usersInfoBatchSize, 5}, batches, "cached users must not be fetched")
+	require.Len(t, members, len(ids))
+	assert.Equal(t, ChannelMember{UserID: "U1", UserName: "alice", RealName: "Alice"}, members[0])
+	assert.Equal(t, "u000x", members[1].UserName)
+	assert.Equal(t, ChannelMember{UserID: ids[len(ids)-1]}, members[len(members)-1], "failed batch keeps bare IDs")
+}
+
+func TestUnitParseParamsToolScheduledList(t *testing.T) {
+	t.Setenv("SLACK_MCP_READ_CHANNELS", "!C0000000BAD")
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = args
+		return r
+	}
+
+	params, err := ch.parseParamsToolScheduledList(request(map[string]any{}))
+	require.NoError(t, err)
+	assert.Equal(t, &scheduledListParams{limit: defaultScheduledLimit, render: true}, params)
+
+	params, err = ch.parseParamsToolScheduledList(request(map[string]any{
+		"channel_id": "C1234567890",
+		"date_from":  "2025-07-01",
+		"date_to":    "2025-07-01",
+		"limit":      5000,
+		"render":     false,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "C1234567890", params.channel)
+	assert.False(t, params.render)
+	assert.Equal(t, "1751328000", params.oldest)
+	assert.Equal(t, "1751414399", params.latest, "date_to includes the whole day")
+	assert.Equal(t, maxScheduledLimit, params.limit)
+
+	_, err = ch.parseParamsToolScheduledList(request(map[string]any{"channel_id": "C0000000BAD"}))
+	assert.Equal(t, ErrCodeChannelNotAllowed, AsToolError(err).Code)
+
+	_, err = ch.parseParamsToolScheduledList(request(map[string]any{"date_from": "2025-07-02", "date_to": "2025-07-01"}))
+	assert.Error(t, err)
+}
+
+func TestUnitParseParamsToolPostEphemeral(t *testing.T) {
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = map[string]any{"channel_id": "C1234567890", "user": "U1234567890", "text": "only for you"}
+		for k, v := range args {
+			r.Params.Arguments.(map[string]any)[k] = v
+		}
+		return r
+	}
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "")
+	_, err := ch.parseParamsToolPostEphemeral(request(nil))
+	assert.Equal(t, ErrCodeCapabilityUnsupported, AsToolError(err).Code, "writes are disabled by default")
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "!C0000000BAD")
+	_, err = ch.parseParamsToolPostEphemeral(request(map[string]any{"channel_id": "C0000000BAD"}))
+	assert.Equal(t, ErrCodeChannelNotAllowed, AsToolError(err).Code)
+
+	params, err := ch.parseParamsToolPostEphemeral(request(map[string]any{
+		"blocks": `[{"type":"section","text":{"type":"mrkdwn","text":"*hi*"}}]`,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "C1234567890", params.channel)
+	assert.Equal(t, "U1234567890", params.user)
+	assert.Equal(t, "only for you", params.text)
+	require.Len(t, params.blocks, 1)
+	assert.Equal(t, slack.MBTSection, params.blocks[0].BlockType())
+
+	_, err = ch.parseParamsToolPostEphemeral(request(map[string]any{"text": " "}))
+	assert.Error(t, err, "text is required")
+
+	_, err = ch.parseParamsToolPostEphemeral(request(map[string]any{"blocks": `{"type":"section"}`}))
+	assert.Error(t, err, "blocks must be an array")
+}
+
+func TestUnitParseParamsToolChannelMetadata(t *testing.T) {
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = args
+		return r
+	}
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "")
+	_, _, err := parseParamsToolChannelMetadata(request(map[string]any{"channel_id": "C1234567890", "topic": "x"}), "conversations_set_topic", "topic", maxTopicChars)
+	assert.Equal(t, ErrCodeCapabilityUnsupported, AsToolError(err).Code, "writes are disabled by default")
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "!C0000000BAD")
+	_, _, err = parseParamsToolChannelMetadata(request(map[string]any{"channel_id": "C0000000BAD", "topic": "x"}), "conversations_set_topic", "topic", maxTopicChars)
+	assert.Equal(t, ErrCodeChannelNotAllowed, AsToolError(err).Code)
+
+	channel, text, err := parseParamsToolChannelMetadata(request(map[string]any{"channel_id": "C1234567890", "topic": " Release train 🚂 "}), "conversations_set_topic", "topic", maxTopicChars)
+	require.NoError(t, err)
+	assert.Equal(t, "C1234567890", channel)
+	assert.Equal(t, "Release train 🚂", text)
+
+	_, text, err = parseParamsToolChannelMetadata(request(map[string]any{"channel_id": "C1234567890", "purpose": ""}), "conversations_set_purpose", "purpose", maxPurposeChars)
+	require.NoError(t, err)
+	assert.Empty(t, text, "an empty purpose clears it")
+
+	_, _, err = parseParamsToolChannelMetadata(request(map[string]any{"channel_id": "C1234567890"}), "conversations_set_purpose", "purpose", maxPurposeChars)
+	assert.Error(t, err, "the text is required")
+
+	_, _, err = parseParamsToolChannelMetadata(request(map[string]any{"channel_id": "C1234567890", "topic": strings.Repeat("ü", maxTopicChars+1)}), "conversations_set_topic", "topic", maxTopicChars)
+	assert.ErrorContains(t, err, "at most 250 characters")
+}
+
+func TestUnitMpimHandles(t *testing.T) {
+	assert.Equal(t, []string{"alice", "bob", "carol"}, mpimHandles("mpdm-alice--bob--carol-1"))
+	assert.Equal(t, []string{"alice", "bob"}, mpimHandles("mpdm-alice--bob"))
+	assert.Equal(t, []string{"mary-jane", "bob"}, mpimHandles("mpdm-mary-jane--bob-12"))
+	assert.Nil(t, mpimHandles("mpdm-"))
+}
+
+func TestUnitUnreadSnapshots(t *testing.T) {
+	at := func(sec int64) fasttime.Time { return fasttime.Time(time.Unix(sec, 0)) }
+	counts := edge.ClientCountsResponse{
+		Channels: []edge.ChannelSnapshot{
+			{ID: "C1", HasUnreads: true, Latest: at(100)},
+			{ID: "C2", HasUnreads: false, Latest: at(500)},
+			{ID: "C3", HasUnreads: true, Latest: at(300)},
+		},
+		MPIMs: []edge.ChannelSnapshot{{ID: "G1", HasUnreads: true, Latest: at(200)}},
+		IMs:   []edge.ChannelSnapshot{{ID: "D1", HasUnreads: true, Latest: at(400)}},
+	}
+	ids := func(snapshots []edge.ChannelSnapshot) []string {
+		var res []string
+		for _, s := range snapshots {
+			res = append(res, s.ID)
+		}
+		return res
+	}
+	all := func(string) bool { return true }
+
+	assert.Equal(t, []string{"D1", "C3", "G1", "C1"}, ids(unreadSnapshots(counts, all, 10)), "read channels are skipped, most recent first")
+	assert.Equal(t, []string{"D1", "C3"}, ids(unreadSnapshots(counts, all, 2)))
+	assert.Equal(t, []string{"C3", "C1"}, ids(unreadSnapshots(counts, func(id string) bool { return id[0] == 'C' }, 10)))
+
+	assert.Equal(t, "", slackTimestamp(fasttime.Time{}))
+	assert.Equal(t, "", slackTimestamp(at(0)))
+	assert.Equal(t, "100.000000", slackTimestamp(at(100)))
+}
+
+func TestUnitParseParamsToolAcknowledge(t *testing.T) {
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = map[string]any{"channel_id": "C1234567890", "ts": "1712345678.123456", "reaction": ":eyes:", "text": "On it"}
+		for k, v := range args {
+			r.Params.Arguments.(map[string]any)[k] = v
+		}
+		return r
+	}
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "")
+	_, err := parseParamsToolAcknowledge(request(nil))
+	assert.Equal(t, ErrCodeCapabilityUnsupported, AsToolError(err).Code, "writes are disabled by default")
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "C1234567890")
+	_, err = parseParamsToolAcknowledge(request(map[string]any{"channel_id": "C0000000BAD"}))
+	assert.Equal(t, ErrCodeChannelNotAllowed, AsToolError(err).Code)
+
+	params, err := parseParamsToolAcknowledge(request(nil))
+	require.NoError(t, err)
+	assert.Equal(t, &acknowledgeParams{channel: "C1234567890", ts: "1712345678.123456", reaction: "eyes", text: "On it"}, params)
+
+	_, err = parseParamsToolAcknowledge(request(map[string]any{"ts": "yesterday"}))
+	assert.Error(t, err, "ts must be a message timestamp")
+
+	_, err = parseParamsToolAcknowledge(request(map[string]any{"reaction": "::"}))
+	assert.Error(t, err, "reaction is required")
+
+	_, err = parseParamsToolAcknowledge(request(map[string]any{"text": ""}))
+	assert.Error(t, err, "text is required")
+}
+
+func TestUnitConvertMessagesEdits(t *testing.T) {
+	ch := &ConversationsHandler{apiProvider: &provider.ApiProvider{}, logger: zap.NewNop()}
+	messages := []slack.Message{
+		{Msg: slack.Msg{Timestamp: "1751328000.000100", User: "U1", Text: "fixed typo", Edited: &slack.Edited{User: "U1", Timestamp: "1751328060.000000"}}},
+		{Msg: slack.Msg{Timestamp: "1751328000.000200", User: "U2", Text: "original"}},
+	}
+
+	rows := ch.convertMessagesFromHistory(messages, "C1", historyOptions{render: true, edits: true})
+	require.Len(t, rows, 2)
+	assert.Equal(t, "fixed typo (edited)", rows[0].Text)
+	assert.Equal(t, "U1", rows[0].EditedBy)
+	assert.Equal(t, "1751328060.000000", rows[0].EditedTs)
+	assert.Equal(t, "original", rows[1].Text)
+	assert.Empty(t, rows[1].EditedTs)
+
+	rows = ch.convertMessagesFromHistory(messages, "C1", historyOptions{render: true})
+	assert.Equal(t, "fixed typo", rows[0].Text)
+	assert.Empty(t, rows[0].EditedBy)
+	assert.Empty(t, rows[0].EditedTs)
+}
+
+func TestUnitConvertMessagesExcludeBots(t *testing.T) {
+	ch := &ConversationsHandler{apiProvider: &provider.ApiProvider{}, logger: zap.NewNop()}
+	messages := []slack.Message{
+		{Msg: slack.Msg{Timestamp: "1751328000.000100", User: "U1", Text: "deploy done?"}},
+		{Msg: slack.Msg{Timestamp: "1751328000.000200", SubType: "bot_message", BotID: "B1", Username: "ci", Text: "build passed"}},
+		{Msg: slack.Msg{Timestamp: "1751328000.000300", User: "U2", BotID: "B2", Text: "posted by an app"}},
+		{Msg: slack.Msg{Timestamp: "1751328000.000400", User: "U2", Text: "yes"}},
+	}
+
+	rows := ch.convertMessagesFromHistory(messages, "C1", historyOptions{render: true})
+	assert.Len(t, rows, 4, "bots are kept by default")
+
+	rows = ch.convertMessagesFromHistory(messages, "C1", historyOptions{render: true, excludeBots: true})
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"1751328000.000100", "1751328000.000400"}, []string{rows[0].MsgID, rows[1].MsgID})
+}
+
+func TestUnitMarshalMessagesPage(t *testing.T) {
+	res, err := marshalMessagesPage([]Message{{MsgID: "1"}, {MsgID: "2"}}, true, "next")
+	require.NoError(t, err)
+	require.Len(t, res.Content, 1)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, ",next")
+
+	// a page emptied by the filters keeps the cursor
+	res, err = marshalMessagesPage(nil, true, "next")
+	require.NoError(t, err)
+	require.Len(t, res.Content, 2)
+	assert.Contains(t, res.Content[1].(mcp.TextContent).Text, "next cursor: next")
+
+	res, err = marshalMessagesPage(nil, false, "")
+	require.NoError(t, err)
+	assert.Len(t, res.Content, 1)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+type EmojiItem struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	AliasFor string `json:"aliasFor"`
+	URL      string `json:"url"`
+}
+
+type EmojiHandler struct {
+	apiProvider *provider.ApiProvider
+	logger      *zap.Logger
+}
+
+func NewEmojiHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *EmojiHandler {
+	return &EmojiHandler{
+		apiProvider: apiProvider,
+		logger:      logger,
+	}
+}
+
+// EmojiListHandler returns custom emoji of the workspace as CSV
+func (eh *EmojiHandler) EmojiListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, eh.logger)
+	logger.Debug("EmojiListHandler called", zap.Any("params", request.Params))
+
+	if request.GetBool("refresh", false) {
+		if err := eh.apiProvider.InvalidateEmojiCache(); err != nil {
+			return nil, internalError(err)
+		}
+	}
+
+	query := strings.ToLower(strings.Trim(strings.TrimSpace(request.GetString("query", "")), ":"))
+
+	emoji, err := eh.apiProvider.ProvideEmoji(ctx)
+	if err != nil {
+		logger.Error("Failed to provide emoji", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	result := make([]EmojiItem, 0, len(emoji))
+	for _, e := range emoji {
+		if query != "" && !strings.Contains(e.Name, query) {
+			continue
+		}
+		result = append(result, EmojiItem{
+			Name:     e.Name,
+			Kind:     e.Kind,
+			AliasFor: e.AliasFor,
+			URL:      e.URL,
+		})
+	}
+	logger.Debug("Emoji listed", zap.Int("total", len(emoji)), zap.Int("returned", len(result)))
+
+	return marshalCSVResult(result)
+}
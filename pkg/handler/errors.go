@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+)
+
+// Stable error codes returned to MCP clients, see ToolError
+const (
+	ErrCodeInvalidArgument       = "INVALID_ARGUMENT"
+	ErrCodeNotFound              = "NOT_FOUND"
+	ErrCodeChannelNotAllowed     = "CHANNEL_NOT_ALLOWED"
+	ErrCodeChannelArchived       = "CHANNEL_ARCHIVED"
+	ErrCodeCapabilityUnsupported = "CAPABILITY_UNSUPPORTED"
+	ErrCodePermissionDenied      = "PERMISSION_DENIED"
+	ErrCodeCacheNotReady         = "CACHE_NOT_READY"
+	ErrCodeRateLimited           = "RATE_LIMITED"
+	ErrCodeTimeout               = "TIMEOUT"
+	ErrCodeServiceDegraded       = "SERVICE_DEGRADED"
+	ErrCodeSlackAPIError         = "SLACK_API_ERROR"
+	ErrCodeUnauthenticated       = "UNAUTHENTICATED"
+	ErrCodeAuthInvalid           = "AUTH_INVALID"
+	ErrCodeReadOnly              = "READ_ONLY"
+	ErrCodeInternal              = "INTERNAL_ERROR"
+)
+
+// ToolError is an error with a stable code that tools return to MCP clients.
+// Error() returns the human readable message only, so wrapping an existing
+// error keeps its text unchanged.
+type ToolError struct {
+	Code    string
+	Message string
+	Details string
+	Err     error
+}
+
+func (e *ToolError) Error() string {
+	return e.Message
+}
+
+func (e *ToolError) Unwrap() error {
+	return e.Err
+}
+
+// NewToolError creates a ToolError, the message defaults to the wrapped error text
+func NewToolError(code, message string, err error) *ToolError {
+	if message == "" && err != nil {
+		message = err.Error()
+	}
+	return &ToolError{Code: code, Message: message, Err: err}
+}
+
+// slackChannelError is the code and explanation of a Slack error about the channel a call targets
+type slackChannelError struct {
+	code string
+	hint string
+}
+
+// slackChannelErrors tells apart the ways a channel can't be used, so that clients can react: pick another
+// channel on NOT_FOUND, unarchive it on CHANNEL_ARCHIVED, join it or ask an admin on PERMISSION_DENIED.
+// The Slack error goes to Details.
+var slackChannelErrors = map[string]slackChannelError{
+	"channel_not_found":   {ErrCodeNotFound, "the channel does not exist or the token can't see it, check the ID with channels_list or channels_resolve"},
+	"is_archived":         {ErrCodeChannelArchived, "the channel is archived, unarchive it in Slack or use another channel"},
+	"channel_is_archived": {ErrCodeChannelArchived, "the channel is archived, unarchive it in Slack or use another channel"},
+	"not_in_channel":      {ErrCodePermissionDenied, "the authenticated user is not a member of the channel, invite it to the channel or join it first"},
+	"restricted_action":   {ErrCodePermissionDenied, "workspace preferences do not allow this action in the channel, ask a workspace admin"},
+}
+
+// slackPermissionErrors explains Slack errors caused by missing scopes or workspace policy
+var slackPermissionErrors = map[string]string{
+	"missing_scope":          "the token is missing an OAuth scope required by this method",
+	"not_allowed_token_type": "this method can't be called with the current token type",
+	"access_denied":          "access to the resource was denied",
+	"permission_denied":      "the authenticated user has no permission for this action",
+}
+
+// ToolErrorPayload mirrors the JSON error shape of the HTTP layer
+type ToolErrorPayload struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Details string `json:"details,omitempty"`
+	} `json:"error"`
+}
+
+// AsToolError converts any error into a ToolError, errors without a code become INTERNAL_ERROR
+func AsToolError(err error) *ToolError {
+	var te *ToolError
+	if errors.As(err, &te) {
+		return te
+	}
+	if errors.Is(err, auth.ErrUnauthenticated) {
+		return NewToolError(ErrCodeUnauthenticated, "", err)
+	}
+	if errors.Is(err, provider.ErrAuthInvalid) {
+		return authInvalidError(err)
+	}
+	if errors.Is(err, provider.ErrUsersNotReady) || errors.Is(err, provider.ErrChannelsNotReady) {
+		return NewToolError(ErrCodeCacheNotReady, "", err)
+	}
+	if errors.Is(err, provider.ErrAPITimeout) {
+		return NewToolError(ErrCodeTimeout, "", err)
+	}
+	if errors.Is(err, provider.ErrServiceDegraded) {
+		return serviceDegradedError(err)
+	}
+	return NewToolError(ErrCodeInternal, "", err)
+}
+
+// ToolErrorResult serializes err as an MCP tool result flagged with isError
+func ToolErrorResult(err error) *mcp.CallToolResult {
+	te := AsToolError(err)
+
+	var payload ToolErrorPayload
+	payload.Error.Code = te.Code
+	payload.Error.Message = te.Message
+	payload.Error.Details = te.Details
+
+	data, mErr := json.Marshal(payload)
+	if mErr != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%s: %s", te.Code, te.Message))
+	}
+	return mcp.NewToolResultError(string(data))
+}
+
+// invalidArgumentError marks err as caused by tool input unless it already carries a code
+func invalidArgumentError(err error) error {
+	var te *ToolError
+	if errors.As(err, &te) {
+		return err
+	}
+	return NewToolError(ErrCodeInvalidArgument, "", err)
+}
+
+// slackAPIError wraps a failed Slack API call, rate limits and timeouts get their own code
+func slackAPIError(err error) error {
+	var te *ToolError
+	if errors.As(err, &te) {
+		return err
+	}
+
+	if errors.Is(err, provider.ErrAuthInvalid) {
+		return authInvalidError(err)
+	}
+
+	if errors.Is(err, provider.ErrAPITimeout) {
+		e := NewToolError(ErrCodeTimeout, "", err)
+		e.Details = "increase SLACK_MCP_API_TIMEOUT or narrow the request"
+		return e
+	}
+
+	if errors.Is(err, provider.ErrServiceDegraded) {
+		return serviceDegradedError(err)
+	}
+
+	var rle *slack.RateLimitedError
+	if errors.As(err, &rle) {
+		e := NewToolError(ErrCodeRateLimited, "", err)
+		e.Details = fmt.Sprintf("retry after %s", rle.RetryAfter)
+		return e
+	}
+
+	e := NewToolError(ErrCodeSlackAPIError, "", err)
+	var ser slack.SlackErrorResponse
+	if errors.As(err, &ser) {
+		e.Details = ser.Err
+		if ce, ok := slackChannelErrors[ser.Err]; ok {
+			e.Code = ce.code
+			e.Message = fmt.Sprintf("%s: %s", err.Error(), ce.hint)
+		} else if hint, ok := slackPermissionErrors[ser.Err]; ok {
+			e.Code = ErrCodePermissionDenied
+			e.Message = fmt.Sprintf("%s: %s", err.Error(), hint)
+		}
+	}
+	return e
+}
+
+// channelAPIError wraps a failed Slack call of tool targeting channel. Errors about the channel itself name
+// the tool and the channel in the message, anything else is left to slackAPIError.
+func channelAPIError(tool, channel string, err error) error {
+	var te *ToolError
+	if errors.As(err, &te) {
+		return err
+	}
+	code := provider.SlackErrorCode(err)
+	ce, ok := slackChannelErrors[code]
+	if !ok {
+		return slackAPIError(err)
+	}
+	e := NewToolError(ce.code, fmt.Sprintf("%s failed for channel %q: %s", tool, channel, ce.hint), err)
+	e.Details = code
+	return e
+}
+
+// serviceDegradedError reports a call rejected by the open circuit breaker without reaching Slack
+func serviceDegradedError(err error) *ToolError {
+	e := NewToolError(ErrCodeServiceDegraded, "", err)
+	e.Details = "slack api is failing, retry after SLACK_MCP_BREAKER_COOLDOWN"
+	return e
+}
+
+// cacheNotReadyError wraps errors returned by provider.IsReady, which also reports rejected credentials
+func cacheNotReadyError(err error) error {
+	if errors.Is(err, provider.ErrAuthInvalid) {
+		return authInvalidError(err)
+	}
+	return NewToolError(ErrCodeCacheNotReady, "", err)
+}
+
+// authInvalidError reports Slack credentials that were revoked or expired, the Slack error code goes to Details
+func authInvalidError(err error) *ToolError {
+	e := NewToolError(ErrCodeAuthInvalid, "", err)
+	if code := provider.SlackErrorCode(err); code != "" {
+		e.Details = code
+	}
+	return e
+}
+
+// internalError wraps unexpected failures such as serialization errors
+func internalError(err error) error {
+	return NewToolError(ErrCodeInternal, "", err)
+}
+
+func notFoundError(format string, args ...any) error {
+	return NewToolError(ErrCodeNotFound, fmt.Sprintf(format, args...), nil)
+}
+
+func channelNotAllowedError(format string, args ...any) error {
+	return NewToolError(ErrCodeChannelNotAllowed, fmt.Sprintf(format, args...), nil)
+}
+
+func capabilityUnsupportedError(format string, args ...any) error {
+	return NewToolError(ErrCodeCapabilityUnsupported, fmt.Sprintf(format, args...), nil)
+}
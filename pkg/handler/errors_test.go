@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitAsToolError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    string
+		details string
+	}{
+		{"plain error", errors.New("boom"), ErrCodeInternal, ""},
+		{"invalid argument", invalidArgumentError(errors.New("limit must be positive")), ErrCodeInvalidArgument, ""},
+		{"invalid argument keeps existing code", invalidArgumentError(notFoundError("channel %q not found", "#x")), ErrCodeNotFound, ""},
+		{"rate limited", slackAPIError(&slack.RateLimitedError{RetryAfter: 30 * time.Second}), ErrCodeRateLimited, "retry after 30s"},
+		{"timeout", slackAPIError(fmt.Errorf("%w after 30s: context deadline exceeded", provider.ErrAPITimeout)), ErrCodeTimeout, "increase SLACK_MCP_API_TIMEOUT or narrow the request"},
+		{"unwrapped timeout", fmt.Errorf("%w after 30s", provider.ErrAPITimeout), ErrCodeTimeout, ""},
+		{"breaker open", slackAPIError(&url.Error{Op: "Post", URL: "https://slack.com/api/auth.test", Err: provider.ErrServiceDegraded}), ErrCodeServiceDegraded, "slack api is failing, retry after SLACK_MCP_BREAKER_COOLDOWN"},
+		{"slack error response", slackAPIError(slack.SlackErrorResponse{Err: "invalid_cursor"}), ErrCodeSlackAPIError, "invalid_cursor"},
+		{"wrapped slack error", slackAPIError(fmt.Errorf("post: %w", slack.SlackErrorResponse{Err: "channel_not_found"})), ErrCodeNotFound, "channel_not_found"},
+		{"archived channel", slackAPIError(slack.SlackErrorResponse{Err: "is_archived"}), ErrCodeChannelArchived, "is_archived"},
+		{"unauthenticated", fmt.Errorf("authentication error: %w", auth.ErrUnauthenticated), ErrCodeUnauthenticated, ""},
+		{"cache not ready", provider.ErrUsersNotReady, ErrCodeCacheNotReady, ""},
+		{"auth invalid", slackAPIError(fmt.Errorf("%w: %w", provider.ErrAuthInvalid, slack.SlackErrorResponse{Err: "token_revoked"})), ErrCodeAuthInvalid, "token_revoked"},
+		{"auth invalid while not ready", cacheNotReadyError(fmt.Errorf("%w: %w", provider.ErrAuthInvalid, slack.SlackErrorResponse{Err: "invalid_auth"})), ErrCodeAuthInvalid, "invalid_auth"},
+		{"channel not allowed", channelNotAllowedError("not allowed for %q", "C1"), ErrCodeChannelNotAllowed, ""},
+		{"capability unsupported", capabilityUnsupportedError("requires a user token"), ErrCodeCapabilityUnsupported, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			te := AsToolError(tt.err)
+			assert.Equal(t, tt.code, te.Code)
+			assert.Equal(t, tt.details, te.Details)
+			assert.Equal(t, tt.err.Error(), te.Message)
+		})
+	}
+}
+
+func TestUnitToolErrorResult(t *testing.T) {
+	res := ToolErrorResult(slackAPIError(slack.SlackErrorResponse{Err: "channel_not_found"}))
+	require.True(t, res.IsError)
+	require.Len(t, res.Content, 1)
+
+	text, ok := res.Content[0].(mcp.TextContent)
+	require.True(t, ok)
+
+	var payload ToolErrorPayload
+	require.NoError(t, json.Unmarshal([]byte(text.Text), &payload))
+	assert.Equal(t, ErrCodeNotFound, payload.Error.Code)
+	assert.Equal(t, "channel_not_found: "+slackChannelErrors["channel_not_found"].hint, payload.Error.Message)
+	assert.Equal(t, "channel_not_found", payload.Error.Details)
+}
+
+func TestUnitSlackPermissionError(t *testing.T) {
+	te := AsToolError(slackAPIError(slack.SlackErrorResponse{Err: "not_in_channel"}))
+	assert.Equal(t, ErrCodePermissionDenied, te.Code)
+	assert.Equal(t, "not_in_channel", te.Details)
+	assert.Contains(t, te.Message, "not a member of the channel")
+}
+
+func TestUnitChannelAPIError(t *testing.T) {
+	tests := []struct {
+		slackErr string
+		code     string
+		message  string
+	}{
+		{"channel_not_found", ErrCodeNotFound, "does not exist"},
+		{"is_archived", ErrCodeChannelArchived, "unarchive it"},
+		{"channel_is_archived", ErrCodeChannelArchived, "unarchive it"},
+		{"not_in_channel", ErrCodePermissionDenied, "not a member of the channel"},
+		{"restricted_action", ErrCodePermissionDenied, "ask a workspace admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slackErr, func(t *testing.T) {
+			err := channelAPIError("conversations_add_message", "C1", fmt.Errorf("post: %w", slack.SlackErrorResponse{Err: tt.slackErr}))
+			te := AsToolError(err)
+			assert.Equal(t, tt.code, te.Code)
+			assert.Equal(t, tt.slackErr, te.Details)
+			assert.Contains(t, te.Message, `conversations_add_message failed for channel "C1"`)
+			assert.Contains(t, te.Message, tt.message)
+			assert.Equal(t, tt.slackErr, provider.SlackErrorCode(err), "the Slack error stays reachable")
+		})
+	}
+
+	te := AsToolError(channelAPIError("conversations_add_message", "C1", slack.SlackErrorResponse{Err: "msg_too_long"}))
+	assert.Equal(t, ErrCodeSlackAPIError, te.Code)
+	assert.Equal(t, "msg_too_long", te.Message)
+
+	te = AsToolError(channelAPIError("conversations_add_message", "C1", &slack.RateLimitedError{RetryAfter: time.Second}))
+	assert.Equal(t, ErrCodeRateLimited, te.Code)
+
+	joined := notInChannelError("conversations_history", "C1", slack.SlackErrorResponse{Err: "not_in_channel"}, "invite the bot")
+	assert.Same(t, joined, channelAPIError("conversations_history", "C1", joined), "tool errors are kept")
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"sync"
+)
+
+// fanOutResult is the outcome of one call made by fanOut
+type fanOutResult[T any] struct {
+	value T
+	err   error
+}
+
+// fanOut calls fn for every item with at most workers calls at once, results are in the order of items. Once ctx
+// is done, items not started yet fail with its error without calling fn.
+func fanOut[I, T any](ctx context.Context, items []I, workers int, fn func(ctx context.Context, item I) (T, error)) []fanOutResult[T] {
+	results := make([]fanOutResult[T], len(items))
+	if workers <= 0 || workers > len(items) {
+		workers = len(items)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					results[i].err = err
+					continue
+				}
+				results[i].value, results[i].err = fn(ctx, items[i])
+			}
+		}()
+	}
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
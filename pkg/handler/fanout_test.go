@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitFanOut(t *testing.T) {
+	var running, peak atomic.Int32
+	results := fanOut(context.Background(), []string{"C0GENERAL", "C0ARCHIVED", "C0RANDOM", "D0DM"}, 2, func(ctx context.Context, channel string) (string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		if channel == "C0ARCHIVED" {
+			return "", errors.New("channel_not_found")
+		}
+		return "hi from " + channel, nil
+	})
+
+	require.Len(t, results, 4)
+	assert.Equal(t, "hi from C0GENERAL", results[0].value)
+	assert.EqualError(t, results[1].err, "channel_not_found")
+	assert.Equal(t, "hi from C0RANDOM", results[2].value)
+	assert.Equal(t, "hi from D0DM", results[3].value)
+	assert.LessOrEqual(t, peak.Load(), int32(2), "at most workers calls run at once")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results = fanOut(ctx, []string{"C0GENERAL"}, 0, func(context.Context, string) (string, error) {
+		return "", errors.New("must not be called")
+	})
+	assert.ErrorIs(t, results[0].err, context.Canceled)
+}
@@ -0,0 +1,542 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultFilesLimit = 20
+	maxFilesLimit     = 100
+
+	// defaultMaxDownloadBytes is used when SLACK_MCP_MAX_DOWNLOAD_BYTES is unset or invalid
+	defaultMaxDownloadBytes = 1 << 20
+)
+
+// errDownloadCapReached stops a download once SLACK_MCP_MAX_DOWNLOAD_BYTES were read
+var errDownloadCapReached = errors.New("download size cap reached")
+
+// textMimeTypes are non text/* mime types whose content is readable text
+var textMimeTypes = map[string]bool{
+	"application/json":       true,
+	"application/xml":        true,
+	"application/javascript": true,
+	"application/x-yaml":     true,
+	"application/yaml":       true,
+	"application/x-sh":       true,
+	"application/sql":        true,
+	"application/csv":        true,
+}
+
+var validFileTypes = map[string]bool{
+	"all":      true,
+	"spaces":   true,
+	"snippets": true,
+	"images":   true,
+	"gdocs":    true,
+	"zips":     true,
+	"pdfs":     true,
+}
+
+type File struct {
+	FileID    string `json:"fileID"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	FileType  string `json:"fileType"`
+	Size      int    `json:"size"`
+	UserID    string `json:"userID"`
+	UserName  string `json:"userName"`
+	Created   string `json:"created"`
+	Permalink string `json:"permalink"`
+	Cursor    string `json:"cursor"`
+}
+
+type FileContent struct {
+	FileID    string `json:"fileID"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	FileType  string `json:"fileType"`
+	MimeType  string `json:"mimeType"`
+	Size      int    `json:"size"`
+	Permalink string `json:"permalink"`
+	IsText    bool   `json:"isText"`
+	Truncated bool   `json:"truncated"`
+	Content   string `json:"content"`
+}
+
+type filesSearchParams struct {
+	query string
+	types map[string]bool
+	limit int
+	page  int
+}
+
+type filesListParams struct {
+	channel string
+	user    string
+	types   string
+	tsFrom  int64
+	tsTo    int64
+	limit   int
+	page    int
+}
+
+type FilesHandler struct {
+	apiProvider *provider.ApiProvider
+	logger      *zap.Logger
+}
+
+func NewFilesHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *FilesHandler {
+	return &FilesHandler{
+		apiProvider: apiProvider,
+		logger:      logger,
+	}
+}
+
+// FilesListHandler lists files shared in a channel as CSV
+func (fh *FilesHandler) FilesListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, fh.logger)
+	logger.Debug("FilesListHandler called", zap.Any("params", request.Params))
+
+	params, err := fh.parseParamsToolFilesList(request)
+	if err != nil {
+		logger.Error("Failed to parse files_list params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+	logger.Debug("Files list params parsed",
+		zap.String("channel", params.channel),
+		zap.String("user", params.user),
+		zap.String("types", params.types),
+		zap.Int64("ts_from", params.tsFrom),
+		zap.Int64("ts_to", params.tsTo),
+		zap.Int("limit", params.limit),
+		zap.Int("page", params.page),
+	)
+
+	files, paging, err := fh.apiProvider.Slack().GetFilesContext(ctx, slack.GetFilesParameters{
+		Channel:       params.channel,
+		User:          params.user,
+		Types:         params.types,
+		TimestampFrom: slack.JSONTime(params.tsFrom),
+		TimestampTo:   slack.JSONTime(params.tsTo),
+		Count:         params.limit,
+		Page:          params.page,
+	})
+	if err != nil {
+		logger.Error("Slack GetFilesContext failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+	logger.Debug("Fetched files", zap.Int("count", len(files)))
+
+	usersMap := fh.apiProvider.ProvideUsersMap()
+
+	result := make([]File, 0, len(files))
+	for _, f := range files {
+		result = append(result, fileRow(f, usersMap.Users))
+	}
+
+	if len(result) > 0 && paging != nil && paging.Page < paging.Pages {
+		nextCursor := fmt.Sprintf("page:%d", paging.Page+1)
+		result[len(result)-1].Cursor = base64.StdEncoding.EncodeToString([]byte(nextCursor))
+	}
+
+	return marshalCSVResult(result)
+}
+
+// FilesSearchHandler searches files by name, title and content with search.files as CSV, uploaders are
+// resolved as selected by SLACK_MCP_CACHE_MISS_POLICY. search.files is not available for bot tokens.
+func (fh *FilesHandler) FilesSearchHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, fh.logger)
+	logger.Debug("FilesSearchHandler called", zap.Any("params", request.Params))
+
+	if _, err := requireUserToken(fh.apiProvider, logger, "search_files"); err != nil {
+		return nil, err
+	}
+
+	params, err := fh.parseParamsToolFilesSearch(request)
+	if err != nil {
+		logger.Error("Failed to parse search_files params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+	logger.Debug("Files search params parsed",
+		zap.String("query", params.query),
+		zap.Int("types", len(params.types)),
+		zap.Int("limit", params.limit),
+		zap.Int("page", params.page),
+	)
+
+	found, err := fh.apiProvider.Slack().SearchFilesContext(ctx, params.query, slack.SearchParameters{
+		Sort:          slack.DEFAULT_SEARCH_SORT,
+		SortDirection: slack.DEFAULT_SEARCH_SORT_DIR,
+		Highlight:     false,
+		Count:         params.limit,
+		Page:          params.page,
+	})
+	if err != nil {
+		logger.Error("Slack SearchFilesContext failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+	logger.Debug("Files search completed", zap.Int("matches", len(found.Matches)), zap.Int("total", found.Total))
+
+	files := filterFilesByType(found.Matches, params.types)
+	uploaders := make([]string, 0, len(files))
+	for _, f := range files {
+		if f.User != "" {
+			uploaders = append(uploaders, f.User)
+		}
+	}
+	if err := fh.apiProvider.ResolveUsers(ctx, uploaders); err != nil {
+		logger.Error("Failed to resolve file uploaders", zap.Error(err))
+		return nil, cacheNotReadyError(err)
+	}
+	usersMap := fh.apiProvider.ProvideUsersMap()
+
+	result := make([]File, 0, len(files))
+	for _, f := range files {
+		result = append(result, fileRow(f, usersMap.Users))
+	}
+
+	var nextCursor string
+	if found.Pagination.Page < found.Pagination.PageCount {
+		nextCursor = base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("page:%d", found.Pagination.Page+1)))
+	}
+	if len(result) > 0 {
+		result[len(result)-1].Cursor = nextCursor
+	}
+
+	res, err := marshalCSVResult(result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 && nextCursor != "" {
+		// the types filter may empty a page that is not the last one
+		res.Content = append(res.Content, mcp.NewTextContent("no files of the requested types on this page, next cursor: "+nextCursor))
+	}
+	return res, nil
+}
+
+// FilesDownloadHandler returns the metadata of a file and, for text files, its content as CSV.
+// Binary files only get metadata and the permalink.
+func (fh *FilesHandler) FilesDownloadHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, fh.logger)
+	logger.Debug("FilesDownloadHandler called", zap.Any("params", request.Params))
+
+	fileID := strings.TrimSpace(request.GetString("file_id", ""))
+	if fileID == "" || !strings.HasPrefix(fileID, "F") {
+		return nil, invalidArgumentError(fmt.Errorf("file_id must be a file ID such as F1234567890, got %q", fileID))
+	}
+
+	file, _, _, err := fh.apiProvider.Slack().GetFileInfoContext(ctx, fileID, 0, 0)
+	if err != nil {
+		logger.Error("Slack GetFileInfoContext failed", zap.String("file_id", fileID), zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	if err := checkFileReadPolicy(logger, "files_download", *file); err != nil {
+		return nil, err
+	}
+
+	result := FileContent{
+		FileID:    file.ID,
+		Name:      file.Name,
+		Title:     file.Title,
+		FileType:  file.Filetype,
+		MimeType:  file.Mimetype,
+		Size:      file.Size,
+		Permalink: file.Permalink,
+		IsText:    isTextFile(*file),
+	}
+
+	if result.IsText {
+		content, truncated, err := downloadText(file.URLPrivateDownload, maxDownloadBytes(), func(url string, w io.Writer) error {
+			return fh.apiProvider.Slack().GetFileContext(ctx, url, w)
+		})
+		if err != nil {
+			logger.Error("Slack GetFileContext failed", zap.String("file_id", fileID), zap.Error(err))
+			return nil, slackAPIError(err)
+		}
+		if !utf8.ValidString(content) {
+			// mime type claimed text, but the bytes are not, e.g. a mislabeled upload
+			result.IsText = false
+		} else {
+			result.Content = content
+			result.Truncated = truncated
+		}
+	}
+
+	return marshalCSVResult([]FileContent{result})
+}
+
+func (fh *FilesHandler) parseParamsToolFilesList(request mcp.CallToolRequest) (*filesListParams, error) {
+	channel := strings.TrimSpace(request.GetString("channel_id", ""))
+	if channel == "" {
+		return nil, errors.New("channel_id must be a string")
+	}
+	if strings.HasPrefix(channel, "#") {
+		if err := channelNameReady(fh.apiProvider, channel); err != nil {
+			fh.logger.Warn("Slack channels sync is not ready yet, query files by Channel ID instead", zap.Error(err))
+			return nil, err
+		}
+		id, err := lookupChannelID(fh.apiProvider, channel, request.GetString("team_id", ""))
+		if errors.Is(err, provider.ErrNameNotFound) {
+			return nil, notFoundError("channel %q not found in synced cache", channel)
+		}
+		if err != nil {
+			return nil, err
+		}
+		channel = id
+	}
+	if err := checkReadPolicy(fh.logger, "files_list", channel); err != nil {
+		return nil, err
+	}
+
+	user := strings.TrimSpace(request.GetString("user", ""))
+	if user != "" && !strings.HasPrefix(user, "U") && !strings.HasPrefix(user, "W") {
+		uid, err := resolveUserID(fh.apiProvider, user, request.GetString("team_id", ""))
+		if err != nil {
+			return nil, err
+		}
+		user = uid
+	}
+
+	types, err := parseFileTypes(request.GetString("types", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	var tsFrom, tsTo int64
+	if raw := request.GetString("date_from", ""); raw != "" {
+		from, _, err := parseFlexibleDate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date_from: %v", err)
+		}
+		tsFrom = from.Unix()
+	}
+	if raw := request.GetString("date_to", ""); raw != "" {
+		to, _, err := parseFlexibleDate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date_to: %v", err)
+		}
+		// date_to is inclusive, include the whole day
+		tsTo = to.AddDate(0, 0, 1).Unix() - 1
+	}
+	if tsFrom > 0 && tsTo > 0 && tsFrom > tsTo {
+		return nil, errors.New("date_from must not be after date_to")
+	}
+
+	limit := request.GetInt("limit", defaultFilesLimit)
+	if limit <= 0 {
+		limit = defaultFilesLimit
+	}
+	if limit > maxFilesLimit {
+		fh.logger.Warn("Limit exceeds maximum, capping", zap.Int("requested", limit), zap.Int("max", maxFilesLimit))
+		limit = maxFilesLimit
+	}
+	limit = capLimit(limit)
+
+	page, err := parsePageCursor(request.GetString("cursor", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	return &filesListParams{
+		channel: channel,
+		user:    user,
+		types:   types,
+		tsFrom:  tsFrom,
+		tsTo:    tsTo,
+		limit:   limit,
+		page:    page,
+	}, nil
+}
+
+func (fh *FilesHandler) parseParamsToolFilesSearch(request mcp.CallToolRequest) (*filesSearchParams, error) {
+	query := strings.TrimSpace(request.GetString("query", ""))
+	if query == "" {
+		return nil, errors.New("query must be a string, e.g. a file name such as 'roadmap' or 'report.pdf'")
+	}
+
+	types, err := parseSearchFileTypes(request.GetString("types", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	limit := request.GetInt("limit", defaultFilesLimit)
+	if limit <= 0 {
+		limit = defaultFilesLimit
+	}
+	if limit > maxFilesLimit {
+		fh.logger.Warn("Limit exceeds maximum, capping", zap.Int("requested", limit), zap.Int("max", maxFilesLimit))
+		limit = maxFilesLimit
+	}
+	limit = capLimit(limit)
+
+	page, err := parsePageCursor(request.GetString("cursor", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	return &filesSearchParams{
+		query: query,
+		types: types,
+		limit: limit,
+		page:  page,
+	}, nil
+}
+
+// parseSearchFileTypes parses a comma-separated list of file types as Slack reports them, e.g. pdf,csv,png,
+// empty means every type
+func parseSearchFileTypes(raw string) (map[string]bool, error) {
+	var types map[string]bool
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if strings.Trim(t, "abcdefghijklmnopqrstuvwxyz0123456789_") != "" {
+			return nil, fmt.Errorf("invalid file type %q, use file types such as pdf, csv, png or gdoc", t)
+		}
+		if types == nil {
+			types = make(map[string]bool)
+		}
+		types[t] = true
+	}
+	return types, nil
+}
+
+// filterFilesByType keeps the files of the given types, nil types keeps every file
+func filterFilesByType(files []slack.File, types map[string]bool) []slack.File {
+	if types == nil {
+		return files
+	}
+	kept := make([]slack.File, 0, len(files))
+	for _, f := range files {
+		if types[strings.ToLower(f.Filetype)] {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// fileRow converts a file to its CSV row, the uploader is shown by name when cached
+func fileRow(f slack.File, users map[string]slack.User) File {
+	userName := f.User
+	if u, ok := users[f.User]; ok {
+		userName = u.Name
+	}
+	return File{
+		FileID:    f.ID,
+		Name:      f.Name,
+		Title:     f.Title,
+		FileType:  f.Filetype,
+		Size:      f.Size,
+		UserID:    f.User,
+		UserName:  userName,
+		Created:   f.Created.Time().UTC().Format(time.RFC3339),
+		Permalink: f.Permalink,
+	}
+}
+
+// parseFileTypes validates a comma-separated list of files.list types, empty means all
+func parseFileTypes(raw string) (string, error) {
+	var types []string
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if !validFileTypes[t] {
+			return "", fmt.Errorf("invalid file type %q, allowed values: all, spaces, snippets, images, gdocs, zips, pdfs", t)
+		}
+		types = append(types, t)
+	}
+	if len(types) == 0 {
+		return slack.DEFAULT_FILES_TYPES, nil
+	}
+	return strings.Join(types, ","), nil
+}
+
+// maxDownloadBytes returns the number of bytes files_download and conversations_canvas_get read, see SLACK_MCP_MAX_DOWNLOAD_BYTES
+func maxDownloadBytes() int {
+	n, err := strconv.Atoi(os.Getenv("SLACK_MCP_MAX_DOWNLOAD_BYTES"))
+	if err != nil || n <= 0 {
+		return defaultMaxDownloadBytes
+	}
+	return n
+}
+
+// isTextFile reports whether the file content is text that can be returned to the client
+func isTextFile(f slack.File) bool {
+	mimeType := strings.ToLower(strings.TrimSpace(strings.Split(f.Mimetype, ";")[0]))
+	return strings.HasPrefix(mimeType, "text/") || textMimeTypes[mimeType] || f.Mode == "snippet"
+}
+
+// capWriter buffers up to max bytes and fails with errDownloadCapReached once more are written
+type capWriter struct {
+	buf strings.Builder
+	max int
+}
+
+func (w *capWriter) Write(p []byte) (int, error) {
+	room := w.max - w.buf.Len()
+	if len(p) > room {
+		w.buf.Write(p[:room])
+		return room, errDownloadCapReached
+	}
+	return w.buf.Write(p)
+}
+
+// downloadText fetches url through get and returns at most max bytes, the bool reports whether the content was cut
+func downloadText(url string, max int, get func(url string, w io.Writer) error) (string, bool, error) {
+	if url == "" {
+		return "", false, errors.New("file has no download URL")
+	}
+	w := &capWriter{max: max}
+	err := get(url, w)
+	if errors.Is(err, errDownloadCapReached) {
+		content := w.buf.String()
+		// don't cut a multi-byte character in half
+		for i := 0; i < utf8.UTFMax && !utf8.ValidString(content); i++ {
+			content = content[:len(content)-1]
+		}
+		return content, true, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return w.buf.String(), false, nil
+}
+
+// parsePageCursor decodes a base64 "page:N" cursor, empty cursor means the first page
+func parsePageCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 1, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor: %v", err)
+	}
+	parts := strings.Split(string(decoded), ":")
+	if len(parts) != 2 || parts[0] != "page" {
+		return 0, fmt.Errorf("invalid cursor: %v", cursor)
+	}
+	page, err := strconv.Atoi(parts[1])
+	if err != nil || page < 1 {
+		return 0, fmt.Errorf("invalid cursor page: %v", cursor)
+	}
+	return page, nil
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitParseFileTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"empty defaults to all", "", "all", false},
+		{"single type", "images", "images", false},
+		{"multiple types with spaces", " pdfs , Zips ", "pdfs,zips", false},
+		{"invalid type", "videos", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFileTypes(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestUnitParseSearchFileTypes(t *testing.T) {
+	types, err := parseSearchFileTypes("")
+	require.NoError(t, err)
+	assert.Nil(t, types)
+
+	types, err = parseSearchFileTypes(" PDF , csv,,gdoc ")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"pdf": true, "csv": true, "gdoc": true}, types)
+
+	_, err = parseSearchFileTypes("pdf,image/png")
+	assert.ErrorContains(t, err, "image/png")
+}
+
+func TestUnitFilterFilesByType(t *testing.T) {
+	files := []slack.File{
+		{ID: "F1", Filetype: "pdf"},
+		{ID: "F2", Filetype: "png"},
+		{ID: "F3", Filetype: "CSV"},
+	}
+
+	assert.Equal(t, files, filterFilesByType(files, nil))
+
+	kept := filterFilesByType(files, map[string]bool{"pdf": true, "csv": true})
+	require.Len(t, kept, 2)
+	assert.Equal(t, "F1", kept[0].ID)
+	assert.Equal(t, "F3", kept[1].ID)
+
+	assert.Empty(t, filterFilesByType(files, map[string]bool{"zip": true}))
+}
+
+func TestUnitFileRow(t *testing.T) {
+	f := slack.File{ID: "F1", Name: "roadmap.pdf", Filetype: "pdf", User: "U1", Created: slack.JSONTime(1700000000), Permalink: "https://example.slack.com/files/U1/F1/roadmap.pdf"}
+
+	row := fileRow(f, map[string]slack.User{"U1": {ID: "U1", Name: "alice"}})
+	assert.Equal(t, "alice", row.UserName)
+	assert.Equal(t, "2023-11-14T22:13:20Z", row.Created)
+	assert.Equal(t, f.Permalink, row.Permalink)
+
+	// uploaders missing from the cache keep their ID
+	assert.Equal(t, "U1", fileRow(f, nil).UserName)
+}
+
+func TestUnitParsePageCursor(t *testing.T) {
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+		wantErr  bool
+	}{
+		{"empty is first page", "", 1, false},
+		{"valid cursor", encode("page:3"), 3, false},
+		{"not base64", "%%%", 0, true},
+		{"wrong prefix", encode("offset:3"), 0, true},
+		{"zero page", encode("page:0"), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePageCursor(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestUnitIsTextFile(t *testing.T) {
+	assert.True(t, isTextFile(slack.File{Mimetype: "text/plain"}))
+	assert.True(t, isTextFile(slack.File{Mimetype: "text/csv; charset=utf-8"}))
+	assert.True(t, isTextFile(slack.File{Mimetype: "application/json"}))
+	assert.True(t, isTextFile(slack.File{Mode: "snippet"}))
+	assert.False(t, isTextFile(slack.File{Mimetype: "image/png"}))
+	assert.False(t, isTextFile(slack.File{Mimetype: "application/pdf"}))
+}
+
+func TestUnitDownloadText(t *testing.T) {
+	get := func(body string) func(url string, w io.Writer) error {
+		return func(url string, w io.Writer) error {
+			_, err := io.Copy(w, strings.NewReader(body))
+			return err
+		}
+	}
+
+	content, truncated, err := downloadText("https://files.slack.com/f", 100, get("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", content)
+	assert.False(t, truncated)
+
+	content, truncated, err = downloadText("https://files.slack.com/f", 4, get("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "hell", content)
+	assert.True(t, truncated)
+
+	content, truncated, err = downloadText("https://files.slack.com/f", 2, get("héllo"))
+	require.NoError(t, err)
+	assert.Equal(t, "h", content, "a cut multi-byte character is dropped")
+	assert.True(t, truncated)
+
+	_, _, err = downloadText("https://files.slack.com/f", 100, func(url string, w io.Writer) error {
+		return errors.New("boom")
+	})
+	assert.Error(t, err)
+
+	_, _, err = downloadText("", 100, get("hello"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+type FormatHandler struct {
+	apiProvider *provider.ApiProvider
+	logger      *zap.Logger
+}
+
+func NewFormatHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *FormatHandler {
+	return &FormatHandler{
+		apiProvider: apiProvider,
+		logger:      logger,
+	}
+}
+
+// FormatRenderHandler expands the Slack markup of arbitrary text, IDs missing from the caches stay raw tokens
+func (fh *FormatHandler) FormatRenderHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, fh.logger)
+	logger.Debug("FormatRenderHandler called", zap.Any("params", request.Params))
+
+	raw := request.GetString("text", "")
+	if raw == "" {
+		return nil, invalidArgumentError(errors.New("text must be a non-empty string"))
+	}
+
+	return mcp.NewToolResultText(newMarkupRenderer(fh.apiProvider).markup(raw)), nil
+}
+
+// markupRenderer resolves mentions and channel references against snapshots of the users and channels caches
+type markupRenderer struct {
+	users    text.Resolver
+	channels text.Resolver
+}
+
+func newMarkupRenderer(apiProvider *provider.ApiProvider) *markupRenderer {
+	users := apiProvider.ProvideUsersMap().Users
+	channels := apiProvider.ProvideChannelsMaps().Channels
+
+	return &markupRenderer{
+		users: func(id string) (string, bool) {
+			u, ok := users[id]
+			if !ok {
+				return "", false
+			}
+			if u.Profile.DisplayName != "" {
+				return u.Profile.DisplayName, true
+			}
+			return u.Name, u.Name != ""
+		},
+		channels: func(id string) (string, bool) {
+			c, ok := channels[id]
+			return c.Name, ok && c.Name != ""
+		},
+	}
+}
+
+// markup expands Slack markup into readable text, see text.RenderMarkup
+func (r *markupRenderer) markup(s string) string {
+	return text.RenderMarkup(s, r.users, r.channels)
+}
+
+// messageText prepares message text for tool results: rendered and cleaned up by default,
+// or the raw Slack markup when the caller passed render=false
+func (r *markupRenderer) messageText(s string, render bool) string {
+	if !render {
+		return s
+	}
+	return text.ProcessRenderedText(s, r.users, r.channels)
+}
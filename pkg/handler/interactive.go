@@ -0,0 +1,316 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxInteractiveButtons is the number of buttons conversations_post_interactive puts in one message
+	maxInteractiveButtons = 5
+	// maxButtonTextChars and maxButtonValueChars are the limits of Slack for a button element
+	maxButtonTextChars  = 75
+	maxButtonValueChars = 2000
+)
+
+// Statuses of a conversations_await_interaction result, a timeout is AwaitStatusTimeout
+const (
+	InteractionStatusClicked = "clicked"
+)
+
+// InteractiveButton is a button of a posted interactive message, clicks report its action ID
+type InteractiveButton struct {
+	ChannelID string `json:"channelID"`
+	MessageTs string `json:"messageTs"`
+	ActionID  string `json:"actionID"`
+	Text      string `json:"text"`
+	Value     string `json:"value"`
+}
+
+// InteractionResult is the first accepted click on an interactive message, or a timeout
+type InteractionResult struct {
+	Status    string `json:"status"`
+	ChannelID string `json:"channelID"`
+	MessageTs string `json:"messageTs"`
+	ActionID  string `json:"actionID"`
+	Text      string `json:"text"`
+	Value     string `json:"value"`
+	UserID    string `json:"userID"`
+	UserName  string `json:"userName"`
+	Waited    string `json:"waited"`
+}
+
+// buttonSpec is a button as passed to conversations_post_interactive
+type buttonSpec struct {
+	Text  string `json:"text"`
+	Value string `json:"value"`
+	Style string `json:"style"`
+}
+
+type postInteractiveParams struct {
+	channel  string
+	threadTs string
+	text     string
+	buttons  []buttonSpec
+}
+
+type awaitInteractionParams struct {
+	channel string
+	ts      string
+	users   map[string]bool
+	timeout time.Duration
+}
+
+// ConversationsPostInteractiveHandler posts a message with Block Kit buttons and registers it, so that the clicks
+// Socket Mode delivers can be waited for with conversations_await_interaction. It needs SLACK_MCP_APP_TOKEN and
+// is gated by SLACK_MCP_ADD_MESSAGE_TOOL like conversations_add_message.
+func (ch *ConversationsHandler) ConversationsPostInteractiveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsPostInteractiveHandler called", zap.Any("params", request.Params))
+
+	if err := requireSocketMode("conversations_post_interactive"); err != nil {
+		return nil, err
+	}
+
+	params, err := parseParamsToolPostInteractive(request)
+	if err != nil {
+		logger.Error("Failed to parse post-interactive params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	options := []slack.MsgOption{
+		slack.MsgOptionText(params.text, false),
+		slack.MsgOptionBlocks(interactiveBlocks(params.text, params.buttons)...),
+	}
+	if params.threadTs != "" {
+		options = append(options, slack.MsgOptionTS(params.threadTs))
+	}
+
+	channel, ts, err := ch.apiProvider.Slack().PostMessageContext(ctx, params.channel, options...)
+	if err != nil {
+		logger.Error("Slack PostMessageContext failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, channelAPIError("conversations_post_interactive", params.channel, err)
+	}
+
+	rows := make([]InteractiveButton, len(params.buttons))
+	actionIDs := make([]string, len(params.buttons))
+	for i, b := range params.buttons {
+		actionIDs[i] = buttonActionID(i)
+		rows[i] = InteractiveButton{ChannelID: channel, MessageTs: ts, ActionID: actionIDs[i], Text: b.Text, Value: b.Value}
+	}
+	ch.apiProvider.Interactions().Register(channel, ts, actionIDs)
+	logger.Debug("Posted interactive message", zap.String("channel", channel), zap.String("ts", ts), zap.Int("buttons", len(rows)))
+
+	return marshalCSVResult(rows)
+}
+
+// ConversationsAwaitInteractionHandler waits until an allowed user clicks a button of a message posted by
+// conversations_post_interactive. A click made before the call counts too, a timeout is a regular result.
+func (ch *ConversationsHandler) ConversationsAwaitInteractionHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsAwaitInteractionHandler called", zap.Any("params", request.Params))
+
+	if err := requireSocketMode("conversations_await_interaction"); err != nil {
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolAwaitInteraction(request)
+	if err != nil {
+		logger.Error("Failed to parse await-interaction params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	started := time.Now()
+	deadline := time.NewTimer(params.timeout)
+	defer deadline.Stop()
+
+	for {
+		clicks, changed, ok := ch.apiProvider.Interactions().Clicks(params.channel, params.ts)
+		if !ok {
+			return nil, notFoundError("message %s in channel %q was not posted by conversations_post_interactive since the server started, or is older than 24h", params.ts, params.channel)
+		}
+		if click := firstAllowedClick(clicks, params.users); click != nil {
+			res := InteractionResult{
+				Status:    InteractionStatusClicked,
+				ChannelID: params.channel,
+				MessageTs: params.ts,
+				ActionID:  click.ActionID,
+				Text:      click.Label,
+				Value:     click.Value,
+				UserID:    click.UserID,
+				UserName:  click.UserName,
+				Waited:    time.Since(started).Round(time.Second).String(),
+			}
+			if u, ok := ch.apiProvider.User(click.UserID); ok {
+				res.UserName = u.Name
+			}
+			return marshalCSVResult([]InteractionResult{res})
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Debug("Waiting for a click cancelled", zap.String("channel", params.channel), zap.String("ts", params.ts))
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return marshalCSVResult([]InteractionResult{{
+				Status:    AwaitStatusTimeout,
+				ChannelID: params.channel,
+				MessageTs: params.ts,
+				Waited:    time.Since(started).Round(time.Second).String(),
+			}})
+		case <-changed:
+		}
+	}
+}
+
+// requireSocketMode rejects the interactive tools when no app-level token is configured to receive clicks
+func requireSocketMode(tool string) error {
+	if strings.TrimSpace(os.Getenv("SLACK_MCP_APP_TOKEN")) == "" {
+		return capabilityUnsupportedError("%s tool requires Socket Mode to receive button clicks, set SLACK_MCP_APP_TOKEN to an app-level token (xapp-...) of the Slack app with interactivity enabled", tool)
+	}
+	return nil
+}
+
+// firstAllowedClick returns the oldest click by one of users, by anyone when users is empty
+func firstAllowedClick(clicks []provider.ButtonClick, users map[string]bool) *provider.ButtonClick {
+	for i := range clicks {
+		if len(users) == 0 || users[clicks[i].UserID] {
+			return &clicks[i]
+		}
+	}
+	return nil
+}
+
+func buttonActionID(i int) string {
+	return fmt.Sprintf("button_%d", i+1)
+}
+
+// interactiveBlocks renders text as a section followed by an actions block with the buttons
+func interactiveBlocks(msgText string, buttons []buttonSpec) []slack.Block {
+	elements := make([]slack.BlockElement, len(buttons))
+	for i, b := range buttons {
+		button := slack.NewButtonBlockElement(buttonActionID(i), b.Value, slack.NewTextBlockObject(slack.PlainTextType, b.Text, false, false))
+		if b.Style != "" {
+			button = button.WithStyle(slack.Style(b.Style))
+		}
+		elements[i] = button
+	}
+	return []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, msgText, false, false), nil, nil),
+		slack.NewActionBlock("interactive_buttons", elements...),
+	}
+}
+
+// parseParamsToolPostInteractive reads the channel, checked against the write policy, the message text and
+// the buttons, a JSON array of objects with text and optional value and style
+func parseParamsToolPostInteractive(request mcp.CallToolRequest) (*postInteractiveParams, error) {
+	channel, err := paramChannelID(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWritePolicy("conversations_post_interactive", channel); err != nil {
+		return nil, err
+	}
+
+	threadTs := strings.TrimSpace(request.GetString("thread_ts", ""))
+	if threadTs != "" && !isMessageTimestamp(threadTs) {
+		return nil, fmt.Errorf("thread_ts must be a message timestamp in format 1234567890.123456, got %q", threadTs)
+	}
+
+	msgText := request.GetString("text", "")
+	if strings.TrimSpace(msgText) == "" {
+		return nil, errors.New("text must be a non-empty string")
+	}
+
+	buttons, err := parseButtons(request.GetString("buttons", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	return &postInteractiveParams{
+		channel:  channel,
+		threadTs: threadTs,
+		text:     escapeMessageText(msgText, false),
+		buttons:  buttons,
+	}, nil
+}
+
+// parseButtons validates the buttons against the limits of Slack, a missing value defaults to the text
+func parseButtons(raw string) ([]buttonSpec, error) {
+	var buttons []buttonSpec
+	if err := json.Unmarshal([]byte(raw), &buttons); err != nil {
+		return nil, fmt.Errorf(`buttons must be a JSON array such as [{"text":"Approve","value":"approve","style":"primary"}]: %v`, err)
+	}
+	if len(buttons) == 0 || len(buttons) > maxInteractiveButtons {
+		return nil, fmt.Errorf("buttons must have between 1 and %d buttons, got %d", maxInteractiveButtons, len(buttons))
+	}
+
+	for i := range buttons {
+		b := &buttons[i]
+		b.Text = strings.TrimSpace(b.Text)
+		if b.Text == "" || len([]rune(b.Text)) > maxButtonTextChars {
+			return nil, fmt.Errorf("buttons[%d].text must have between 1 and %d characters", i, maxButtonTextChars)
+		}
+		if b.Value == "" {
+			b.Value = b.Text
+		}
+		if len(b.Value) > maxButtonValueChars {
+			return nil, fmt.Errorf("buttons[%d].value must have at most %d characters", i, maxButtonValueChars)
+		}
+		if b.Style != "" && b.Style != string(slack.StylePrimary) && b.Style != string(slack.StyleDanger) {
+			return nil, fmt.Errorf("buttons[%d].style must be primary or danger, got %q", i, b.Style)
+		}
+	}
+	return buttons, nil
+}
+
+// parseParamsToolAwaitInteraction reads the message to watch, who may click and the timeout
+func (ch *ConversationsHandler) parseParamsToolAwaitInteraction(request mcp.CallToolRequest) (*awaitInteractionParams, error) {
+	channel, err := paramChannelID(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkReadPolicy(ch.logger, "conversations_await_interaction", channel); err != nil {
+		return nil, err
+	}
+
+	ts := strings.TrimSpace(request.GetString("ts", ""))
+	if !isMessageTimestamp(ts) {
+		return nil, fmt.Errorf("ts must be the message timestamp returned by conversations_post_interactive in format 1234567890.123456, got %q", ts)
+	}
+
+	users := make(map[string]bool)
+	for _, raw := range strings.Split(request.GetString("users", ""), ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		id, err := resolveUserID(ch.apiProvider, raw, "")
+		if err != nil {
+			return nil, err
+		}
+		users[id] = true
+	}
+
+	timeout, err := parseAwaitTimeout(request.GetString("timeout", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	return &awaitInteractionParams{
+		channel: channel,
+		ts:      ts,
+		users:   users,
+		timeout: timeout,
+	}, nil
+}
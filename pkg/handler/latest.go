@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxLatestChannels is the number of channels conversations_latest reads in one call
+	maxLatestChannels = 50
+	// latestHistoryWindow is how many of the newest messages of a channel are read to find the latest one that
+	// isn't a join, leave or other activity message
+	latestHistoryWindow = 10
+)
+
+// LatestMessage is the most recent message of a channel, the message columns are empty when the channel
+// has no messages and the error columns are set when it could not be read
+type LatestMessage struct {
+	ChannelID   string `json:"channelID"`
+	ChannelName string `json:"channelName"`
+	MsgID       string `json:"msgID"`
+	UserID      string `json:"userID"`
+	UserName    string `json:"userName"`
+	Text        string `json:"text"`
+	Time        string `json:"time"`
+	Truncated   bool   `json:"truncated"`
+	ErrorCode   string `json:"errorCode"`
+	Error       string `json:"error"`
+}
+
+type latestParams struct {
+	channels []string
+	render   bool
+}
+
+// ConversationsLatestHandler returns the most recent message of each of several channels, one CSV row per channel,
+// with a single short history call per channel. Every channel is checked against SLACK_MCP_READ_CHANNELS on its
+// own and failures are reported in the row of their channel.
+func (ch *ConversationsHandler) ConversationsLatestHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsLatestHandler called", zap.Any("params", request.Params))
+
+	params, err := parseParamsToolLatest(request)
+	if err != nil {
+		logger.Error("Failed to parse latest params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	fetch := func(ctx context.Context, channel string) (*slack.Message, error) {
+		if err := checkReadPolicy(logger, "conversations_latest", channel); err != nil {
+			return nil, err
+		}
+		var history *slack.GetConversationHistoryResponse
+		err := readChannel(ctx, ch.apiProvider.Slack(), logger, "conversations_latest", channel, func() (err error) {
+			history, err = ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+				ChannelID: channel,
+				Limit:     latestHistoryWindow,
+			})
+			return err
+		})
+		if err != nil {
+			logger.Warn("GetConversationHistoryContext failed", zap.String("channel", channel), zap.Error(err))
+			return nil, slackAPIError(err)
+		}
+		return latestMessage(history.Messages), nil
+	}
+
+	workers := ch.apiProvider.SlackCallsCapacity()
+	logger.Debug("Fetching latest Slack messages", zap.Int("channels", len(params.channels)), zap.Int("workers", workers))
+	reads := fanOut(ctx, params.channels, workers, fetch)
+
+	var found []slack.Message
+	for _, r := range reads {
+		if r.value != nil {
+			found = append(found, *r.value)
+		}
+	}
+	if err := resolveMessageReferences(ctx, ch.apiProvider, found); err != nil {
+		logger.Error("Failed to resolve message references", zap.Error(err))
+		return nil, err
+	}
+
+	return marshalCSVResult(ch.latestRows(params.channels, reads, params.render))
+}
+
+// latestMessage returns the newest of msgs, history newest first, that isn't a join, leave or other activity
+// message, nil when there is none
+func latestMessage(msgs []slack.Message) *slack.Message {
+	for i, msg := range msgs {
+		if msg.SubType == "" || msg.SubType == "bot_message" {
+			return &msgs[i]
+		}
+	}
+	return nil
+}
+
+// latestRows maps the reads to one row per channel with the author and channel names from the caches
+func (ch *ConversationsHandler) latestRows(channels []string, reads []fanOutResult[*slack.Message], render bool) []LatestMessage {
+	usersMap := ch.apiProvider.ProvideUsersMap().Users
+	channelsMap := ch.apiProvider.ProvideChannelsMaps().Channels
+	renderer := newMarkupRenderer(ch.apiProvider)
+
+	rows := make([]LatestMessage, len(channels))
+	for i, channel := range channels {
+		row := LatestMessage{ChannelID: channel}
+		if cached, ok := channelsMap[channel]; ok {
+			row.ChannelName = cached.Name
+		}
+
+		switch r := reads[i]; {
+		case r.err != nil:
+			te := AsToolError(r.err)
+			row.ErrorCode, row.Error = te.Code, te.Message
+		case r.value != nil:
+			msg := *r.value
+			timestamp, err := text.TimestampToIsoRFC3339(msg.Timestamp)
+			if err != nil {
+				ch.logger.Error("Failed to convert timestamp to RFC3339", zap.Error(err))
+			}
+			msgText := msg.Text + text.AttachmentsTo2CSV(msg.Text, msg.Attachments)
+			row.MsgID = msg.Timestamp
+			row.UserID = msg.User
+			row.UserName = messageAuthorName(msg, usersMap)
+			row.Text, row.Truncated = clipMessageText(renderer.messageText(msgText, render))
+			row.Time = timestamp
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// parseParamsToolLatest reads the deduplicated channel IDs, at most maxLatestChannels of them
+func parseParamsToolLatest(request mcp.CallToolRequest) (*latestParams, error) {
+	var (
+		channels []string
+		seen     = make(map[string]bool)
+	)
+	for _, raw := range strings.Split(request.GetString("channel_ids", ""), ",") {
+		channel := strings.TrimSpace(raw)
+		if channel == "" || seen[channel] {
+			continue
+		}
+		if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
+			return nil, fmt.Errorf("channel_ids must be channel IDs such as C1234567890, got %q", channel)
+		}
+		seen[channel] = true
+		channels = append(channels, channel)
+	}
+	if len(channels) == 0 {
+		return nil, errors.New("channel_ids must be a comma-separated list of channel IDs")
+	}
+	if len(channels) > maxLatestChannels {
+		return nil, fmt.Errorf("channel_ids has %d channels, at most %d are allowed per call", len(channels), maxLatestChannels)
+	}
+
+	return &latestParams{
+		channels: channels,
+		render:   request.GetBool("render", true),
+	}, nil
+}
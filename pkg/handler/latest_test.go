@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitParseParamsToolLatest(t *testing.T) {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"channel_ids": " C0GENERAL, D0DM,,C0GENERAL ", "render": false}
+	params, err := parseParamsToolLatest(request)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C0GENERAL", "D0DM"}, params.channels)
+	assert.False(t, params.render)
+
+	request.Params.Arguments = map[string]any{"channel_ids": "C0GENERAL,#random"}
+	_, err = parseParamsToolLatest(request)
+	assert.ErrorContains(t, err, "must be channel IDs")
+
+	request.Params.Arguments = map[string]any{"channel_ids": " , "}
+	_, err = parseParamsToolLatest(request)
+	assert.Error(t, err)
+
+	ids := make([]string, maxLatestChannels+1)
+	for i := range ids {
+		ids[i] = "C" + strings.Repeat("0", i+1)
+	}
+	request.Params.Arguments = map[string]any{"channel_ids": strings.Join(ids, ",")}
+	_, err = parseParamsToolLatest(request)
+	assert.ErrorContains(t, err, "at most 50")
+}
+
+func TestUnitLatestMessage(t *testing.T) {
+	msgs := []slack.Message{
+		{Msg: slack.Msg{SubType: "channel_join", Timestamp: "1700000000.000300"}},
+		{Msg: slack.Msg{SubType: "channel_leave", Timestamp: "1700000000.000200"}},
+		{Msg: slack.Msg{Text: "hi", Timestamp: "1700000000.000100"}},
+	}
+	latest := latestMessage(msgs)
+	require.NotNil(t, latest)
+	assert.Equal(t, "1700000000.000100", latest.Timestamp, "join and leave messages are skipped")
+
+	assert.Equal(t, "1700000000.000400", latestMessage([]slack.Message{{Msg: slack.Msg{SubType: "bot_message", Timestamp: "1700000000.000400"}}}).Timestamp)
+	assert.Nil(t, latestMessage(msgs[:2]))
+	assert.Nil(t, latestMessage(nil))
+}
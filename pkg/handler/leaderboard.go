@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultLeaderboardLookback = 7 * 24 * time.Hour
+	maxLeaderboardLookback     = 30 * 24 * time.Hour
+	defaultLeaderboardMessages = 1000
+	// maxLeaderboardMessages bounds the history pages fetched for one reactions_leaderboard call
+	maxLeaderboardMessages = 5000
+	defaultLeaderboardTop  = 10
+	maxLeaderboardTop      = 50
+	// maxLeaderboardThreads bounds the threads whose replies are fetched with include_replies
+	maxLeaderboardThreads = 100
+)
+
+// Sections of a reactions_leaderboard result
+const (
+	LeaderboardSectionTotals = "totals"
+	LeaderboardSectionEmoji  = "emoji"
+	LeaderboardSectionUser   = "user"
+)
+
+// LeaderboardRow is one line of a reactions leaderboard: totals name a counter, emoji rows count the uses of
+// an emoji and user rows count the reactions a user added. Ties share a rank.
+type LeaderboardRow struct {
+	Section string `json:"section"`
+	Rank    int    `json:"rank"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Count   int    `json:"count"`
+	Note    string `json:"note"`
+}
+
+type leaderboardParams struct {
+	channel        string
+	lookback       time.Duration
+	limit          int
+	top            int
+	includeReplies bool
+}
+
+// reactionTally counts the reactions of the scanned messages per emoji and per reacting user
+type reactionTally struct {
+	messages  int
+	reactions int
+	emoji     map[string]int
+	users     map[string]int
+}
+
+// ReactionsLeaderboardHandler pages through the history of a channel over a lookback window and ranks the most
+// used reaction emoji and the users who react most. With include_replies the replies of the threads in the
+// window are read too, concurrently within SLACK_MCP_MAX_CONCURRENT_CALLS.
+func (ch *ConversationsHandler) ReactionsLeaderboardHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ReactionsLeaderboardHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolLeaderboard(request)
+	if err != nil {
+		logger.Error("Failed to parse leaderboard params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	oldest := time.Now().Add(-params.lookback)
+	var (
+		msgs         []slack.Message
+		limitReached bool
+	)
+	err = readChannel(ctx, ch.apiProvider.Slack(), logger, "reactions_leaderboard", params.channel, func() (err error) {
+		msgs, limitReached, err = ch.historyRange(ctx, params.channel, oldest, time.Time{}, params.limit)
+		return err
+	})
+	if err != nil {
+		logger.Error("GetConversationHistoryContext failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+	logger.Debug("Fetched history for leaderboard", zap.Int("message_count", len(msgs)), zap.Bool("limit_reached", limitReached))
+
+	var threadsSkipped int
+	if params.includeReplies {
+		var threads []string
+		for _, msg := range msgs {
+			if msg.ReplyCount > 0 {
+				threads = append(threads, msg.Timestamp)
+			}
+		}
+		if len(threads) > maxLeaderboardThreads {
+			threadsSkipped = len(threads) - maxLeaderboardThreads
+			threads = threads[:maxLeaderboardThreads]
+		}
+		replies, err := ch.threadReplies(ctx, params.channel, threads, oldest)
+		if err != nil {
+			logger.Error("GetConversationRepliesContext failed", zap.String("channel", params.channel), zap.Error(err))
+			return nil, slackAPIError(err)
+		}
+		msgs = append(msgs, replies...)
+	}
+
+	tally := tallyReactions(msgs)
+	var reactors []string
+	for user := range tally.users {
+		reactors = append(reactors, user)
+	}
+	if err := ch.apiProvider.ResolveUsers(ctx, reactors); err != nil {
+		logger.Error("Failed to resolve reacting users", zap.Error(err))
+		return nil, cacheNotReadyError(err)
+	}
+
+	var notes []string
+	if limitReached {
+		notes = append(notes, fmt.Sprintf("only the latest %d messages were scanned", params.limit))
+	}
+	if threadsSkipped > 0 {
+		notes = append(notes, fmt.Sprintf("replies of %d threads were not scanned", threadsSkipped))
+	}
+	rows := ch.leaderboardRows(tally, params.top)
+	rows[0].Note = strings.Join(notes, ", ")
+	return marshalCSVResult(rows)
+}
+
+// threadReplies fetches the replies of threads posted since oldest, with at most SlackCallsCapacity calls at once.
+// The first failure cancels the remaining fetches and is returned.
+func (ch *ConversationsHandler) threadReplies(ctx context.Context, channel string, threads []string, oldest time.Time) ([]slack.Message, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		once     sync.Once
+		firstErr error
+	)
+	results := fanOut(ctx, threads, ch.apiProvider.SlackCallsCapacity(), func(ctx context.Context, ts string) ([]slack.Message, error) {
+		thread, err := ch.threadMessages(ctx, channel, ts, oldest)
+		if err != nil {
+			once.Do(func() {
+				firstErr = err
+				cancel()
+			})
+		}
+		return thread, err
+	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var replies []slack.Message
+	for _, r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+		replies = append(replies, r.value...)
+	}
+	return replies, nil
+}
+
+// threadMessages pages through the replies of the thread ts posted since oldest, the parent itself is left out
+func (ch *ConversationsHandler) threadMessages(ctx context.Context, channel, ts string, oldest time.Time) ([]slack.Message, error) {
+	var (
+		msgs   []slack.Message
+		cursor string
+	)
+	for {
+		replies, hasMore, nextCursor, err := ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: channel,
+			Timestamp: ts,
+			Oldest:    fmt.Sprintf("%d.000000", oldest.Unix()),
+			Limit:     200,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, reply := range replies {
+			if reply.Timestamp != ts {
+				msgs = append(msgs, reply)
+			}
+		}
+		if !hasMore || nextCursor == "" {
+			return msgs, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// tallyReactions counts the reactions of msgs per emoji, skin tones merged, and per user. Replies also sent to
+// the channel are counted once. Slack lists only the first users of a reaction used very often, so user counts
+// may be lower than emoji counts.
+func tallyReactions(msgs []slack.Message) *reactionTally {
+	tally := &reactionTally{emoji: make(map[string]int), users: make(map[string]int)}
+	seen := make(map[string]bool)
+	for _, msg := range msgs {
+		if msg.SubType != "" && msg.SubType != "bot_message" && msg.SubType != "thread_broadcast" {
+			continue
+		}
+		if seen[msg.Timestamp] {
+			continue
+		}
+		seen[msg.Timestamp] = true
+		tally.messages++
+		for _, reaction := range msg.Reactions {
+			name, _, _ := strings.Cut(reaction.Name, "::")
+			tally.emoji[name] += reaction.Count
+			tally.reactions += reaction.Count
+			for _, user := range reaction.Users {
+				tally.users[user]++
+			}
+		}
+	}
+	return tally
+}
+
+// leaderboardRows renders the totals and the top emoji and users of tally, user IDs resolved to names
+func (ch *ConversationsHandler) leaderboardRows(tally *reactionTally, top int) []LeaderboardRow {
+	usersMap := ch.apiProvider.ProvideUsersMap().Users
+
+	rows := []LeaderboardRow{
+		{Section: LeaderboardSectionTotals, ID: "messages", Count: tally.messages},
+		{Section: LeaderboardSectionTotals, ID: "reactions", Count: tally.reactions},
+		{Section: LeaderboardSectionTotals, ID: "emoji", Count: len(tally.emoji)},
+		{Section: LeaderboardSectionTotals, ID: "users", Count: len(tally.users)},
+	}
+	emoji := topCounts(tally.emoji, top)
+	for i, rank := range competitionRanks(emoji) {
+		c := emoji[i]
+		rows = append(rows, LeaderboardRow{Section: LeaderboardSectionEmoji, Rank: rank, ID: c.key, Name: ":" + c.key + ":", Count: c.count})
+	}
+	users := topCounts(tally.users, top)
+	for i, rank := range competitionRanks(users) {
+		c := users[i]
+		name := c.key
+		if userName, _, ok := getUserInfo(c.key, usersMap); ok {
+			name = userName
+		}
+		rows = append(rows, LeaderboardRow{Section: LeaderboardSectionUser, Rank: rank, ID: c.key, Name: name, Count: c.count})
+	}
+	return rows
+}
+
+// competitionRanks returns the rank of each count of sorted, largest first. Ties share the rank of the first
+// of them and the next count skips the shared places, e.g. 1, 2, 2, 4.
+func competitionRanks(sorted []summaryCount) []int {
+	ranks := make([]int, len(sorted))
+	for i, c := range sorted {
+		ranks[i] = i + 1
+		if i > 0 && c.count == sorted[i-1].count {
+			ranks[i] = ranks[i-1]
+		}
+	}
+	return ranks
+}
+
+// parseParamsToolLeaderboard reads the channel, checked against SLACK_MCP_READ_CHANNELS, the lookback window and the
+// scan and result sizes
+func (ch *ConversationsHandler) parseParamsToolLeaderboard(request mcp.CallToolRequest) (*leaderboardParams, error) {
+	channel, err := ch.paramReadableChannel(request, "reactions_leaderboard")
+	if err != nil {
+		return nil, err
+	}
+
+	lookback := defaultLeaderboardLookback
+	if raw := strings.TrimSpace(request.GetString("lookback", "")); raw != "" {
+		lookback, err = parseLookbackDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("lookback must be a positive duration such as 12h, 3d or 2w, got %q", raw)
+		}
+		if lookback > maxLeaderboardLookback {
+			return nil, fmt.Errorf("lookback must be at most 30d, got %q", raw)
+		}
+	}
+
+	limit := request.GetInt("limit", defaultLeaderboardMessages)
+	if limit <= 0 || limit > maxLeaderboardMessages {
+		return nil, fmt.Errorf("limit must be between 1 and %d, got %d", maxLeaderboardMessages, limit)
+	}
+	top := request.GetInt("top", defaultLeaderboardTop)
+	if top <= 0 || top > maxLeaderboardTop {
+		return nil, fmt.Errorf("top must be between 1 and %d, got %d", maxLeaderboardTop, top)
+	}
+
+	return &leaderboardParams{
+		channel:        channel,
+		lookback:       lookback,
+		limit:          limit,
+		top:            top,
+		includeReplies: request.GetBool("include_replies", false),
+	}, nil
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitTallyReactions(t *testing.T) {
+	newMessage := func(ts, subtype string, reactions ...slack.ItemReaction) slack.Message {
+		msg := slack.Message{}
+		msg.Timestamp, msg.SubType, msg.Reactions = ts, subtype, reactions
+		return msg
+	}
+
+	broadcast := newMessage("1700000300.000100", "thread_broadcast", slack.ItemReaction{Name: "eyes", Count: 1, Users: []string{"U0BOB"}})
+	msgs := []slack.Message{
+		newMessage("1700000500.000100", "", slack.ItemReaction{Name: "rocket", Count: 2, Users: []string{"U0ALICE", "U0BOB"}}),
+		newMessage("1700000400.000100", "", slack.ItemReaction{Name: "+1::skin-tone-3", Count: 1, Users: []string{"U0ALICE"}}, slack.ItemReaction{Name: "+1", Count: 2, Users: []string{"U0BOB", "U0CAROL"}}),
+		newMessage("1700000350.000100", "channel_join", slack.ItemReaction{Name: "wave", Count: 5, Users: []string{"U0DAN"}}),
+		broadcast,
+		// the same reply read from its thread
+		broadcast,
+	}
+
+	tally := tallyReactions(msgs)
+	assert.Equal(t, 3, tally.messages, "activity messages and repeated replies are not counted")
+	assert.Equal(t, 6, tally.reactions)
+	assert.Equal(t, map[string]int{"rocket": 2, "+1": 3, "eyes": 1}, tally.emoji, "skin tones are merged")
+	assert.Equal(t, map[string]int{"U0ALICE": 2, "U0BOB": 3, "U0CAROL": 1}, tally.users)
+}
+
+func TestUnitCompetitionRanks(t *testing.T) {
+	sorted := []summaryCount{{key: "a", count: 5}, {key: "b", count: 3}, {key: "c", count: 3}, {key: "d", count: 1}}
+	assert.Equal(t, []int{1, 2, 2, 4}, competitionRanks(sorted))
+	assert.Empty(t, competitionRanks(nil))
+}
+
+func TestUnitLeaderboardRows(t *testing.T) {
+	ch := &ConversationsHandler{apiProvider: &provider.ApiProvider{}, logger: zap.NewNop()}
+	tally := &reactionTally{
+		messages:  4,
+		reactions: 6,
+		emoji:     map[string]int{"rocket": 3, "eyes": 3},
+		users:     map[string]int{"U0ALICE": 4, "U0BOB": 2},
+	}
+
+	rows := ch.leaderboardRows(tally, 1)
+	require.Len(t, rows, 6)
+	assert.Equal(t, LeaderboardRow{Section: LeaderboardSectionTotals, ID: "reactions", Count: 6}, rows[1])
+	assert.Equal(t, LeaderboardRow{Section: LeaderboardSectionEmoji, Rank: 1, ID: "eyes", Name: ":eyes:", Count: 3}, rows[4], "ties are sorted by name")
+	assert.Equal(t, LeaderboardRow{Section: LeaderboardSectionUser, Rank: 1, ID: "U0ALICE", Name: "U0ALICE", Count: 4}, rows[5], "users missing from the cache keep their ID")
+}
+
+func TestUnitParseParamsToolLeaderboard(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "")
+	ch := &ConversationsHandler{apiProvider: &provider.ApiProvider{}, logger: zap.NewNop()}
+
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = args
+		return r
+	}
+
+	params, err := ch.parseParamsToolLeaderboard(request(map[string]any{"channel_id": "C1"}))
+	require.NoError(t, err)
+	assert.Equal(t, defaultLeaderboardLookback, params.lookback)
+	assert.Equal(t, defaultLeaderboardMessages, params.limit)
+	assert.Equal(t, defaultLeaderboardTop, params.top)
+	assert.False(t, params.includeReplies)
+
+	params, err = ch.parseParamsToolLeaderboard(request(map[string]any{"channel_id": "C1", "lookback": "2w", "include_replies": true}))
+	require.NoError(t, err)
+	assert.Equal(t, 14*24*time.Hour, params.lookback)
+	assert.True(t, params.includeReplies)
+
+	for _, args := range []map[string]any{
+		{"channel_id": "C1", "lookback": "31d"},
+		{"channel_id": "C1", "lookback": "soon"},
+		{"channel_id": "C1", "limit": 10000},
+		{"channel_id": "C1", "top": 0},
+	} {
+		_, err := ch.parseParamsToolLeaderboard(request(args))
+		assert.Error(t, err, "%v", args)
+	}
+
+	t.Setenv("SLACK_MCP_READ_CHANNELS", "!C1")
+	_, err = ch.parseParamsToolLeaderboard(request(map[string]any{"channel_id": "C1"}))
+	assert.Error(t, err, "denied channels are rejected")
+}
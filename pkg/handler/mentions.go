@@ -0,0 +1,330 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultMentionsLookback = 7 * 24 * time.Hour
+	maxMentionsLookback     = 30 * 24 * time.Hour
+	defaultMentionsLimit    = 50
+	// maxMentionsLimit is the page size limit of search.messages
+	maxMentionsLimit = 100
+	// maxMentionsChannels bounds the channels whose history is scanned when search is not available
+	maxMentionsChannels = 100
+	// maxMentionsChannelMessages bounds the messages read per scanned channel
+	maxMentionsChannelMessages = 200
+)
+
+// Sources of a mentions_recent result
+const (
+	MentionsSourceSearch  = "search"
+	MentionsSourceHistory = "history"
+)
+
+// Mention is a message mentioning the authenticated user. Note is set on the first row only and tells which
+// source was used and what was left out.
+type Mention struct {
+	MsgID       string `json:"msgID"`
+	ChannelID   string `json:"channelID"`
+	ChannelName string `json:"channelName"`
+	UserID      string `json:"userID"`
+	UserName    string `json:"userName"`
+	Text        string `json:"text"`
+	Time        string `json:"time"`
+	Permalink   string `json:"permalink"`
+	Truncated   bool   `json:"truncated"`
+	Note        string `json:"note"`
+}
+
+type mentionsParams struct {
+	lookback time.Duration
+	limit    int
+	render   bool
+}
+
+// mentionMatch is a message mentioning the user, with the channel it was posted in and its permalink
+type mentionMatch struct {
+	channel   string
+	permalink string
+	msg       slack.Message
+}
+
+// MentionsRecentHandler lists the messages of the lookback window that mention the authenticated user, newest
+// first. User tokens with search access use search.messages, other tokens scan the recent history of the cached
+// channels. Channels denied by SLACK_MCP_READ_CHANNELS are left out either way.
+func (ch *ConversationsHandler) MentionsRecentHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("MentionsRecentHandler called", zap.Any("params", request.Params))
+
+	params, err := parseParamsToolMentions(request)
+	if err != nil {
+		logger.Error("Failed to parse mentions params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	identity, err := ch.apiProvider.Identity(ctx)
+	if err != nil {
+		logger.Error("Slack AuthTest failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	oldest := time.Now().Add(-params.lookback)
+	var (
+		matches []mentionMatch
+		notes   []string
+		source  = MentionsSourceHistory
+	)
+	if canSearchMentions(identity) {
+		source = MentionsSourceSearch
+		matches, err = ch.searchMentions(ctx, identity.UserID, oldest, params.limit)
+		if err != nil {
+			logger.Error("Slack SearchContext failed", zap.Error(err))
+			return nil, slackAPIError(err)
+		}
+	} else {
+		matches, notes = ch.scanMentions(ctx, identity, oldest)
+	}
+	logger.Debug("Mentions found", zap.String("source", source), zap.Int("matches", len(matches)))
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].msg.Timestamp > matches[j].msg.Timestamp
+	})
+	if len(matches) > params.limit {
+		notes = append(notes, fmt.Sprintf("%d older mentions were left out", len(matches)-params.limit))
+		matches = matches[:params.limit]
+	}
+
+	msgs := make([]slack.Message, len(matches))
+	channels := make([]string, len(matches))
+	for i, m := range matches {
+		msgs[i], channels[i] = m.msg, m.channel
+	}
+	if err := resolveMessageReferences(ctx, ch.apiProvider, msgs); err != nil {
+		logger.Error("Failed to resolve message references", zap.Error(err))
+		return nil, err
+	}
+	if err := ch.apiProvider.ResolveChannels(ctx, channels); err != nil {
+		logger.Error("Failed to resolve mention channels", zap.Error(err))
+		return nil, cacheNotReadyError(err)
+	}
+
+	rows := ch.mentionRows(matches, params.render)
+	if len(rows) > 0 {
+		rows[0].Note = strings.Join(append([]string{"source: " + source}, notes...), ", ")
+	}
+	return marshalCSVResult(rows)
+}
+
+// canSearchMentions reports whether identity may call search.messages: bot tokens can't, and OAuth tokens
+// need the search:read scope. Tokens with unknown scopes are assumed to have it.
+func canSearchMentions(identity *provider.Identity) bool {
+	if identity.BotID != "" {
+		return false
+	}
+	return len(identity.Scopes) == 0 || slices.Contains(identity.Scopes, "search:read")
+}
+
+// searchMentions searches for the messages mentioning user posted since oldest. Search only filters by day, so
+// the matches are filtered by their timestamp too.
+func (ch *ConversationsHandler) searchMentions(ctx context.Context, user string, oldest time.Time, limit int) ([]mentionMatch, error) {
+	// after: is exclusive, the day before oldest keeps the messages of its own day
+	query := fmt.Sprintf("<@%s> after:%s", user, oldest.AddDate(0, 0, -1).Format("2006-01-02"))
+	res, _, err := ch.apiProvider.Slack().SearchContext(ctx, query, slack.SearchParameters{
+		Sort:          "timestamp",
+		SortDirection: "desc",
+		Count:         limit,
+		Page:          1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	since := fmt.Sprintf("%d.000000", oldest.Unix())
+	var matches []mentionMatch
+	for _, m := range res.Matches {
+		if m.Timestamp < since || m.User == user || !IsChannelReadable(m.Channel.ID) {
+			continue
+		}
+		matches = append(matches, mentionMatch{
+			channel:   m.Channel.ID,
+			permalink: m.Permalink,
+			msg: slack.Message{Msg: slack.Msg{
+				Channel:     m.Channel.ID,
+				User:        m.User,
+				Username:    m.Username,
+				Text:        m.Text,
+				Timestamp:   m.Timestamp,
+				Attachments: m.Attachments,
+			}},
+		})
+	}
+	return matches, nil
+}
+
+// scanMentions reads the recent history of the cached channels and keeps the messages mentioning the user.
+// Channels that can't be read are skipped and counted in the returned notes.
+func (ch *ConversationsHandler) scanMentions(ctx context.Context, identity *provider.Identity, oldest time.Time) ([]mentionMatch, []string) {
+	logger := requestid.Logger(ctx, ch.logger)
+	channels, skipped := mentionChannels(ch.apiProvider.ProvideChannelsMaps().Channels)
+
+	fetch := func(ctx context.Context, channel string) ([]slack.Message, error) {
+		msgs, _, err := ch.historyRange(ctx, channel, oldest, time.Time{}, maxMentionsChannelMessages)
+		return msgs, err
+	}
+	workers := ch.apiProvider.SlackCallsCapacity()
+	logger.Debug("Scanning channels for mentions", zap.Int("channels", len(channels)), zap.Int("workers", workers))
+	scans := fanOut(ctx, channels, workers, fetch)
+
+	var (
+		matches    []mentionMatch
+		notMember  int
+		unreadable int
+	)
+	for i, scan := range scans {
+		if scan.err != nil {
+			if provider.SlackErrorCode(scan.err) == "not_in_channel" {
+				notMember++
+			} else {
+				logger.Warn("GetConversationHistoryContext failed", zap.String("channel", channels[i]), zap.Error(scan.err))
+				unreadable++
+			}
+			continue
+		}
+		for _, msg := range scan.value {
+			if msg.User == identity.UserID || !mentionsUser(msg.Text, identity.UserID) {
+				continue
+			}
+			matches = append(matches, mentionMatch{
+				channel:   channels[i],
+				permalink: messagePermalink(identity.URL, channels[i], msg.Timestamp),
+				msg:       msg,
+			})
+		}
+	}
+
+	var notes []string
+	if skipped > 0 {
+		notes = append(notes, fmt.Sprintf("%d channels were not scanned", skipped))
+	}
+	if notMember > 0 {
+		notes = append(notes, fmt.Sprintf("%d channels could not be read without membership", notMember))
+	}
+	if unreadable > 0 {
+		notes = append(notes, fmt.Sprintf("%d channels failed to load", unreadable))
+	}
+	return matches, notes
+}
+
+// mentionChannels picks the channels to scan for mentions: unarchived ones allowed by SLACK_MCP_READ_CHANNELS,
+// direct messages and private channels first as the token is a member of them, at most maxMentionsChannels.
+// It also returns how many were left out by the limit.
+func mentionChannels(cached map[string]provider.Channel) ([]string, int) {
+	var candidates []provider.Channel
+	for _, c := range cached {
+		if c.IsArchived || !IsChannelReadable(c.ID) {
+			continue
+		}
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		pi, pj := candidates[i].IsIM || candidates[i].IsMpIM || candidates[i].IsPrivate, candidates[j].IsIM || candidates[j].IsMpIM || candidates[j].IsPrivate
+		if pi != pj {
+			return pi
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	skipped := 0
+	if len(candidates) > maxMentionsChannels {
+		skipped = len(candidates) - maxMentionsChannels
+		candidates = candidates[:maxMentionsChannels]
+	}
+	channels := make([]string, len(candidates))
+	for i, c := range candidates {
+		channels[i] = c.ID
+	}
+	return channels, skipped
+}
+
+// mentionsUser reports whether msgText mentions user as <@U123> or <@U123|name>
+func mentionsUser(msgText, user string) bool {
+	return strings.Contains(msgText, "<@"+user+">") || strings.Contains(msgText, "<@"+user+"|")
+}
+
+// messagePermalink builds the permalink of message ts in channel from the workspace URL returned by auth.test
+func messagePermalink(workspaceURL, channel, ts string) string {
+	if workspaceURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/archives/%s/p%s", strings.TrimSuffix(workspaceURL, "/"), channel, strings.ReplaceAll(ts, ".", ""))
+}
+
+// mentionRows maps the matches to rows with the author and channel names from the caches
+func (ch *ConversationsHandler) mentionRows(matches []mentionMatch, render bool) []Mention {
+	usersMap := ch.apiProvider.ProvideUsersMap().Users
+	channelsMap := ch.apiProvider.ProvideChannelsMaps().Channels
+	renderer := newMarkupRenderer(ch.apiProvider)
+
+	rows := make([]Mention, 0, len(matches))
+	for _, m := range matches {
+		timestamp, err := text.TimestampToIsoRFC3339(m.msg.Timestamp)
+		if err != nil {
+			ch.logger.Error("Failed to convert timestamp to RFC3339", zap.Error(err))
+			continue
+		}
+		row := Mention{
+			MsgID:     m.msg.Timestamp,
+			ChannelID: m.channel,
+			UserID:    m.msg.User,
+			UserName:  messageAuthorName(m.msg, usersMap),
+			Time:      timestamp,
+			Permalink: m.permalink,
+		}
+		if cached, ok := channelsMap[m.channel]; ok {
+			row.ChannelName = cached.Name
+		}
+		msgText := m.msg.Text + text.AttachmentsTo2CSV(m.msg.Text, m.msg.Attachments)
+		row.Text, row.Truncated = clipMessageText(renderer.messageText(msgText, render))
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// parseParamsToolMentions reads the lookback window, the number of mentions to return and the render flag
+func parseParamsToolMentions(request mcp.CallToolRequest) (*mentionsParams, error) {
+	lookback := defaultMentionsLookback
+	if raw := strings.TrimSpace(request.GetString("lookback", "")); raw != "" {
+		var err error
+		lookback, err = parseLookbackDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("lookback must be a positive duration such as 12h, 3d or 2w, got %q", raw)
+		}
+		if lookback > maxMentionsLookback {
+			return nil, fmt.Errorf("lookback must be at most 30d, got %q", raw)
+		}
+	}
+
+	limit := request.GetInt("limit", defaultMentionsLimit)
+	if limit <= 0 || limit > maxMentionsLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d, got %d", maxMentionsLimit, limit)
+	}
+
+	return &mentionsParams{
+		lookback: lookback,
+		limit:    limit,
+		render:   request.GetBool("render", true),
+	}, nil
+}
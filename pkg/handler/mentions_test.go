@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitParseParamsToolMentions(t *testing.T) {
+	request := mcp.CallToolRequest{}
+	params, err := parseParamsToolMentions(request)
+	require.NoError(t, err)
+	assert.Equal(t, defaultMentionsLookback, params.lookback)
+	assert.Equal(t, defaultMentionsLimit, params.limit)
+	assert.True(t, params.render)
+
+	request.Params.Arguments = map[string]any{"lookback": " 2d ", "limit": 10, "render": false}
+	params, err = parseParamsToolMentions(request)
+	require.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, params.lookback)
+	assert.Equal(t, 10, params.limit)
+	assert.False(t, params.render)
+
+	for _, args := range []map[string]any{
+		{"lookback": "5w"},
+		{"lookback": "soon"},
+		{"limit": 0},
+		{"limit": maxMentionsLimit + 1},
+	} {
+		request.Params.Arguments = args
+		_, err := parseParamsToolMentions(request)
+		assert.Error(t, err, args)
+	}
+}
+
+func TestUnitCanSearchMentions(t *testing.T) {
+	assert.True(t, canSearchMentions(&provider.Identity{UserID: "U0ME"}))
+	assert.True(t, canSearchMentions(&provider.Identity{UserID: "U0ME", Scopes: []string{"channels:history", "search:read"}}))
+	assert.False(t, canSearchMentions(&provider.Identity{UserID: "U0ME", Scopes: []string{"channels:history"}}))
+	assert.False(t, canSearchMentions(&provider.Identity{UserID: "U0BOT", BotID: "B0BOT"}))
+}
+
+func TestUnitMentionsUser(t *testing.T) {
+	assert.True(t, mentionsUser("hey <@U0ME> look", "U0ME"))
+	assert.True(t, mentionsUser("hey <@U0ME|me>", "U0ME"))
+	assert.False(t, mentionsUser("hey <@U0MEX>", "U0ME"))
+	assert.False(t, mentionsUser("hey U0ME", "U0ME"))
+}
+
+func TestUnitMessagePermalink(t *testing.T) {
+	assert.Equal(t, "https://acme.slack.com/archives/C0GENERAL/p1700000000000100", messagePermalink("https://acme.slack.com/", "C0GENERAL", "1700000000.000100"))
+	assert.Empty(t, messagePermalink("", "C0GENERAL", "1700000000.000100"))
+}
+
+func TestUnitMentionChannels(t *testing.T) {
+	t.Setenv("SLACK_MCP_READ_CHANNELS", "!C0DENIED")
+	cached := map[string]provider.Channel{
+		"C0B":        {ID: "C0B"},
+		"C0A":        {ID: "C0A"},
+		"G0PRIVATE":  {ID: "G0PRIVATE", IsPrivate: true},
+		"D0DM":       {ID: "D0DM", IsIM: true},
+		"C0ARCHIVED": {ID: "C0ARCHIVED", IsArchived: true},
+		"C0DENIED":   {ID: "C0DENIED"},
+	}
+	channels, skipped := mentionChannels(cached)
+	assert.Equal(t, []string{"D0DM", "G0PRIVATE", "C0A", "C0B"}, channels)
+	assert.Zero(t, skipped)
+
+	for i := 0; i < maxMentionsChannels; i++ {
+		id := "C1" + string(rune('A'+i%26)) + string(rune('A'+i/26))
+		cached[id] = provider.Channel{ID: id}
+	}
+	channels, skipped = mentionChannels(cached)
+	assert.Len(t, channels, maxMentionsChannels)
+	assert.Equal(t, 4, skipped)
+	assert.Equal(t, "D0DM", channels[0])
+}
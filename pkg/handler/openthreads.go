@@ -0,0 +1,294 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultOpenThreadsLookback = 7 * 24 * time.Hour
+	maxOpenThreadsLookback     = 30 * 24 * time.Hour
+	defaultOpenThreadsLimit    = 20
+	maxOpenThreadsLimit        = 100
+	// maxOpenThreadsVerify bounds the threads whose replies are fetched to look for a responder, the others are
+	// listed with the status unchecked
+	maxOpenThreadsVerify = 50
+	// replyUsersShown is the number of repliers Slack lists in reply_users, longer threads may hide a responder
+	replyUsersShown = 5
+)
+
+type OpenThread struct {
+	MsgID      string `json:"msgID"`
+	UserID     string `json:"userID"`
+	UserName   string `json:"userName"`
+	RealName   string `json:"realName"`
+	ChannelID  string `json:"channelID"`
+	Time       string `json:"time"`
+	Waiting    string `json:"waiting"`
+	ReplyCount int    `json:"replyCount"`
+	LastReply  string `json:"lastReply"`
+	// Status is waiting, or unchecked for a thread whose replies were not fetched to look for a responder
+	Status string `json:"status"`
+	Text   string `json:"text"`
+}
+
+const (
+	OpenThreadWaiting   = "waiting"
+	OpenThreadUnchecked = "unchecked"
+)
+
+type openThreadsParams struct {
+	channel    string
+	lookback   time.Duration
+	responders map[string]bool
+	limit      int
+	render     bool
+}
+
+// ConversationsOpenThreadsHandler lists the top-level messages of a channel that are still waiting for an answer,
+// oldest first: messages without replies, or without a reply of one of the given responders.
+func (ch *ConversationsHandler) ConversationsOpenThreadsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsOpenThreadsHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolOpenThreads(request)
+	if err != nil {
+		logger.Error("Failed to parse open-threads params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	now := time.Now()
+	var (
+		msgs         []slack.Message
+		limitReached bool
+	)
+	err = readChannel(ctx, ch.apiProvider.Slack(), logger, "conversations_open_threads", params.channel, func() (err error) {
+		msgs, limitReached, err = ch.historyRange(ctx, params.channel, now.Add(-params.lookback), time.Time{}, maxSummaryMessages)
+		return err
+	})
+	if err != nil {
+		logger.Error("GetConversationHistoryContext failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+	if limitReached {
+		logger.Warn("Only the latest messages were scanned for open threads", zap.String("channel", params.channel), zap.Int("scanned", len(msgs)))
+	}
+
+	open, verify := openThreadCandidates(msgs, params.responders)
+	waiting, unchecked, err := verifyOpenThreads(verify, func(ts string) (bool, error) {
+		return ch.threadAnsweredBy(ctx, params.channel, ts, params.responders)
+	})
+	if err != nil {
+		logger.Error("GetConversationRepliesContext failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+	open = append(open, waiting...)
+	if len(unchecked) > 0 {
+		logger.Warn("Too many threads to check for responders, listing them as unchecked", zap.Int("unchecked", len(unchecked)))
+	}
+
+	sort.Slice(open, func(i, j int) bool {
+		return open[i].Timestamp < open[j].Timestamp
+	})
+	if len(open) > params.limit {
+		open = open[:params.limit]
+	}
+
+	if err := resolveMessageReferences(ctx, ch.apiProvider, open); err != nil {
+		logger.Error("Failed to resolve message references", zap.Error(err))
+		return nil, err
+	}
+	res, err := marshalCSVResult(ch.openThreadRows(open, unchecked, params, now))
+	if err != nil {
+		return nil, err
+	}
+	if len(unchecked) > 0 {
+		res.Content = append(res.Content, mcp.NewTextContent(fmt.Sprintf(
+			"%d threads with %d or more repliers were not checked for a reply of the responders, they are listed with status %s",
+			len(unchecked), replyUsersShown, OpenThreadUnchecked)))
+	}
+	return res, nil
+}
+
+// openThreadCandidates splits top-level messages into those waiting for an answer and those whose reply_users
+// can't tell, since Slack lists only the first repliers. Without responders any reply answers a message.
+// Activity messages and messages posted by a responder are never waiting.
+func openThreadCandidates(msgs []slack.Message, responders map[string]bool) (open, verify []slack.Message) {
+	for _, msg := range msgs {
+		if (msg.SubType != "" && msg.SubType != "bot_message") || responders[msg.User] {
+			continue
+		}
+		if msg.ThreadTimestamp != "" && msg.ThreadTimestamp != msg.Timestamp {
+			continue
+		}
+		if msg.ReplyCount == 0 {
+			open = append(open, msg)
+			continue
+		}
+		if len(responders) == 0 {
+			continue
+		}
+
+		answered := false
+		for _, user := range msg.ReplyUsers {
+			if responders[user] {
+				answered = true
+				break
+			}
+		}
+		switch {
+		case answered:
+		case len(msg.ReplyUsers) >= replyUsersShown:
+			verify = append(verify, msg)
+		default:
+			open = append(open, msg)
+		}
+	}
+	return open, verify
+}
+
+// verifyOpenThreads checks the first maxOpenThreadsVerify threads of verify with answered and returns the ones
+// still waiting. Threads past the bound are not known to be answered, so they are returned as well and marked
+// in unchecked by their timestamp.
+func verifyOpenThreads(verify []slack.Message, answered func(ts string) (bool, error)) (waiting []slack.Message, unchecked map[string]bool, err error) {
+	unchecked = make(map[string]bool)
+	for i, msg := range verify {
+		if i >= maxOpenThreadsVerify {
+			unchecked[msg.Timestamp] = true
+			waiting = append(waiting, msg)
+			continue
+		}
+		ok, err := answered(msg.Timestamp)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			waiting = append(waiting, msg)
+		}
+	}
+	return waiting, unchecked, nil
+}
+
+// threadAnsweredBy reports whether one of responders replied in the thread of ts
+func (ch *ConversationsHandler) threadAnsweredBy(ctx context.Context, channel, ts string, responders map[string]bool) (bool, error) {
+	var cursor string
+	for {
+		replies, hasMore, nextCursor, err := ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: channel,
+			Timestamp: ts,
+			Limit:     200,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, reply := range replies {
+			if reply.Timestamp != ts && responders[reply.User] {
+				return true, nil
+			}
+		}
+		if !hasMore || nextCursor == "" {
+			return false, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+func (ch *ConversationsHandler) openThreadRows(open []slack.Message, unchecked map[string]bool, params *openThreadsParams, now time.Time) []OpenThread {
+	usersMap := ch.apiProvider.ProvideUsersMap().Users
+	renderer := newMarkupRenderer(ch.apiProvider)
+
+	rows := make([]OpenThread, 0, len(open))
+	for _, msg := range open {
+		_, realName, _ := getUserInfo(msg.User, usersMap)
+		if msg.User == "" {
+			realName = ""
+		}
+		posted, err := text.TimestampToIsoRFC3339(msg.Timestamp)
+		if err != nil {
+			ch.logger.Error("Failed to convert timestamp to RFC3339", zap.Error(err))
+			continue
+		}
+		var waiting string
+		if t, err := time.Parse(time.RFC3339, posted); err == nil {
+			waiting = now.Sub(t).Round(time.Minute).String()
+		}
+		var lastReply string
+		if msg.LatestReply != "" {
+			lastReply, _ = text.TimestampToIsoRFC3339(msg.LatestReply)
+		}
+		msgText, _ := clipMessageText(renderer.messageText(msg.Text+text.AttachmentsTo2CSV(msg.Text, msg.Attachments), params.render))
+		status := OpenThreadWaiting
+		if unchecked[msg.Timestamp] {
+			status = OpenThreadUnchecked
+		}
+
+		rows = append(rows, OpenThread{
+			MsgID:      msg.Timestamp,
+			UserID:     msg.User,
+			UserName:   messageAuthorName(msg, usersMap),
+			RealName:   realName,
+			ChannelID:  params.channel,
+			Time:       posted,
+			Waiting:    waiting,
+			ReplyCount: msg.ReplyCount,
+			LastReply:  lastReply,
+			Status:     status,
+			Text:       msgText,
+		})
+	}
+	return rows
+}
+
+// parseParamsToolOpenThreads reads the channel, the lookback window, the responders and the result count
+func (ch *ConversationsHandler) parseParamsToolOpenThreads(request mcp.CallToolRequest) (*openThreadsParams, error) {
+	channel, err := ch.paramReadableChannel(request, "conversations_open_threads")
+	if err != nil {
+		return nil, err
+	}
+
+	lookback := defaultOpenThreadsLookback
+	if raw := strings.TrimSpace(request.GetString("lookback", "")); raw != "" {
+		lookback, err = parseLookbackDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("lookback must be a positive duration such as 12h, 3d or 2w, got %q", raw)
+		}
+		if lookback > maxOpenThreadsLookback {
+			return nil, fmt.Errorf("lookback must be at most 30d, got %q", raw)
+		}
+	}
+
+	responders := make(map[string]bool)
+	for _, raw := range strings.Split(request.GetString("responders", ""), ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		id, err := resolveUserID(ch.apiProvider, raw, request.GetString("team_id", ""))
+		if err != nil {
+			return nil, err
+		}
+		responders[id] = true
+	}
+
+	limit := request.GetInt("limit", defaultOpenThreadsLimit)
+	if limit <= 0 || limit > maxOpenThreadsLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d, got %d", maxOpenThreadsLimit, limit)
+	}
+
+	return &openThreadsParams{
+		channel:    channel,
+		lookback:   lookback,
+		responders: responders,
+		limit:      limit,
+		render:     request.GetBool("render", true),
+	}, nil
+}
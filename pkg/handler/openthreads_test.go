@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitOpenThreadCandidates(t *testing.T) {
+	newMessage := func(ts, user string, replyUsers ...string) slack.Message {
+		msg := slack.Message{}
+		msg.Timestamp, msg.User = ts, user
+		msg.ReplyCount, msg.ReplyUsers = len(replyUsers), replyUsers
+		if len(replyUsers) > 0 {
+			msg.ThreadTimestamp = ts
+		}
+		return msg
+	}
+	timestamps := func(msgs []slack.Message) []string {
+		ts := make([]string, 0, len(msgs))
+		for _, msg := range msgs {
+			ts = append(ts, msg.Timestamp)
+		}
+		return ts
+	}
+
+	unanswered := newMessage("1700000100.000100", "U0CUSTOMER")
+	answeredBySupport := newMessage("1700000200.000100", "U0CUSTOMER", "U0SUPPORT")
+	answeredByPeer := newMessage("1700000300.000100", "U0CUSTOMER", "U0PEER")
+	busyThread := newMessage("1700000400.000100", "U0CUSTOMER", "U0A", "U0B", "U0C", "U0D", "U0E")
+	bySupport := newMessage("1700000500.000100", "U0SUPPORT")
+	join := newMessage("1700000600.000100", "U0NEW")
+	join.SubType = "channel_join"
+	broadcastReply := newMessage("1700000700.000100", "U0CUSTOMER")
+	broadcastReply.ThreadTimestamp = "1700000300.000100"
+	msgs := []slack.Message{broadcastReply, join, bySupport, busyThread, answeredByPeer, answeredBySupport, unanswered}
+
+	open, verify := openThreadCandidates(msgs, nil)
+	assert.Equal(t, []string{bySupport.Timestamp, unanswered.Timestamp}, timestamps(open), "any reply answers without responders")
+	assert.Empty(t, verify)
+
+	open, verify = openThreadCandidates(msgs, map[string]bool{"U0SUPPORT": true})
+	assert.Equal(t, []string{answeredByPeer.Timestamp, unanswered.Timestamp}, timestamps(open))
+	assert.Equal(t, []string{busyThread.Timestamp}, timestamps(verify), "reply_users may hide a responder in long threads")
+}
+
+func TestUnitVerifyOpenThreads(t *testing.T) {
+	verify := make([]slack.Message, maxOpenThreadsVerify+2)
+	for i := range verify {
+		verify[i].Timestamp = fmt.Sprintf("1700000000.%06d", len(verify)-i)
+	}
+
+	var checked []string
+	waiting, unchecked, err := verifyOpenThreads(verify, func(ts string) (bool, error) {
+		checked = append(checked, ts)
+		// every other thread got a reply of a responder
+		return len(checked)%2 == 0, nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, checked, maxOpenThreadsVerify, "the replies of the newest threads are fetched")
+	assert.Len(t, waiting, maxOpenThreadsVerify/2+2)
+	assert.Equal(t, map[string]bool{verify[maxOpenThreadsVerify].Timestamp: true, verify[maxOpenThreadsVerify+1].Timestamp: true}, unchecked,
+		"older threads are listed as unchecked instead of being treated as answered")
+
+	_, _, err = verifyOpenThreads(verify, func(ts string) (bool, error) {
+		return false, errors.New("ratelimited")
+	})
+	assert.ErrorContains(t, err, "ratelimited")
+}
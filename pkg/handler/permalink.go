@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// permalinkPathRe matches the path of a message permalink, /archives/<channel>/p<ts without the dot>
+var permalinkPathRe = regexp.MustCompile(`^/archives/([CDG][A-Z0-9]{2,})/p(\d{10})(\d{6})/?$`)
+
+// permalink is a Slack message URL split into its parts
+type permalink struct {
+	host     string
+	channel  string
+	ts       string
+	threadTs string
+}
+
+// ChatResolvePermalinkHandler parses a message permalink of the authenticated workspace and returns the message as CSV.
+// Replies are fetched from their thread, which thread permalinks name in the thread_ts query parameter.
+func (ch *ConversationsHandler) ChatResolvePermalinkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ChatResolvePermalinkHandler called", zap.Any("params", request.Params))
+
+	link, err := parsePermalink(request.GetString("permalink", ""))
+	if err != nil {
+		logger.Error("Failed to parse permalink", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	ar, err := ch.apiProvider.Slack().AuthTest()
+	if err != nil {
+		logger.Error("Slack AuthTest failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+	if err := checkPermalinkWorkspace(link, ar.URL, ch.apiProvider.IsOrgScoped()); err != nil {
+		logger.Warn("Permalink of another workspace", zap.String("host", link.host), zap.String("workspace", ar.URL))
+		return nil, invalidArgumentError(err)
+	}
+
+	if err := checkReadPolicy(logger, "chat_resolve_permalink", link.channel); err != nil {
+		return nil, err
+	}
+
+	msg, err := ch.fetchMessage(ctx, link.channel, link.ts, link.threadTs)
+	if err != nil {
+		logger.Error("Failed to fetch permalink message", zap.String("channel", link.channel), zap.String("ts", link.ts), zap.Error(err))
+		return nil, err
+	}
+	found := []slack.Message{*msg}
+
+	if err := resolveMessageReferences(ctx, ch.apiProvider, found); err != nil {
+		logger.Error("Failed to resolve message references", zap.Error(err))
+		return nil, err
+	}
+	messages := ch.convertMessagesFromHistory(found, link.channel, historyOptions{
+		render: request.GetBool("render", true),
+		edits:  true,
+	})
+	return marshalMessagesToCSV(messages)
+}
+
+// fetchMessage returns the message ts of channel, replies are fetched from the thread threadTs
+func (ch *ConversationsHandler) fetchMessage(ctx context.Context, channel, ts, threadTs string) (*slack.Message, error) {
+	var (
+		msgs []slack.Message
+		err  error
+	)
+	if threadTs != "" && threadTs != ts {
+		msgs, _, _, err = ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: channel,
+			Timestamp: threadTs,
+			Oldest:    ts,
+			Latest:    ts,
+			Inclusive: true,
+			Limit:     1,
+		})
+	} else {
+		var history *slack.GetConversationHistoryResponse
+		history, err = ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channel,
+			Oldest:    ts,
+			Latest:    ts,
+			Inclusive: true,
+			Limit:     1,
+		})
+		if history != nil {
+			msgs = history.Messages
+		}
+	}
+	if err != nil {
+		return nil, slackAPIError(err)
+	}
+
+	// replies always start with the thread parent, keep the requested message only
+	for _, msg := range msgs {
+		if msg.Timestamp == ts {
+			return &msg, nil
+		}
+	}
+	return nil, notFoundError("message %s not found in channel %s, it may have been deleted", ts, channel)
+}
+
+// parsePermalink splits a message URL such as https://acme.slack.com/archives/C1234567890/p1234567890123456
+// into channel and timestamp, with the thread_ts query parameter of replies
+func parsePermalink(raw string) (*permalink, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, fmt.Errorf("permalink must be a Slack message URL such as https://acme.slack.com/archives/C1234567890/p1234567890123456")
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("permalink must be an absolute Slack URL, got %q", raw)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host != "slack.com" && !strings.HasSuffix(host, ".slack.com") {
+		return nil, fmt.Errorf("permalink must be a slack.com URL, got host %q", host)
+	}
+
+	m := permalinkPathRe.FindStringSubmatch(u.Path)
+	if m == nil {
+		return nil, fmt.Errorf("permalink must point to a message as /archives/<channel>/p<timestamp>, got path %q", u.Path)
+	}
+
+	threadTs := u.Query().Get("thread_ts")
+	if threadTs != "" && !isMessageTimestamp(threadTs) {
+		return nil, fmt.Errorf("permalink has an invalid thread_ts %q", threadTs)
+	}
+
+	return &permalink{
+		host:     host,
+		channel:  m[1],
+		ts:       m[2] + "." + m[3],
+		threadTs: threadTs,
+	}, nil
+}
+
+// checkPermalinkWorkspace compares the host of a permalink with the workspace URL returned by auth.test.
+// Org-level Enterprise Grid tokens read every workspace of the org, so any workspace host is accepted then.
+func checkPermalinkWorkspace(link *permalink, workspaceURL string, orgScoped bool) error {
+	// app.slack.com and slack.com links don't name a workspace
+	if link.host == "slack.com" || link.host == "app.slack.com" || orgScoped {
+		return nil
+	}
+
+	u, err := url.Parse(workspaceURL)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	if workspace := strings.ToLower(u.Hostname()); workspace != link.host {
+		return fmt.Errorf("permalink belongs to %s, but the token authenticates to %s", link.host, workspace)
+	}
+	return nil
+}
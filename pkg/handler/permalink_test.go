@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestUnitParsePermalink(t *testing.T) {
+	link, err := parsePermalink("https://Acme.slack.com/archives/C1234567890/p1712345678123456")
+	require.NoError(t, err)
+	assert.Equal(t, &permalink{host: "acme.slack.com", channel: "C1234567890", ts: "1712345678.123456"}, link)
+
+	link, err = parsePermalink("https://acme.slack.com/archives/C1234567890/p1712345678123456?thread_ts=1712345000.000100&cid=C1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, "1712345000.000100", link.threadTs)
+
+	for _, raw := range []string{
+		"",
+		"not a url",
+		"https://example.com/archives/C1234567890/p1712345678123456",
+		"https://acme.slack.com/archives/C1234567890",
+		"https://acme.slack.com/archives/C1234567890/p17123456",
+		"https://acme.slack.com/archives/C1234567890/p1712345678123456?thread_ts=yesterday",
+	} {
+		_, err := parsePermalink(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestUnitCheckPermalinkWorkspace(t *testing.T) {
+	link := &permalink{host: "acme.slack.com", channel: "C1234567890", ts: "1712345678.123456"}
+
+	assert.NoError(t, checkPermalinkWorkspace(link, "https://acme.slack.com/", false))
+	assert.EqualError(t, checkPermalinkWorkspace(link, "https://other.slack.com/", false),
+		"permalink belongs to acme.slack.com, but the token authenticates to other.slack.com")
+	assert.NoError(t, checkPermalinkWorkspace(link, "https://other.slack.com/", true), "org-level tokens read every workspace of the org")
+	assert.NoError(t, checkPermalinkWorkspace(&permalink{host: "app.slack.com"}, "https://other.slack.com/", false))
+}
+
+func TestUnitHandlerLogsCarryRequestID(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	ch := &ConversationsHandler{logger: zap.New(core)}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"permalink": "not a url"}
+	_, err := ch.ChatResolvePermalinkHandler(requestid.WithID(context.Background(), "req-1"), request)
+	require.Error(t, err)
+
+	require.NotZero(t, logs.Len())
+	for _, entry := range logs.All() {
+		assert.Equal(t, "req-1", entry.ContextMap()["request_id"], entry.Message)
+	}
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// channelListAllows applies a channel list such as SLACK_MCP_ADD_MESSAGE_TOOL to channel: empty, true or 1
+// allow every channel, a list of channel IDs allows only the listed ones, and a list starting with ! allows
+// every channel except the listed ones
+func channelListAllows(config, channel string) bool {
+	if config == "" || config == "true" || config == "1" {
+		return true
+	}
+	items := strings.Split(config, ",")
+	isNegated := strings.HasPrefix(strings.TrimSpace(items[0]), "!")
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if isNegated {
+			if strings.TrimPrefix(item, "!") == channel {
+				return false
+			}
+		} else {
+			if item == channel {
+				return true
+			}
+		}
+	}
+	return isNegated
+}
+
+// isChannelAllowed applies the write policy SLACK_MCP_ADD_MESSAGE_TOOL to channel
+func isChannelAllowed(channel string) bool {
+	return channelListAllows(os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL"), channel)
+}
+
+// IsChannelReadable applies the read policy SLACK_MCP_READ_CHANNELS to channel, every channel is readable
+// when it is unset
+func IsChannelReadable(channel string) bool {
+	return channelListAllows(os.Getenv("SLACK_MCP_READ_CHANNELS"), channel)
+}
+
+// IsReadOnlyEnabled returns true if SLACK_MCP_READ_ONLY disables every tool and side effect that writes to Slack
+func IsReadOnlyEnabled() bool {
+	enabled := os.Getenv("SLACK_MCP_READ_ONLY")
+	return enabled == "true" || enabled == "1"
+}
+
+// checkReadPolicy applies SLACK_MCP_READ_CHANNELS to tools, prompts and resources that read a channel
+func checkReadPolicy(logger *zap.Logger, tool, channel string) error {
+	if IsChannelReadable(channel) {
+		return nil
+	}
+	logger.Warn("Reading the channel is not allowed by policy", zap.String("tool", tool), zap.String("channel", channel))
+	return channelNotAllowedError("%s is not allowed to read channel %q, applied policy: SLACK_MCP_READ_CHANNELS", tool, channel)
+}
+
+// checkFileReadPolicy applies SLACK_MCP_READ_CHANNELS to a file: it is readable when it isn't shared at all
+// or at least one of the channels it is shared in is readable
+func checkFileReadPolicy(logger *zap.Logger, tool string, f slack.File) error {
+	var shared []string
+	shared = append(shared, f.Channels...)
+	shared = append(shared, f.Groups...)
+	shared = append(shared, f.IMs...)
+	if len(shared) == 0 || slices.ContainsFunc(shared, IsChannelReadable) {
+		return nil
+	}
+	logger.Warn("Reading the file is not allowed by policy", zap.String("tool", tool), zap.String("file_id", f.ID), zap.String("channel", shared[0]))
+	return channelNotAllowedError("%s is not allowed to read file %q shared in channel %q, applied policy: SLACK_MCP_READ_CHANNELS", tool, f.ID, shared[0])
+}
+
+// checkWritePolicy applies SLACK_MCP_ADD_MESSAGE_TOOL to tools that modify a channel:
+// writes are disabled when it is unset and limited to the configured channels otherwise
+func checkWritePolicy(tool, channel string) error {
+	if os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL") == "" {
+		return capabilityUnsupportedError("%s tool is disabled by default, set SLACK_MCP_ADD_MESSAGE_TOOL to true, 1, or a comma separated list of channels to enable it", tool)
+	}
+	return checkWriteChannel(tool, channel)
+}
+
+// checkWriteChannel applies the channel list of SLACK_MCP_ADD_MESSAGE_TOOL to tools that change the state of a
+// channel for the authenticated user only, such as its read cursor, and are enabled when it is unset
+func checkWriteChannel(tool, channel string) error {
+	if !isChannelAllowed(channel) {
+		return channelNotAllowedError("%s tool is not allowed for channel %q, applied policy: %s", tool, channel, os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL"))
+	}
+	return nil
+}
+
+// paramReadableChannel reads channel_id, an ID or a #name resolved from the cache, and checks it against
+// SLACK_MCP_READ_CHANNELS
+func (ch *ConversationsHandler) paramReadableChannel(request mcp.CallToolRequest, tool string) (string, error) {
+	channel := strings.TrimSpace(request.GetString("channel_id", ""))
+	if channel == "" {
+		return "", errors.New("channel_id must be a string")
+	}
+	if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
+		if err := channelNameReady(ch.apiProvider, channel); err != nil {
+			ch.logger.Warn("Slack channels sync is not ready yet, query channels by ID instead", zap.Error(err))
+			return "", err
+		}
+		id, err := lookupChannelID(ch.apiProvider, channel, request.GetString("team_id", ""))
+		if errors.Is(err, provider.ErrNameNotFound) {
+			return "", notFoundError("channel %q not found in synced cache", channel)
+		}
+		if err != nil {
+			return "", err
+		}
+		channel = id
+	}
+	if err := checkReadPolicy(ch.logger, tool, channel); err != nil {
+		return "", err
+	}
+	return channel, nil
+}
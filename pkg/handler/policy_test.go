@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitCheckReadPolicy(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "C0WRITE")
+	t.Setenv("SLACK_MCP_READ_CHANNELS", "")
+	assert.NoError(t, checkReadPolicy(zap.NewNop(), "conversations_history", "C0OTHER"), "the write policy doesn't apply to reads")
+
+	t.Setenv("SLACK_MCP_READ_CHANNELS", "!C0SECRET")
+	assert.NoError(t, checkReadPolicy(zap.NewNop(), "conversations_history", "C0OTHER"))
+	err := checkReadPolicy(zap.NewNop(), "conversations_history", "C0SECRET")
+	require.Error(t, err)
+	assert.Equal(t, ErrCodeChannelNotAllowed, AsToolError(err).Code)
+	assert.Equal(t, `conversations_history is not allowed to read channel "C0SECRET", applied policy: SLACK_MCP_READ_CHANNELS`, AsToolError(err).Message)
+}
+
+func TestUnitCheckFileReadPolicy(t *testing.T) {
+	t.Setenv("SLACK_MCP_READ_CHANNELS", "!C0000000BAD")
+
+	assert.NoError(t, checkFileReadPolicy(zap.NewNop(), "files_download", slack.File{}), "unshared files are readable")
+	assert.NoError(t, checkFileReadPolicy(zap.NewNop(), "files_download", slack.File{Channels: []string{"C0000000BAD"}, Groups: []string{"G1234567890"}}), "one readable channel is enough")
+
+	err := checkFileReadPolicy(zap.NewNop(), "files_download", slack.File{ID: "F1", Channels: []string{"C0000000BAD"}})
+	require.Error(t, err)
+	assert.Equal(t, ErrCodeChannelNotAllowed, AsToolError(err).Code)
+	assert.Contains(t, AsToolError(err).Message, "C0000000BAD")
+}
+
+func TestUnitCheckWriteChannel(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "")
+	assert.NoError(t, checkWriteChannel("conversations_mark", "C1"), "user state changes are enabled when SLACK_MCP_ADD_MESSAGE_TOOL is unset")
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "C1")
+	assert.NoError(t, checkWriteChannel("conversations_mark", "C1"))
+	err := checkWriteChannel("conversations_mark", "C2")
+	require.Error(t, err)
+	assert.Equal(t, ErrCodeChannelNotAllowed, AsToolError(err).Code)
+}
@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// defaultMaxUploadBytes is used when SLACK_MCP_MAX_UPLOAD_BYTES is unset or invalid
+const defaultMaxUploadBytes = 10 << 20
+
+// Statuses of a conversations_post_with_file result, uploaded means the file was uploaded but the
+// message failed, the file is then not shared anywhere yet
+const (
+	PostWithFileStatusPosted   = "posted"
+	PostWithFileStatusUploaded = "uploaded"
+)
+
+type PostWithFileResult struct {
+	Status    string `json:"status"`
+	ChannelID string `json:"channelID"`
+	Ts        string `json:"ts"`
+	FileID    string `json:"fileID"`
+	FileName  string `json:"fileName"`
+	Permalink string `json:"permalink"`
+	ErrorCode string `json:"errorCode"`
+	Error     string `json:"error"`
+}
+
+type postWithFileParams struct {
+	channel  string
+	threadTs string
+	text     string
+	filename string
+	title    string
+	content  []byte
+}
+
+// fileUploader is the part of the Slack API conversations_post_with_file calls
+type fileUploader interface {
+	UploadFileV2Context(ctx context.Context, params slack.UploadFileV2Parameters) (*slack.FileSummary, error)
+	GetFileInfoContext(ctx context.Context, fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error)
+	PostMessageContext(ctx context.Context, channel string, options ...slack.MsgOption) (string, string, error)
+}
+
+// ConversationsPostWithFileHandler uploads a file and posts a message with it to a channel in one call. The file
+// is uploaded without being shared, then the message links its permalink so that Slack attaches it. When the
+// upload succeeds but the message fails, the result still carries the file so the caller can link it later.
+func (ch *ConversationsHandler) ConversationsPostWithFileHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsPostWithFileHandler called", zap.String("channel_id", request.GetString("channel_id", "")))
+
+	if os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL") == "" {
+		return nil, capabilityUnsupportedError("conversations_post_with_file tool is disabled by default, set SLACK_MCP_ADD_MESSAGE_TOOL to true, 1, or a comma separated list of channels to enable it")
+	}
+
+	params, err := ch.parseParamsToolPostWithFile(request)
+	if err != nil {
+		logger.Error("Failed to parse post-with-file params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+	if err := checkWritePolicy("conversations_post_with_file", params.channel); err != nil {
+		return nil, err
+	}
+	if params.threadTs != "" {
+		if err := ch.checkThreadExists(ctx, params.channel, params.threadTs); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Debug("Uploading file",
+		zap.String("channel", params.channel),
+		zap.String("filename", params.filename),
+		zap.Int("size", len(params.content)),
+	)
+	result, err := postWithFile(ctx, ch.apiProvider.Slack(), params)
+	if err != nil {
+		logger.Error("Slack UploadFileV2Context failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, err
+	}
+	if result.Status != PostWithFileStatusPosted {
+		logger.Warn("File uploaded but the message failed",
+			zap.String("channel", params.channel),
+			zap.String("file_id", result.FileID),
+			zap.String("error", result.Error),
+		)
+	}
+
+	return marshalCSVResult([]PostWithFileResult{result})
+}
+
+// postWithFile uploads the file and posts the message linking it. An error is returned only when the upload
+// itself fails, later failures are reported in the result next to the uploaded file.
+func postWithFile(ctx context.Context, api fileUploader, params *postWithFileParams) (PostWithFileResult, error) {
+	file, err := api.UploadFileV2Context(ctx, slack.UploadFileV2Parameters{
+		Reader:   bytes.NewReader(params.content),
+		FileSize: len(params.content),
+		Filename: params.filename,
+		Title:    params.title,
+	})
+	if err != nil {
+		return PostWithFileResult{}, slackAPIError(err)
+	}
+
+	result := PostWithFileResult{
+		Status:    PostWithFileStatusUploaded,
+		ChannelID: params.channel,
+		FileID:    file.ID,
+		FileName:  params.filename,
+	}
+	partial := func(err error) (PostWithFileResult, error) {
+		te := AsToolError(channelAPIError("conversations_post_with_file", params.channel, err))
+		result.ErrorCode, result.Error = te.Code, te.Message
+		return result, nil
+	}
+
+	info, _, _, err := api.GetFileInfoContext(ctx, file.ID, 0, 0)
+	if err != nil {
+		return partial(err)
+	}
+	result.Permalink = info.Permalink
+
+	msgText := info.Permalink
+	if params.text != "" {
+		msgText = params.text + "\n" + info.Permalink
+	}
+	options := []slack.MsgOption{slack.MsgOptionText(msgText, false)}
+	if params.threadTs != "" {
+		options = append(options, slack.MsgOptionTS(params.threadTs))
+	}
+	_, ts, err := api.PostMessageContext(ctx, params.channel, options...)
+	if err != nil {
+		return partial(err)
+	}
+
+	result.Status = PostWithFileStatusPosted
+	result.Ts = ts
+	return result, nil
+}
+
+// maxUploadBytes returns the largest file conversations_post_with_file uploads, see SLACK_MCP_MAX_UPLOAD_BYTES
+func maxUploadBytes() int {
+	n, err := strconv.Atoi(os.Getenv("SLACK_MCP_MAX_UPLOAD_BYTES"))
+	if err != nil || n <= 0 {
+		return defaultMaxUploadBytes
+	}
+	return n
+}
+
+func (ch *ConversationsHandler) parseParamsToolPostWithFile(request mcp.CallToolRequest) (*postWithFileParams, error) {
+	channel := strings.TrimSpace(request.GetString("channel_id", ""))
+	if channel == "" {
+		return nil, errors.New("channel_id must be a channel ID such as C1234567890, or a #channel or @user name")
+	}
+	if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
+		if err := channelNameReady(ch.apiProvider, channel); err != nil {
+			return nil, err
+		}
+		id, err := lookupChannelID(ch.apiProvider, channel, request.GetString("team_id", ""))
+		if errors.Is(err, provider.ErrNameNotFound) {
+			return nil, notFoundError("channel %q not found", channel)
+		}
+		if err != nil {
+			return nil, err
+		}
+		channel = id
+	}
+
+	threadTs := request.GetString("thread_ts", "")
+	if threadTs != "" && !strings.Contains(threadTs, ".") {
+		return nil, errors.New("thread_ts must be a valid timestamp in format 1234567890.123456")
+	}
+
+	filename := strings.TrimSpace(request.GetString("filename", ""))
+	if filename == "" {
+		return nil, errors.New("filename must be a file name such as report.csv")
+	}
+
+	content, err := parseUploadContent(request.GetString("content", ""), request.GetString("content_base64", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	return &postWithFileParams{
+		channel:  channel,
+		threadTs: threadTs,
+		text:     escapeMessageText(strings.TrimRightFunc(request.GetString("payload", ""), unicode.IsSpace), false),
+		filename: filename,
+		title:    strings.TrimSpace(request.GetString("title", "")),
+		content:  content,
+	}, nil
+}
+
+// parseUploadContent returns the file content given as text or base64, exactly one of them is required
+// and the content must not be larger than SLACK_MCP_MAX_UPLOAD_BYTES
+func parseUploadContent(text, encoded string) ([]byte, error) {
+	var content []byte
+	switch {
+	case text != "" && encoded != "":
+		return nil, errors.New("content and content_base64 are mutually exclusive")
+	case text != "":
+		content = []byte(text)
+	case encoded != "":
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("content_base64 must be standard base64: %v", err)
+		}
+		content = decoded
+	default:
+		return nil, errors.New("either content or content_base64 must be set")
+	}
+
+	if max := maxUploadBytes(); len(content) > max {
+		return nil, fmt.Errorf("the file is %d bytes, at most %d bytes can be uploaded (SLACK_MCP_MAX_UPLOAD_BYTES)", len(content), max)
+	}
+	return content, nil
+}
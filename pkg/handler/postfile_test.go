@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFileUploader struct {
+	uploadErr error
+	infoErr   error
+	postErr   error
+
+	uploaded string
+	posted   []slack.MsgOption
+}
+
+func (f *fakeFileUploader) UploadFileV2Context(ctx context.Context, params slack.UploadFileV2Parameters) (*slack.FileSummary, error) {
+	if f.uploadErr != nil {
+		return nil, f.uploadErr
+	}
+	content, _ := io.ReadAll(params.Reader)
+	f.uploaded = string(content)
+	return &slack.FileSummary{ID: "F0REPORT", Title: params.Title}, nil
+}
+
+func (f *fakeFileUploader) GetFileInfoContext(ctx context.Context, fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error) {
+	if f.infoErr != nil {
+		return nil, nil, nil, f.infoErr
+	}
+	return &slack.File{ID: fileID, Permalink: "https://example.slack.com/files/U1/F0REPORT/report.csv"}, nil, nil, nil
+}
+
+func (f *fakeFileUploader) PostMessageContext(ctx context.Context, channel string, options ...slack.MsgOption) (string, string, error) {
+	if f.postErr != nil {
+		return "", "", f.postErr
+	}
+	f.posted = options
+	return channel, "1700000000.000100", nil
+}
+
+func TestUnitPostWithFile(t *testing.T) {
+	params := &postWithFileParams{channel: "C0GENERAL", text: "Weekly report", filename: "report.csv", content: []byte("a,b\n1,2\n")}
+
+	api := &fakeFileUploader{}
+	result, err := postWithFile(context.Background(), api, params)
+	require.NoError(t, err)
+	assert.Equal(t, PostWithFileResult{
+		Status:    PostWithFileStatusPosted,
+		ChannelID: "C0GENERAL",
+		Ts:        "1700000000.000100",
+		FileID:    "F0REPORT",
+		FileName:  "report.csv",
+		Permalink: "https://example.slack.com/files/U1/F0REPORT/report.csv",
+	}, result)
+	assert.Equal(t, "a,b\n1,2\n", api.uploaded)
+
+	_, values, err := slack.UnsafeApplyMsgOptions("", "C0GENERAL", "", api.posted...)
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly report\nhttps://example.slack.com/files/U1/F0REPORT/report.csv", values.Get("text"))
+
+	// the upload failing fails the call, nothing was created
+	_, err = postWithFile(context.Background(), &fakeFileUploader{uploadErr: slack.SlackErrorResponse{Err: "invalid_auth"}}, params)
+	require.Error(t, err)
+
+	// the message failing returns the uploaded file
+	result, err = postWithFile(context.Background(), &fakeFileUploader{postErr: slack.SlackErrorResponse{Err: "is_archived"}}, params)
+	require.NoError(t, err)
+	assert.Equal(t, PostWithFileStatusUploaded, result.Status)
+	assert.Equal(t, "F0REPORT", result.FileID)
+	assert.NotEmpty(t, result.Permalink)
+	assert.Empty(t, result.Ts)
+	assert.Equal(t, ErrCodeChannelArchived, result.ErrorCode)
+	assert.Contains(t, result.Error, "archived")
+}
+
+func TestUnitParseUploadContent(t *testing.T) {
+	t.Setenv("SLACK_MCP_MAX_UPLOAD_BYTES", "8")
+
+	content, err := parseUploadContent("hello", "")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), content)
+
+	content, err = parseUploadContent("", base64.StdEncoding.EncodeToString([]byte{0x89, 'P', 'N', 'G'}))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x89, 'P', 'N', 'G'}, content)
+
+	_, err = parseUploadContent("hello", "aGVsbG8=")
+	assert.ErrorContains(t, err, "mutually exclusive")
+
+	_, err = parseUploadContent("", "")
+	assert.Error(t, err)
+
+	_, err = parseUploadContent("", "not base64!")
+	assert.ErrorContains(t, err, "base64")
+
+	_, err = parseUploadContent(strings.Repeat("x", 9), "")
+	assert.ErrorContains(t, err, "SLACK_MCP_MAX_UPLOAD_BYTES")
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// notificationLevels are the desktop and mobile notification levels of a channel, default follows the
+// global preference of the user
+var notificationLevels = []string{"everything", "mention", "nothing", "default"}
+
+type ChannelPrefs struct {
+	ChannelID   string `json:"channelID"`
+	ChannelName string `json:"channelName"`
+	Muted       bool   `json:"muted"`
+	Desktop     string `json:"desktop"`
+	Mobile      string `json:"mobile"`
+}
+
+type setPrefsParams struct {
+	channel string
+	muted   *bool
+	desktop string
+	mobile  string
+}
+
+// ConversationsGetPrefsHandler returns the mute state and notification levels of a channel for the
+// authenticated user as CSV
+func (ch *ConversationsHandler) ConversationsGetPrefsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsGetPrefsHandler called", zap.Any("params", request.Params))
+
+	channel, err := ch.paramReadableChannel(request, "conversations_get_prefs")
+	if err != nil {
+		return nil, invalidArgumentError(err)
+	}
+	if err := requireBrowserSession(ch.apiProvider, logger, "conversations_get_prefs"); err != nil {
+		return nil, err
+	}
+
+	return ch.channelPrefsResult(ctx, channel)
+}
+
+// ConversationsSetPrefsHandler mutes or unmutes a channel and changes its notification levels for the
+// authenticated user, then returns the resulting preferences as CSV
+func (ch *ConversationsHandler) ConversationsSetPrefsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsSetPrefsHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolSetPrefs(request)
+	if err != nil {
+		logger.Error("Failed to parse set-prefs params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+	if err := requireBrowserSession(ch.apiProvider, logger, "conversations_set_prefs"); err != nil {
+		return nil, err
+	}
+
+	if err := ch.apiProvider.Slack().UsersPrefsSetChannelNotifications(ctx, params.channel, params.muted, params.desktop, params.mobile); err != nil {
+		logger.Error("Slack UsersPrefsSetChannelNotifications failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, channelAPIError("conversations_set_prefs", params.channel, err)
+	}
+
+	return ch.channelPrefsResult(ctx, params.channel)
+}
+
+// channelPrefsResult reads the preferences of channel back from Slack
+func (ch *ConversationsHandler) channelPrefsResult(ctx context.Context, channel string) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	prefs, err := ch.apiProvider.Slack().UsersPrefsGet(ctx)
+	if err != nil {
+		logger.Error("Slack UsersPrefsGet failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	channelName := channel
+	if c, ok := ch.apiProvider.ProvideChannelsMaps().Channels[channel]; ok {
+		channelName = c.Name
+	}
+	return marshalCSVResult([]ChannelPrefs{channelPrefs(prefs, channel, channelName)})
+}
+
+// channelPrefs returns the preferences of a channel, levels Slack doesn't store are default
+func channelPrefs(prefs edge.NotificationPrefs, channel, channelName string) ChannelPrefs {
+	p := prefs.Channels[channel]
+	row := ChannelPrefs{
+		ChannelID:   channel,
+		ChannelName: channelName,
+		Muted:       p.Muted,
+		Desktop:     p.Desktop,
+		Mobile:      p.Mobile,
+	}
+	if row.Desktop == "" {
+		row.Desktop = "default"
+	}
+	if row.Mobile == "" {
+		row.Mobile = "default"
+	}
+	return row
+}
+
+// parseParamsToolSetPrefs reads the channel and the preferences to change, at least one of them
+func (ch *ConversationsHandler) parseParamsToolSetPrefs(request mcp.CallToolRequest) (*setPrefsParams, error) {
+	channel, err := paramChannelID(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWriteChannel("conversations_set_prefs", channel); err != nil {
+		ch.logger.Warn("conversations_set_prefs is not allowed for channel by policy", zap.String("channel", channel))
+		return nil, err
+	}
+
+	params := &setPrefsParams{channel: channel}
+	if _, ok := request.GetArguments()["muted"]; ok {
+		muted := request.GetBool("muted", false)
+		params.muted = &muted
+	}
+	if params.desktop, err = paramNotificationLevel(request, "desktop"); err != nil {
+		return nil, err
+	}
+	if params.mobile, err = paramNotificationLevel(request, "mobile"); err != nil {
+		return nil, err
+	}
+	if params.muted == nil && params.desktop == "" && params.mobile == "" {
+		return nil, errors.New("at least one of muted, desktop or mobile must be set")
+	}
+	return params, nil
+}
+
+// paramNotificationLevel reads one of notificationLevels, empty when the argument is not set
+func paramNotificationLevel(request mcp.CallToolRequest, name string) (string, error) {
+	level := strings.ToLower(strings.TrimSpace(request.GetString(name, "")))
+	if level == "" {
+		return "", nil
+	}
+	for _, l := range notificationLevels {
+		if level == l {
+			return level, nil
+		}
+	}
+	return "", fmt.Errorf("%s must be one of %s, got %q", name, strings.Join(notificationLevels, ", "), level)
+}
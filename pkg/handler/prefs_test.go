@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitParseParamsToolSetPrefs(t *testing.T) {
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+	request := func(args map[string]any) mcp.CallToolRequest {
+		var r mcp.CallToolRequest
+		r.Params.Arguments = args
+		return r
+	}
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "")
+	params, err := ch.parseParamsToolSetPrefs(request(map[string]any{"channel_id": "C1", "muted": false, "desktop": "Mention"}))
+	require.NoError(t, err)
+	require.NotNil(t, params.muted, "muted=false is an explicit unmute")
+	assert.False(t, *params.muted)
+	assert.Equal(t, "mention", params.desktop)
+	assert.Empty(t, params.mobile)
+
+	_, err = ch.parseParamsToolSetPrefs(request(map[string]any{"channel_id": "C1"}))
+	assert.ErrorContains(t, err, "at least one of")
+
+	_, err = ch.parseParamsToolSetPrefs(request(map[string]any{"channel_id": "C1", "mobile": "loud"}))
+	assert.ErrorContains(t, err, "mobile must be one of")
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "!C1")
+	_, err = ch.parseParamsToolSetPrefs(request(map[string]any{"channel_id": "C1", "muted": true}))
+	assert.Equal(t, ErrCodeChannelNotAllowed, AsToolError(err).Code)
+}
+
+func TestUnitChannelPrefs(t *testing.T) {
+	prefs := edge.NotificationPrefs{Channels: map[string]edge.ChannelNotificationPrefs{
+		"C1": {Muted: true, Desktop: "nothing"},
+	}}
+
+	assert.Equal(t, ChannelPrefs{ChannelID: "C1", ChannelName: "#noisy", Muted: true, Desktop: "nothing", Mobile: "default"},
+		channelPrefs(prefs, "C1", "#noisy"))
+	assert.Equal(t, ChannelPrefs{ChannelID: "C2", ChannelName: "C2", Desktop: "default", Mobile: "default"},
+		channelPrefs(prefs, "C2", "C2"), "channels without stored preferences follow the global ones")
+}
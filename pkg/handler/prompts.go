@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// defaultPromptLookback is the window summarize_channel covers when none is given
+const defaultPromptLookback = "1d"
+
+// PromptsHandler expands the MCP prompt templates, each one a ready-made request for a common task
+// that names the tools to call with the arguments filled in
+type PromptsHandler struct {
+	apiProvider *provider.ApiProvider
+	toolEnabled func(name string) bool
+	logger      *zap.Logger
+}
+
+// NewPromptsHandler creates the prompts handler, toolEnabled reports whether a tool is registered and callable,
+// e.g. not left out by SLACK_MCP_ENABLED_TOOLS or disabled by SLACK_MCP_READ_ONLY
+func NewPromptsHandler(apiProvider *provider.ApiProvider, toolEnabled func(name string) bool, logger *zap.Logger) *PromptsHandler {
+	return &PromptsHandler{
+		apiProvider: apiProvider,
+		toolEnabled: toolEnabled,
+		logger:      logger,
+	}
+}
+
+// promptChannel is a channel argument checked against the channels cache
+type promptChannel struct {
+	ID   string
+	Name string
+}
+
+// label names the channel the way the prompt text refers to it, e.g. #general (C1234567890)
+func (c promptChannel) label() string {
+	if c.Name == "" || c.Name == c.ID {
+		return c.ID
+	}
+	return fmt.Sprintf("%s (%s)", c.Name, c.ID)
+}
+
+// SummarizeChannelPrompt expands summarize_channel, a summary of the recent activity of a channel
+func (ph *PromptsHandler) SummarizeChannelPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	logger := requestid.Logger(ctx, ph.logger)
+	logger.Debug("SummarizeChannelPrompt called", zap.Any("params", request.Params))
+
+	channel, err := ph.channelArgument(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	lookback, err := parsePromptLookback(request.Params.Arguments["lookback"])
+	if err != nil {
+		return nil, invalidArgumentError(err)
+	}
+
+	return promptResult("Summary of the recent activity of "+channel.label(), summarizeChannelText(channel, lookback)), nil
+}
+
+// SummarizeThreadPrompt expands summarize_thread, a summary of one thread with its decisions and open questions
+func (ph *PromptsHandler) SummarizeThreadPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	logger := requestid.Logger(ctx, ph.logger)
+	logger.Debug("SummarizeThreadPrompt called", zap.Any("params", request.Params))
+
+	channel, err := ph.channelArgument(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	threadTs, err := threadArgument(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return promptResult("Summary of a thread in "+channel.label(), summarizeThreadText(channel, threadTs)), nil
+}
+
+// DraftThreadReplyPrompt expands draft_thread_reply, a reply to a thread drafted for review and not posted
+func (ph *PromptsHandler) DraftThreadReplyPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	logger := requestid.Logger(ctx, ph.logger)
+	logger.Debug("DraftThreadReplyPrompt called", zap.Any("params", request.Params))
+
+	channel, err := ph.channelArgument(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	threadTs, err := threadArgument(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// the draft is only offered for posting when conversations_add_message could post it to the channel
+	canPost := ph.toolEnabled("conversations_add_message") && checkWritePolicy("conversations_add_message", channel.ID) == nil
+	return promptResult("Draft reply to a thread in "+channel.label(), draftThreadReplyText(channel, threadTs, strings.TrimSpace(request.Params.Arguments["intent"]), canPost)), nil
+}
+
+// channelArgument reads the channel argument, an ID or a #name, and checks that the channels cache knows it
+// and SLACK_MCP_READ_CHANNELS allows it. Channels missing from the cache are looked up as selected by
+// SLACK_MCP_CACHE_MISS_POLICY.
+func (ph *PromptsHandler) channelArgument(ctx context.Context, request mcp.GetPromptRequest) (promptChannel, error) {
+	logger := requestid.Logger(ctx, ph.logger)
+	// mark3labs/mcp-go does not support middlewares for prompts.
+	if authenticated, err := auth.IsAuthenticated(ctx, ph.apiProvider.ServerTransport(), logger); !authenticated {
+		logger.Error("Authentication failed for prompt", zap.String("prompt", request.Params.Name), zap.Error(err))
+		return promptChannel{}, err
+	}
+
+	raw := strings.TrimSpace(request.Params.Arguments["channel"])
+	if raw == "" {
+		return promptChannel{}, invalidArgumentError(errors.New("channel is required, e.g. C1234567890 or #general"))
+	}
+	if ready, err := ph.apiProvider.CollectionReady(provider.RefreshChannels); !ready {
+		logger.Warn("Slack channels sync is not ready yet, prompt can't check its channel", zap.Error(err))
+		return promptChannel{}, cacheNotReadyError(err)
+	}
+
+	id := raw
+	if strings.HasPrefix(raw, "#") || strings.HasPrefix(raw, "@") {
+		var err error
+		id, err = lookupChannelID(ph.apiProvider, raw, "")
+		if errors.Is(err, provider.ErrNameNotFound) {
+			return promptChannel{}, notFoundError("channel %q not found in synced cache", raw)
+		}
+		if err != nil {
+			return promptChannel{}, err
+		}
+	} else if idKind(strings.ToUpper(raw)) != ResolvedKindChannel {
+		return promptChannel{}, invalidArgumentError(fmt.Errorf("channel must be a channel ID such as C1234567890 or a name such as #general, got %q", raw))
+	} else {
+		id = strings.ToUpper(raw)
+		if err := ph.apiProvider.ResolveChannels(ctx, []string{id}); err != nil {
+			return promptChannel{}, slackAPIError(err)
+		}
+	}
+
+	if err := checkReadPolicy(logger, request.Params.Name+" prompt", id); err != nil {
+		return promptChannel{}, err
+	}
+	cached, ok := ph.apiProvider.ProvideChannelsMaps().Channels[id]
+	if !ok {
+		return promptChannel{}, notFoundError("channel %q not found in synced cache", raw)
+	}
+	return promptChannel{ID: id, Name: cached.Name}, nil
+}
+
+// parsePromptLookback reads a window of whole days or weeks such as 3d or 2w, the only windows both
+// conversations_summary and conversations_history accept, empty means defaultPromptLookback
+func parsePromptLookback(raw string) (string, error) {
+	lookback := strings.TrimSpace(raw)
+	if lookback == "" {
+		return defaultPromptLookback, nil
+	}
+	if _, err := parseLookbackDuration(lookback); err != nil || !strings.HasSuffix(lookback, "d") && !strings.HasSuffix(lookback, "w") {
+		return "", fmt.Errorf("lookback must be a number of days or weeks such as 1d, 3d or 2w, got %q", lookback)
+	}
+	return lookback, nil
+}
+
+// threadArgument reads the thread_ts argument, the timestamp of the thread parent
+func threadArgument(request mcp.GetPromptRequest) (string, error) {
+	threadTs := strings.TrimSpace(request.Params.Arguments["thread_ts"])
+	if !isMessageTimestamp(threadTs) {
+		return "", invalidArgumentError(fmt.Errorf("thread_ts must be a message timestamp in format 1234567890.123456, got %q", threadTs))
+	}
+	return threadTs, nil
+}
+
+func promptResult(description, text string) *mcp.GetPromptResult {
+	return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	})
+}
+
+func summarizeChannelText(channel promptChannel, lookback string) string {
+	return fmt.Sprintf(`Summarize what happened in the Slack channel %[1]s over the last %[2]s.
+
+1. Call conversations_summary with channel_id=%[3]s and lookback=%[2]s for the message counts, the most active people and threads.
+2. Call conversations_history with channel_id=%[3]s and limit=%[2]s to read the messages themselves, and conversations_replies for threads that matter.
+3. Write a short summary grouped by topic. List decisions made, open questions and action items with their owners.`,
+		channel.label(), lookback, channel.ID)
+}
+
+func summarizeThreadText(channel promptChannel, threadTs string) string {
+	return fmt.Sprintf(`Summarize the Slack thread %[2]s in %[1]s.
+
+1. Call conversations_replies with channel_id=%[3]s and thread_ts=%[2]s to read the whole thread.
+2. Write a short summary of the discussion: what was asked, the answers and decisions, who is involved, and what is still open.`,
+		channel.label(), threadTs, channel.ID)
+}
+
+func draftThreadReplyText(channel promptChannel, threadTs, intent string, canPost bool) string {
+	text := fmt.Sprintf(`Draft a reply to the Slack thread %[2]s in %[1]s.
+
+1. Call conversations_replies with channel_id=%[3]s and thread_ts=%[2]s to read the whole thread.
+2. Draft a reply that fits the tone of the thread and answers what is still open.
+`, channel.label(), threadTs, channel.ID)
+	if canPost {
+		text += fmt.Sprintf("3. Show the draft for review and don't post it. Only once it is approved, post it with conversations_add_message, channel_id=%s and thread_ts=%s.", channel.ID, threadTs)
+	} else {
+		text += "3. Show the draft for review. Posting is not enabled on this server, so don't try to post it: the user copies it into Slack."
+	}
+	if intent != "" {
+		text += "\n\nThe reply should: " + intent
+	}
+	return text
+}
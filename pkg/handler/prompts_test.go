@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitParsePromptLookback(t *testing.T) {
+	lookback, err := parsePromptLookback("")
+	require.NoError(t, err)
+	assert.Equal(t, "1d", lookback)
+
+	lookback, err = parsePromptLookback(" 2w ")
+	require.NoError(t, err)
+	assert.Equal(t, "2w", lookback)
+
+	for _, raw := range []string{"12h", "0d", "-3d", "week"} {
+		_, err := parsePromptLookback(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestUnitThreadArgument(t *testing.T) {
+	request := mcp.GetPromptRequest{}
+	request.Params.Arguments = map[string]string{"thread_ts": " 1700000000.000100 "}
+	threadTs, err := threadArgument(request)
+	require.NoError(t, err)
+	assert.Equal(t, "1700000000.000100", threadTs)
+
+	request.Params.Arguments = map[string]string{}
+	_, err = threadArgument(request)
+	assert.Equal(t, ErrCodeInvalidArgument, AsToolError(err).Code)
+}
+
+func TestUnitPromptTexts(t *testing.T) {
+	general := promptChannel{ID: "C0GENERAL", Name: "#general"}
+	assert.Equal(t, "#general (C0GENERAL)", general.label())
+	assert.Equal(t, "D0DM", promptChannel{ID: "D0DM"}.label())
+
+	text := summarizeChannelText(general, "3d")
+	assert.Contains(t, text, "#general (C0GENERAL) over the last 3d")
+	assert.Contains(t, text, "conversations_summary with channel_id=C0GENERAL and lookback=3d")
+	assert.Contains(t, text, "conversations_history with channel_id=C0GENERAL and limit=3d")
+
+	text = summarizeThreadText(general, "1700000000.000100")
+	assert.Contains(t, text, "conversations_replies with channel_id=C0GENERAL and thread_ts=1700000000.000100")
+
+	text = draftThreadReplyText(general, "1700000000.000100", "", true)
+	assert.Contains(t, text, "don't post it")
+	assert.Contains(t, text, "conversations_add_message, channel_id=C0GENERAL and thread_ts=1700000000.000100")
+	assert.NotContains(t, text, "The reply should")
+
+	text = draftThreadReplyText(general, "1700000000.000100", "propose Friday", true)
+	assert.Contains(t, text, "The reply should: propose Friday")
+
+	text = draftThreadReplyText(general, "1700000000.000100", "", false)
+	assert.NotContains(t, text, "conversations_add_message")
+	assert.Contains(t, text, "Posting is not enabled")
+}
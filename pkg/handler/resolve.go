@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// maxResolveIDs caps the IDs resolve_ids accepts per call, lookups of cache misses are bounded by the provider
+const maxResolveIDs = 200
+
+// Kinds and statuses of a resolve_ids row
+const (
+	ResolvedKindUser    = "user"
+	ResolvedKindChannel = "channel"
+	ResolvedKindUnknown = "unknown"
+
+	ResolvedFound    = "found"
+	ResolvedNotFound = "not_found"
+	// ResolvedInvalid marks an input that is neither a user nor a channel ID
+	ResolvedInvalid = "invalid"
+)
+
+// ResolvedID is a resolve_ids row, Name is the user handle or the channel name with its # or @ sigil
+type ResolvedID struct {
+	ID       string `json:"id"`
+	Kind     string `json:"kind"`
+	Status   string `json:"status"`
+	Name     string `json:"name"`
+	RealName string `json:"realName"`
+	IsBot    bool   `json:"isBot"`
+	Deleted  bool   `json:"deleted"`
+	Private  bool   `json:"private"`
+	Archived bool   `json:"archived"`
+}
+
+// ResolveIDsHandler resolves a mixed list of user and channel IDs in one call. IDs are served from the
+// caches and misses are looked up like SLACK_MCP_CACHE_MISS_POLICY=fetch would, an ID that can't be
+// resolved gets a not_found row instead of failing the call.
+func (ch *ChannelsHandler) ResolveIDsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ResolveIDsHandler called", zap.Any("params", request.Params))
+
+	ids := parseIDList(request.GetString("ids", ""))
+	if len(ids) == 0 {
+		return nil, invalidArgumentError(errors.New("ids must be a comma-separated list of user and channel IDs, e.g. 'U0123ABCD,C0123ABCD'"))
+	}
+	if len(ids) > maxResolveIDs {
+		return nil, invalidArgumentError(fmt.Errorf("ids accepts at most %d IDs per call, got %d", maxResolveIDs, len(ids)))
+	}
+
+	var users, channels []string
+	for _, id := range ids {
+		switch idKind(id) {
+		case ResolvedKindUser:
+			users = append(users, id)
+		case ResolvedKindChannel:
+			channels = append(channels, id)
+		}
+	}
+
+	// misses stay unresolved when the policy refuses to fetch them, they are reported per ID below
+	if err := ch.apiProvider.ResolveUsers(ctx, users); err != nil && !errors.Is(err, provider.ErrCacheMiss) {
+		logger.Warn("Failed to resolve users", zap.Error(err))
+	}
+	if err := ch.apiProvider.ResolveChannels(ctx, channels); err != nil && !errors.Is(err, provider.ErrCacheMiss) {
+		logger.Warn("Failed to resolve channels", zap.Error(err))
+	}
+
+	rows := resolveIDs(ids, ch.apiProvider.ProvideUsersMap().Users, ch.apiProvider.ProvideChannelsMaps().Channels)
+	logger.Debug("Resolved IDs", zap.Int("ids", len(ids)), zap.Int("users", len(users)), zap.Int("channels", len(channels)))
+
+	return marshalCSVResult(rows)
+}
+
+// parseIDList splits IDs separated by commas or whitespace, accepts Slack markup such as <@U0123ABCD> or
+// <#C0123ABCD|general> and drops duplicates keeping the first occurrence
+func parseIDList(raw string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, field := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		id, _, _ := strings.Cut(strings.Trim(field, "<>@#!"), "|")
+		id = strings.ToUpper(id)
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// idKind tells users (U, W) from channels (C, G, D) by the prefix of their ID
+func idKind(id string) string {
+	if len(id) < 2 {
+		return ResolvedKindUnknown
+	}
+	switch id[0] {
+	case 'U', 'W':
+		return ResolvedKindUser
+	case 'C', 'G', 'D':
+		return ResolvedKindChannel
+	}
+	return ResolvedKindUnknown
+}
+
+// resolveIDs returns one row per ID in input order
+func resolveIDs(ids []string, users map[string]slack.User, channels map[string]provider.Channel) []ResolvedID {
+	rows := make([]ResolvedID, 0, len(ids))
+	for _, id := range ids {
+		row := ResolvedID{ID: id, Kind: idKind(id), Status: ResolvedNotFound}
+		switch row.Kind {
+		case ResolvedKindUser:
+			if u, ok := users[id]; ok {
+				row.Status = ResolvedFound
+				row.Name = u.Name
+				row.RealName = u.RealName
+				row.IsBot = u.IsBot
+				row.Deleted = u.Deleted
+			}
+		case ResolvedKindChannel:
+			if c, ok := channels[id]; ok {
+				row.Status = ResolvedFound
+				row.Name = c.Name
+				row.Private = c.IsPrivate
+				row.Archived = c.IsArchived
+			}
+		default:
+			row.Status = ResolvedInvalid
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitParseIDList(t *testing.T) {
+	assert.Equal(t,
+		[]string{"U0123ABCD", "C0123ABCD", "W0123ABCD", "D0123ABCD"},
+		parseIDList(" U0123ABCD, <#C0123ABCD|general>\n<@w0123abcd> U0123ABCD,,D0123ABCD "),
+	)
+	assert.Empty(t, parseIDList(" , "))
+}
+
+func TestUnitResolveIDs(t *testing.T) {
+	users := map[string]slack.User{
+		"U0123ABCD": {ID: "U0123ABCD", Name: "alice", RealName: "Alice Doe"},
+		"W0123BOT0": {ID: "W0123BOT0", Name: "deploybot", IsBot: true},
+	}
+	channels := map[string]provider.Channel{
+		"C0123ABCD": {ID: "C0123ABCD", Name: "#general"},
+		"G0123ABCD": {ID: "G0123ABCD", Name: "#secret", IsPrivate: true, IsArchived: true},
+	}
+
+	rows := resolveIDs([]string{"U0123ABCD", "W0123BOT0", "G0123ABCD", "C9999ZZZZ", "U9999ZZZZ", "T0123ABCD", "C0123ABCD"}, users, channels)
+	assert.Equal(t, []ResolvedID{
+		{ID: "U0123ABCD", Kind: ResolvedKindUser, Status: ResolvedFound, Name: "alice", RealName: "Alice Doe"},
+		{ID: "W0123BOT0", Kind: ResolvedKindUser, Status: ResolvedFound, Name: "deploybot", IsBot: true},
+		{ID: "G0123ABCD", Kind: ResolvedKindChannel, Status: ResolvedFound, Name: "#secret", Private: true, Archived: true},
+		{ID: "C9999ZZZZ", Kind: ResolvedKindChannel, Status: ResolvedNotFound},
+		{ID: "U9999ZZZZ", Kind: ResolvedKindUser, Status: ResolvedNotFound},
+		{ID: "T0123ABCD", Kind: ResolvedKindUnknown, Status: ResolvedInvalid},
+		{ID: "C0123ABCD", Kind: ResolvedKindChannel, Status: ResolvedFound, Name: "#general"},
+	}, rows)
+}
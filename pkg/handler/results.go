@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+
+	"github.com/gocarina/gocsv"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultMaxResults is used when SLACK_MCP_MAX_RESULTS is unset or invalid
+const defaultMaxResults = 1000
+
+// maxResults returns the global cap on rows returned by any list tool, see SLACK_MCP_MAX_RESULTS
+func maxResults() int {
+	n, err := strconv.Atoi(os.Getenv("SLACK_MCP_MAX_RESULTS"))
+	if err != nil || n <= 0 {
+		return defaultMaxResults
+	}
+	return n
+}
+
+// maxMessageChars returns the per-message text limit, see SLACK_MCP_MAX_MESSAGE_CHARS, 0 means unlimited
+func maxMessageChars() int {
+	n, err := strconv.Atoi(os.Getenv("SLACK_MCP_MAX_MESSAGE_CHARS"))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// clipMessageText cuts rendered message text to SLACK_MCP_MAX_MESSAGE_CHARS characters and
+// appends a marker with the original length, the bool reports whether text was cut
+func clipMessageText(text string) (string, bool) {
+	max := maxMessageChars()
+	if max == 0 {
+		return text, false
+	}
+
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text, false
+	}
+	return fmt.Sprintf("%s… [truncated, %d chars total]", string(runes[:max]), len(runes)), true
+}
+
+// capLimit lowers a requested page size to SLACK_MCP_MAX_RESULTS, so paged tools
+// fetch fewer items instead of truncating a page and losing its cursor
+func capLimit(limit int) int {
+	if max := maxResults(); limit > max {
+		return max
+	}
+	return limit
+}
+
+// truncateResults cuts items to SLACK_MCP_MAX_RESULTS and returns the count before truncation
+func truncateResults[T any](items []T) ([]T, int) {
+	total := len(items)
+	if max := maxResults(); total > max {
+		return items[:max], total
+	}
+	return items, total
+}
+
+// marshalCSVResult serializes rows as a CSV tool result. When rows exceed SLACK_MCP_MAX_RESULTS
+// they are truncated, the result _meta carries truncated and total, and a note follows the CSV.
+// A next page cursor on a truncated row is kept on the last returned row, so paging can go on.
+func marshalCSVResult[T any](rows []T) (*mcp.CallToolResult, error) {
+	all := rows
+	rows, total := truncateResults(rows)
+	cursor := ""
+	if total > len(rows) {
+		cursor = keepCursor(rows, all)
+	}
+
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	res := mcp.NewToolResultText(string(csvBytes))
+	if total > len(rows) {
+		res.Meta = map[string]any{
+			"truncated": true,
+			"total":     total,
+			"returned":  len(rows),
+		}
+		note := fmt.Sprintf(
+			"truncated: true, returned %d of %d results (SLACK_MCP_MAX_RESULTS=%d), narrow the request to see the rest",
+			len(rows), total, maxResults(),
+		)
+		if cursor != "" {
+			res.Meta["next_cursor"] = cursor
+			note += ", next cursor: " + cursor
+		}
+		res.Content = append(res.Content, mcp.NewTextContent(note))
+	}
+	return res, nil
+}
+
+// keepCursor moves the Cursor field of the last row of all, a truncated page, to the last of the kept rows and
+// returns it. Rows without a Cursor string field have nothing to keep.
+func keepCursor[T any](kept, all []T) string {
+	if len(kept) == 0 || len(all) == 0 {
+		return ""
+	}
+	last := reflect.ValueOf(&all[len(all)-1]).Elem()
+	if last.Kind() != reflect.Struct {
+		return ""
+	}
+	field := last.FieldByName("Cursor")
+	if !field.IsValid() || field.Kind() != reflect.String || field.String() == "" {
+		return ""
+	}
+	cursor := field.String()
+	reflect.ValueOf(&kept[len(kept)-1]).Elem().FieldByName("Cursor").SetString(cursor)
+	return cursor
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitMaxResults(t *testing.T) {
+	t.Setenv("SLACK_MCP_MAX_RESULTS", "")
+	assert.Equal(t, defaultMaxResults, maxResults())
+
+	t.Setenv("SLACK_MCP_MAX_RESULTS", "-5")
+	assert.Equal(t, defaultMaxResults, maxResults())
+
+	t.Setenv("SLACK_MCP_MAX_RESULTS", "10")
+	assert.Equal(t, 10, maxResults())
+	assert.Equal(t, 5, capLimit(5), "tools may request fewer")
+	assert.Equal(t, 10, capLimit(50), "but never more")
+	assert.Equal(t, 0, capLimit(0), "unset limits keep the Slack default")
+}
+
+func TestUnitMarshalCSVResult(t *testing.T) {
+	t.Setenv("SLACK_MCP_MAX_RESULTS", "2")
+
+	rows := []Bookmark{{BookmarkID: "Bk1"}, {BookmarkID: "Bk2"}, {BookmarkID: "Bk3"}}
+	res, err := marshalCSVResult(rows)
+	require.NoError(t, err)
+
+	require.Len(t, res.Content, 2)
+	csv := res.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, csv, "Bk2")
+	assert.NotContains(t, csv, "Bk3")
+	assert.Contains(t, res.Content[1].(mcp.TextContent).Text, "truncated: true, returned 2 of 3 results")
+	assert.Equal(t, map[string]any{"truncated": true, "total": 3, "returned": 2}, res.Meta)
+
+	res, err = marshalCSVResult(rows[:2])
+	require.NoError(t, err)
+	assert.Len(t, res.Content, 1)
+	assert.Nil(t, res.Meta)
+}
+
+func TestUnitMarshalCSVResultKeepsCursor(t *testing.T) {
+	t.Setenv("SLACK_MCP_MAX_RESULTS", "2")
+
+	rows := []Channel{{ID: "C1"}, {ID: "C2"}, {ID: "C3", Cursor: "next"}}
+	res, err := marshalCSVResult(rows)
+	require.NoError(t, err)
+
+	require.Len(t, res.Content, 2)
+	csv := res.Content[0].(mcp.TextContent).Text
+	assert.NotContains(t, csv, "C3")
+	assert.Contains(t, csv, "C2")
+	assert.Contains(t, csv, ",next", "the cursor moves to the last returned row")
+	assert.Contains(t, res.Content[1].(mcp.TextContent).Text, "next cursor: next")
+	assert.Equal(t, "next", res.Meta["next_cursor"])
+}
+
+func TestUnitClipMessageText(t *testing.T) {
+	t.Setenv("SLACK_MCP_MAX_MESSAGE_CHARS", "")
+	text, truncated := clipMessageText("a long message")
+	assert.Equal(t, "a long message", text)
+	assert.False(t, truncated)
+
+	t.Setenv("SLACK_MCP_MAX_MESSAGE_CHARS", "6")
+	text, truncated = clipMessageText("héllo wörld")
+	assert.Equal(t, "héllo … [truncated, 11 chars total]", text)
+	assert.True(t, truncated)
+
+	text, truncated = clipMessageText("short")
+	assert.Equal(t, "short", text)
+	assert.False(t, truncated)
+}
@@ -0,0 +1,384 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultSummaryLookback = 7 * 24 * time.Hour
+	defaultSummaryMessages = 500
+	// maxSummaryMessages bounds the history pages fetched for one summary
+	maxSummaryMessages = 1000
+	defaultSummaryTop  = 5
+	maxSummaryTop      = 20
+)
+
+// Sections of a conversations_summary result
+const (
+	SummarySectionTotals   = "totals"
+	SummarySectionUser     = "user"
+	SummarySectionReaction = "reaction"
+	SummarySectionThread   = "thread"
+	SummarySectionRecent   = "recent"
+)
+
+// SummaryRow is one line of a channel summary, the meaning of ID, Name, Count and Time depends on Section:
+// totals name a counter, user rows count messages per author, reaction rows count uses per emoji,
+// thread rows count replies of active threads and recent rows are the latest messages.
+type SummaryRow struct {
+	Section string `json:"section"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Count   int    `json:"count"`
+	Time    string `json:"time"`
+	Text    string `json:"text"`
+}
+
+type summaryParams struct {
+	channel     string
+	lookback    time.Duration
+	limit       int
+	top         int
+	render      bool
+	excludeBots bool
+}
+
+// channelSummary aggregates the messages of a channel, see summarizeMessages
+type channelSummary struct {
+	messages  int
+	reactions int
+	users     []summaryCount
+	emoji     []summaryCount
+	threads   []slack.Message
+	recent    []slack.Message
+	// authors maps the keys of users to the message they were taken from, for bot names
+	authors map[string]slack.Message
+	// botsExcluded counts the bot and app messages left out by exclude_bots
+	botsExcluded int
+}
+
+type summaryCount struct {
+	key   string
+	count int
+}
+
+// ConversationsSummaryHandler fetches the recent history of a channel and returns counts per user, top reactions,
+// active threads and the latest messages as CSV, so that clients don't have to aggregate raw history themselves.
+func (ch *ConversationsHandler) ConversationsSummaryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ConversationsSummaryHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolSummary(request)
+	if err != nil {
+		logger.Error("Failed to parse summary params", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	oldest := time.Now().Add(-params.lookback)
+	var (
+		msgs         []slack.Message
+		limitReached bool
+	)
+	err = readChannel(ctx, ch.apiProvider.Slack(), logger, "conversations_summary", params.channel, func() (err error) {
+		msgs, limitReached, err = ch.historyRange(ctx, params.channel, oldest, time.Time{}, params.limit)
+		return err
+	})
+	if err != nil {
+		logger.Error("GetConversationHistoryContext failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+	logger.Debug("Fetched history for summary", zap.Int("message_count", len(msgs)), zap.Bool("limit_reached", limitReached))
+
+	if err := resolveMessageReferences(ctx, ch.apiProvider, msgs); err != nil {
+		logger.Error("Failed to resolve message references", zap.Error(err))
+		return nil, err
+	}
+	var botsExcluded int
+	if params.excludeBots {
+		msgs, botsExcluded = dropBotMessages(msgs)
+	}
+	summary := summarizeMessages(msgs, params.top)
+	summary.botsExcluded = botsExcluded
+	return marshalCSVResult(ch.summaryRows(summary, oldest, params, limitReached))
+}
+
+// historyRange pages through the history of channel from oldest up to latest, a zero latest means up to now, at
+// most limit messages newest first. It also reports whether the limit left messages of the range unread.
+func (ch *ConversationsHandler) historyRange(ctx context.Context, channel string, oldest, latest time.Time, limit int) ([]slack.Message, bool, error) {
+	params := slack.GetConversationHistoryParameters{
+		ChannelID: channel,
+		Oldest:    fmt.Sprintf("%d.000000", oldest.Unix()),
+		Inclusive: true,
+	}
+	if !latest.IsZero() {
+		params.Latest = fmt.Sprintf("%d.000000", latest.Unix())
+	}
+
+	var msgs []slack.Message
+	for {
+		params.Limit = min(200, limit-len(msgs))
+		history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &params)
+		if err != nil {
+			return nil, false, err
+		}
+		msgs = append(msgs, history.Messages...)
+		if len(msgs) >= limit {
+			return msgs[:limit], history.HasMore || len(msgs) > limit, nil
+		}
+		if !history.HasMore || history.ResponseMetaData.NextCursor == "" {
+			return msgs, false, nil
+		}
+		params.Cursor = history.ResponseMetaData.NextCursor
+	}
+}
+
+// dropBotMessages returns msgs without the messages of bots and apps, and how many were dropped
+func dropBotMessages(msgs []slack.Message) ([]slack.Message, int) {
+	kept := make([]slack.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		if !isBotMessage(msg) {
+			kept = append(kept, msg)
+		}
+	}
+	return kept, len(msgs) - len(kept)
+}
+
+// summarizeMessages counts messages per author and reactions per emoji, and picks the threads with the latest
+// replies and the newest messages, top of each. Join, leave and other activity messages are not counted.
+func summarizeMessages(msgs []slack.Message, top int) *channelSummary {
+	summary := &channelSummary{authors: make(map[string]slack.Message)}
+	users := make(map[string]int)
+	emoji := make(map[string]int)
+
+	for _, msg := range msgs {
+		if msg.SubType != "" && msg.SubType != "bot_message" {
+			continue
+		}
+		summary.messages++
+
+		author := msg.User
+		if author == "" {
+			author = msg.BotID
+		}
+		if author != "" {
+			users[author]++
+			if _, ok := summary.authors[author]; !ok {
+				summary.authors[author] = msg
+			}
+		}
+
+		for _, reaction := range msg.Reactions {
+			emoji[reaction.Name] += reaction.Count
+			summary.reactions += reaction.Count
+		}
+
+		if msg.ReplyCount > 0 {
+			summary.threads = append(summary.threads, msg)
+		}
+		if len(summary.recent) < top {
+			summary.recent = append(summary.recent, msg)
+		}
+	}
+
+	summary.users = topCounts(users, top)
+	summary.emoji = topCounts(emoji, top)
+
+	sort.SliceStable(summary.threads, func(i, j int) bool {
+		return summary.threads[i].LatestReply > summary.threads[j].LatestReply
+	})
+	if len(summary.threads) > top {
+		summary.threads = summary.threads[:top]
+	}
+	return summary
+}
+
+// topCounts returns the top largest counts, ties sorted by key
+func topCounts(counts map[string]int, top int) []summaryCount {
+	list := make([]summaryCount, 0, len(counts))
+	for key, count := range counts {
+		list = append(list, summaryCount{key: key, count: count})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].count != list[j].count {
+			return list[i].count > list[j].count
+		}
+		return list[i].key < list[j].key
+	})
+	if len(list) > top {
+		list = list[:top]
+	}
+	return list
+}
+
+// summaryRows renders a summary as CSV rows with user IDs resolved to names and message text rendered
+func (ch *ConversationsHandler) summaryRows(summary *channelSummary, oldest time.Time, params *summaryParams, limitReached bool) []SummaryRow {
+	usersMap := ch.apiProvider.ProvideUsersMap().Users
+	renderer := newMarkupRenderer(ch.apiProvider)
+	since := oldest.UTC().Format(time.RFC3339)
+
+	authorName := func(msg slack.Message) string {
+		return messageAuthorName(msg, usersMap)
+	}
+	messageText := func(msg slack.Message) string {
+		clipped, _ := clipMessageText(renderer.messageText(msg.Text+text.AttachmentsTo2CSV(msg.Text, msg.Attachments), params.render))
+		return clipped
+	}
+	rfc3339 := func(ts string) string {
+		t, err := text.TimestampToIsoRFC3339(ts)
+		if err != nil {
+			return ""
+		}
+		return t
+	}
+
+	rows := []SummaryRow{
+		{Section: SummarySectionTotals, ID: "messages", Count: summary.messages, Time: since},
+		{Section: SummarySectionTotals, ID: "users", Count: len(summary.authors), Time: since},
+		{Section: SummarySectionTotals, ID: "reactions", Count: summary.reactions, Time: since},
+	}
+	if limitReached {
+		rows[0].Text = fmt.Sprintf("only the latest %d messages were scanned", params.limit)
+	}
+	if params.excludeBots {
+		rows = append(rows, SummaryRow{Section: SummarySectionTotals, ID: "bot_messages_excluded", Count: summary.botsExcluded, Time: since})
+	}
+
+	for _, c := range summary.users {
+		rows = append(rows, SummaryRow{Section: SummarySectionUser, ID: c.key, Name: authorName(summary.authors[c.key]), Count: c.count})
+	}
+	for _, c := range summary.emoji {
+		rows = append(rows, SummaryRow{Section: SummarySectionReaction, ID: c.key, Name: ":" + c.key + ":", Count: c.count})
+	}
+	for _, msg := range summary.threads {
+		rows = append(rows, SummaryRow{
+			Section: SummarySectionThread,
+			ID:      msg.Timestamp,
+			Name:    authorName(msg),
+			Count:   msg.ReplyCount,
+			Time:    rfc3339(msg.LatestReply),
+			Text:    messageText(msg),
+		})
+	}
+	for _, msg := range summary.recent {
+		rows = append(rows, SummaryRow{
+			Section: SummarySectionRecent,
+			ID:      msg.Timestamp,
+			Name:    authorName(msg),
+			Time:    rfc3339(msg.Timestamp),
+			Text:    messageText(msg),
+		})
+	}
+	return rows
+}
+
+// messageAuthorName returns the user name of the author of msg, or the name of the bot that posted it
+func messageAuthorName(msg slack.Message, usersMap map[string]slack.User) string {
+	if name, _, ok := getUserInfo(msg.User, usersMap); ok {
+		return name
+	}
+	if msg.Username != "" {
+		return msg.Username
+	}
+	if msg.BotProfile != nil && msg.BotProfile.Name != "" {
+		return msg.BotProfile.Name
+	}
+	if msg.User != "" {
+		return msg.User
+	}
+	return msg.BotID
+}
+
+// parseParamsToolSummary reads the channel, which may be a #name, the lookback window and the result sizes
+func (ch *ConversationsHandler) parseParamsToolSummary(request mcp.CallToolRequest) (*summaryParams, error) {
+	channel, err := ch.paramReadableChannel(request, "conversations_summary")
+	if err != nil {
+		return nil, err
+	}
+
+	lookback, err := parseSummaryLookback(request.GetString("lookback", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	limit := request.GetInt("limit", defaultSummaryMessages)
+	if limit <= 0 || limit > maxSummaryMessages {
+		return nil, fmt.Errorf("limit must be between 1 and %d, got %d", maxSummaryMessages, limit)
+	}
+	top := request.GetInt("top", defaultSummaryTop)
+	if top <= 0 || top > maxSummaryTop {
+		return nil, fmt.Errorf("top must be between 1 and %d, got %d", maxSummaryTop, top)
+	}
+
+	return &summaryParams{
+		channel:     channel,
+		lookback:    lookback,
+		limit:       limit,
+		top:         top,
+		render:      request.GetBool("render", true),
+		excludeBots: request.GetBool("exclude_bots", false),
+	}, nil
+}
+
+// parseSummaryLookback reads a window such as 12h, 3d or 2w, empty means SLACK_MCP_SUMMARY_LOOKBACK which also caps it
+func parseSummaryLookback(raw string) (time.Duration, error) {
+	max := summaryMaxLookback()
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return max, nil
+	}
+
+	d, err := parseLookbackDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("lookback must be a positive duration such as 12h, 3d or 2w, got %q", raw)
+	}
+	if d > max {
+		return max, nil
+	}
+	return d, nil
+}
+
+// summaryMaxLookback returns the default and maximum window of conversations_summary, see SLACK_MCP_SUMMARY_LOOKBACK
+func summaryMaxLookback() time.Duration {
+	d, err := parseLookbackDuration(os.Getenv("SLACK_MCP_SUMMARY_LOOKBACK"))
+	if err != nil {
+		return defaultSummaryLookback
+	}
+	return d
+}
+
+// parseLookbackDuration parses Go durations and whole days or weeks, e.g. 3d or 2w
+func parseLookbackDuration(raw string) (time.Duration, error) {
+	var d time.Duration
+	if unit := strings.TrimLeft(raw, "0123456789"); unit == "d" || unit == "w" {
+		n, err := strconv.Atoi(strings.TrimSuffix(raw, unit))
+		if err != nil {
+			return 0, err
+		}
+		d = time.Duration(n) * 24 * time.Hour
+		if unit == "w" {
+			d *= 7
+		}
+	} else {
+		var err error
+		if d, err = time.ParseDuration(raw); err != nil {
+			return 0, err
+		}
+	}
+	if d <= 0 {
+		return 0, errors.New("duration must be positive")
+	}
+	return d, nil
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitSummarizeMessages(t *testing.T) {
+	newMessage := func(ts, user, subtype string) slack.Message {
+		msg := slack.Message{}
+		msg.Timestamp, msg.User, msg.SubType = ts, user, subtype
+		return msg
+	}
+
+	// history is newest first
+	deploy := newMessage("1700000500.000100", "U0ALICE", "")
+	deploy.Reactions = []slack.ItemReaction{{Name: "rocket", Count: 3}, {Name: "eyes", Count: 1}}
+	deploy.ReplyCount, deploy.LatestReply = 2, "1700000900.000100"
+	join := newMessage("1700000400.000100", "U0CAROL", "channel_join")
+	bot := newMessage("1700000300.000100", "", "bot_message")
+	bot.BotID, bot.Username = "B0CI", "ci"
+	bot.Reactions = []slack.ItemReaction{{Name: "eyes", Count: 2}}
+	question := newMessage("1700000200.000100", "U0BOB", "")
+	question.ReplyCount, question.LatestReply = 5, "1700000950.000100"
+	older := newMessage("1700000100.000100", "U0ALICE", "")
+
+	summary := summarizeMessages([]slack.Message{deploy, join, bot, question, older}, 2)
+
+	assert.Equal(t, 4, summary.messages, "activity messages are not counted")
+	assert.Equal(t, 6, summary.reactions)
+	assert.Equal(t, []summaryCount{{key: "U0ALICE", count: 2}, {key: "B0CI", count: 1}}, summary.users)
+	assert.Equal(t, []summaryCount{{key: "eyes", count: 3}, {key: "rocket", count: 3}}, summary.emoji)
+	require.Len(t, summary.threads, 2)
+	assert.Equal(t, question.Timestamp, summary.threads[0].Timestamp, "threads with the latest reply come first")
+	require.Len(t, summary.recent, 2)
+	assert.Equal(t, []string{deploy.Timestamp, bot.Timestamp}, []string{summary.recent[0].Timestamp, summary.recent[1].Timestamp})
+	assert.Len(t, summary.authors, 3)
+}
+
+func TestUnitDropBotMessages(t *testing.T) {
+	human := slack.Message{Msg: slack.Msg{Timestamp: "1700000300.000100", User: "U0ALICE"}}
+	bot := slack.Message{Msg: slack.Msg{Timestamp: "1700000200.000100", SubType: "bot_message", BotID: "B0CI"}}
+	app := slack.Message{Msg: slack.Msg{Timestamp: "1700000100.000100", User: "U0APP", BotProfile: &slack.BotProfile{Name: "Deployer"}}}
+
+	kept, dropped := dropBotMessages([]slack.Message{human, bot, app})
+	assert.Equal(t, []slack.Message{human}, kept)
+	assert.Equal(t, 2, dropped)
+}
+
+func TestUnitParseSummaryLookback(t *testing.T) {
+	t.Setenv("SLACK_MCP_SUMMARY_LOOKBACK", "")
+
+	d, err := parseSummaryLookback("")
+	require.NoError(t, err)
+	assert.Equal(t, defaultSummaryLookback, d)
+
+	d, err = parseSummaryLookback("12h")
+	require.NoError(t, err)
+	assert.Equal(t, 12*time.Hour, d)
+
+	d, err = parseSummaryLookback("3d")
+	require.NoError(t, err)
+	assert.Equal(t, 72*time.Hour, d)
+
+	d, err = parseSummaryLookback("4w")
+	require.NoError(t, err)
+	assert.Equal(t, defaultSummaryLookback, d, "capped at SLACK_MCP_SUMMARY_LOOKBACK")
+
+	t.Setenv("SLACK_MCP_SUMMARY_LOOKBACK", "2w")
+	d, err = parseSummaryLookback("")
+	require.NoError(t, err)
+	assert.Equal(t, 14*24*time.Hour, d)
+
+	for _, raw := range []string{"soon", "0d", "-1h", "d"} {
+		_, err = parseSummaryLookback(raw)
+		assert.Error(t, err, raw)
+	}
+}
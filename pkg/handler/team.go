@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+type TeamInfo struct {
+	TeamID      string `json:"teamID"`
+	Name        string `json:"name"`
+	Domain      string `json:"domain"`
+	EmailDomain string `json:"emailDomain"`
+	IconURL     string `json:"iconURL"`
+}
+
+type WhoAmI struct {
+	UserID       string `json:"userID"`
+	User         string `json:"user"`
+	BotID        string `json:"botID"`
+	TeamID       string `json:"teamID"`
+	Team         string `json:"team"`
+	EnterpriseID string `json:"enterpriseID"`
+	OrgScoped    bool   `json:"orgScoped"`
+	URL          string `json:"url"`
+	Scopes       string `json:"scopes"`
+}
+
+type TeamHandler struct {
+	apiProvider *provider.ApiProvider
+	logger      *zap.Logger
+}
+
+func NewTeamHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *TeamHandler {
+	return &TeamHandler{
+		apiProvider: apiProvider,
+		logger:      logger,
+	}
+}
+
+// TeamInfoHandler returns the workspace the token authenticates to as CSV
+func (th *TeamHandler) TeamInfoHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, th.logger)
+	logger.Debug("TeamInfoHandler called", zap.Any("params", request.Params))
+
+	team, err := th.apiProvider.ProvideTeam(ctx)
+	if err != nil {
+		return nil, slackAPIError(err)
+	}
+
+	return marshalCSVResult([]TeamInfo{{
+		TeamID:      team.ID,
+		Name:        team.Name,
+		Domain:      team.Domain,
+		EmailDomain: team.EmailDomain,
+		IconURL:     team.IconURL,
+	}})
+}
+
+// AuthWhoamiHandler returns who the configured token authenticates as via auth.test as CSV
+func (th *TeamHandler) AuthWhoamiHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, th.logger)
+	logger.Debug("AuthWhoamiHandler called", zap.Any("params", request.Params))
+
+	identity, err := th.apiProvider.Identity(ctx)
+	if err != nil {
+		logger.Error("Slack AuthTestContext failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	return marshalCSVResult([]WhoAmI{{
+		UserID:       identity.UserID,
+		User:         identity.User,
+		BotID:        identity.BotID,
+		TeamID:       identity.TeamID,
+		Team:         identity.Team,
+		EnterpriseID: identity.EnterpriseID,
+		OrgScoped:    identity.OrgScoped,
+		URL:          identity.URL,
+		Scopes:       strings.Join(identity.Scopes, ","),
+	}})
+}
@@ -0,0 +1,334 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/korotovsky/slack-mcp-server/pkg/version"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+	"golang.org/x/net/html"
+)
+
+const (
+	// unfurlFetchTimeout bounds a whole OpenGraph fetch, redirects included
+	unfurlFetchTimeout = 5 * time.Second
+	// unfurlMaxBytes is read of a page at most, OpenGraph tags are in its head
+	unfurlMaxBytes = 512 << 10
+	// unfurlMaxRedirects is the number of redirects an OpenGraph fetch follows
+	unfurlMaxRedirects = 3
+)
+
+// Sources of a chat_unfurl_preview result
+const (
+	UnfurlSourceMessage   = "message"
+	UnfurlSourceOpenGraph = "opengraph"
+)
+
+// errPrivateAddress rejects OpenGraph fetches of loopback, private and link-local addresses
+var errPrivateAddress = errors.New("fetching private network addresses is not allowed")
+
+type UnfurlPreview struct {
+	URL         string `json:"url"`
+	Source      string `json:"source"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ImageURL    string `json:"imageURL"`
+	SiteName    string `json:"siteName"`
+}
+
+// ogFetcher reads the OpenGraph tags of web pages for chat_unfurl_preview
+type ogFetcher struct {
+	client   *http.Client
+	maxBytes int64
+}
+
+// newOGFetcher returns a fetcher that only connects to public addresses and ignores proxy settings, the
+// Slack credentials are never sent along
+func newOGFetcher() *ogFetcher {
+	dialer := &net.Dialer{
+		Timeout: unfurlFetchTimeout,
+		Control: publicAddressOnly,
+	}
+	return &ogFetcher{
+		client: &http.Client{
+			Timeout: unfurlFetchTimeout,
+			Transport: &http.Transport{
+				DialContext:           dialer.DialContext,
+				TLSHandshakeTimeout:   unfurlFetchTimeout,
+				ResponseHeaderTimeout: unfurlFetchTimeout,
+			},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) > unfurlMaxRedirects {
+					return fmt.Errorf("stopped after %d redirects", unfurlMaxRedirects)
+				}
+				return nil
+			},
+		},
+		maxBytes: unfurlMaxBytes,
+	}
+}
+
+// specialUseRanges are global unicast ranges reserved for special purposes, e.g. carrier-grade NAT,
+// benchmarking, documentation or translation to IPv4, which an OpenGraph fetch must not reach
+var specialUseRanges = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("192.88.99.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001::/23"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("2002::/16"),
+}
+
+// publicAddressOnly is a net.Dialer Control only allowing connections to global unicast addresses that are
+// neither private nor in specialUseRanges. It runs after name resolution, so it also covers redirects and DNS names.
+func publicAddressOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errPrivateAddress, host)
+	}
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return fmt.Errorf("%w: %s", errPrivateAddress, host)
+	}
+	for _, prefix := range specialUseRanges {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("%w: %s", errPrivateAddress, host)
+		}
+	}
+	return nil
+}
+
+// isUnfurlFetchEnabled returns true if SLACK_MCP_UNFURL_FETCH lets chat_unfurl_preview fetch pages itself
+func isUnfurlFetchEnabled() bool {
+	value := os.Getenv("SLACK_MCP_UNFURL_FETCH")
+	return value == "true" || value == "1"
+}
+
+// ChatUnfurlPreviewHandler returns the title, description and image of a link as CSV. The unfurl Slack stored
+// on a message is used when channel_id and ts name one, otherwise the OpenGraph tags of the page are fetched.
+func (ch *ConversationsHandler) ChatUnfurlPreviewHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, ch.logger)
+	logger.Debug("ChatUnfurlPreviewHandler called", zap.Any("params", request.Params))
+
+	link, err := parseUnfurlURL(request.GetString("url", ""))
+	if err != nil {
+		logger.Error("Failed to parse unfurl url", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	channel := strings.TrimSpace(request.GetString("channel_id", ""))
+	ts := strings.TrimSpace(request.GetString("ts", ""))
+	if (channel == "") != (ts == "") {
+		return nil, invalidArgumentError(errors.New("channel_id and ts must be given together to use the unfurl of a message"))
+	}
+	if channel != "" {
+		if !isMessageTimestamp(ts) {
+			return nil, invalidArgumentError(fmt.Errorf("ts must be a message timestamp in format 1234567890.123456, got %q", ts))
+		}
+		if err := checkReadPolicy(logger, "chat_unfurl_preview", channel); err != nil {
+			return nil, err
+		}
+
+		var msg *slack.Message
+		err := readChannel(ctx, ch.apiProvider.Slack(), logger, "chat_unfurl_preview", channel, func() (err error) {
+			msg, err = ch.fetchMessage(ctx, channel, ts, request.GetString("thread_ts", ""))
+			return err
+		})
+		if err != nil {
+			logger.Error("Failed to fetch message for unfurl", zap.String("channel", channel), zap.String("ts", ts), zap.Error(err))
+			return nil, slackAPIError(err)
+		}
+		if preview, ok := messageUnfurl(*msg, link); ok {
+			return marshalCSVResult([]UnfurlPreview{preview})
+		}
+		logger.Debug("Message has no unfurl for url", zap.String("channel", channel), zap.String("ts", ts))
+	}
+
+	if !isUnfurlFetchEnabled() {
+		return nil, notFoundError("no stored unfurl for %s, and fetching pages is off, set SLACK_MCP_UNFURL_FETCH=true to enable it", link)
+	}
+	preview, err := ch.unfurls.fetch(ctx, link)
+	if err != nil {
+		logger.Warn("OpenGraph fetch failed", zap.String("url", link), zap.Error(err))
+		return nil, notFoundError("no preview for %s: %v", link, err)
+	}
+	return marshalCSVResult([]UnfurlPreview{preview})
+}
+
+// parseUnfurlURL accepts an absolute http or https URL, also in Slack markup such as <https://example.com|label>
+func parseUnfurlURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "<") && strings.HasSuffix(raw, ">") {
+		raw, _, _ = strings.Cut(strings.TrimSuffix(strings.TrimPrefix(raw, "<"), ">"), "|")
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "", fmt.Errorf("url must be an absolute http or https URL such as https://example.com/article, got %q", raw)
+	}
+	return u.String(), nil
+}
+
+// messageUnfurl returns the unfurl Slack attached to msg for link, matched by the URL it was generated from
+func messageUnfurl(msg slack.Message, link string) (UnfurlPreview, bool) {
+	for _, a := range msg.Attachments {
+		if !sameURL(a.FromURL, link) && !sameURL(a.OriginalURL, link) && !sameURL(a.TitleLink, link) {
+			continue
+		}
+		imageURL := a.ImageURL
+		if imageURL == "" {
+			imageURL = a.ThumbURL
+		}
+		return UnfurlPreview{
+			URL:         link,
+			Source:      UnfurlSourceMessage,
+			Title:       a.Title,
+			Description: a.Text,
+			ImageURL:    imageURL,
+			SiteName:    a.ServiceName,
+		}, true
+	}
+	return UnfurlPreview{}, false
+}
+
+// sameURL compares URLs ignoring a trailing slash, Slack stores links as posted
+func sameURL(a, b string) bool {
+	return a != "" && strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
+
+// fetch reads the head of the page at link and returns its OpenGraph tags, falling back to the title
+// and description meta tags. Only HTML pages are read, at most maxBytes of them.
+func (f *ogFetcher) fetch(ctx context.Context, link string) (UnfurlPreview, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return UnfurlPreview{}, err
+	}
+	req.Header.Set("User-Agent", "slack-mcp-server/"+version.Version)
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return UnfurlPreview{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return UnfurlPreview{}, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return UnfurlPreview{}, fmt.Errorf("not an HTML page: %q", resp.Header.Get("Content-Type"))
+	}
+
+	preview := parseOpenGraph(io.LimitReader(resp.Body, f.maxBytes), resp.Request.URL)
+	preview.URL = link
+	preview.Source = UnfurlSourceOpenGraph
+	if preview.Title == "" && preview.Description == "" {
+		return UnfurlPreview{}, errors.New("the page has no title or description")
+	}
+	return preview, nil
+}
+
+// parseOpenGraph reads og:title, og:description, og:image and og:site_name from the head of an HTML page,
+// with <title>, description and twitter:* as fallbacks. Relative image URLs are resolved against base.
+func parseOpenGraph(r io.Reader, base *url.URL) UnfurlPreview {
+	meta := make(map[string]string)
+	var title string
+
+	z := html.NewTokenizer(r)
+	inTitle := false
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return openGraphPreview(meta, title, base)
+		case html.TextToken:
+			if inTitle && title == "" {
+				title = strings.TrimSpace(string(z.Text()))
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				return openGraphPreview(meta, title, base)
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = tt == html.StartTagToken
+			case "body":
+				return openGraphPreview(meta, title, base)
+			case "meta":
+				var key, content string
+				for hasAttr {
+					var k, v []byte
+					k, v, hasAttr = z.TagAttr()
+					switch string(k) {
+					case "property", "name":
+						key = strings.ToLower(strings.TrimSpace(string(v)))
+					case "content":
+						content = strings.TrimSpace(string(v))
+					}
+				}
+				if key != "" && content != "" && meta[key] == "" {
+					meta[key] = content
+				}
+			}
+		}
+	}
+}
+
+func openGraphPreview(meta map[string]string, title string, base *url.URL) UnfurlPreview {
+	first := func(keys ...string) string {
+		for _, k := range keys {
+			if v := meta[k]; v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	preview := UnfurlPreview{
+		Title:       first("og:title", "twitter:title"),
+		Description: first("og:description", "twitter:description", "description"),
+		ImageURL:    first("og:image", "og:image:url", "og:image:secure_url", "twitter:image"),
+		SiteName:    first("og:site_name"),
+	}
+	if preview.Title == "" {
+		preview.Title = title
+	}
+	if preview.ImageURL != "" && base != nil {
+		if ref, err := url.Parse(preview.ImageURL); err == nil {
+			preview.ImageURL = base.ResolveReference(ref).String()
+		}
+	}
+	return preview
+}
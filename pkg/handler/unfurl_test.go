@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitParseUnfurlURL(t *testing.T) {
+	link, err := parseUnfurlURL(" https://example.com/post ")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/post", link)
+
+	link, err = parseUnfurlURL("<https://example.com/post?a=1|the post>")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/post?a=1", link)
+
+	for _, raw := range []string{"", "example.com", "ftp://example.com/file", "file:///etc/passwd"} {
+		_, err := parseUnfurlURL(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestUnitMessageUnfurl(t *testing.T) {
+	msg := slack.Message{Msg: slack.Msg{Attachments: []slack.Attachment{
+		{FromURL: "https://other.example.com/", Title: "Other"},
+		{FromURL: "https://example.com/post/", Title: "Launch", Text: "We launched", ThumbURL: "https://example.com/thumb.png", ServiceName: "Example Blog"},
+	}}}
+
+	preview, ok := messageUnfurl(msg, "https://example.com/post")
+	require.True(t, ok)
+	assert.Equal(t, UnfurlPreview{
+		URL:         "https://example.com/post",
+		Source:      UnfurlSourceMessage,
+		Title:       "Launch",
+		Description: "We launched",
+		ImageURL:    "https://example.com/thumb.png",
+		SiteName:    "Example Blog",
+	}, preview)
+
+	_, ok = messageUnfurl(msg, "https://example.com/missing")
+	assert.False(t, ok)
+}
+
+func TestUnitParseOpenGraph(t *testing.T) {
+	base, _ := url.Parse("https://example.com/blog/launch")
+	page := `<!doctype html><html><head>
+<title>Launch &amp; more</title>
+<meta name="description" content="Fallback description">
+<meta property="og:title" content="We launched">
+<meta property="og:image" content="/img/cover.png">
+<meta property="og:site_name" content="Example">
+</head><body><meta property="og:description" content="not in the head"></body></html>`
+
+	preview := parseOpenGraph(strings.NewReader(page), base)
+	assert.Equal(t, "We launched", preview.Title)
+	assert.Equal(t, "Fallback description", preview.Description)
+	assert.Equal(t, "https://example.com/img/cover.png", preview.ImageURL)
+	assert.Equal(t, "Example", preview.SiteName)
+
+	// without OpenGraph tags the title is used
+	preview = parseOpenGraph(strings.NewReader(`<html><head><title>Launch &amp; more</title></head></html>`), base)
+	assert.Equal(t, "Launch & more", preview.Title)
+}
+
+func TestUnitOGFetcher(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(`<html><head><meta property="og:title" content="Hello"></head></html>`))
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte{0x89, 'P', 'N', 'G'})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	f := &ogFetcher{client: srv.Client(), maxBytes: 1024}
+	preview, err := f.fetch(context.Background(), srv.URL+"/page")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", preview.Title)
+	assert.Equal(t, UnfurlSourceOpenGraph, preview.Source)
+
+	_, err = f.fetch(context.Background(), srv.URL+"/image")
+	assert.ErrorContains(t, err, "not an HTML page")
+
+	_, err = f.fetch(context.Background(), srv.URL+"/missing")
+	assert.ErrorContains(t, err, "404")
+
+	// the default fetcher refuses local addresses
+	_, err = newOGFetcher().fetch(context.Background(), srv.URL+"/page")
+	assert.True(t, errors.Is(err, errPrivateAddress), "got %v", err)
+}
+
+func TestUnitPublicAddressOnly(t *testing.T) {
+	for _, address := range []string{
+		"127.0.0.1:80", "[::1]:443", "10.0.0.8:80", "192.168.1.1:80", "169.254.169.254:80", "0.0.0.0:80",
+		"100.64.0.1:80", "198.18.0.1:80", "192.0.0.170:80", "240.0.0.1:80", "255.255.255.255:80", "224.0.0.1:80",
+		"[64:ff9b::a00:1]:80", "[::ffff:127.0.0.1]:80", "[fd00::1]:80", "[fe80::1]:80", "[2001:db8::1]:80",
+		"[2002:a00:1::1]:80", "example.com:80",
+	} {
+		assert.ErrorIs(t, publicAddressOnly("tcp", address, nil), errPrivateAddress, address)
+	}
+	assert.NoError(t, publicAddressOnly("tcp", "93.184.216.34:443", nil))
+	assert.NoError(t, publicAddressOnly("tcp", "[2606:2800:220:1:248:1893:25c8:1946]:443", nil))
+}
+
+func TestUnitUnfurlFetchOptIn(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "false": false, "0": false, "true": true, "1": true} {
+		t.Setenv("SLACK_MCP_UNFURL_FETCH", value)
+		assert.Equal(t, want, isUnfurlFetchEnabled(), value)
+	}
+}
@@ -0,0 +1,724 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+var validPresences = map[string]bool{
+	"auto": true,
+	"away": true,
+}
+
+type UserPresence struct {
+	UserID   string `json:"userID"`
+	Presence string `json:"presence"`
+}
+
+type UserStatus struct {
+	UserID           string `json:"userID"`
+	StatusText       string `json:"statusText"`
+	StatusEmoji      string `json:"statusEmoji"`
+	StatusExpiration string `json:"statusExpiration"`
+}
+
+type UserLocalTime struct {
+	UserID        string `json:"userID"`
+	UserName      string `json:"userName"`
+	TimezoneKnown bool   `json:"timezoneKnown"`
+	Timezone      string `json:"timezone"`
+	TimezoneLabel string `json:"timezoneLabel"`
+	UTCOffset     string `json:"utcOffset"`
+	LocalTime     string `json:"localTime"`
+}
+
+// UserConversation is a channel the user passed to users_conversations is a member of
+type UserConversation struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Topic       string `json:"topic"`
+	MemberCount int    `json:"memberCount"`
+	Cursor      string `json:"cursor"`
+}
+
+// Sections of a users_profile_get row
+const (
+	ProfileSectionStandard = "standard"
+	ProfileSectionCustom   = "custom"
+)
+
+// UserProfileField is a users_profile_get row. Standard fields are named after users.profile.get, custom
+// fields by their ID with the label of team.profile.get.
+type UserProfileField struct {
+	UserID  string `json:"userID"`
+	Section string `json:"section"`
+	Field   string `json:"field"`
+	Label   string `json:"label"`
+	Value   string `json:"value"`
+	Alt     string `json:"alt"`
+	Hidden  bool   `json:"hidden"`
+}
+
+const (
+	defaultUserConversationsLimit = 100
+	// maxUserConversationsLimit is the largest page users.conversations returns
+	maxUserConversationsLimit = 999
+)
+
+type UserAdmin struct {
+	UserID   string `json:"userID"`
+	UserName string `json:"userName"`
+	RealName string `json:"realName"`
+	Role     string `json:"role"`
+	TeamID   string `json:"teamID"`
+}
+
+// Roles of users_list_admins, highest first
+const (
+	UserRolePrimaryOwner = "primary_owner"
+	UserRoleOwner        = "owner"
+	UserRoleAdmin        = "admin"
+)
+
+// userRoleRank orders the roles of users_list_admins, lower is higher
+var userRoleRank = map[string]int{
+	UserRolePrimaryOwner: 0,
+	UserRoleOwner:        1,
+	UserRoleAdmin:        2,
+}
+
+type UsersHandler struct {
+	apiProvider *provider.ApiProvider
+	logger      *zap.Logger
+}
+
+func NewUsersHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *UsersHandler {
+	return &UsersHandler{
+		apiProvider: apiProvider,
+		logger:      logger,
+	}
+}
+
+// UsersSetPresenceHandler sets presence of the authenticated user and returns it as CSV
+func (uh *UsersHandler) UsersSetPresenceHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, uh.logger)
+	logger.Debug("UsersSetPresenceHandler called", zap.Any("params", request.Params))
+
+	ar, err := requireUserToken(uh.apiProvider, logger, "users_set_presence")
+	if err != nil {
+		return nil, err
+	}
+
+	presence := strings.ToLower(strings.TrimSpace(request.GetString("presence", "")))
+	if !validPresences[presence] {
+		logger.Error("Invalid presence", zap.String("presence", presence))
+		return nil, invalidArgumentError(errors.New("presence must be either 'auto' or 'away'"))
+	}
+
+	if err := uh.apiProvider.Slack().SetUserPresenceContext(ctx, presence); err != nil {
+		logger.Error("Slack SetUserPresenceContext failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	result := []UserPresence{{
+		UserID:   ar.UserID,
+		Presence: presence,
+	}}
+
+	csvBytes, err := gocsv.MarshalBytes(&result)
+	if err != nil {
+		logger.Error("Failed to marshal presence to CSV", zap.Error(err))
+		return nil, internalError(err)
+	}
+
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// UsersProfileSetStatusHandler sets custom status of the authenticated user and returns the resulting status as CSV
+func (uh *UsersHandler) UsersProfileSetStatusHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, uh.logger)
+	logger.Debug("UsersProfileSetStatusHandler called", zap.Any("params", request.Params))
+
+	ar, err := requireUserToken(uh.apiProvider, logger, "users_profile_set_status")
+	if err != nil {
+		return nil, err
+	}
+
+	statusText := request.GetString("status_text", "")
+	statusEmoji := normalizeEmoji(request.GetString("status_emoji", ""))
+
+	expiration, err := parseStatusExpiration(request.GetString("expiration", ""), time.Now())
+	if err != nil {
+		logger.Error("Invalid status expiration", zap.Error(err))
+		return nil, invalidArgumentError(err)
+	}
+
+	logger.Debug("Setting Slack user status",
+		zap.String("status_text", statusText),
+		zap.String("status_emoji", statusEmoji),
+		zap.Int64("status_expiration", expiration),
+	)
+	if err := uh.apiProvider.Slack().SetUserCustomStatusContext(ctx, statusText, statusEmoji, expiration); err != nil {
+		logger.Error("Slack SetUserCustomStatusContext failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	// fetch the profile back so the client can confirm the applied status
+	profile, err := uh.apiProvider.Slack().GetUserProfileContext(ctx, &slack.GetUserProfileParameters{UserID: ar.UserID})
+	if err != nil {
+		logger.Error("Slack GetUserProfileContext failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	statusExpiration := ""
+	if profile.StatusExpiration > 0 {
+		statusExpiration = time.Unix(int64(profile.StatusExpiration), 0).UTC().Format(time.RFC3339)
+	}
+
+	result := []UserStatus{{
+		UserID:           ar.UserID,
+		StatusText:       profile.StatusText,
+		StatusEmoji:      profile.StatusEmoji,
+		StatusExpiration: statusExpiration,
+	}}
+
+	csvBytes, err := gocsv.MarshalBytes(&result)
+	if err != nil {
+		logger.Error("Failed to marshal status to CSV", zap.Error(err))
+		return nil, internalError(err)
+	}
+
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// UsersLocalTimeHandler returns the current local time of a user based on the timezone of their profile
+func (uh *UsersHandler) UsersLocalTimeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, uh.logger)
+	logger.Debug("UsersLocalTimeHandler called", zap.Any("params", request.Params))
+
+	raw := strings.TrimSpace(request.GetString("user", ""))
+	if raw == "" {
+		return nil, invalidArgumentError(errors.New("user must be a user ID or @handle"))
+	}
+	userID, err := resolveUserID(uh.apiProvider, raw, request.GetString("team_id", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	user, ok := uh.apiProvider.User(userID)
+	if !ok {
+		logger.Debug("User not in cache, fetching users.info", zap.String("user", userID))
+		users, err := uh.apiProvider.Slack().GetUsersInfoContext(ctx, userID)
+		if err != nil {
+			logger.Error("Slack GetUsersInfoContext failed", zap.String("user", userID), zap.Error(err))
+			return nil, slackAPIError(err)
+		}
+		if len(*users) == 0 {
+			return nil, notFoundError("user %q not found", raw)
+		}
+		user = (*users)[0]
+	}
+
+	return marshalCSVResult([]UserLocalTime{userLocalTime(user, time.Now())})
+}
+
+// UsersListAdminsHandler lists the admins and owners of the workspace with their highest role as CSV. It reads
+// the users cache only, no Slack API is called.
+func (uh *UsersHandler) UsersListAdminsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, uh.logger)
+	logger.Debug("UsersListAdminsHandler called", zap.Any("params", request.Params))
+
+	roles, err := parseUserRoles(request.GetString("role", ""))
+	if err != nil {
+		return nil, invalidArgumentError(err)
+	}
+	if ready, err := uh.apiProvider.CollectionReady(provider.RefreshUsers); !ready {
+		logger.Warn("Slack users sync is not ready yet, admins can't be listed", zap.Error(err))
+		return nil, cacheNotReadyError(err)
+	}
+
+	admins := workspaceAdmins(uh.apiProvider.ProvideUsersMap().Users, roles, request.GetString("team_id", ""))
+	logger.Debug("Listed workspace admins", zap.Int("count", len(admins)))
+
+	res, err := marshalCSVResult(admins)
+	if err != nil {
+		return nil, err
+	}
+	if capped := uh.apiProvider.CacheStats().UsersCapped; capped > 0 {
+		// the cache only holds users up to SLACK_MCP_CACHE_MAX_ENTRIES, admins may be among the rest
+		res.Content = append(res.Content, mcp.NewTextContent(fmt.Sprintf("the users cache is capped by SLACK_MCP_CACHE_MAX_ENTRIES, %d users were not cached and may include more admins", capped)))
+	}
+	return res, nil
+}
+
+// UsersProfileGetHandler returns the profile of a user with its custom fields, e.g. department, location
+// or manager. Fields a bot token can't see are left out by Slack, they are not an error.
+func (uh *UsersHandler) UsersProfileGetHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, uh.logger)
+	logger.Debug("UsersProfileGetHandler called", zap.Any("params", request.Params))
+
+	raw := strings.TrimSpace(request.GetString("user", ""))
+	if raw == "" {
+		return nil, invalidArgumentError(errors.New("user must be a user ID or @handle"))
+	}
+	userID, err := resolveUserID(uh.apiProvider, raw, request.GetString("team_id", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := uh.apiProvider.Slack().GetUserProfileContext(ctx, &slack.GetUserProfileParameters{UserID: userID})
+	if err != nil {
+		logger.Error("Slack GetUserProfileContext failed", zap.String("user", userID), zap.Error(err))
+		if provider.SlackErrorCode(err) == "user_not_found" {
+			return nil, notFoundError("user %q not found", raw)
+		}
+		return nil, slackAPIError(err)
+	}
+
+	// labels are optional, custom fields keep their IDs when team.profile.get is not available to the token
+	var fields map[string]slack.TeamProfileField
+	if request.GetBool("include_labels", true) && profile.Fields.Len() > 0 {
+		if fields, err = uh.apiProvider.ProfileFields(ctx); err != nil {
+			logger.Warn("Failed to load profile field labels, returning field IDs", zap.Error(err))
+		}
+	}
+
+	rows := userProfileRows(userID, profile, fields, uh.apiProvider.ProvideUsersMap().Users)
+	logger.Debug("Fetched user profile", zap.String("user", userID), zap.Int("custom_fields", profile.Fields.Len()))
+
+	return marshalCSVResult(rows)
+}
+
+// UsersConversationsHandler lists the channels a user is a member of, one page at a time, with names resolved
+// from the channels cache. Channels excluded by the SLACK_MCP_READ_CHANNELS policy are left out of the page.
+func (uh *UsersHandler) UsersConversationsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, uh.logger)
+	logger.Debug("UsersConversationsHandler called", zap.Any("params", request.Params))
+
+	ar, err := uh.apiProvider.Slack().AuthTest()
+	if err != nil {
+		logger.Error("Slack AuthTest failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	teamID := strings.TrimSpace(request.GetString("team_id", ""))
+	userID := ar.UserID
+	if raw := strings.TrimSpace(request.GetString("user", "")); raw != "" {
+		if userID, err = resolveUserID(uh.apiProvider, raw, teamID); err != nil {
+			return nil, err
+		}
+	}
+
+	types, err := parseConversationTypes(request.GetString("channel_types", ""))
+	if err != nil {
+		return nil, invalidArgumentError(err)
+	}
+
+	limit := request.GetInt("limit", defaultUserConversationsLimit)
+	if limit <= 0 {
+		limit = defaultUserConversationsLimit
+	}
+	if limit > maxUserConversationsLimit {
+		logger.Warn("Limit exceeds maximum, capping", zap.Int("requested", limit), zap.Int("max", maxUserConversationsLimit))
+		limit = maxUserConversationsLimit
+	}
+	limit = capLimit(limit)
+
+	channels, nextCursor, err := uh.apiProvider.Slack().GetConversationsForUserContext(ctx, &slack.GetConversationsForUserParameters{
+		UserID:          userID,
+		Cursor:          request.GetString("cursor", ""),
+		Types:           types,
+		Limit:           limit,
+		ExcludeArchived: !request.GetBool("include_archived", false),
+		TeamID:          teamID,
+	})
+	if err != nil {
+		logger.Error("Slack GetConversationsForUserContext failed", zap.String("user", userID), zap.Error(err))
+		e := slackAPIError(err)
+		var te *ToolError
+		if userID != ar.UserID && errors.As(e, &te) && te.Code == ErrCodePermissionDenied {
+			te.Message += "; listing the channels of another user needs the channels:read, groups:read, im:read and mpim:read scopes"
+		}
+		return nil, e
+	}
+	logger.Debug("Fetched user conversations", zap.Int("count", len(channels)), zap.Bool("has_more", nextCursor != ""))
+
+	result := userConversations(channels, uh.apiProvider.ProvideChannelsMaps().Channels, uh.apiProvider.ProvideUsersMap().Users)
+	res, err := marshalUserConversationsPage(result, nextCursor)
+	if err != nil {
+		return nil, err
+	}
+	if ar.BotID != "" && userID != ar.UserID {
+		res.Content = append(res.Content, mcp.NewTextContent(
+			"note: called with a bot token, only channels the bot can see are listed, use a user token for a complete audit"))
+	}
+	return res, nil
+}
+
+// marshalUserConversationsPage puts nextCursor on the last row like marshalMessagesPage. A page whose channels
+// were all filtered out by policy still returns the cursor, so the listing can go on.
+func marshalUserConversationsPage(rows []UserConversation, nextCursor string) (*mcp.CallToolResult, error) {
+	if len(rows) > 0 && nextCursor != "" {
+		rows[len(rows)-1].Cursor = nextCursor
+	}
+	res, err := marshalCSVResult(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 && nextCursor != "" {
+		res.Content = append(res.Content, mcp.NewTextContent("all channels on this page were filtered out, next cursor: "+nextCursor))
+	}
+	return res, nil
+}
+
+// parseConversationTypes validates a comma separated list of conversation types, public and
+// private channels are returned when it is empty
+func parseConversationTypes(raw string) ([]string, error) {
+	var types []string
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if !contains(provider.AllChanTypes, t) {
+			return nil, fmt.Errorf("invalid channel type %q, allowed values: %s", t, strings.Join(provider.AllChanTypes, ", "))
+		}
+		types = append(types, t)
+	}
+	if len(types) == 0 {
+		types = []string{provider.PubChanType, provider.PrivateChanType}
+	}
+	return types, nil
+}
+
+// userConversations names channels from the channels cache, falling back to the fields returned by
+// users.conversations. Channels not allowed by policy are skipped.
+func userConversations(channels []slack.Channel, cached map[string]provider.Channel, users map[string]slack.User) []UserConversation {
+	result := make([]UserConversation, 0, len(channels))
+	for _, c := range channels {
+		if !IsChannelReadable(c.ID) {
+			continue
+		}
+
+		row := UserConversation{
+			ID:          c.ID,
+			Name:        "#" + c.Name,
+			Type:        provider.PubChanType,
+			Topic:       c.Topic.Value,
+			MemberCount: c.NumMembers,
+		}
+		switch {
+		case c.IsIM:
+			row.Type = "im"
+			row.Name = "@" + c.User
+			if u, ok := users[c.User]; ok {
+				row.Name = "@" + u.Name
+			}
+		case c.IsMpIM:
+			row.Type = "mpim"
+			row.Name = "@" + c.Name
+		case c.IsPrivate:
+			row.Type = provider.PrivateChanType
+		}
+		if cc, ok := cached[c.ID]; ok {
+			row.Name = cc.Name
+			row.Topic = cc.Topic
+			row.MemberCount = cc.MemberCount
+		}
+		result = append(result, row)
+	}
+	return result
+}
+
+// userProfileRows returns the non-empty standard fields followed by the custom fields in the order of the
+// workspace profile. The value of a user field, e.g. a manager, gets the @handle of the user as alt.
+func userProfileRows(userID string, profile *slack.UserProfile, fields map[string]slack.TeamProfileField, users map[string]slack.User) []UserProfileField {
+	standard := []struct{ field, label, value string }{
+		{"real_name", "Full name", profile.RealName},
+		{"display_name", "Display name", profile.DisplayName},
+		{"first_name", "First name", profile.FirstName},
+		{"last_name", "Last name", profile.LastName},
+		{"title", "Title", profile.Title},
+		{"email", "Email", profile.Email},
+		{"phone", "Phone", profile.Phone},
+		{"skype", "Skype", profile.Skype},
+		{"status_text", "Status", profile.StatusText},
+		{"status_emoji", "Status emoji", profile.StatusEmoji},
+		{"team", "Team", profile.Team},
+	}
+
+	var rows []UserProfileField
+	for _, f := range standard {
+		if f.value != "" {
+			rows = append(rows, UserProfileField{UserID: userID, Section: ProfileSectionStandard, Field: f.field, Label: f.label, Value: f.value})
+		}
+	}
+
+	custom := profile.FieldsMap()
+	ids := make([]string, 0, len(custom))
+	for id := range custom {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		oi, iok := fields[ids[i]]
+		oj, jok := fields[ids[j]]
+		if iok != jok {
+			return iok
+		}
+		if oi.Ordering != oj.Ordering {
+			return oi.Ordering < oj.Ordering
+		}
+		return ids[i] < ids[j]
+	})
+
+	for _, id := range ids {
+		value := custom[id]
+		row := UserProfileField{UserID: userID, Section: ProfileSectionCustom, Field: id, Label: value.Label, Value: value.Value, Alt: value.Alt}
+		if field, ok := fields[id]; ok {
+			row.Label = field.Label
+			row.Hidden = field.IsHidden
+			if field.Type == "user" && row.Alt == "" {
+				row.Alt = userHandles(value.Value, users)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// userHandles maps the comma-separated user IDs of a user field to @handles, unknown IDs are kept
+func userHandles(value string, users map[string]slack.User) string {
+	var handles []string
+	for _, id := range strings.Split(value, ",") {
+		id = strings.TrimSpace(id)
+		if u, ok := users[id]; ok {
+			handles = append(handles, "@"+u.Name)
+		} else if id != "" {
+			handles = append(handles, id)
+		}
+	}
+	return strings.Join(handles, ",")
+}
+
+// parseUserRoles reads a comma separated list of roles, empty means all of them
+func parseUserRoles(raw string) (map[string]bool, error) {
+	roles := make(map[string]bool)
+	for _, role := range strings.Split(raw, ",") {
+		role = strings.ToLower(strings.TrimSpace(role))
+		if role == "" {
+			continue
+		}
+		if _, ok := userRoleRank[role]; !ok {
+			return nil, fmt.Errorf("role must be a comma separated list of %s, %s and %s, got %q", UserRoleAdmin, UserRoleOwner, UserRolePrimaryOwner, role)
+		}
+		roles[role] = true
+	}
+	if len(roles) == 0 {
+		return nil, nil
+	}
+	return roles, nil
+}
+
+// userRoles returns the admin roles of user by the flags Slack sets, owners are usually admins as well
+func userRoles(user slack.User) []string {
+	var roles []string
+	if user.IsPrimaryOwner {
+		roles = append(roles, UserRolePrimaryOwner)
+	}
+	if user.IsOwner {
+		roles = append(roles, UserRoleOwner)
+	}
+	if user.IsAdmin {
+		roles = append(roles, UserRoleAdmin)
+	}
+	return roles
+}
+
+// workspaceAdmins returns the active users holding one of roles, any admin role when roles is nil, with their
+// highest role. They are sorted by role, then by name. teamID restricts them to one team of an Enterprise Grid org.
+func workspaceAdmins(users map[string]slack.User, roles map[string]bool, teamID string) []UserAdmin {
+	admins := make([]UserAdmin, 0)
+	for _, user := range users {
+		if user.Deleted || user.IsBot || (teamID != "" && user.TeamID != teamID) {
+			continue
+		}
+		held := userRoles(user)
+		if len(held) == 0 {
+			continue
+		}
+		if roles != nil && !slices.ContainsFunc(held, func(role string) bool { return roles[role] }) {
+			continue
+		}
+		admins = append(admins, UserAdmin{
+			UserID:   user.ID,
+			UserName: user.Name,
+			RealName: user.RealName,
+			Role:     held[0],
+			TeamID:   user.TeamID,
+		})
+	}
+	sort.Slice(admins, func(i, j int) bool {
+		if admins[i].Role != admins[j].Role {
+			return userRoleRank[admins[i].Role] < userRoleRank[admins[j].Role]
+		}
+		if admins[i].UserName != admins[j].UserName {
+			return admins[i].UserName < admins[j].UserName
+		}
+		return admins[i].UserID < admins[j].UserID
+	})
+	return admins
+}
+
+// userLocalTime converts now into the timezone of the user's profile. Profiles without
+// a timezone return TimezoneKnown false and no local time instead of a guess.
+func userLocalTime(user slack.User, now time.Time) UserLocalTime {
+	res := UserLocalTime{
+		UserID:        user.ID,
+		UserName:      user.Name,
+		Timezone:      user.TZ,
+		TimezoneLabel: user.TZLabel,
+	}
+	if user.TZ == "" {
+		return res
+	}
+
+	// tz_offset is a fallback for hosts without the timezone database, it ignores DST changes since the profile was fetched
+	loc, err := time.LoadLocation(user.TZ)
+	if err != nil {
+		loc = time.FixedZone(user.TZ, user.TZOffset)
+	}
+
+	local := now.In(loc)
+	res.TimezoneKnown = true
+	res.UTCOffset = local.Format("-07:00")
+	res.LocalTime = local.Format(time.RFC3339)
+	return res
+}
+
+// resolveUserID accepts a user ID such as U1234567890 or a @handle resolved through the users cache,
+// teamID restricts handles to members of one team of an Enterprise Grid org
+func resolveUserID(apiProvider *provider.ApiProvider, raw, teamID string) (string, error) {
+	if userIDRe.MatchString(raw) {
+		return raw, nil
+	}
+	uid, err := apiProvider.LookupUser(strings.TrimPrefix(raw, "@"), teamID)
+	if errors.Is(err, provider.ErrAmbiguousName) {
+		return "", invalidArgumentError(fmt.Errorf("user %q: %w", raw, err))
+	}
+	if err != nil {
+		return "", notFoundError("user %q not found", raw)
+	}
+	return uid, nil
+}
+
+// requireUserToken ensures the tool is called with a user token, bot tokens can't act on behalf of a user
+// e.g. change presence, profile or read cursors
+func requireUserToken(apiProvider *provider.ApiProvider, logger *zap.Logger, tool string) (*slack.AuthTestResponse, error) {
+	ar, err := apiProvider.Slack().AuthTest()
+	if err != nil {
+		logger.Error("Slack AuthTest failed", zap.Error(err))
+		return nil, slackAPIError(err)
+	}
+
+	if ar.BotID != "" {
+		logger.Warn("Tool requires a user token", zap.String("tool", tool), zap.String("bot_id", ar.BotID))
+		return nil, capabilityUnsupportedError("%s tool requires a user token (xoxp or xoxc/xoxd), it is not available for bot tokens", tool)
+	}
+
+	return ar, nil
+}
+
+// requireBrowserSession ensures the tool is called with browser session credentials (xoxc/xoxd), the tools
+// built on Slack's internal edge API don't accept OAuth user or bot tokens
+func requireBrowserSession(apiProvider *provider.ApiProvider, logger *zap.Logger, tool string) error {
+	if apiProvider.IsBrowserSession() {
+		return nil
+	}
+	logger.Warn("Tool requires a browser session", zap.String("tool", tool))
+	return capabilityUnsupportedError("%s tool uses Slack's internal client API and requires browser session credentials (xoxc/xoxd), it is not available for xoxp and xoxb tokens", tool)
+}
+
+// requireBotToken ensures the tool is called with a bot token, e.g. to override the bot identity of a message
+func requireBotToken(apiProvider *provider.ApiProvider, logger *zap.Logger, feature string) error {
+	ar, err := apiProvider.Slack().AuthTest()
+	if err != nil {
+		logger.Error("Slack AuthTest failed", zap.Error(err))
+		return slackAPIError(err)
+	}
+
+	if ar.BotID == "" {
+		logger.Warn("Feature requires a bot token", zap.String("feature", feature))
+		return capabilityUnsupportedError("%s requires a bot token (xoxb), it is not available for user tokens", feature)
+	}
+
+	return nil
+}
+
+// requireScope fails with a capability error when the OAuth scopes of the token are known and lack scope.
+// Session tokens (xoxc/xoxd) report no scopes and are let through, Slack rejects the call if needed.
+func requireScope(ctx context.Context, apiProvider *provider.ApiProvider, logger *zap.Logger, tool, scope string) error {
+	identity, err := apiProvider.Identity(ctx)
+	if err != nil || len(identity.Scopes) == 0 || slices.Contains(identity.Scopes, scope) {
+		return nil
+	}
+	logger.Warn("Token lacks the scope a tool requires", zap.String("tool", tool), zap.String("scope", scope), zap.Strings("scopes", identity.Scopes))
+	return capabilityUnsupportedError("%s tool requires the %s scope, the current token lacks it", tool, scope)
+}
+
+// normalizeEmoji wraps an emoji name into colons, e.g. calendar becomes :calendar:
+func normalizeEmoji(raw string) string {
+	raw = strings.Trim(strings.TrimSpace(raw), ":")
+	if raw == "" {
+		return ""
+	}
+	return ":" + raw + ":"
+}
+
+// parseStatusExpiration accepts an empty value (no expiration), a duration such as 30m, 2h or 1d,
+// a unix timestamp or an RFC3339 timestamp and returns unix seconds
+func parseStatusExpiration(raw string, now time.Time) (int64, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw == "0" {
+		return 0, nil
+	}
+
+	var expiration time.Time
+	if ts, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		expiration = time.Unix(ts, 0)
+	} else if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		expiration = t
+	} else if strings.HasSuffix(raw, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(raw, "d"))
+		if err != nil || days <= 0 {
+			return 0, fmt.Errorf("invalid expiration %q: days must be a positive integer followed by 'd'", raw)
+		}
+		expiration = now.AddDate(0, 0, days)
+	} else if d, err := time.ParseDuration(raw); err == nil {
+		if d <= 0 {
+			return 0, fmt.Errorf("invalid expiration %q: duration must be positive", raw)
+		}
+		expiration = now.Add(d)
+	} else {
+		return 0, fmt.Errorf("invalid expiration %q: must be a duration (e.g. 30m, 2h, 1d), a unix timestamp or an RFC3339 timestamp", raw)
+	}
+
+	if !expiration.After(now) {
+		return 0, fmt.Errorf("invalid expiration %q: must be in the future", raw)
+	}
+
+	return expiration.Unix(), nil
+}
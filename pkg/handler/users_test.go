@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitParseStatusExpiration(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected int64
+		wantErr  bool
+	}{
+		{"empty means no expiration", "", 0, false},
+		{"zero means no expiration", "0", 0, false},
+		{"minutes duration", "30m", now.Add(30 * time.Minute).Unix(), false},
+		{"hours duration", "2h", now.Add(2 * time.Hour).Unix(), false},
+		{"days duration", "1d", now.AddDate(0, 0, 1).Unix(), false},
+		{"unix timestamp", "1751374800", 1751374800, false},
+		{"rfc3339 timestamp", "2025-07-01T13:00:00Z", now.Add(time.Hour).Unix(), false},
+		{"past timestamp", "2025-07-01T11:00:00Z", 0, true},
+		{"past unix timestamp", "1000", 0, true},
+		{"negative duration", "-5m", 0, true},
+		{"invalid days", "xd", 0, true},
+		{"garbage", "tomorrow", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStatusExpiration(tt.input, now)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestUnitNormalizeEmoji(t *testing.T) {
+	assert.Equal(t, "", normalizeEmoji(""))
+	assert.Equal(t, "", normalizeEmoji("::"))
+	assert.Equal(t, ":calendar:", normalizeEmoji("calendar"))
+	assert.Equal(t, ":calendar:", normalizeEmoji(" :calendar: "))
+	assert.Equal(t, ":calendar:", normalizeEmoji(":calendar"))
+}
+
+func TestUnitUserLocalTime(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	got := userLocalTime(slack.User{ID: "U1", Name: "alice", TZ: "Asia/Kolkata", TZLabel: "India Standard Time", TZOffset: 19800}, now)
+	assert.Equal(t, UserLocalTime{
+		UserID:        "U1",
+		UserName:      "alice",
+		TimezoneKnown: true,
+		Timezone:      "Asia/Kolkata",
+		TimezoneLabel: "India Standard Time",
+		UTCOffset:     "+05:30",
+		LocalTime:     "2025-07-01T17:30:00+05:30",
+	}, got)
+
+	// unknown zone names fall back to the profile offset
+	got = userLocalTime(slack.User{ID: "U2", TZ: "Mars/Olympus_Mons", TZOffset: -7200}, now)
+	assert.True(t, got.TimezoneKnown)
+	assert.Equal(t, "-02:00", got.UTCOffset)
+	assert.Equal(t, "2025-07-01T10:00:00-02:00", got.LocalTime)
+
+	got = userLocalTime(slack.User{ID: "U3", Name: "bot"}, now)
+	assert.False(t, got.TimezoneKnown)
+	assert.Empty(t, got.LocalTime)
+	assert.Empty(t, got.UTCOffset)
+}
+
+func TestUnitParseConversationTypes(t *testing.T) {
+	types, err := parseConversationTypes("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"public_channel", "private_channel"}, types)
+
+	types, err = parseConversationTypes(" im, mpim ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"im", "mpim"}, types)
+
+	_, err = parseConversationTypes("public_channel,group")
+	assert.ErrorContains(t, err, `invalid channel type "group"`)
+}
+
+func TestUnitUserConversations(t *testing.T) {
+	t.Setenv("SLACK_MCP_READ_CHANNELS", "!C3")
+
+	public := slack.Channel{}
+	public.ID, public.Name, public.NumMembers = "C1", "general", 10
+	private := slack.Channel{}
+	private.ID, private.Name, private.IsPrivate = "G1", "secret", true
+	im := slack.Channel{}
+	im.ID, im.IsIM, im.User = "D1", true, "U2"
+	hidden := slack.Channel{}
+	hidden.ID, hidden.Name = "C3", "hidden"
+
+	cached := map[string]provider.Channel{"C1": {ID: "C1", Name: "#general", Topic: "cached topic", MemberCount: 12}}
+	users := map[string]slack.User{"U2": {ID: "U2", Name: "bob"}}
+
+	got := userConversations([]slack.Channel{public, private, im, hidden}, cached, users)
+	assert.Equal(t, []UserConversation{
+		{ID: "C1", Name: "#general", Type: "public_channel", Topic: "cached topic", MemberCount: 12},
+		{ID: "G1", Name: "#secret", Type: "private_channel"},
+		{ID: "D1", Name: "@bob", Type: "im"},
+	}, got)
+}
+
+func TestUnitMarshalUserConversationsPage(t *testing.T) {
+	res, err := marshalUserConversationsPage([]UserConversation{{ID: "C1", Name: "#general"}}, "next")
+	require.NoError(t, err)
+	require.Len(t, res.Content, 1)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, ",next")
+
+	// a page emptied by the channel policy keeps the cursor
+	res, err = marshalUserConversationsPage([]UserConversation{}, "next")
+	require.NoError(t, err)
+	require.Len(t, res.Content, 2)
+	assert.Contains(t, res.Content[1].(mcp.TextContent).Text, "next cursor: next")
+
+	res, err = marshalUserConversationsPage([]UserConversation{}, "")
+	require.NoError(t, err)
+	assert.Len(t, res.Content, 1)
+}
+
+func TestUnitUserProfileRows(t *testing.T) {
+	profile := &slack.UserProfile{RealName: "Bob Example", Title: "Backend Engineer", Email: "bob@example.com"}
+	profile.SetFieldsMap(map[string]slack.UserProfileCustomField{
+		"Xf3": {Value: "U1"},
+		"Xf1": {Value: "Engineering"},
+		"Xf9": {Value: "secret", Label: "Clearance"},
+	})
+	fields := map[string]slack.TeamProfileField{
+		"Xf1": {ID: "Xf1", Label: "Department", Ordering: 0},
+		"Xf3": {ID: "Xf3", Label: "Manager", Type: "user", Ordering: 1, IsHidden: true},
+	}
+	users := map[string]slack.User{"U1": {ID: "U1", Name: "alice"}}
+
+	rows := userProfileRows("U2", profile, fields, users)
+	require.Len(t, rows, 6)
+	assert.Equal(t, UserProfileField{UserID: "U2", Section: ProfileSectionStandard, Field: "real_name", Label: "Full name", Value: "Bob Example"}, rows[0])
+	assert.Equal(t, "title", rows[1].Field)
+	assert.Equal(t, "email", rows[2].Field)
+	assert.Equal(t, UserProfileField{UserID: "U2", Section: ProfileSectionCustom, Field: "Xf1", Label: "Department", Value: "Engineering"}, rows[3])
+	assert.Equal(t, UserProfileField{UserID: "U2", Section: ProfileSectionCustom, Field: "Xf3", Label: "Manager", Value: "U1", Alt: "@alice", Hidden: true}, rows[4])
+	assert.Equal(t, "Clearance", rows[5].Label, "fields missing from the workspace profile keep the label of users.profile.get")
+
+	rows = userProfileRows("U2", profile, nil, users)
+	assert.Equal(t, "", rows[3].Label, "without labels custom fields are identified by ID")
+	assert.Equal(t, "Xf1", rows[3].Field)
+}
+
+func TestUnitWorkspaceAdmins(t *testing.T) {
+	users := map[string]slack.User{
+		"U0ALICE": {ID: "U0ALICE", Name: "alice", RealName: "Alice", IsAdmin: true, TeamID: "T1"},
+		"U0BOB":   {ID: "U0BOB", Name: "bob", IsAdmin: true, IsOwner: true, IsPrimaryOwner: true, TeamID: "T1"},
+		"U0CAROL": {ID: "U0CAROL", Name: "carol", IsAdmin: true, IsOwner: true, TeamID: "T2"},
+		"U0DAVE":  {ID: "U0DAVE", Name: "dave", TeamID: "T1"},
+		"U0ERIN":  {ID: "U0ERIN", Name: "erin", IsAdmin: true, Deleted: true, TeamID: "T1"},
+		"U0BOT":   {ID: "U0BOT", Name: "deployer", IsAdmin: true, IsBot: true, TeamID: "T1"},
+	}
+
+	admins := workspaceAdmins(users, nil, "")
+	assert.Equal(t, []UserAdmin{
+		{UserID: "U0BOB", UserName: "bob", Role: UserRolePrimaryOwner, TeamID: "T1"},
+		{UserID: "U0CAROL", UserName: "carol", Role: UserRoleOwner, TeamID: "T2"},
+		{UserID: "U0ALICE", UserName: "alice", RealName: "Alice", Role: UserRoleAdmin, TeamID: "T1"},
+	}, admins)
+
+	roles, err := parseUserRoles(" Owner ")
+	require.NoError(t, err)
+	admins = workspaceAdmins(users, roles, "")
+	assert.Equal(t, []string{"U0BOB", "U0CAROL"}, []string{admins[0].UserID, admins[1].UserID})
+
+	admins = workspaceAdmins(users, nil, "T2")
+	require.Len(t, admins, 1)
+	assert.Equal(t, "U0CAROL", admins[0].UserID)
+
+	assert.Empty(t, workspaceAdmins(map[string]slack.User{}, nil, ""))
+}
+
+func TestUnitParseUserRoles(t *testing.T) {
+	roles, err := parseUserRoles("")
+	require.NoError(t, err)
+	assert.Nil(t, roles)
+
+	roles, err = parseUserRoles("admin, primary_owner")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"admin": true, "primary_owner": true}, roles)
+
+	_, err = parseUserRoles("guest")
+	assert.ErrorContains(t, err, "role must be")
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWorkflowsLimit = 100
+	maxWorkflowsLimit     = 1000
+	// workflowsScope is the OAuth scope workflows.triggers.list requires
+	workflowsScope = "triggers:read"
+)
+
+// workflowsUnsupportedErrors are Slack errors meaning the token can't list workflows at all
+var workflowsUnsupportedErrors = map[string]bool{
+	"missing_scope":          true,
+	"not_allowed_token_type": true,
+	"unknown_method":         true,
+}
+
+type Workflow struct {
+	WorkflowID   string `json:"workflowID"`
+	WorkflowName string `json:"workflowName"`
+	CallbackID   string `json:"callbackID"`
+	AppID        string `json:"appID"`
+	TriggerID    string `json:"triggerID"`
+	TriggerName  string `json:"triggerName"`
+	TriggerType  string `json:"triggerType"`
+	Created      string `json:"created"`
+	Cursor       string `json:"cursor"`
+}
+
+type WorkflowsHandler struct {
+	apiProvider *provider.ApiProvider
+	logger      *zap.Logger
+}
+
+func NewWorkflowsHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *WorkflowsHandler {
+	return &WorkflowsHandler{
+		apiProvider: apiProvider,
+		logger:      logger,
+	}
+}
+
+// WorkflowsListHandler lists the workflows the token can see with their triggers as CSV, one row per
+// trigger, the last row carries the next cursor
+func (wh *WorkflowsHandler) WorkflowsListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger := requestid.Logger(ctx, wh.logger)
+	logger.Debug("WorkflowsListHandler called", zap.Any("params", request.Params))
+
+	limit := request.GetInt("limit", defaultWorkflowsLimit)
+	if limit <= 0 || limit > maxWorkflowsLimit {
+		return nil, invalidArgumentError(fmt.Errorf("limit must be between 1 and %d, got %d", maxWorkflowsLimit, limit))
+	}
+
+	if err := requireScope(ctx, wh.apiProvider, logger, "workflows_list", workflowsScope); err != nil {
+		return nil, err
+	}
+
+	triggers, nextCursor, err := wh.apiProvider.Slack().ListWorkflowTriggersContext(ctx, provider.WorkflowTriggersParameters{
+		Limit:  capLimit(limit),
+		Cursor: request.GetString("cursor", ""),
+	})
+	if err != nil {
+		logger.Error("Slack ListWorkflowTriggersContext failed", zap.Error(err))
+		if code := provider.SlackErrorCode(err); workflowsUnsupportedErrors[code] {
+			return nil, capabilityUnsupportedError("workflows_list tool is not available for the current token (%s), it requires a token with the %s scope on a workspace with workflows", code, workflowsScope)
+		}
+		return nil, slackAPIError(err)
+	}
+	logger.Debug("Fetched workflow triggers", zap.Int("count", len(triggers)), zap.Bool("has_more", nextCursor != ""))
+
+	return marshalCSVResult(workflowRows(triggers, nextCursor))
+}
+
+// workflowRows maps triggers to tool rows, the next cursor goes to the last one
+func workflowRows(triggers []provider.WorkflowTrigger, nextCursor string) []Workflow {
+	rows := make([]Workflow, 0, len(triggers))
+	for _, t := range triggers {
+		row := Workflow{
+			WorkflowID:   t.Workflow.ID,
+			WorkflowName: t.Workflow.Title,
+			CallbackID:   t.Workflow.CallbackID,
+			AppID:        t.Workflow.AppID,
+			TriggerID:    t.ID,
+			TriggerName:  t.Name,
+			TriggerType:  t.Type,
+		}
+		if t.DateCreated > 0 {
+			row.Created = time.Unix(t.DateCreated, 0).UTC().Format(time.RFC3339)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) > 0 && nextCursor != "" {
+		rows[len(rows)-1].Cursor = nextCursor
+	}
+	return rows
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitWorkflowRows(t *testing.T) {
+	triggers := []provider.WorkflowTrigger{
+		{
+			ID:          "Ft0001",
+			Type:        "shortcut",
+			Name:        "Request time off",
+			Workflow:    provider.WorkflowMetadata{ID: "Wf0001", CallbackID: "time_off", Title: "Time off", AppID: "A0001"},
+			DateCreated: 1700000000,
+		},
+		{
+			ID:       "Ft0002",
+			Type:     "scheduled",
+			Name:     "Weekly reminder",
+			Workflow: provider.WorkflowMetadata{ID: "Wf0001", Title: "Time off"},
+		},
+	}
+
+	rows := workflowRows(triggers, "next-page")
+	assert.Len(t, rows, 2)
+	assert.Equal(t, Workflow{
+		WorkflowID:   "Wf0001",
+		WorkflowName: "Time off",
+		CallbackID:   "time_off",
+		AppID:        "A0001",
+		TriggerID:    "Ft0001",
+		TriggerName:  "Request time off",
+		TriggerType:  "shortcut",
+		Created:      "2023-11-14T22:13:20Z",
+	}, rows[0])
+	assert.Equal(t, "scheduled", rows[1].TriggerType)
+	assert.Empty(t, rows[1].Created)
+	assert.Equal(t, "next-page", rows[1].Cursor, "the last row carries the cursor")
+
+	assert.Empty(t, workflowRows(nil, "next-page"))
+}
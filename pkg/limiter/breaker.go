@@ -0,0 +1,121 @@
+package limiter
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBreakerOpen is returned instead of calling Slack while the circuit breaker is open
+var ErrBreakerOpen = errors.New("slack api circuit breaker is open, calls fail fast until slack recovers")
+
+// Circuit breaker states
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// Breaker stops calls after a number of consecutive failures. Once the cooldown has passed
+// a single probe is let through, its success closes the breaker and its failure reopens it.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewBreaker creates a breaker opening after threshold consecutive failures, threshold must be positive.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	if threshold <= 0 {
+		threshold = 1
+	}
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		state:     BreakerClosed,
+	}
+}
+
+// Allow returns ErrBreakerOpen while the breaker is open or a half-open probe is in flight.
+// Every allowed call must be followed by Success, Failure or Cancel.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrBreakerOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrBreakerOpen
+		}
+		b.probing = true
+		return nil
+	}
+	return nil
+}
+
+// Success resets the failure count and reports whether it closed an open breaker.
+func (b *Breaker) Success() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	recovered := b.state != BreakerClosed
+	b.state = BreakerClosed
+	b.failures = 0
+	b.probing = false
+	return recovered
+}
+
+// Failure counts a failed call and reports whether it opened the breaker.
+func (b *Breaker) Failure() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	b.failures++
+	if b.state == BreakerHalfOpen || (b.state == BreakerClosed && b.failures >= b.threshold) {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+		return true
+	}
+	return false
+}
+
+// Cancel releases a call that ended without telling anything about Slack, e.g. cancelled by its caller.
+func (b *Breaker) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// State returns closed, open or half_open.
+func (b *Breaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// Threshold returns the number of consecutive failures that open the breaker.
+func (b *Breaker) Threshold() int {
+	return b.threshold
+}
+
+// Cooldown returns how long the breaker stays open before a probe is let through.
+func (b *Breaker) Cooldown() time.Duration {
+	return b.cooldown
+}
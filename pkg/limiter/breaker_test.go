@@ -0,0 +1,64 @@
+package limiter
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Unix(1000, 0)
+	b := NewBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	if err := b.Allow(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.Failure() {
+		t.Error("expected breaker to stay closed below the threshold")
+	}
+	b.Success()
+	b.Failure()
+	if b.State() != BreakerClosed {
+		t.Errorf("expected success to reset consecutive failures, got state %s", b.State())
+	}
+	if !b.Failure() {
+		t.Fatal("expected breaker to open at the threshold")
+	}
+
+	if err := b.Allow(); !errors.Is(err, ErrBreakerOpen) {
+		t.Errorf("expected ErrBreakerOpen while open, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected a probe after the cooldown, got %v", err)
+	}
+	if b.State() != BreakerHalfOpen {
+		t.Errorf("expected half_open during the probe, got %s", b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrBreakerOpen) {
+		t.Errorf("expected a single probe in flight, got %v", err)
+	}
+	if !b.Failure() {
+		t.Error("expected a failed probe to reopen the breaker")
+	}
+	if err := b.Allow(); !errors.Is(err, ErrBreakerOpen) {
+		t.Errorf("expected ErrBreakerOpen after a failed probe, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected a probe after the cooldown, got %v", err)
+	}
+	b.Cancel()
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected a cancelled probe to let the next call probe, got %v", err)
+	}
+	if !b.Success() {
+		t.Error("expected a successful probe to close the breaker")
+	}
+	if b.State() != BreakerClosed {
+		t.Errorf("expected closed after recovery, got %s", b.State())
+	}
+}
@@ -0,0 +1,47 @@
+package limiter
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Semaphore bounds the number of concurrent operations, e.g. in-flight Slack API calls.
+type Semaphore struct {
+	slots    chan struct{}
+	inFlight atomic.Int64
+}
+
+// NewSemaphore creates a semaphore allowing up to n concurrent holders, n must be positive.
+func NewSemaphore(n int) *Semaphore {
+	if n <= 0 {
+		n = 1
+	}
+	return &Semaphore{slots: make(chan struct{}, n)}
+}
+
+// Acquire blocks until a slot is available or ctx is done.
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	select {
+	case s.slots <- struct{}{}:
+		s.inFlight.Add(1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees a slot previously taken with Acquire.
+func (s *Semaphore) Release() {
+	s.inFlight.Add(-1)
+	<-s.slots
+}
+
+// InFlight returns the number of currently held slots.
+func (s *Semaphore) InFlight() int {
+	return int(s.inFlight.Load())
+}
+
+// Capacity returns the maximum number of concurrent holders.
+func (s *Semaphore) Capacity() int {
+	return cap(s.slots)
+}
@@ -0,0 +1,40 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSemaphore(t *testing.T) {
+	sem := NewSemaphore(2)
+	ctx := context.Background()
+
+	if err := sem.Acquire(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sem.Acquire(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := sem.InFlight(); got != 2 {
+		t.Errorf("expected 2 in flight, got %d", got)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := sem.Acquire(timeoutCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded when semaphore is full, got %v", err)
+	}
+
+	sem.Release()
+	if got := sem.InFlight(); got != 1 {
+		t.Errorf("expected 1 in flight after release, got %d", got)
+	}
+	if err := sem.Acquire(ctx); err != nil {
+		t.Fatalf("unexpected error after release: %v", err)
+	}
+	if got := sem.Capacity(); got != 2 {
+		t.Errorf("expected capacity 2, got %d", got)
+	}
+}
@@ -4,13 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
 	"github.com/korotovsky/slack-mcp-server/pkg/transport"
+	"github.com/korotovsky/slack-mcp-server/pkg/webhook"
 	"github.com/rusq/slackdump/v3/auth"
 	"github.com/slack-go/slack"
 	"go.uber.org/zap"
@@ -19,6 +28,19 @@ import (
 
 const usersNotReadyMsg = "users cache is not ready yet, sync process is still running... please wait"
 const channelsNotReadyMsg = "channels cache is not ready yet, sync process is still running... please wait"
+const defaultUsersDeltaWindow = 24 * time.Hour
+const defaultMaxConcurrentCalls = 8
+const defaultAPITimeout = 30 * time.Second
+
+// defaultBreakerThreshold leaves the circuit breaker disabled unless SLACK_MCP_BREAKER_THRESHOLD is set
+const defaultBreakerThreshold = 0
+const defaultBreakerCooldown = 30 * time.Second
+
+// usersInfoBatchSize is the number of user IDs resolved per users.info call
+const usersInfoBatchSize = 100
+
+// maxCacheMissChannels bounds the conversations.info calls ResolveChannels makes per call
+const maxCacheMissChannels = 50
 const defaultUA = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/136.0.0.0 Safari/537.36"
 
 var AllChanTypes = []string{"mpim", "im", "public_channel", "private_channel"}
@@ -28,11 +50,57 @@ var PubChanType = "public_channel"
 var ErrUsersNotReady = errors.New(usersNotReadyMsg)
 var ErrChannelsNotReady = errors.New(channelsNotReadyMsg)
 
+// ErrAPITimeout is returned when a Slack API call exceeds SLACK_MCP_API_TIMEOUT
+var ErrAPITimeout = errors.New("slack api call timed out")
+
+// ErrServiceDegraded is returned without calling Slack while the circuit breaker is open, see SLACK_MCP_BREAKER_THRESHOLD
+var ErrServiceDegraded = limiter.ErrBreakerOpen
+
+// ErrCacheMiss is returned by ResolveUsers and ResolveChannels for IDs missing from the caches
+// when SLACK_MCP_CACHE_MISS_POLICY is error
+var ErrCacheMiss = errors.New("not in the cache")
+
+// ErrAuthInvalid is returned once Slack rejects the credentials, e.g. after an xoxc/xoxd session expired
+var ErrAuthInvalid = errors.New("slack credentials are invalid or expired")
+
+// authErrorCodes are Slack errors meaning the token or session is no longer usable
+var authErrorCodes = map[string]bool{
+	"invalid_auth":     true,
+	"not_authed":       true,
+	"token_revoked":    true,
+	"token_expired":    true,
+	"account_inactive": true,
+}
+
 type UsersCache struct {
 	Users    map[string]slack.User `json:"users"`
 	UsersInv map[string]string     `json:"users_inv"`
 }
 
+// CacheStats describes the state of the provider caches
+type CacheStats struct {
+	Users              int       `json:"users"`
+	Channels           int       `json:"channels"`
+	UsersLastRefresh   time.Time `json:"users_last_refresh"`
+	UsersRefreshMode   string    `json:"users_refresh_mode"`
+	UsersUpdatedSince  int64     `json:"users_updated_since"`
+	UsersDeltaWindow   string    `json:"users_delta_window"`
+	UsersLastDeltaSize int       `json:"users_last_delta_size"`
+	UsersExcluded      int       `json:"users_excluded"`
+	// UsersCapped and ChannelsCapped count the entries left out of the caches by SLACK_MCP_CACHE_MAX_ENTRIES
+	UsersCapped        int            `json:"users_capped"`
+	ChannelsCapped     int            `json:"channels_capped"`
+	CacheMaxEntries    int            `json:"cache_max_entries"`
+	ChannelsByType     map[string]int `json:"channels_by_type"`
+	SlackCallsInFlight int            `json:"slack_calls_in_flight"`
+	SlackCallsLimit    int            `json:"slack_calls_limit"`
+	BreakerState       string         `json:"breaker_state,omitempty"`
+	// Refresh holds the refresh counters per collection, see RefreshUsers, RefreshChannels and RefreshEmoji
+	Refresh          map[string]RefreshStats `json:"refresh,omitempty"`
+	Emoji            int                     `json:"emoji"`
+	EmojiLastRefresh time.Time               `json:"emoji_last_refresh"`
+}
+
 type ChannelsCache struct {
 	Channels    map[string]Channel `json:"channels"`
 	ChannelsInv map[string]string  `json:"channels_inv"`
@@ -47,27 +115,78 @@ type Channel struct {
 	IsMpIM      bool   `json:"mpim"`
 	IsIM        bool   `json:"im"`
 	IsPrivate   bool   `json:"private"`
+	IsArchived  bool   `json:"archived"`
+	// Created is when the channel was created as a unix timestamp, 0 for channels cached before it was recorded
+	Created int64 `json:"created,omitempty"`
+	// TeamID is the team of an Enterprise Grid org the channel belongs to, empty outside of Grid
+	TeamID string `json:"team_id,omitempty"`
 }
 
 type SlackAPI interface {
 	// Standard slack-go API methods
 	AuthTest() (*slack.AuthTestResponse, error)
 	AuthTestContext(ctx context.Context) (*slack.AuthTestResponse, error)
-	GetUsersContext(ctx context.Context, options ...slack.GetUsersOption) ([]slack.User, error)
+	GetUsersPagesContext(ctx context.Context, page func([]slack.User) error, options ...slack.GetUsersOption) error
 	GetUsersInfo(users ...string) (*[]slack.User, error)
+	GetUsersInfoContext(ctx context.Context, users ...string) (*[]slack.User, error)
 	PostMessageContext(ctx context.Context, channel string, options ...slack.MsgOption) (string, string, error)
+	PostEphemeralContext(ctx context.Context, channel, user string, options ...slack.MsgOption) (string, error)
+	AddReactionContext(ctx context.Context, name string, item slack.ItemRef) error
 	MarkConversationContext(ctx context.Context, channel, ts string) error
+	GetScheduledMessagesContext(ctx context.Context, params *slack.GetScheduledMessagesParameters) ([]slack.ScheduledMessage, string, error)
+	OpenConversationContext(ctx context.Context, params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error)
+	GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error)
+	GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error)
+	SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error)
+	SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (*slack.Channel, error)
+	JoinConversationContext(ctx context.Context, channelID string) (*slack.Channel, string, []string, error)
+
+	// Used to manage the authenticated user's presence and status
+	SetUserPresenceContext(ctx context.Context, presence string) error
+	SetUserCustomStatusContext(ctx context.Context, statusText, statusEmoji string, statusExpiration int64) error
+	GetUserProfileContext(ctx context.Context, params *slack.GetUserProfileParameters) (*slack.UserProfile, error)
 
 	// Useed to get messages
 	GetConversationHistoryContext(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
 	GetConversationRepliesContext(ctx context.Context, params *slack.GetConversationRepliesParameters) (msgs []slack.Message, hasMore bool, nextCursor string, err error)
 	SearchContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchMessages, *slack.SearchFiles, error)
+	SearchFilesContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error)
+
+	// Used to manage channel bookmarks
+	ListBookmarksContext(ctx context.Context, channelID string) ([]slack.Bookmark, error)
+	AddBookmarkContext(ctx context.Context, channelID string, params slack.AddBookmarkParameters) (slack.Bookmark, error)
+	RemoveBookmarkContext(ctx context.Context, channelID, bookmarkID string) error
+
+	// Used to list custom emoji
+	GetEmojiContext(ctx context.Context) (map[string]string, error)
+
+	// Used to describe the workspace of the token
+	GetTeamInfoContext(ctx context.Context) (*slack.TeamInfo, error)
+	GetTeamProfileContext(ctx context.Context, teamID ...string) (*slack.TeamProfile, error)
+
+	// Used to list files shared in channels
+	GetFilesContext(ctx context.Context, params slack.GetFilesParameters) ([]slack.File, *slack.Paging, error)
+	GetFileInfoContext(ctx context.Context, fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error)
+	GetFileContext(ctx context.Context, downloadURL string, writer io.Writer) error
+	// Used to upload a file to attach to a message
+	UploadFileV2Context(ctx context.Context, params slack.UploadFileV2Parameters) (*slack.FileSummary, error)
+	// Used to find sections of a canvas
+	LookupCanvasSectionsContext(ctx context.Context, params slack.LookupCanvasSectionsParams) ([]slack.CanvasSection, error)
 
 	// Useed to get channels list from both Slack and Enterprise Grid versions
 	GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error)
 
+	// Used to list the channels a user is a member of
+	GetConversationsForUserContext(ctx context.Context, params *slack.GetConversationsForUserParameters) ([]slack.Channel, string, error)
+
+	// Used to list workflows and their triggers
+	ListWorkflowTriggersContext(ctx context.Context, params WorkflowTriggersParameters) ([]WorkflowTrigger, string, error)
+
 	// Edge API methods
 	ClientUserBoot(ctx context.Context) (*edge.ClientUserBootResponse, error)
+	ClientCounts(ctx context.Context) (edge.ClientCountsResponse, error)
+	UsersPrefsGet(ctx context.Context) (edge.NotificationPrefs, error)
+	UsersPrefsSetChannelNotifications(ctx context.Context, channelID string, muted *bool, desktop, mobile string) error
 }
 
 type MCPSlackClient struct {
@@ -78,8 +197,22 @@ type MCPSlackClient struct {
 	authProvider auth.Provider
 
 	isEnterprise bool
+	// isOrgScoped is set for tokens of org-wide Enterprise Grid installs, see isOrgScopedAuth
+	isOrgScoped  bool
 	isOAuth      bool
+	isSession    bool
 	teamEndpoint string
+	scopes       *transport.ScopesTransport
+	apiTimeout   time.Duration
+	logger       *zap.Logger
+
+	// httpClient and apiURL call Web API methods slack-go has no binding for, see postForm
+	httpClient *http.Client
+	apiURL     string
+
+	// authErr is set once Slack rejects the credentials and cleared by a successful auth.test
+	authMu  sync.RWMutex
+	authErr error
 }
 
 type ApiProvider struct {
@@ -88,26 +221,115 @@ type ApiProvider struct {
 	logger    *zap.Logger
 
 	rateLimiter *rate.Limiter
+	slackCalls  *limiter.Semaphore
+	// breaker is nil when SLACK_MCP_BREAKER_THRESHOLD is 0
+	breaker *limiter.Breaker
+	// refresh counts cache refresh attempts, failures and durations per collection
+	refresh *refreshMetrics
 
+	usersMu    sync.RWMutex
 	users      map[string]slack.User
 	usersInv   map[string]string
 	usersCache string
 	usersReady bool
 
+	// merge refresh state, usersUpdatedSince is the newest `updated` value seen in users.list
+	usersDeltaWindow   time.Duration
+	usersLastRefresh   time.Time
+	usersRefreshMode   string
+	usersUpdatedSince  int64
+	usersLastDeltaSize int
+
+	// excludeBots and excludeDeleted keep bots and deactivated users out of the users cache,
+	// usersExcluded counts the users skipped by the last refresh
+	excludeBots    bool
+	excludeDeleted bool
+	usersExcluded  int
+
+	// cacheMissPolicy selects how ResolveUsers and ResolveChannels treat IDs missing from the caches,
+	// userMisses and channelMisses remember the IDs their lookups did not find
+	cacheMissPolicy CacheMissPolicy
+	userMisses      missCache
+	channelMisses   missCache
+	// readyRequires lists the collections IsReady waits for, nil means users and channels
+	readyRequires []string
+	// cacheMaxEntries caps the users and the channels loaded into memory, 0 means unlimited. usersCapped
+	// and channelsCapped count the entries the loads left out because of it, see SLACK_MCP_CACHE_MAX_ENTRIES.
+	cacheMaxEntries int
+	usersCapped     int
+	channelsCapped  int
+
+	// channelsMu guards channels, channelsInv, channelsReady and channelsCapped, which refreshes and tools
+	// update while handlers and health checks read them
+	channelsMu    sync.RWMutex
 	channels      map[string]Channel
 	channelsInv   map[string]string
 	channelsCache string
 	channelsReady bool
+	// channelsHooks run after the channels cache changed, see OnChannelsChanged
+	channelsHooksMu sync.Mutex
+	channelsHooks   []func()
+
+	// channelTypes and includeArchived select what RefreshChannels fetches and keeps, empty channelTypes means all
+	channelTypes    []string
+	includeArchived bool
+
+	emojiMu          sync.RWMutex
+	emoji            map[string]Emoji
+	emojiCache       string
+	emojiReady       bool
+	emojiLastRefresh time.Time
+
+	teamMu sync.RWMutex
+	team   *Team
+	// profileFields are the custom profile fields of team.profile.get by ID
+	profileFields map[string]slack.TeamProfileField
+	// profileFieldsFetched is when profileFields were loaded, see profileFieldsTTL
+	profileFieldsFetched time.Time
+
+	identityMu      sync.Mutex
+	identity        *Identity
+	identityFetched time.Time
+	// identityLatency is how long the last auth.test called by Identity took
+	identityLatency time.Duration
+
+	// lifecycle is the phase of the server reported by health checks, nil outside of New
+	lifecycle *Lifecycle
+	// interactions records button clicks delivered by ListenInteractions, nil outside of New
+	interactions *Interactions
+	// webhook forwards message events to SLACK_MCP_WEBHOOK_URL, nil when it is not set
+	webhook *webhook.Sink
 }
 
-func NewMCPSlackClient(authProvider auth.Provider, logger *zap.Logger) (*MCPSlackClient, error) {
-	httpClient := transport.ProvideHTTPClient(authProvider.Cookies(), logger)
+func NewMCPSlackClient(authProvider auth.Provider, slackCalls *limiter.Semaphore, breaker *limiter.Breaker, logger *zap.Logger) (*MCPSlackClient, error) {
+	baseURL, err := parseAPIBaseURL()
+	if err != nil {
+		return nil, err
+	}
 
-	slackClient := slack.New(authProvider.SlackToken(),
-		slack.OptionHTTPClient(httpClient),
-	)
+	httpClient := transport.ProvideHTTPClient(authProvider.Cookies(), logger)
+	if slackCalls != nil {
+		httpClient.Transport = transport.NewConcurrencyLimitTransport(httpClient.Transport, slackCalls, logger)
+	}
+	if breaker != nil {
+		httpClient.Transport = transport.NewBreakerTransport(httpClient.Transport, breaker, logger)
+	}
+	scopes := transport.NewScopesTransport(httpClient.Transport)
+	httpClient.Transport = scopes
+	httpClient.Transport = transport.NewRawResponseTransport(httpClient.Transport)
+
+	options := []slack.Option{slack.OptionHTTPClient(httpClient)}
+	if baseURL != "" {
+		logger.Info("Using custom Slack API base URL", zap.String("base_url", baseURL))
+		options = append(options, slack.OptionAPIURL(baseURL))
+	}
+	slackClient := slack.New(authProvider.SlackToken(), options...)
 
-	authResp, err := slackClient.AuthTest()
+	authURL := baseURL
+	if authURL == "" {
+		authURL = slack.APIURL
+	}
+	authResp, err := authTest(context.Background(), httpClient, authURL, authProvider.SlackToken())
 	if err != nil {
 		return nil, err
 	}
@@ -122,10 +344,15 @@ func NewMCPSlackClient(authProvider auth.Provider, logger *zap.Logger) (*MCPSlac
 		BotID:        authResp.BotID,
 	}
 
-	slackClient = slack.New(authProvider.SlackToken(),
-		slack.OptionHTTPClient(httpClient),
-		slack.OptionAPIURL(authResp.URL+"api/"),
-	)
+	// SLACK_MCP_API_BASE_URL wins over the workspace URL returned by auth.test
+	apiURL := baseURL
+	if baseURL == "" {
+		apiURL = authResp.URL + "api/"
+		slackClient = slack.New(authProvider.SlackToken(),
+			slack.OptionHTTPClient(httpClient),
+			slack.OptionAPIURL(apiURL),
+		)
+	}
 
 	edgeClient, err := edge.NewWithInfo(authResponse, authProvider,
 		edge.OptionHTTPClient(httpClient),
@@ -142,24 +369,19 @@ func NewMCPSlackClient(authProvider auth.Provider, logger *zap.Logger) (*MCPSlac
 		authResponse: authResponse,
 		authProvider: authProvider,
 		isEnterprise: isEnterprise,
+		isOrgScoped:  isOrgScopedAuth(authResp),
 		isOAuth:      strings.HasPrefix(authProvider.SlackToken(), "xoxp-"),
+		isSession:    strings.HasPrefix(authProvider.SlackToken(), "xoxc-"),
 		teamEndpoint: authResp.URL,
+		scopes:       scopes,
+		httpClient:   httpClient,
+		apiURL:       apiURL,
+		apiTimeout:   parseAPITimeout(logger),
+		logger:       logger,
 	}, nil
 }
 
 func (c *MCPSlackClient) AuthTest() (*slack.AuthTestResponse, error) {
-	if os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo") {
-		return &slack.AuthTestResponse{
-			URL:          "https://_.slack.com",
-			Team:         "Demo Team",
-			User:         "Username",
-			TeamID:       "TEAM123456",
-			UserID:       "U1234567890",
-			EnterpriseID: "",
-			BotID:        "",
-		}, nil
-	}
-
 	if c.authResponse != nil {
 		return c.authResponse, nil
 	}
@@ -168,19 +390,113 @@ func (c *MCPSlackClient) AuthTest() (*slack.AuthTestResponse, error) {
 }
 
 func (c *MCPSlackClient) AuthTestContext(ctx context.Context) (*slack.AuthTestResponse, error) {
-	return c.slackClient.AuthTestContext(ctx)
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.slackClient.AuthTestContext(callCtx)
+	if err == nil {
+		c.setAuthError(nil)
+	}
+	return res, c.callError(ctx, callCtx, err)
 }
 
-func (c *MCPSlackClient) GetUsersContext(ctx context.Context, options ...slack.GetUsersOption) ([]slack.User, error) {
-	return c.slackClient.GetUsersContext(ctx, options...)
+// GetUsersPagesContext pages through users.list and hands each page to page as it arrives, so callers keeping
+// only some users don't hold the whole list. It is bounded by the HTTP client timeout of each page rather
+// than by SLACK_MCP_API_TIMEOUT, rate limited pages are retried after the delay Slack asks for.
+func (c *MCPSlackClient) GetUsersPagesContext(ctx context.Context, page func([]slack.User) error, options ...slack.GetUsersOption) error {
+	var err error
+	p := c.slackClient.GetUsersPaginated(options...)
+	for err == nil {
+		p, err = p.Next(ctx)
+		if err == nil {
+			err = page(p.Users)
+		} else if rateLimitedError, ok := err.(*slack.RateLimitedError); ok {
+			select {
+			case <-ctx.Done():
+				err = ctx.Err()
+			case <-time.After(rateLimitedError.RetryAfter):
+				err = nil
+			}
+		}
+	}
+	return c.checkAuth(p.Failure(err))
 }
 
 func (c *MCPSlackClient) GetUsersInfo(users ...string) (*[]slack.User, error) {
-	return c.slackClient.GetUsersInfo(users...)
+	res, err := c.slackClient.GetUsersInfo(users...)
+	return res, c.checkAuth(err)
+}
+
+func (c *MCPSlackClient) GetUsersInfoContext(ctx context.Context, users ...string) (*[]slack.User, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.slackClient.GetUsersInfoContext(callCtx, users...)
+	return res, c.callError(ctx, callCtx, err)
 }
 
 func (c *MCPSlackClient) MarkConversationContext(ctx context.Context, channel, ts string) error {
-	return c.slackClient.MarkConversationContext(ctx, channel, ts)
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	err := c.slackClient.MarkConversationContext(callCtx, channel, ts)
+	return c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) GetScheduledMessagesContext(ctx context.Context, params *slack.GetScheduledMessagesParameters) ([]slack.ScheduledMessage, string, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	messages, nextCursor, err := c.slackClient.GetScheduledMessagesContext(callCtx, params)
+	return messages, nextCursor, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) OpenConversationContext(ctx context.Context, params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	channel, noOp, alreadyOpen, err := c.slackClient.OpenConversationContext(callCtx, params)
+	return channel, noOp, alreadyOpen, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	members, nextCursor, err := c.slackClient.GetUsersInConversationContext(callCtx, params)
+	return members, nextCursor, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) SetUserPresenceContext(ctx context.Context, presence string) error {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	err := c.slackClient.SetUserPresenceContext(callCtx, presence)
+	return c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) SetUserCustomStatusContext(ctx context.Context, statusText, statusEmoji string, statusExpiration int64) error {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	err := c.slackClient.SetUserCustomStatusContext(callCtx, statusText, statusEmoji, statusExpiration)
+	return c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) GetUserProfileContext(ctx context.Context, params *slack.GetUserProfileParameters) (*slack.UserProfile, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.slackClient.GetUserProfileContext(callCtx, params)
+	return res, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) GetConversationsForUserContext(ctx context.Context, params *slack.GetConversationsForUserParameters) ([]slack.Channel, string, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	channels, nextCursor, err := c.slackClient.GetConversationsForUserContext(callCtx, params)
+	return channels, nextCursor, c.callError(ctx, callCtx, err)
 }
 
 func (c *MCPSlackClient) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
@@ -190,11 +506,12 @@ func (c *MCPSlackClient) GetConversationsContext(ctx context.Context, params *sl
 	// In non Enterprise Grid setups we always use `conversations.list` api as it accepts both token types wtf.
 	if c.isEnterprise {
 		if c.isOAuth {
-			return c.slackClient.GetConversationsContext(ctx, params)
+			return c.getConversationsContext(ctx, params)
 		} else {
+			// the edge client fetches all pages at once, so the per-call timeout is not applied
 			edgeChannels, _, err := c.edgeClient.GetConversationsContext(ctx, nil)
 			if err != nil {
-				return nil, "", err
+				return nil, "", c.checkAuth(err)
 			}
 
 			var channels []slack.Channel
@@ -237,37 +554,335 @@ func (c *MCPSlackClient) GetConversationsContext(ctx context.Context, params *sl
 		}
 	}
 
-	return c.slackClient.GetConversationsContext(ctx, params)
+	return c.getConversationsContext(ctx, params)
+}
+
+func (c *MCPSlackClient) getConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	channels, nextCursor, err := c.slackClient.GetConversationsContext(callCtx, params)
+	return channels, nextCursor, c.callError(ctx, callCtx, err)
 }
 
 func (c *MCPSlackClient) GetConversationHistoryContext(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
-	return c.slackClient.GetConversationHistoryContext(ctx, params)
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.slackClient.GetConversationHistoryContext(callCtx, params)
+	return res, c.callError(ctx, callCtx, err)
 }
 
 func (c *MCPSlackClient) GetConversationRepliesContext(ctx context.Context, params *slack.GetConversationRepliesParameters) (msgs []slack.Message, hasMore bool, nextCursor string, err error) {
-	return c.slackClient.GetConversationRepliesContext(ctx, params)
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	msgs, hasMore, nextCursor, err = c.slackClient.GetConversationRepliesContext(callCtx, params)
+	return msgs, hasMore, nextCursor, c.callError(ctx, callCtx, err)
 }
 
 func (c *MCPSlackClient) SearchContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchMessages, *slack.SearchFiles, error) {
-	return c.slackClient.SearchContext(ctx, query, params)
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	messages, files, err := c.slackClient.SearchContext(callCtx, query, params)
+	return messages, files, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) SearchFilesContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	files, err := c.slackClient.SearchFilesContext(callCtx, query, params)
+	return files, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) ListBookmarksContext(ctx context.Context, channelID string) ([]slack.Bookmark, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.slackClient.ListBookmarksContext(callCtx, channelID)
+	return res, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) AddBookmarkContext(ctx context.Context, channelID string, params slack.AddBookmarkParameters) (slack.Bookmark, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.slackClient.AddBookmarkContext(callCtx, channelID, params)
+	return res, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) RemoveBookmarkContext(ctx context.Context, channelID, bookmarkID string) error {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	err := c.slackClient.RemoveBookmarkContext(callCtx, channelID, bookmarkID)
+	return c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) GetEmojiContext(ctx context.Context) (map[string]string, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.slackClient.GetEmojiContext(callCtx)
+	return res, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) GetTeamInfoContext(ctx context.Context) (*slack.TeamInfo, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.slackClient.GetTeamInfoContext(callCtx)
+	return res, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) GetTeamProfileContext(ctx context.Context, teamID ...string) (*slack.TeamProfile, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.slackClient.GetTeamProfileContext(callCtx, teamID...)
+	return res, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) GetFilesContext(ctx context.Context, params slack.GetFilesParameters) ([]slack.File, *slack.Paging, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	files, paging, err := c.slackClient.GetFilesContext(callCtx, params)
+	return files, paging, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) GetFileInfoContext(ctx context.Context, fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	file, comments, paging, err := c.slackClient.GetFileInfoContext(callCtx, fileID, count, page)
+	return file, comments, paging, c.callError(ctx, callCtx, err)
+}
+
+// GetFileContext downloads a private file URL authenticated with the configured token
+func (c *MCPSlackClient) GetFileContext(ctx context.Context, downloadURL string, writer io.Writer) error {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	err := c.slackClient.GetFileContext(callCtx, downloadURL, writer)
+	return c.callError(ctx, callCtx, err)
+}
+
+// UploadFileV2Context uploads a file with files.getUploadURLExternal and files.completeUploadExternal
+func (c *MCPSlackClient) UploadFileV2Context(ctx context.Context, params slack.UploadFileV2Parameters) (*slack.FileSummary, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.slackClient.UploadFileV2Context(callCtx, params)
+	return res, c.callError(ctx, callCtx, err)
+}
+
+// LookupCanvasSectionsContext finds the sections of a canvas matching params with canvases.sections.lookup
+func (c *MCPSlackClient) LookupCanvasSectionsContext(ctx context.Context, params slack.LookupCanvasSectionsParams) ([]slack.CanvasSection, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	sections, err := c.slackClient.LookupCanvasSectionsContext(callCtx, params)
+	return sections, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.slackClient.SetTopicOfConversationContext(callCtx, channelID, topic)
+	return res, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (*slack.Channel, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.slackClient.SetPurposeOfConversationContext(callCtx, channelID, purpose)
+	return res, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) JoinConversationContext(ctx context.Context, channelID string) (*slack.Channel, string, []string, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	channel, warning, warnings, err := c.slackClient.JoinConversationContext(callCtx, channelID)
+	return channel, warning, warnings, c.callError(ctx, callCtx, err)
 }
 
 func (c *MCPSlackClient) PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
-	return c.slackClient.PostMessageContext(ctx, channelID, options...)
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	respChannel, respTimestamp, err := c.slackClient.PostMessageContext(callCtx, channelID, options...)
+	return respChannel, respTimestamp, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) PostEphemeralContext(ctx context.Context, channelID, userID string, options ...slack.MsgOption) (string, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	ts, err := c.slackClient.PostEphemeralContext(callCtx, channelID, userID, options...)
+	return ts, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) AddReactionContext(ctx context.Context, name string, item slack.ItemRef) error {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	err := c.slackClient.AddReactionContext(callCtx, name, item)
+	return c.callError(ctx, callCtx, err)
 }
 
 func (c *MCPSlackClient) ClientUserBoot(ctx context.Context) (*edge.ClientUserBootResponse, error) {
-	return c.edgeClient.ClientUserBoot(ctx)
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.edgeClient.ClientUserBoot(callCtx)
+	return res, c.callError(ctx, callCtx, err)
+}
+
+func (c *MCPSlackClient) GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.slackClient.GetConversationInfoContext(callCtx, input)
+	return res, c.callError(ctx, callCtx, err)
+}
+
+// IsBrowserSession reports whether the client uses browser session credentials (xoxc/xoxd), which the edge
+// API methods such as ClientCounts and UsersPrefsGet require
+func (c *MCPSlackClient) IsBrowserSession() bool {
+	return c.isSession
+}
+
+// ClientCounts returns the read state of every conversation of the authenticated user
+func (c *MCPSlackClient) ClientCounts(ctx context.Context) (edge.ClientCountsResponse, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.edgeClient.ClientCounts(callCtx)
+	return res, c.callError(ctx, callCtx, err)
+}
+
+// UsersPrefsGet returns the notification preferences of the authenticated user
+func (c *MCPSlackClient) UsersPrefsGet(ctx context.Context) (edge.NotificationPrefs, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.edgeClient.UsersPrefsGet(callCtx)
+	return res, c.callError(ctx, callCtx, err)
+}
+
+// UsersPrefsSetChannelNotifications updates the mute state and notification levels of a channel
+func (c *MCPSlackClient) UsersPrefsSetChannelNotifications(ctx context.Context, channelID string, muted *bool, desktop, mobile string) error {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	err := c.edgeClient.UsersPrefsSetChannelNotifications(callCtx, channelID, muted, desktop, mobile)
+	return c.callError(ctx, callCtx, err)
+}
+
+// withTimeout derives a context bounded by SLACK_MCP_API_TIMEOUT, cancellation of the
+// caller's context still applies and whichever fires first wins
+func (c *MCPSlackClient) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.apiTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.apiTimeout)
+}
+
+// callError wraps err with ErrAPITimeout when the per-call deadline fired before the caller's
+// context was done, err stays in the chain for errors.Is, and with ErrAuthInvalid when Slack rejected
+// the credentials
+func (c *MCPSlackClient) callError(parent, callCtx context.Context, err error) error {
+	if err != nil && parent.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s: %w", ErrAPITimeout, c.apiTimeout, err)
+	}
+	return c.checkAuth(err)
+}
+
+// checkAuth marks the credentials invalid when err is an authentication failure and wraps it with ErrAuthInvalid
+func (c *MCPSlackClient) checkAuth(err error) error {
+	code := SlackErrorCode(err)
+	if !authErrorCodes[code] {
+		return err
+	}
+
+	authErr := fmt.Errorf("%w: %w", ErrAuthInvalid, err)
+	if c.setAuthError(authErr) {
+		c.logger.Error("Slack rejected the credentials, tools will fail until they are renewed",
+			zap.String("slack_error", code))
+	}
+	return authErr
+}
+
+// setAuthError records the credentials state and reports whether it changed from valid to invalid
+func (c *MCPSlackClient) setAuthError(err error) bool {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	changed := c.authErr == nil && err != nil
+	c.authErr = err
+	return changed
+}
+
+// AuthError returns an error wrapping ErrAuthInvalid with the Slack error code once the credentials were rejected
+func (c *MCPSlackClient) AuthError() error {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.authErr
+}
+
+// SlackErrorCode extracts the Slack error code of Web API and edge API errors
+func SlackErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	var ser slack.SlackErrorResponse
+	if errors.As(err, &ser) {
+		return ser.Err
+	}
+	var eae *edge.APIError
+	if errors.As(err, &eae) {
+		return eae.Err
+	}
+	return ""
 }
 
 func (c *MCPSlackClient) IsEnterprise() bool {
 	return c.isEnterprise
 }
 
+func (c *MCPSlackClient) IsOrgScoped() bool {
+	return c.isOrgScoped
+}
+
+// ListTeamsContext lists the teams of an Enterprise Grid org. It returns none for session based tokens
+// (xoxc/xoxd) since the edge client already lists the conversations of all teams at once.
+func (c *MCPSlackClient) ListTeamsContext(ctx context.Context, params slack.ListTeamsParameters) ([]slack.Team, string, error) {
+	if !c.isOAuth {
+		return nil, "", nil
+	}
+
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	teams, nextCursor, err := c.slackClient.ListTeamsContext(callCtx, params)
+	return teams, nextCursor, c.callError(ctx, callCtx, err)
+}
+
 func (c *MCPSlackClient) AuthResponse() *slack.AuthTestResponse {
 	return c.authResponse
 }
 
+// Scopes returns the OAuth scopes of the token as last reported by Slack, nil for session tokens
+func (c *MCPSlackClient) Scopes() []string {
+	return c.scopes.Scopes()
+}
+
 func (c *MCPSlackClient) Raw() struct {
 	Slack *slack.Client
 	Edge  *edge.Client
@@ -281,59 +896,69 @@ func (c *MCPSlackClient) Raw() struct {
 	}
 }
 
+// ErrNoCredentials is returned when neither an OAuth token nor a session token pair is configured
+var ErrNoCredentials = errors.New("Authentication required: Either SLACK_MCP_XOXP_TOKEN (User OAuth) or both SLACK_MCP_XOXC_TOKEN and SLACK_MCP_XOXD_TOKEN (session-based) environment variables must be provided")
+
 func New(transport string, logger *zap.Logger) *ApiProvider {
-	var (
-		authProvider auth.ValueAuth
-		err          error
-	)
+	authProvider, err := AuthFromEnv()
+	if errors.Is(err, ErrNoCredentials) {
+		logger.Fatal(err.Error())
+	}
 
 	// Check for XOXP token first (User OAuth)
-	xoxpToken := os.Getenv("SLACK_MCP_XOXP_TOKEN")
-	if xoxpToken != "" {
-		authProvider, err = auth.NewValueAuth(xoxpToken, "")
+	if os.Getenv("SLACK_MCP_XOXP_TOKEN") != "" {
 		if err != nil {
 			logger.Fatal("Failed to create auth provider with XOXP token", zap.Error(err))
 		}
-
 		return newWithXOXP(transport, authProvider, logger)
 	}
 
 	// Fall back to XOXC/XOXD tokens (session-based)
-	xoxcToken := os.Getenv("SLACK_MCP_XOXC_TOKEN")
-	xoxdToken := os.Getenv("SLACK_MCP_XOXD_TOKEN")
-
-	if xoxcToken == "" || xoxdToken == "" {
-		logger.Fatal("Authentication required: Either SLACK_MCP_XOXP_TOKEN (User OAuth) or both SLACK_MCP_XOXC_TOKEN and SLACK_MCP_XOXD_TOKEN (session-based) environment variables must be provided")
-	}
-
-	authProvider, err = auth.NewValueAuth(xoxcToken, xoxdToken)
 	if err != nil {
 		logger.Fatal("Failed to create auth provider with XOXC/XOXD tokens", zap.Error(err))
 	}
-
 	return newWithXOXC(transport, authProvider, logger)
 }
 
+// AuthFromEnv creates the auth provider from SLACK_MCP_XOXP_TOKEN, or from
+// SLACK_MCP_XOXC_TOKEN and SLACK_MCP_XOXD_TOKEN when no OAuth token is set
+func AuthFromEnv() (auth.ValueAuth, error) {
+	if xoxpToken := os.Getenv("SLACK_MCP_XOXP_TOKEN"); xoxpToken != "" {
+		return auth.NewValueAuth(xoxpToken, "")
+	}
+
+	xoxcToken := os.Getenv("SLACK_MCP_XOXC_TOKEN")
+	xoxdToken := os.Getenv("SLACK_MCP_XOXD_TOKEN")
+	if xoxcToken == "" || xoxdToken == "" {
+		return auth.ValueAuth{}, ErrNoCredentials
+	}
+	return auth.NewValueAuth(xoxcToken, xoxdToken)
+}
+
 func newWithXOXP(transport string, authProvider auth.ValueAuth, logger *zap.Logger) *ApiProvider {
 	var (
-		client *MCPSlackClient
+		client SlackAPI
 		err    error
 	)
 
-	usersCache := os.Getenv("SLACK_MCP_USERS_CACHE")
-	if usersCache == "" {
-		usersCache = ".users_cache.json"
-	}
+	usersCache, channelsCache, emojiCache := cacheFiles(false)
 
-	channelsCache := os.Getenv("SLACK_MCP_CHANNELS_CACHE")
-	if channelsCache == "" {
-		channelsCache = ".channels_cache.json"
-	}
+	slackCalls := limiter.NewSemaphore(parseMaxConcurrentCalls(logger))
+	breaker := parseBreaker(logger)
 
-	if os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo") {
-		logger.Info("Demo credentials are set, skip.")
+	if IsDemoMode() {
+		client, err = newDemoSlackAPI(os.Getenv("SLACK_MCP_DEMO_DATA"))
+		if err != nil {
+			logger.Fatal("Failed to load demo data", zap.Error(err))
+		}
+		logger.Info("Demo credentials are set, serving fixture data",
+			zap.String("demo_data", os.Getenv("SLACK_MCP_DEMO_DATA")))
+		// fixtures must neither be served from nor written to the cache files of a real workspace
+		usersCache, channelsCache, emojiCache = "", "", ""
 	} else {
-		client, err = NewMCPSlackClient(authProvider, logger)
+		client, err = retryBoot(ParseBootRetry(logger), "connect", logger, func() (*MCPSlackClient, error) {
+			return NewMCPSlackClient(authProvider, slackCalls, breaker, logger)
+		})
 		if err != nil {
 			logger.Fatal("Failed to create MCP Slack client", zap.Error(err))
 		}
@@ -344,38 +969,59 @@ func newWithXOXP(transport string, authProvider auth.ValueAuth, logger *zap.Logg
 		client:    client,
 		logger:    logger,
 
-		rateLimiter: limiter.Tier2.Limiter(),
-
-		users:      make(map[string]slack.User),
-		usersInv:   map[string]string{},
-		usersCache: usersCache,
-
-		channels:      make(map[string]Channel),
-		channelsInv:   map[string]string{},
-		channelsCache: channelsCache,
+		rateLimiter:  limiter.Tier2.Limiter(),
+		slackCalls:   slackCalls,
+		breaker:      breaker,
+		refresh:      newRefreshMetrics(logger),
+		lifecycle:    NewLifecycle(logger),
+		interactions: NewInteractions(),
+		webhook:      webhook.New(webhook.ConfigFromEnv(logger), logger),
+
+		users:            make(map[string]slack.User),
+		usersInv:         map[string]string{},
+		usersCache:       usersCache,
+		usersDeltaWindow: parseUsersDeltaWindow(logger),
+		excludeBots:      isEnvTrue("SLACK_MCP_EXCLUDE_BOTS"),
+		excludeDeleted:   isEnvTrue("SLACK_MCP_EXCLUDE_DELETED"),
+		cacheMissPolicy:  parseCacheMissPolicy(logger),
+		readyRequires:    parseReadyRequires(logger),
+		cacheMaxEntries:  parseCacheMaxEntries(logger),
+
+		channels:        make(map[string]Channel),
+		channelsInv:     map[string]string{},
+		channelsCache:   channelsCache,
+		channelTypes:    parseChannelTypes(logger),
+		includeArchived: parseIncludeArchived(),
+
+		emoji:      make(map[string]Emoji),
+		emojiCache: emojiCache,
 	}
 }
 
 func newWithXOXC(transport string, authProvider auth.ValueAuth, logger *zap.Logger) *ApiProvider {
 	var (
-		client *MCPSlackClient
+		client SlackAPI
 		err    error
 	)
 
-	usersCache := os.Getenv("SLACK_MCP_USERS_CACHE")
-	if usersCache == "" {
-		usersCache = ".users_cache.json"
-	}
+	usersCache, channelsCache, emojiCache := cacheFiles(true)
 
-	channelsCache := os.Getenv("SLACK_MCP_CHANNELS_CACHE")
-	if channelsCache == "" {
-		channelsCache = ".channels_cache_v2.json"
-	}
+	slackCalls := limiter.NewSemaphore(parseMaxConcurrentCalls(logger))
+	breaker := parseBreaker(logger)
 
-	if os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo") {
-		logger.Info("Demo credentials are set, skip.")
+	if IsDemoMode() {
+		client, err = newDemoSlackAPI(os.Getenv("SLACK_MCP_DEMO_DATA"))
+		if err != nil {
+			logger.Fatal("Failed to load demo data", zap.Error(err))
+		}
+		logger.Info("Demo credentials are set, serving fixture data",
+			zap.String("demo_data", os.Getenv("SLACK_MCP_DEMO_DATA")))
+		// fixtures must neither be served from nor written to the cache files of a real workspace
+		usersCache, channelsCache, emojiCache = "", "", ""
 	} else {
-		client, err = NewMCPSlackClient(authProvider, logger)
+		client, err = retryBoot(ParseBootRetry(logger), "connect", logger, func() (*MCPSlackClient, error) {
+			return NewMCPSlackClient(authProvider, slackCalls, breaker, logger)
+		})
 		if err != nil {
 			logger.Fatal("Failed to create MCP Slack client", zap.Error(err))
 		}
@@ -386,24 +1032,37 @@ func newWithXOXC(transport string, authProvider auth.ValueAuth, logger *zap.Logg
 		client:    client,
 		logger:    logger,
 
-		rateLimiter: limiter.Tier2.Limiter(),
-
-		users:      make(map[string]slack.User),
-		usersInv:   map[string]string{},
-		usersCache: usersCache,
-
-		channels:      make(map[string]Channel),
-		channelsInv:   map[string]string{},
-		channelsCache: channelsCache,
+		rateLimiter:  limiter.Tier2.Limiter(),
+		slackCalls:   slackCalls,
+		breaker:      breaker,
+		refresh:      newRefreshMetrics(logger),
+		lifecycle:    NewLifecycle(logger),
+		interactions: NewInteractions(),
+		webhook:      webhook.New(webhook.ConfigFromEnv(logger), logger),
+
+		users:            make(map[string]slack.User),
+		usersInv:         map[string]string{},
+		usersCache:       usersCache,
+		usersDeltaWindow: parseUsersDeltaWindow(logger),
+		excludeBots:      isEnvTrue("SLACK_MCP_EXCLUDE_BOTS"),
+		excludeDeleted:   isEnvTrue("SLACK_MCP_EXCLUDE_DELETED"),
+		cacheMissPolicy:  parseCacheMissPolicy(logger),
+		readyRequires:    parseReadyRequires(logger),
+		cacheMaxEntries:  parseCacheMaxEntries(logger),
+
+		channels:        make(map[string]Channel),
+		channelsInv:     map[string]string{},
+		channelsCache:   channelsCache,
+		channelTypes:    parseChannelTypes(logger),
+		includeArchived: parseIncludeArchived(),
+
+		emoji:      make(map[string]Emoji),
+		emojiCache: emojiCache,
 	}
 }
 
-func (ap *ApiProvider) RefreshUsers(ctx context.Context) error {
-	var (
-		list         []slack.User
-		usersCounter = 0
-		optionLimit  = slack.GetUsersOptionLimit(1000)
-	)
+func (ap *ApiProvider) RefreshUsers(ctx context.Context) (err error) {
+	defer ap.refresh.observe(RefreshUsers, time.Now(), &err)
 
 	if data, err := ioutil.ReadFile(ap.usersCache); err == nil {
 		var cachedUsers []slack.User
@@ -412,48 +1071,207 @@ func (ap *ApiProvider) RefreshUsers(ctx context.Context) error {
 				zap.String("cache_file", ap.usersCache),
 				zap.Error(err))
 		} else {
+			ap.usersMu.Lock()
+			excluded := 0
+			limit := cacheCap{max: ap.cacheMaxEntries}
 			for _, u := range cachedUsers {
-				ap.users[u.ID] = u
-				ap.usersInv[u.Name] = u.ID
+				if int64(u.Updated) > ap.usersUpdatedSince {
+					ap.usersUpdatedSince = int64(u.Updated)
+				}
+				// the cache file may have been written with other SLACK_MCP_EXCLUDE_BOTS or SLACK_MCP_EXCLUDE_DELETED
+				if !ap.keepsUser(u) {
+					excluded++
+					continue
+				}
+				if _, cached := ap.users[u.ID]; !limit.admits(len(ap.users), cached) {
+					continue
+				}
+				ap.putUser(u)
 			}
+			ap.usersExcluded = excluded
+			ap.usersCapped = limit.skipped
+			ap.usersLastRefresh = time.Now()
+			ap.usersRefreshMode = "cache"
+			ap.usersReady = true
+			ap.usersMu.Unlock()
+
+			limit.warn(ap.logger, RefreshUsers)
 			ap.logger.Info("Loaded users from cache",
-				zap.Int("count", len(cachedUsers)),
+				zap.Int("count", len(cachedUsers)-excluded-limit.skipped),
+				zap.Int("excluded", excluded),
 				zap.String("cache_file", ap.usersCache))
-			ap.usersReady = true
 			return nil
 		}
 	}
 
-	users, err := ap.client.GetUsersContext(ctx,
-		optionLimit,
+	return ap.refreshUsersFull(ctx)
+}
+
+// RefreshUsersMerge lists all users again and merges the ones updated since the last refresh into the
+// existing cache. users.list has no modified-since filter, so this saves the writes of unchanged users and
+// the Slack Connect lookups, not the listing. It falls back to a full refresh when there is no prior state
+// or the last refresh is older than SLACK_MCP_USERS_DELTA_WINDOW.
+func (ap *ApiProvider) RefreshUsersMerge(ctx context.Context) (err error) {
+	defer ap.refresh.observe(RefreshUsers, time.Now(), &err)
+
+	ap.usersMu.RLock()
+	lastRefresh := ap.usersLastRefresh
+	updatedSince := ap.usersUpdatedSince
+	ap.usersMu.RUnlock()
+
+	if lastRefresh.IsZero() || updatedSince == 0 || time.Since(lastRefresh) > ap.usersDeltaWindow {
+		ap.logger.Info("Users merge refresh not possible, falling back to full refresh",
+			zap.Time("last_refresh", lastRefresh),
+			zap.Duration("delta_window", ap.usersDeltaWindow))
+		return ap.refreshUsersFull(ctx)
+	}
+
+	var (
+		newest   = updatedSince
+		changed  int
+		excluded int
+		// the cap is counted afresh, users it left out earlier are counted again if still left out
+		limit = cacheCap{max: ap.cacheMaxEntries}
 	)
+	err = ap.listUsers(ctx, func(users []slack.User) {
+		ap.usersMu.Lock()
+		defer ap.usersMu.Unlock()
+
+		for _, user := range users {
+			_, known := ap.users[user.ID]
+			updated := int64(user.Updated) > updatedSince
+			if updated && int64(user.Updated) > newest {
+				newest = int64(user.Updated)
+			}
+			if !ap.keepsUser(user) {
+				excluded++
+				// e.g. a user deactivated since the last refresh
+				if known {
+					ap.dropUser(user.ID)
+					changed++
+				}
+				continue
+			}
+			if !limit.admits(len(ap.users), known) || (known && !updated) {
+				continue
+			}
+			ap.putUser(user)
+			changed++
+		}
+	}, slack.GetUsersOptionLimit(1000))
 	if err != nil {
-		ap.logger.Error("Failed to fetch users", zap.Error(err))
+		ap.logger.Error("Failed to fetch users for merge refresh", zap.Error(err))
 		return err
-	} else {
-		list = append(list, users...)
 	}
 
-	for _, user := range users {
-		ap.users[user.ID] = user
-		ap.usersInv[user.Name] = user.ID
-		usersCounter++
+	ap.usersMu.Lock()
+	ap.usersCapped = limit.skipped
+	ap.usersExcluded = excluded
+	ap.usersUpdatedSince = newest
+	ap.usersLastRefresh = time.Now()
+	ap.usersRefreshMode = "merge"
+	ap.usersLastDeltaSize = changed
+	total := len(ap.users)
+	ap.usersMu.Unlock()
+
+	limit.warn(ap.logger, RefreshUsers)
+	ap.logger.Info("Merged updated users into cache",
+		zap.Int("changed", changed),
+		zap.Int("total", total),
+		zap.Int64("updated_since", updatedSince))
+
+	if changed > 0 {
+		ap.writeUsersCache(ap.cachedUsers())
+	}
+
+	ap.usersMu.Lock()
+	ap.usersReady = true
+	ap.usersMu.Unlock()
+
+	return nil
+}
+
+// refreshUsersFull fetches all users from the API and rebuilds the cache. Users are added page by page,
+// so with SLACK_MCP_CACHE_MAX_ENTRIES set only the users kept in memory are held, and written to the cache file.
+func (ap *ApiProvider) refreshUsersFull(ctx context.Context) error {
+	var (
+		newest   int64
+		excluded int
+		limit    = cacheCap{max: ap.cacheMaxEntries}
+	)
+	add := func(users []slack.User) {
+		ap.usersMu.Lock()
+		defer ap.usersMu.Unlock()
+
+		for _, user := range users {
+			if int64(user.Updated) > newest {
+				newest = int64(user.Updated)
+			}
+			if !ap.keepsUser(user) {
+				excluded++
+				continue
+			}
+			if _, cached := ap.users[user.ID]; !limit.admits(len(ap.users), cached) {
+				continue
+			}
+			ap.putUser(user)
+		}
+	}
+
+	if err := ap.listUsers(ctx, add, slack.GetUsersOptionLimit(1000)); err != nil {
+		ap.logger.Error("Failed to fetch users", zap.Error(err))
+		return err
 	}
 
-	users, err = ap.GetSlackConnect(ctx)
+	users, err := ap.GetSlackConnect(ctx)
 	if err != nil {
 		ap.logger.Error("Failed to fetch users from Slack Connect", zap.Error(err))
 		return err
-	} else {
-		list = append(list, users...)
 	}
+	add(users)
+
+	ap.usersMu.Lock()
+	ap.usersCapped = limit.skipped
+	ap.usersExcluded = excluded
+	ap.usersUpdatedSince = newest
+	ap.usersLastRefresh = time.Now()
+	ap.usersRefreshMode = "full"
+	ap.usersMu.Unlock()
+
+	limit.warn(ap.logger, RefreshUsers)
+
+	if excluded > 0 {
+		ap.logger.Info("Excluded users from cache",
+			zap.Int("excluded", excluded),
+			zap.Bool("exclude_bots", ap.excludeBots),
+			zap.Bool("exclude_deleted", ap.excludeDeleted))
+	}
+
+	ap.writeUsersCache(ap.cachedUsers())
+
+	ap.usersMu.Lock()
+	ap.usersReady = true
+	ap.usersMu.Unlock()
+
+	return nil
+}
+
+// cachedUsers lists the users kept in memory, which is what the cache file holds
+func (ap *ApiProvider) cachedUsers() []slack.User {
+	ap.usersMu.RLock()
+	defer ap.usersMu.RUnlock()
 
-	for _, user := range users {
-		ap.users[user.ID] = user
-		ap.usersInv[user.Name] = user.ID
-		usersCounter++
+	list := make([]slack.User, 0, len(ap.users))
+	for _, user := range ap.users {
+		list = append(list, user)
 	}
+	return list
+}
 
+func (ap *ApiProvider) writeUsersCache(list []slack.User) {
+	if ap.usersCache == "" {
+		return
+	}
 	if data, err := json.MarshalIndent(list, "", "  "); err != nil {
 		ap.logger.Error("Failed to marshal users for cache", zap.Error(err))
 	} else {
@@ -463,17 +1281,20 @@ func (ap *ApiProvider) RefreshUsers(ctx context.Context) error {
 				zap.Error(err))
 		} else {
 			ap.logger.Info("Wrote users to cache",
-				zap.Int("count", usersCounter),
+				zap.Int("count", len(list)),
 				zap.String("cache_file", ap.usersCache))
 		}
 	}
-
-	ap.usersReady = true
-
-	return nil
 }
 
-func (ap *ApiProvider) RefreshChannels(ctx context.Context) error {
+func (ap *ApiProvider) RefreshChannels(ctx context.Context) (err error) {
+	defer ap.refresh.observe(RefreshChannels, time.Now(), &err)
+	defer func() {
+		if err == nil {
+			ap.channelsChanged()
+		}
+	}()
+
 	if data, err := ioutil.ReadFile(ap.channelsCache); err == nil {
 		var cachedChannels []Channel
 		if err := json.Unmarshal(data, &cachedChannels); err != nil {
@@ -481,20 +1302,52 @@ func (ap *ApiProvider) RefreshChannels(ctx context.Context) error {
 				zap.String("cache_file", ap.channelsCache),
 				zap.Error(err))
 		} else {
+			// the cache file may have been written with other SLACK_MCP_CHANNEL_TYPES or SLACK_MCP_INCLUDE_ARCHIVED
+			loaded := 0
+			limit := cacheCap{max: ap.cacheMaxEntries}
+			ap.channelsMu.Lock()
 			for _, c := range cachedChannels {
+				if !ap.keepsChannel(c) {
+					continue
+				}
+				if _, cached := ap.channels[c.ID]; !limit.admits(len(ap.channels), cached) {
+					continue
+				}
 				ap.channels[c.ID] = c
-				ap.channelsInv[c.Name] = c.ID
+				ap.channelsInv[ap.channelKey(c)] = c.ID
+				loaded++
 			}
+			ap.channelsCapped = limit.skipped
+			ap.channelsReady = true
+			ap.channelsMu.Unlock()
+
+			limit.warn(ap.logger, RefreshChannels)
 			ap.logger.Info("Loaded channels from cache",
-				zap.Int("count", len(cachedChannels)),
+				zap.Int("count", loaded),
+				zap.Int("skipped", len(cachedChannels)-loaded),
 				zap.String("cache_file", ap.channelsCache))
-			ap.channelsReady = true
 			return nil
 		}
 	}
 
-	channels := ap.GetChannels(ctx, AllChanTypes)
+	// a failed or partial listing is neither written to the cache file nor marked ready
+	channels, err := ap.GetChannels(ctx, ap.fetchedChannelTypes())
+	if err != nil {
+		return err
+	}
+
+	if ap.channelsCache != "" {
+		ap.writeChannelsCache(channels)
+	}
+
+	ap.channelsMu.Lock()
+	ap.channelsReady = true
+	ap.channelsMu.Unlock()
 
+	return nil
+}
+
+func (ap *ApiProvider) writeChannelsCache(channels []Channel) {
 	if data, err := json.MarshalIndent(channels, "", "  "); err != nil {
 		ap.logger.Error("Failed to marshal channels for cache", zap.Error(err))
 	} else {
@@ -508,10 +1361,6 @@ func (ap *ApiProvider) RefreshChannels(ctx context.Context) error {
 				zap.String("cache_file", ap.channelsCache))
 		}
 	}
-
-	ap.channelsReady = true
-
-	return nil
 }
 
 func (ap *ApiProvider) GetSlackConnect(ctx context.Context) ([]slack.User, error) {
@@ -527,8 +1376,7 @@ func (ap *ApiProvider) GetSlackConnect(ctx context.Context) ([]slack.User, error
 			continue
 		}
 
-		_, ok := ap.users[im.User]
-		if !ok {
+		if _, ok := ap.User(im.User); !ok {
 			collectedIDs = append(collectedIDs, im.User)
 		}
 	}
@@ -549,15 +1397,15 @@ func (ap *ApiProvider) GetSlackConnect(ctx context.Context) ([]slack.User, error
 	return res, nil
 }
 
-func (ap *ApiProvider) GetChannels(ctx context.Context, channelTypes []string) []Channel {
+func (ap *ApiProvider) GetChannels(ctx context.Context, channelTypes []string) ([]Channel, error) {
 	if len(channelTypes) == 0 {
 		channelTypes = AllChanTypes
 	}
 
 	params := &slack.GetConversationsParameters{
-		Types:           AllChanTypes,
+		Types:           ap.fetchedChannelTypes(),
 		Limit:           999,
-		ExcludeArchived: true,
+		ExcludeArchived: !ap.includeArchived,
 	}
 
 	var (
@@ -567,53 +1415,79 @@ func (ap *ApiProvider) GetChannels(ctx context.Context, channelTypes []string) [
 		nextcur string
 		err     error
 	)
+	limit := cacheCap{max: ap.cacheMaxEntries}
+	usersMap := ap.ProvideUsersMap().Users
+
+	// an org-scoped Enterprise Grid token lists the channels of each team of the org separately
+	for _, team := range ap.orgTeams(ctx) {
+		params.TeamID = team
+		params.Cursor = ""
+
+		for {
+			if err := ap.rateLimiter.Wait(ctx); err != nil {
+				ap.logger.Error("Rate limiter wait failed", zap.Error(err))
+				return nil, err
+			}
 
-	for {
-		if err := ap.rateLimiter.Wait(ctx); err != nil {
-			ap.logger.Error("Rate limiter wait failed", zap.Error(err))
-			return nil
-		}
+			channels, nextcur, err = ap.client.GetConversationsContext(ctx, params)
+			if err != nil {
+				ap.logger.Error("Failed to fetch channels", zap.Error(err), zap.String("team_id", team))
+				return nil, err
+			}
 
-		channels, nextcur, err = ap.client.GetConversationsContext(ctx, params)
-		if err != nil {
-			ap.logger.Error("Failed to fetch channels", zap.Error(err))
-			break
-		}
+			chans = make([]Channel, 0, len(channels))
+			for _, channel := range channels {
+				ch := mapChannel(
+					channel.ID,
+					channel.Name,
+					channel.NameNormalized,
+					channel.Topic.Value,
+					channel.Purpose.Value,
+					channel.User,
+					channel.Members,
+					channel.NumMembers,
+					channel.IsIM,
+					channel.IsMpIM,
+					channel.IsPrivate,
+					usersMap,
+				)
+				ch.IsArchived = channel.IsArchived
+				ch.Created = channelCreated(channel.Created)
+				ch.TeamID = channelTeamID(channel, team)
+				// the edge client returns every conversation regardless of the requested types
+				if !ap.keepsChannel(ch) {
+					continue
+				}
+				chans = append(chans, ch)
+			}
 
-		chans = make([]Channel, 0, len(channels))
-		for _, channel := range channels {
-			ch := mapChannel(
-				channel.ID,
-				channel.Name,
-				channel.NameNormalized,
-				channel.Topic.Value,
-				channel.Purpose.Value,
-				channel.User,
-				channel.Members,
-				channel.NumMembers,
-				channel.IsIM,
-				channel.IsMpIM,
-				channel.IsPrivate,
-				ap.ProvideUsersMap().Users,
-			)
-			chans = append(chans, ch)
-		}
+			ap.channelsMu.Lock()
+			for _, ch := range chans {
+				if _, cached := ap.channels[ch.ID]; !limit.admits(len(ap.channels), cached) {
+					continue
+				}
+				ap.channels[ch.ID] = ch
+				ap.channelsInv[ap.channelKey(ch)] = ch.ID
+			}
+			ap.channelsMu.Unlock()
 
-		for _, ch := range chans {
-			ap.channels[ch.ID] = ch
-			ap.channelsInv[ch.Name] = ch.ID
-		}
+			if nextcur == "" {
+				break
+			}
 
-		if nextcur == "" {
-			break
+			params.Cursor = nextcur
 		}
-
-		params.Cursor = nextcur
 	}
 
+	ap.channelsMu.Lock()
+	ap.channelsCapped = limit.skipped
+	ap.channelsMu.Unlock()
+	limit.warn(ap.logger, RefreshChannels)
+
 	var res []Channel
+	cached := ap.ProvideChannelsMaps().Channels
 	for _, t := range channelTypes {
-		for _, channel := range ap.channels {
+		for _, channel := range cached {
 			if t == "public_channel" && !channel.IsPrivate {
 				res = append(res, channel)
 			}
@@ -629,33 +1503,373 @@ func (ap *ApiProvider) GetChannels(ctx context.Context, channelTypes []string) [
 		}
 	}
 
-	return res
+	return res, nil
 }
 
+// ProvideUsersMap returns a copy of the users cache, later refreshes and lookups don't change it.
+// Use User to look up a single user without copying the cache.
 func (ap *ApiProvider) ProvideUsersMap() *UsersCache {
-	return &UsersCache{
-		Users:    ap.users,
-		UsersInv: ap.usersInv,
+	ap.usersMu.RLock()
+	defer ap.usersMu.RUnlock()
+
+	cache := &UsersCache{
+		Users:    make(map[string]slack.User, len(ap.users)),
+		UsersInv: make(map[string]string, len(ap.usersInv)),
+	}
+	for id, u := range ap.users {
+		cache.Users[id] = u
 	}
+	for name, id := range ap.usersInv {
+		cache.UsersInv[name] = id
+	}
+	return cache
 }
 
+// User returns a cached user by ID
+func (ap *ApiProvider) User(id string) (slack.User, bool) {
+	ap.usersMu.RLock()
+	defer ap.usersMu.RUnlock()
+
+	u, ok := ap.users[id]
+	return u, ok
+}
+
+// channelCreated returns the creation time of a conversation as a unix timestamp. Conversations listed by the
+// edge client carry it in milliseconds, which never fit a plausible date in seconds.
+func channelCreated(created slack.JSONTime) int64 {
+	if ts := int64(created); ts > 1e11 {
+		return ts / 1000
+	}
+	return int64(created)
+}
+
+// AddChannel maps a conversation returned by Slack, e.g. by conversations.open, and adds it to the channels cache
+// unless SLACK_MCP_CACHE_MAX_ENTRIES is reached
+func (ap *ApiProvider) AddChannel(channel slack.Channel) Channel {
+	ch := mapChannel(
+		channel.ID,
+		channel.Name,
+		channel.NameNormalized,
+		channel.Topic.Value,
+		channel.Purpose.Value,
+		channel.User,
+		channel.Members,
+		channel.NumMembers,
+		channel.IsIM,
+		channel.IsMpIM,
+		channel.IsPrivate,
+		ap.ProvideUsersMap().Users,
+	)
+	ch.Created = channelCreated(channel.Created)
+	ch.TeamID = channelTeamID(channel, "")
+
+	limit := cacheCap{max: ap.cacheMaxEntries}
+	ap.channelsMu.Lock()
+	_, cached := ap.channels[ch.ID]
+	added := limit.admits(len(ap.channels), cached)
+	if added {
+		ap.channels[ch.ID] = ch
+		ap.channelsInv[ap.channelKey(ch)] = ch.ID
+	}
+	ap.channelsMu.Unlock()
+
+	if added {
+		ap.channelsChanged()
+	}
+	return ch
+}
+
+// UpdateChannel applies update to a cached channel, e.g. after its topic changed, and reports whether it was cached.
+// update runs with the channels cache locked and must not call back into the provider.
+func (ap *ApiProvider) UpdateChannel(id string, update func(*Channel)) (Channel, bool) {
+	ap.channelsMu.Lock()
+	c, ok := ap.channels[id]
+	if !ok {
+		ap.channelsMu.Unlock()
+		return Channel{}, false
+	}
+	update(&c)
+	ap.channels[id] = c
+	ap.channelsMu.Unlock()
+
+	ap.channelsChanged()
+	return c, true
+}
+
+// InvalidateChannel refetches a channel with conversations.info and replaces its cache entry, e.g. after
+// a rename or membership change. A channel Slack no longer finds, or one excluded by SLACK_MCP_CHANNEL_TYPES
+// or SLACK_MCP_INCLUDE_ARCHIVED, is removed from the cache and reported as not cached.
+func (ap *ApiProvider) InvalidateChannel(ctx context.Context, id string) (Channel, bool, error) {
+	channel, err := ap.client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+		ChannelID:         id,
+		IncludeNumMembers: true,
+	})
+	if err != nil {
+		if SlackErrorCode(err) != "channel_not_found" {
+			return Channel{}, false, err
+		}
+		ap.channelsMu.Lock()
+		ap.removeChannel(id)
+		ap.channelsMu.Unlock()
+		ap.channelsChanged()
+		return Channel{}, false, nil
+	}
+
+	ch := mapChannel(
+		channel.ID,
+		channel.Name,
+		channel.NameNormalized,
+		channel.Topic.Value,
+		channel.Purpose.Value,
+		channel.User,
+		channel.Members,
+		channel.NumMembers,
+		channel.IsIM,
+		channel.IsMpIM,
+		channel.IsPrivate,
+		ap.ProvideUsersMap().Users,
+	)
+	ch.IsArchived = channel.IsArchived
+	ch.Created = channelCreated(channel.Created)
+
+	// deferred first, so it runs once the lock is released
+	defer ap.channelsChanged()
+	ap.channelsMu.Lock()
+	defer ap.channelsMu.Unlock()
+
+	old, cached := ap.channels[id]
+	ch.TeamID = channelTeamID(*channel, old.TeamID)
+	// conversations.info omits members of group DMs, keep the names resolved from them
+	if ch.IsMpIM && len(channel.Members) == 0 && cached {
+		ch.Name, ch.Purpose, ch.MemberCount = old.Name, old.Purpose, old.MemberCount
+	}
+
+	ap.removeChannel(id)
+	limit := cacheCap{max: ap.cacheMaxEntries}
+	if !ap.keepsChannel(ch) || !limit.admits(len(ap.channels), false) {
+		return ch, false, nil
+	}
+	ap.channels[ch.ID] = ch
+	ap.channelsInv[ap.channelKey(ch)] = ch.ID
+	return ch, true, nil
+}
+
+// removeChannel drops a channel and its name from the cache, callers hold channelsMu
+func (ap *ApiProvider) removeChannel(id string) {
+	if old, ok := ap.channels[id]; ok {
+		delete(ap.channels, id)
+		if key := ap.channelKey(old); ap.channelsInv[key] == id {
+			delete(ap.channelsInv, key)
+		}
+	}
+}
+
+// InvalidateUser refetches a user with users.info and replaces its cache entry, e.g. after a rename or
+// deactivation. A user Slack no longer finds, or one excluded by SLACK_MCP_EXCLUDE_BOTS or
+// SLACK_MCP_EXCLUDE_DELETED, is removed from the cache and reported as not cached.
+func (ap *ApiProvider) InvalidateUser(ctx context.Context, id string) (slack.User, bool, error) {
+	users, err := ap.client.GetUsersInfoContext(ctx, id)
+	if err != nil && SlackErrorCode(err) != "user_not_found" {
+		return slack.User{}, false, err
+	}
+
+	ap.usersMu.Lock()
+	defer ap.usersMu.Unlock()
+
+	ap.dropUser(id)
+	if err != nil || users == nil || len(*users) == 0 {
+		return slack.User{}, false, nil
+	}
+
+	user := (*users)[0]
+	limit := cacheCap{max: ap.cacheMaxEntries}
+	if !ap.keepsUser(user) || !limit.admits(len(ap.users), false) {
+		return user, false, nil
+	}
+	ap.putUser(user)
+	return user, true, nil
+}
+
+// putUser adds or replaces a cached user. The handle of a renamed user is dropped from the inverse index,
+// so lookups by the old name no longer resolve. Callers hold usersMu.
+func (ap *ApiProvider) putUser(user slack.User) {
+	if old, ok := ap.users[user.ID]; ok && old.Name != user.Name && ap.usersInv[old.Name] == user.ID {
+		delete(ap.usersInv, old.Name)
+	}
+	ap.users[user.ID] = user
+	ap.usersInv[user.Name] = user.ID
+}
+
+// dropUser removes a cached user and its handle. Callers hold usersMu.
+func (ap *ApiProvider) dropUser(id string) {
+	if old, ok := ap.users[id]; ok {
+		delete(ap.users, id)
+		if ap.usersInv[old.Name] == id {
+			delete(ap.usersInv, old.Name)
+		}
+	}
+}
+
+// OnChannelsChanged registers fn to run after the channels cache changed: once it is refreshed, and when a
+// channel is added, updated, refetched or removed. fn runs without the cache locked, e.g. to read
+// ProvideChannelsMaps.
+func (ap *ApiProvider) OnChannelsChanged(fn func()) {
+	ap.channelsHooksMu.Lock()
+	defer ap.channelsHooksMu.Unlock()
+	ap.channelsHooks = append(ap.channelsHooks, fn)
+}
+
+func (ap *ApiProvider) channelsChanged() {
+	ap.channelsHooksMu.Lock()
+	hooks := append([]func(){}, ap.channelsHooks...)
+	ap.channelsHooksMu.Unlock()
+
+	for _, fn := range hooks {
+		fn()
+	}
+}
+
+// ProvideChannelsMaps returns a copy of the channels cache, later refreshes and updates don't change it.
+// For org-scoped tokens ChannelsInv only has the names that are not shared by several teams, see LookupChannel.
 func (ap *ApiProvider) ProvideChannelsMaps() *ChannelsCache {
-	return &ChannelsCache{
-		Channels:    ap.channels,
-		ChannelsInv: ap.channelsInv,
+	ap.channelsMu.RLock()
+	defer ap.channelsMu.RUnlock()
+
+	cache := &ChannelsCache{
+		Channels:    make(map[string]Channel, len(ap.channels)),
+		ChannelsInv: make(map[string]string, len(ap.channelsInv)),
+	}
+	for id, c := range ap.channels {
+		cache.Channels[id] = c
+	}
+	if !ap.IsOrgScoped() {
+		for name, id := range ap.channelsInv {
+			cache.ChannelsInv[name] = id
+		}
+		return cache
+	}
+
+	// names of an org-scoped cache are keyed by team, callers get the names found in a single team only
+	ambiguous := make(map[string]bool)
+	for id, c := range ap.channels {
+		if _, ok := cache.ChannelsInv[c.Name]; ok {
+			ambiguous[c.Name] = true
+			continue
+		}
+		cache.ChannelsInv[c.Name] = id
+	}
+	for name := range ambiguous {
+		delete(cache.ChannelsInv, name)
 	}
+	return cache
 }
 
+// CacheStats returns counters and refresh state of the users and channels caches
+func (ap *ApiProvider) CacheStats() CacheStats {
+	ap.usersMu.RLock()
+	defer ap.usersMu.RUnlock()
+
+	stats := CacheStats{
+		Users:              len(ap.users),
+		ChannelsByType:     make(map[string]int),
+		UsersLastRefresh:   ap.usersLastRefresh,
+		UsersRefreshMode:   ap.usersRefreshMode,
+		UsersUpdatedSince:  ap.usersUpdatedSince,
+		UsersDeltaWindow:   ap.usersDeltaWindow.String(),
+		UsersLastDeltaSize: ap.usersLastDeltaSize,
+		UsersExcluded:      ap.usersExcluded,
+		UsersCapped:        ap.usersCapped,
+		CacheMaxEntries:    ap.cacheMaxEntries,
+	}
+
+	ap.channelsMu.RLock()
+	stats.Channels = len(ap.channels)
+	stats.ChannelsCapped = ap.channelsCapped
+	for _, c := range ap.channels {
+		stats.ChannelsByType[ChannelType(c)]++
+	}
+	ap.channelsMu.RUnlock()
+
+	ap.emojiMu.RLock()
+	stats.Emoji = len(ap.emoji)
+	stats.EmojiLastRefresh = ap.emojiLastRefresh
+	ap.emojiMu.RUnlock()
+
+	if ap.slackCalls != nil {
+		stats.SlackCallsInFlight = ap.slackCalls.InFlight()
+		stats.SlackCallsLimit = ap.slackCalls.Capacity()
+	}
+	if ap.breaker != nil {
+		stats.BreakerState = ap.breaker.State()
+	}
+	stats.Refresh = ap.refresh.Snapshot()
+
+	return stats
+}
+
+// SlackCallsCapacity returns how many Slack API calls may be in flight at once, see SLACK_MCP_MAX_CONCURRENT_CALLS.
+// It is 0 when calls are not bounded, e.g. for providers built in tests.
+func (ap *ApiProvider) SlackCallsCapacity() int {
+	if ap.slackCalls == nil {
+		return 0
+	}
+	return ap.slackCalls.Capacity()
+}
+
+// IsReady reports whether the collections selected by SLACK_MCP_READY_REQUIRES have loaded, both users
+// and channels by default. The error tells which collection is missing, see CollectionReady.
 func (ap *ApiProvider) IsReady() (bool, error) {
-	if !ap.usersReady {
-		return false, ErrUsersNotReady
+	requires := ap.readyRequires
+	if requires == nil {
+		requires = defaultReadyRequires
 	}
-	if !ap.channelsReady {
-		return false, ErrChannelsNotReady
+	for _, collection := range requires {
+		if ready, err := ap.CollectionReady(collection); !ready {
+			return false, err
+		}
 	}
 	return true, nil
 }
 
+// CollectionReady reports whether the users or channels collection has loaded. A collection whose last
+// load failed returns ErrUsersNotReady or ErrChannelsNotReady wrapping the failure, so that tools and the
+// health check can tell a failed load from one still running.
+func (ap *ApiProvider) CollectionReady(collection string) (bool, error) {
+	if err := ap.AuthError(); err != nil {
+		return false, err
+	}
+
+	var ready bool
+	var notReady error
+	switch collection {
+	case RefreshUsers:
+		ap.usersMu.RLock()
+		ready, notReady = ap.usersReady, ErrUsersNotReady
+		ap.usersMu.RUnlock()
+	case RefreshChannels:
+		ap.channelsMu.RLock()
+		ready, notReady = ap.channelsReady, ErrChannelsNotReady
+		ap.channelsMu.RUnlock()
+	default:
+		return false, fmt.Errorf("unknown cache collection %q", collection)
+	}
+	if ready {
+		return true, nil
+	}
+	if stats, ok := ap.refresh.Snapshot()[collection]; ok && stats.LastError != "" {
+		return false, fmt.Errorf("%w, loading it failed: %s", notReady, stats.LastError)
+	}
+	return false, notReady
+}
+
+// AuthError returns a non-nil error wrapping ErrAuthInvalid once Slack rejected the credentials
+func (ap *ApiProvider) AuthError() error {
+	if c, ok := ap.client.(interface{ AuthError() error }); ok {
+		return c.AuthError()
+	}
+	return nil
+}
+
 func (ap *ApiProvider) ServerTransport() string {
 	return ap.transport
 }
@@ -664,6 +1878,11 @@ func (ap *ApiProvider) Slack() SlackAPI {
 	return ap.client
 }
 
+// Lifecycle returns the shared lifecycle state, nil for providers not built by New
+func (ap *ApiProvider) Lifecycle() *Lifecycle {
+	return ap.lifecycle
+}
+
 func mapChannel(
 	id, name, nameNormalized, topic, purpose, user string,
 	members []string,
@@ -716,3 +1935,447 @@ func mapChannel(
 		IsPrivate:   isPrivate,
 	}
 }
+
+// cacheFiles returns the users, channels and emoji cache paths, session based tokens
+// (xoxc/xoxd) default to a separate channels cache file
+func cacheFiles(sessionBased bool) (users, channels, emoji string) {
+	users = os.Getenv("SLACK_MCP_USERS_CACHE")
+	if users == "" {
+		users = ".users_cache.json"
+	}
+
+	channels = os.Getenv("SLACK_MCP_CHANNELS_CACHE")
+	if channels == "" {
+		channels = ".channels_cache.json"
+		if sessionBased {
+			channels = ".channels_cache_v2.json"
+		}
+	}
+
+	emoji = os.Getenv("SLACK_MCP_EMOJI_CACHE")
+	if emoji == "" {
+		emoji = ".emoji_cache.json"
+	}
+	return users, channels, emoji
+}
+
+// CacheFiles returns the cache file paths the provider uses with the configured tokens
+func CacheFiles() []string {
+	users, channels, emoji := cacheFiles(os.Getenv("SLACK_MCP_XOXP_TOKEN") == "")
+	return []string{users, channels, emoji}
+}
+
+// parseUsersDeltaWindow returns the maximum age of the last refresh for which a delta refresh is allowed
+func parseUsersDeltaWindow(logger *zap.Logger) time.Duration {
+	value := os.Getenv("SLACK_MCP_USERS_DELTA_WINDOW")
+	if value == "" {
+		return defaultUsersDeltaWindow
+	}
+
+	window, err := time.ParseDuration(value)
+	if err != nil || window <= 0 {
+		logger.Warn("Invalid SLACK_MCP_USERS_DELTA_WINDOW, using default",
+			zap.String("value", value),
+			zap.Duration("default", defaultUsersDeltaWindow))
+		return defaultUsersDeltaWindow
+	}
+
+	return window
+}
+
+// parseChannelTypes reads SLACK_MCP_CHANNEL_TYPES, a comma-separated subset of AllChanTypes, unset means all types
+func parseChannelTypes(logger *zap.Logger) []string {
+	value := os.Getenv("SLACK_MCP_CHANNEL_TYPES")
+	if value == "" {
+		return AllChanTypes
+	}
+
+	var types []string
+	for _, t := range strings.Split(value, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		valid := false
+		for _, known := range AllChanTypes {
+			if t == known {
+				valid = true
+			}
+		}
+		if !valid {
+			logger.Warn("Invalid SLACK_MCP_CHANNEL_TYPES, using all channel types",
+				zap.String("value", value),
+				zap.String("invalid_type", t),
+				zap.Strings("allowed", AllChanTypes))
+			return AllChanTypes
+		}
+		types = append(types, t)
+	}
+	if len(types) == 0 {
+		return AllChanTypes
+	}
+	return types
+}
+
+// keepsUser reports whether a user matches SLACK_MCP_EXCLUDE_BOTS and SLACK_MCP_EXCLUDE_DELETED
+func (ap *ApiProvider) keepsUser(u slack.User) bool {
+	if ap.excludeBots && u.IsBot {
+		return false
+	}
+	if ap.excludeDeleted && u.Deleted {
+		return false
+	}
+	return true
+}
+
+// CacheMissPolicy selects what happens when an ID shown by name is missing from the users or channels cache,
+// e.g. while the caches are still loading or for bots skipped by SLACK_MCP_EXCLUDE_BOTS
+type CacheMissPolicy string
+
+const (
+	// CacheMissFetch looks missing IDs up with users.info or conversations.info
+	CacheMissFetch CacheMissPolicy = "fetch"
+	// CacheMissRaw shows missing IDs unresolved
+	CacheMissRaw CacheMissPolicy = "raw"
+	// CacheMissError fails the tool call
+	CacheMissError CacheMissPolicy = "error"
+)
+
+// notFoundCodes are the Slack errors of a lookup that will fail again, the ID is remembered as a miss
+var notFoundCodes = map[string]bool{
+	"user_not_found":    true,
+	"users_not_found":   true,
+	"channel_not_found": true,
+}
+
+// parseCacheMissPolicy reads SLACK_MCP_CACHE_MISS_POLICY, fetch by default
+func parseCacheMissPolicy(logger *zap.Logger) CacheMissPolicy {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv("SLACK_MCP_CACHE_MISS_POLICY")))
+	switch policy := CacheMissPolicy(value); policy {
+	case "":
+		return CacheMissFetch
+	case CacheMissFetch, CacheMissRaw, CacheMissError:
+		return policy
+	default:
+		logger.Warn("Invalid SLACK_MCP_CACHE_MISS_POLICY, using fetch",
+			zap.String("value", value),
+			zap.Strings("allowed", []string{string(CacheMissFetch), string(CacheMissRaw), string(CacheMissError)}))
+		return CacheMissFetch
+	}
+}
+
+// fetchesUsers reports whether ResolveUsers looks missing users up
+func (ap *ApiProvider) fetchesUsers() bool {
+	return ap.cacheMissPolicy == CacheMissFetch
+}
+
+// ResolveUsers adds users referenced by ID that are missing from the users cache, e.g. bots or deactivated
+// users skipped by SLACK_MCP_EXCLUDE_BOTS or SLACK_MCP_EXCLUDE_DELETED, or any user while the cache is loading.
+// SLACK_MCP_CACHE_MISS_POLICY selects whether they are looked up with users.info and kept in memory only,
+// left unresolved, or reported as an ErrCacheMiss error. Failed lookups leave the users unresolved, users
+// Slack did not find are not looked up again for cacheMissTTL. Users over SLACK_MCP_CACHE_MAX_ENTRIES aren't kept.
+func (ap *ApiProvider) ResolveUsers(ctx context.Context, ids []string) error {
+	var missing []string
+	seen := make(map[string]bool)
+	ap.usersMu.RLock()
+	for _, id := range ids {
+		if _, ok := ap.users[id]; !ok && id != "" && !seen[id] {
+			seen[id] = true
+			missing = append(missing, id)
+		}
+	}
+	ap.usersMu.RUnlock()
+
+	if len(missing) == 0 {
+		return nil
+	}
+	if ap.cacheMissPolicy == CacheMissError {
+		return fmt.Errorf("users %s: %w, SLACK_MCP_CACHE_MISS_POLICY is error", strings.Join(missing, ", "), ErrCacheMiss)
+	}
+	if !ap.fetchesUsers() {
+		return nil
+	}
+
+	lookups := missing[:0]
+	for _, id := range missing {
+		if !ap.userMisses.has(id) {
+			lookups = append(lookups, id)
+		}
+	}
+
+	for start := 0; start < len(lookups); start += usersInfoBatchSize {
+		batch := lookups[start:min(start+usersInfoBatchSize, len(lookups))]
+		users, err := ap.client.GetUsersInfoContext(ctx, batch...)
+		if err != nil {
+			ap.logger.Warn("Failed to resolve users missing from cache", zap.Strings("users", batch), zap.Error(err))
+			if notFoundCodes[SlackErrorCode(err)] {
+				for _, id := range batch {
+					ap.userMisses.add(id)
+				}
+			}
+			continue
+		}
+
+		found := make(map[string]bool, len(*users))
+		limit := cacheCap{max: ap.cacheMaxEntries}
+		ap.usersMu.Lock()
+		for _, u := range *users {
+			found[u.ID] = true
+			if _, cached := ap.users[u.ID]; limit.admits(len(ap.users), cached) {
+				ap.putUser(u)
+			}
+		}
+		ap.usersMu.Unlock()
+		for _, id := range batch {
+			if !found[id] {
+				ap.userMisses.add(id)
+			}
+		}
+	}
+	return nil
+}
+
+// ResolveChannels adds channels referenced by ID that are missing from the channels cache, as selected by
+// SLACK_MCP_CACHE_MISS_POLICY like ResolveUsers. Lookups use conversations.info, one call per channel made
+// concurrently within SLACK_MCP_MAX_CONCURRENT_CALLS, and at most maxCacheMissChannels per call. Channels
+// Slack did not find are not looked up again for cacheMissTTL.
+func (ap *ApiProvider) ResolveChannels(ctx context.Context, ids []string) error {
+	var missing []string
+	seen := make(map[string]bool)
+	ap.channelsMu.RLock()
+	for _, id := range ids {
+		if _, ok := ap.channels[id]; !ok && id != "" && !seen[id] {
+			seen[id] = true
+			missing = append(missing, id)
+		}
+	}
+	ap.channelsMu.RUnlock()
+
+	if len(missing) == 0 {
+		return nil
+	}
+	if ap.cacheMissPolicy == CacheMissError {
+		return fmt.Errorf("channels %s: %w, SLACK_MCP_CACHE_MISS_POLICY is error", strings.Join(missing, ", "), ErrCacheMiss)
+	}
+	if ap.cacheMissPolicy != CacheMissFetch {
+		return nil
+	}
+
+	lookups := missing[:0]
+	for _, id := range missing {
+		if !ap.channelMisses.has(id) {
+			lookups = append(lookups, id)
+		}
+	}
+	missing = lookups
+	if len(missing) > maxCacheMissChannels {
+		ap.logger.Warn("Too many channels missing from cache, leaving the rest unresolved",
+			zap.Int("missing", len(missing)),
+			zap.Int("resolved", maxCacheMissChannels))
+		missing = missing[:maxCacheMissChannels]
+	}
+
+	found := make([]*slack.Channel, len(missing))
+	var wg sync.WaitGroup
+	for i, id := range missing {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			channel, err := ap.client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+				ChannelID:         id,
+				IncludeNumMembers: true,
+			})
+			if err != nil {
+				ap.logger.Warn("Failed to resolve channel missing from cache", zap.String("channel", id), zap.Error(err))
+				if notFoundCodes[SlackErrorCode(err)] {
+					ap.channelMisses.add(id)
+				}
+				return
+			}
+			found[i] = channel
+		}()
+	}
+	wg.Wait()
+
+	for _, channel := range found {
+		if channel != nil {
+			ap.AddChannel(*channel)
+		}
+	}
+	return nil
+}
+
+// defaultReadyRequires are the collections IsReady waits for unless SLACK_MCP_READY_REQUIRES says otherwise
+var defaultReadyRequires = []string{RefreshUsers, RefreshChannels}
+
+// parseReadyRequires reads SLACK_MCP_READY_REQUIRES, a comma separated list of users and channels or all
+func parseReadyRequires(logger *zap.Logger) []string {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv("SLACK_MCP_READY_REQUIRES")))
+	if value == "" || value == "all" {
+		return defaultReadyRequires
+	}
+
+	var requires []string
+	valid := true
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != RefreshUsers && item != RefreshChannels {
+			valid = false
+			break
+		}
+		if !slices.Contains(requires, item) {
+			requires = append(requires, item)
+		}
+	}
+	if !valid {
+		logger.Warn("Invalid SLACK_MCP_READY_REQUIRES, waiting for users and channels",
+			zap.String("value", value),
+			zap.Strings("allowed", []string{"all", RefreshUsers, RefreshChannels}))
+		return defaultReadyRequires
+	}
+	return requires
+}
+
+func isEnvTrue(name string) bool {
+	value := os.Getenv(name)
+	return value == "true" || value == "1"
+}
+
+// parseIncludeArchived reads SLACK_MCP_INCLUDE_ARCHIVED, archived channels are not cached by default
+func parseIncludeArchived() bool {
+	return isEnvTrue("SLACK_MCP_INCLUDE_ARCHIVED")
+}
+
+// ChannelType returns the conversations.list type of a cached channel, e.g. public_channel or im
+func ChannelType(c Channel) string {
+	switch {
+	case c.IsIM:
+		return "im"
+	case c.IsMpIM:
+		return "mpim"
+	case c.IsPrivate:
+		return PrivateChanType
+	default:
+		return PubChanType
+	}
+}
+
+// fetchedChannelTypes returns the channel types RefreshChannels requests from Slack
+func (ap *ApiProvider) fetchedChannelTypes() []string {
+	if len(ap.channelTypes) == 0 {
+		return AllChanTypes
+	}
+	return ap.channelTypes
+}
+
+// keepsChannel reports whether a channel matches SLACK_MCP_CHANNEL_TYPES and SLACK_MCP_INCLUDE_ARCHIVED
+func (ap *ApiProvider) keepsChannel(c Channel) bool {
+	if c.IsArchived && !ap.includeArchived {
+		return false
+	}
+	t := ChannelType(c)
+	for _, want := range ap.fetchedChannelTypes() {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
+
+func parseMaxConcurrentCalls(logger *zap.Logger) int {
+	value := os.Getenv("SLACK_MCP_MAX_CONCURRENT_CALLS")
+	if value == "" {
+		return defaultMaxConcurrentCalls
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		logger.Warn("Invalid SLACK_MCP_MAX_CONCURRENT_CALLS, using default",
+			zap.String("value", value),
+			zap.Int("default", defaultMaxConcurrentCalls))
+		return defaultMaxConcurrentCalls
+	}
+
+	return n
+}
+
+// parseBreaker reads SLACK_MCP_BREAKER_THRESHOLD and SLACK_MCP_BREAKER_COOLDOWN, the breaker is disabled when the
+// threshold is unset or 0
+func parseBreaker(logger *zap.Logger) *limiter.Breaker {
+	threshold, cooldown := parseBreakerSettings(logger)
+	if threshold == 0 {
+		return nil
+	}
+	return limiter.NewBreaker(threshold, cooldown)
+}
+
+// parseBreakerSettings returns the circuit breaker threshold and cooldown, see parseBreaker
+func parseBreakerSettings(logger *zap.Logger) (int, time.Duration) {
+	threshold := defaultBreakerThreshold
+	if value := os.Getenv("SLACK_MCP_BREAKER_THRESHOLD"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			logger.Warn("Invalid SLACK_MCP_BREAKER_THRESHOLD, using default",
+				zap.String("value", value),
+				zap.Int("default", defaultBreakerThreshold))
+		} else {
+			threshold = n
+		}
+	}
+
+	cooldown := defaultBreakerCooldown
+	if value := os.Getenv("SLACK_MCP_BREAKER_COOLDOWN"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			logger.Warn("Invalid SLACK_MCP_BREAKER_COOLDOWN, using default",
+				zap.String("value", value),
+				zap.Duration("default", defaultBreakerCooldown))
+		} else {
+			cooldown = d
+		}
+	}
+
+	return threshold, cooldown
+}
+
+// parseAPIBaseURL reads SLACK_MCP_API_BASE_URL, an https URL replacing the Slack Web API base
+// such as a mock server or an Enterprise proxy. The result always ends with a slash.
+func parseAPIBaseURL() (string, error) {
+	value := strings.TrimSpace(os.Getenv("SLACK_MCP_API_BASE_URL"))
+	if value == "" {
+		return "", nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid SLACK_MCP_API_BASE_URL %q: %w", value, err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return "", fmt.Errorf("invalid SLACK_MCP_API_BASE_URL %q: must be an absolute https URL", value)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid SLACK_MCP_API_BASE_URL %q: must not contain a query or fragment", value)
+	}
+
+	if !strings.HasSuffix(value, "/") {
+		value += "/"
+	}
+	return value, nil
+}
+
+func parseAPITimeout(logger *zap.Logger) time.Duration {
+	value := os.Getenv("SLACK_MCP_API_TIMEOUT")
+	if value == "" {
+		return defaultAPITimeout
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		logger.Warn("Invalid SLACK_MCP_API_TIMEOUT, using default",
+			zap.String("value", value),
+			zap.Duration("default", defaultAPITimeout))
+		return defaultAPITimeout
+	}
+
+	return timeout
+}
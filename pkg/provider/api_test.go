@@ -0,0 +1,1017 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"golang.org/x/time/rate"
+)
+
+type fakeSlackAPI struct {
+	SlackAPI
+
+	users          []slack.User
+	getUsersCalls  int
+	clientBootCall int
+
+	emoji         map[string]string
+	emojiErr      error
+	getEmojiCalls int
+
+	team             *slack.TeamInfo
+	getTeamInfoCalls int
+
+	teamProfile         *slack.TeamProfile
+	teamProfileErr      error
+	getTeamProfileCalls int
+
+	conversations      []slack.Channel
+	conversationsErr   error
+	conversationParams *slack.GetConversationsParameters
+
+	usersInfo          map[string]slack.User
+	usersInfoRequested []string
+
+	conversationInfo map[string]slack.Channel
+
+	authTestErr   error
+	authTestCalls int
+	scopes        []string
+}
+
+func (f *fakeSlackAPI) AuthTestContext(ctx context.Context) (*slack.AuthTestResponse, error) {
+	f.authTestCalls++
+	if f.authTestErr != nil {
+		return nil, f.authTestErr
+	}
+	return &slack.AuthTestResponse{UserID: "U1", User: "alice", TeamID: "T1", Team: "Acme", URL: "https://acme.slack.com/"}, nil
+}
+
+func (f *fakeSlackAPI) Scopes() []string {
+	return f.scopes
+}
+
+func (f *fakeSlackAPI) GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	c, ok := f.conversationInfo[input.ChannelID]
+	if !ok {
+		return nil, slack.SlackErrorResponse{Err: "channel_not_found"}
+	}
+	return &c, nil
+}
+
+func (f *fakeSlackAPI) GetUsersInfoContext(ctx context.Context, users ...string) (*[]slack.User, error) {
+	f.usersInfoRequested = append(f.usersInfoRequested, users...)
+	var res []slack.User
+	for _, id := range users {
+		if u, ok := f.usersInfo[id]; ok {
+			res = append(res, u)
+		}
+	}
+	return &res, nil
+}
+
+func (f *fakeSlackAPI) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	f.conversationParams = params
+	if f.conversationsErr != nil {
+		return nil, "", f.conversationsErr
+	}
+	return f.conversations, "", nil
+}
+
+func (f *fakeSlackAPI) GetUsersPagesContext(ctx context.Context, page func([]slack.User) error, options ...slack.GetUsersOption) error {
+	f.getUsersCalls++
+	return page(f.users)
+}
+
+func (f *fakeSlackAPI) GetEmojiContext(ctx context.Context) (map[string]string, error) {
+	f.getEmojiCalls++
+	if f.emojiErr != nil {
+		return nil, f.emojiErr
+	}
+	res := make(map[string]string, len(f.emoji))
+	for k, v := range f.emoji {
+		res[k] = v
+	}
+	return res, nil
+}
+
+func (f *fakeSlackAPI) GetTeamInfoContext(ctx context.Context) (*slack.TeamInfo, error) {
+	f.getTeamInfoCalls++
+	return f.team, nil
+}
+
+func (f *fakeSlackAPI) GetTeamProfileContext(ctx context.Context, teamID ...string) (*slack.TeamProfile, error) {
+	f.getTeamProfileCalls++
+	if f.teamProfileErr != nil {
+		return nil, f.teamProfileErr
+	}
+	return f.teamProfile, nil
+}
+
+func (f *fakeSlackAPI) ClientUserBoot(ctx context.Context) (*edge.ClientUserBootResponse, error) {
+	f.clientBootCall++
+	return &edge.ClientUserBootResponse{}, nil
+}
+
+func newTestProvider(t *testing.T, client SlackAPI) *ApiProvider {
+	t.Helper()
+
+	return &ApiProvider{
+		client:           client,
+		logger:           zap.NewNop(),
+		users:            make(map[string]slack.User),
+		usersInv:         map[string]string{},
+		usersCache:       filepath.Join(t.TempDir(), "users_cache.json"),
+		usersDeltaWindow: defaultUsersDeltaWindow,
+		cacheMissPolicy:  CacheMissFetch,
+		channels:         make(map[string]Channel),
+		channelsInv:      map[string]string{},
+		emoji:            make(map[string]Emoji),
+		emojiCache:       filepath.Join(t.TempDir(), "emoji_cache.json"),
+	}
+}
+
+func TestUnitRefreshUsersMerge(t *testing.T) {
+	client := &fakeSlackAPI{
+		users: []slack.User{
+			{ID: "U1", Name: "alice", Updated: 100},
+			{ID: "U2", Name: "bob", Updated: 200},
+		},
+	}
+	ap := newTestProvider(t, client)
+
+	// no prior state, falls back to full refresh
+	require.NoError(t, ap.RefreshUsersMerge(context.Background()))
+	stats := ap.CacheStats()
+	assert.Equal(t, "full", stats.UsersRefreshMode)
+	assert.Equal(t, 2, stats.Users)
+	assert.Equal(t, int64(200), stats.UsersUpdatedSince)
+	assert.Equal(t, 1, client.clientBootCall)
+
+	// only changed and new users are merged
+	client.users = []slack.User{
+		{ID: "U1", Name: "alice", Updated: 100},
+		{ID: "U2", Name: "bobby", Updated: 300},
+		{ID: "U3", Name: "carol", Updated: 150},
+	}
+	require.NoError(t, ap.RefreshUsersMerge(context.Background()))
+	stats = ap.CacheStats()
+	assert.Equal(t, "merge", stats.UsersRefreshMode)
+	assert.Equal(t, 3, stats.Users)
+	assert.Equal(t, 2, stats.UsersLastDeltaSize)
+	assert.Equal(t, int64(300), stats.UsersUpdatedSince)
+	assert.Equal(t, 1, client.clientBootCall, "merge refresh must not query Slack Connect users")
+
+	users := ap.ProvideUsersMap()
+	assert.Equal(t, "bobby", users.Users["U2"].Name)
+	assert.Equal(t, "U3", users.UsersInv["carol"])
+	assert.Equal(t, "U2", users.UsersInv["bobby"])
+	assert.NotContains(t, users.UsersInv, "bob", "the handle of a renamed user must no longer resolve")
+
+	_, err := os.Stat(ap.usersCache)
+	assert.NoError(t, err, "cache file must be written")
+}
+
+func TestUnitRefreshUsersMergeWindowExceeded(t *testing.T) {
+	client := &fakeSlackAPI{
+		users: []slack.User{{ID: "U1", Name: "alice", Updated: 100}},
+	}
+	ap := newTestProvider(t, client)
+	ap.usersDeltaWindow = time.Minute
+
+	require.NoError(t, ap.RefreshUsersMerge(context.Background()))
+	ap.usersLastRefresh = time.Now().Add(-time.Hour)
+
+	require.NoError(t, ap.RefreshUsersMerge(context.Background()))
+	assert.Equal(t, "full", ap.CacheStats().UsersRefreshMode)
+	assert.Equal(t, 2, client.clientBootCall)
+}
+
+func TestUnitResolveEmoji(t *testing.T) {
+	raw := map[string]string{
+		"party":      "https://emoji.slack-edge.com/T1/party/abc.gif",
+		"celebrate":  "alias:party",
+		"yay":        "alias:celebrate",
+		"thumbsup2":  "alias:+1",
+		"loop_a":     "alias:loop_b",
+		"loop_b":     "alias:loop_a",
+		"chained_up": "alias:thumbsup2",
+	}
+
+	emoji := resolveEmoji(raw)
+	byName := make(map[string]Emoji, len(emoji))
+	for _, e := range emoji {
+		byName[e.Name] = e
+	}
+
+	require.Len(t, emoji, len(raw))
+	assert.Equal(t, Emoji{Name: "party", Kind: EmojiKindCustom, URL: raw["party"]}, byName["party"])
+	assert.Equal(t, Emoji{Name: "celebrate", Kind: EmojiKindAlias, AliasFor: "party", URL: raw["party"]}, byName["celebrate"])
+	assert.Equal(t, Emoji{Name: "yay", Kind: EmojiKindAlias, AliasFor: "party", URL: raw["party"]}, byName["yay"])
+	assert.Equal(t, Emoji{Name: "thumbsup2", Kind: EmojiKindStandardAlias, AliasFor: "+1"}, byName["thumbsup2"])
+	assert.Equal(t, Emoji{Name: "chained_up", Kind: EmojiKindStandardAlias, AliasFor: "+1"}, byName["chained_up"])
+	assert.Equal(t, Emoji{Name: "loop_a", Kind: EmojiKindBrokenAlias, AliasFor: "loop_b"}, byName["loop_a"])
+	assert.Equal(t, Emoji{Name: "loop_b", Kind: EmojiKindBrokenAlias, AliasFor: "loop_a"}, byName["loop_b"])
+}
+
+func TestUnitEmojiCacheInvalidate(t *testing.T) {
+	client := &fakeSlackAPI{
+		emoji: map[string]string{"party": "https://example.com/party.gif"},
+	}
+	ap := newTestProvider(t, client)
+
+	emoji, err := ap.ProvideEmoji(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, emoji, 1)
+	assert.Equal(t, 1, ap.CacheStats().Emoji)
+
+	// served from memory and file cache without hitting Slack again
+	client.emoji["dance"] = "https://example.com/dance.gif"
+	_, err = ap.ProvideEmoji(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.getEmojiCalls)
+
+	require.NoError(t, ap.InvalidateEmojiCache())
+	assert.Equal(t, 0, ap.CacheStats().Emoji)
+
+	emoji, err = ap.ProvideEmoji(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, emoji, 2)
+	assert.Equal(t, 2, client.getEmojiCalls)
+}
+
+func TestUnitAPITimeout(t *testing.T) {
+	c := &MCPSlackClient{apiTimeout: 10 * time.Millisecond}
+
+	callCtx, cancel := c.withTimeout(context.Background())
+	defer cancel()
+	<-callCtx.Done()
+
+	err := c.callError(context.Background(), callCtx, callCtx.Err())
+	assert.ErrorIs(t, err, ErrAPITimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "the cause stays in the chain")
+	assert.Contains(t, err.Error(), "10ms")
+
+	// cancellation by the caller is not reported as a timeout
+	parent, parentCancel := context.WithCancel(context.Background())
+	callCtx, cancel = c.withTimeout(parent)
+	defer cancel()
+	parentCancel()
+	<-callCtx.Done()
+
+	err = c.callError(parent, callCtx, callCtx.Err())
+	assert.NotErrorIs(t, err, ErrAPITimeout)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// zero disables the per-call deadline
+	c.apiTimeout = 0
+	callCtx, cancel = c.withTimeout(context.Background())
+	defer cancel()
+	_, ok := callCtx.Deadline()
+	assert.False(t, ok)
+}
+
+func TestUnitParseAPITimeout(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", defaultAPITimeout},
+		{"45s", 45 * time.Second},
+		{"0", 0},
+		{"-1s", defaultAPITimeout},
+		{"soon", defaultAPITimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("SLACK_MCP_API_TIMEOUT", tt.value)
+			assert.Equal(t, tt.want, parseAPITimeout(zap.NewNop()))
+		})
+	}
+}
+
+func TestUnitAddChannel(t *testing.T) {
+	ap := newTestProvider(t, &fakeSlackAPI{})
+	ap.users["U1"] = slack.User{ID: "U1", Name: "alice", RealName: "Alice"}
+
+	dm := slack.Channel{}
+	dm.ID, dm.IsIM, dm.User = "D1", true, "U1"
+	got := ap.AddChannel(dm)
+	assert.Equal(t, "@alice", got.Name)
+	assert.Equal(t, "DM with Alice", got.Purpose)
+
+	mpim := slack.Channel{}
+	mpim.ID, mpim.IsMpIM, mpim.NameNormalized, mpim.Members = "G1", true, "mpdm-alice--bob-1", []string{"U1", "U2"}
+	ap.AddChannel(mpim)
+
+	channels := ap.ProvideChannelsMaps()
+	assert.Equal(t, "D1", channels.ChannelsInv["@alice"])
+	assert.Equal(t, "G1", channels.ChannelsInv["@mpdm-alice--bob-1"])
+	assert.Equal(t, "Group DM with Alice, U2", channels.Channels["G1"].Purpose)
+}
+
+func TestUnitChannelsCacheConcurrentReads(t *testing.T) {
+	client := &fakeSlackAPI{}
+	for i := 0; i < 50; i++ {
+		c := slack.Channel{}
+		c.ID, c.NameNormalized = fmt.Sprintf("C%d", i), fmt.Sprintf("channel-%d", i)
+		client.conversations = append(client.conversations, c)
+	}
+	ap := newTestProvider(t, client)
+	ap.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			_, _ = ap.GetChannels(context.Background(), nil)
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			assert.Equal(t, 50, ap.CacheStats().Channels)
+			channels := ap.ProvideChannelsMaps()
+			channels.Channels["C0"] = Channel{ID: "C0", Name: "#changed"}
+			assert.Equal(t, "#channel-0", ap.ProvideChannelsMaps().Channels["C0"].Name, "callers get a copy")
+			return
+		default:
+			ap.CacheStats()
+			ap.ProvideChannelsMaps()
+		}
+	}
+}
+
+func TestUnitAddChannelConcurrent(t *testing.T) {
+	ap := newTestProvider(t, &fakeSlackAPI{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := slack.Channel{}
+			c.ID, c.NameNormalized = fmt.Sprintf("C%d", i), fmt.Sprintf("channel-%d", i)
+			ap.AddChannel(c)
+			ap.CacheStats()
+		}(i)
+	}
+	wg.Wait()
+	assert.Len(t, ap.ProvideChannelsMaps().Channels, 20)
+}
+
+func TestUnitUpdateChannel(t *testing.T) {
+	ap := newTestProvider(t, &fakeSlackAPI{})
+	ap.channels["C1"] = Channel{ID: "C1", Name: "#general", Topic: "old"}
+
+	_, ok := ap.UpdateChannel("C2", func(c *Channel) { c.Topic = "new" })
+	assert.False(t, ok, "channels that are not cached are not added")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ap.UpdateChannel("C1", func(c *Channel) { c.Topic = fmt.Sprintf("topic %d", i) })
+			ap.ProvideChannelsMaps()
+		}(i)
+	}
+	wg.Wait()
+
+	got, ok := ap.UpdateChannel("C1", func(c *Channel) { c.Topic = "new" })
+	require.True(t, ok)
+	assert.Equal(t, "new", got.Topic)
+	assert.Equal(t, "new", ap.ProvideChannelsMaps().Channels["C1"].Topic)
+}
+
+func TestUnitUsersCacheConcurrentReads(t *testing.T) {
+	client := &fakeSlackAPI{}
+	for i := 0; i < 50; i++ {
+		client.users = append(client.users, slack.User{ID: fmt.Sprintf("U%d", i), Name: fmt.Sprintf("user-%d", i)})
+	}
+	ap := newTestProvider(t, client)
+	ap.usersCache = ""
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			assert.NoError(t, ap.RefreshUsers(context.Background()))
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			users := ap.ProvideUsersMap()
+			users.Users["U0"] = slack.User{ID: "U0", Name: "changed"}
+			u, ok := ap.User("U0")
+			require.True(t, ok)
+			assert.Equal(t, "user-0", u.Name, "callers get a copy")
+			return
+		default:
+			for range ap.ProvideUsersMap().Users {
+			}
+			ap.User("U1")
+			ap.CollectionReady(RefreshUsers)
+		}
+	}
+}
+
+func TestUnitParseBreakerIsOptIn(t *testing.T) {
+	tests := []struct {
+		value   string
+		enabled bool
+	}{
+		{"", false},
+		{"0", false},
+		{"5", true},
+		{"many", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("SLACK_MCP_BREAKER_THRESHOLD", tt.value)
+			assert.Equal(t, tt.enabled, parseBreaker(zap.NewNop()) != nil)
+		})
+	}
+}
+
+func TestUnitChannelCreated(t *testing.T) {
+	assert.Equal(t, int64(1700000000), channelCreated(slack.JSONTime(1700000000)))
+	assert.Equal(t, int64(1700000000), channelCreated(slack.JSONTime(1700000000123)))
+	assert.Equal(t, int64(0), channelCreated(0))
+}
+
+func TestUnitParseAPIBaseURL(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"https://slack-proxy.example.com/api", "https://slack-proxy.example.com/api/", false},
+		{"https://localhost:8443/api/", "https://localhost:8443/api/", false},
+		{"http://slack-proxy.example.com/api/", "", true},
+		{"slack-proxy.example.com/api/", "", true},
+		{"https:///api/", "", true},
+		{"https://slack-proxy.example.com/api/?token=x", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("SLACK_MCP_API_BASE_URL", tt.value)
+			got, err := parseAPIBaseURL()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUnitCheckAuth(t *testing.T) {
+	c := &MCPSlackClient{logger: zap.NewNop()}
+	ap := newTestProvider(t, c)
+	ap.usersReady, ap.channelsReady = true, true
+
+	err := c.checkAuth(errors.New("connection reset"))
+	assert.NotErrorIs(t, err, ErrAuthInvalid)
+	assert.NoError(t, ap.AuthError())
+
+	err = c.checkAuth(fmt.Errorf("users.list: %w", slack.SlackErrorResponse{Err: "invalid_auth"}))
+	assert.ErrorIs(t, err, ErrAuthInvalid)
+	assert.Equal(t, "invalid_auth", SlackErrorCode(err))
+
+	ready, err := ap.IsReady()
+	assert.False(t, ready)
+	assert.ErrorIs(t, err, ErrAuthInvalid)
+	assert.Contains(t, err.Error(), "invalid_auth")
+
+	// edge API errors of browser session tokens are detected as well
+	err = c.checkAuth(&edge.APIError{Err: "token_expired", Endpoint: "client.userBoot"})
+	assert.ErrorIs(t, err, ErrAuthInvalid)
+	assert.Equal(t, "token_expired", SlackErrorCode(ap.AuthError()))
+
+	// a successful auth.test clears the state
+	c.setAuthError(nil)
+	ready, err = ap.IsReady()
+	assert.True(t, ready)
+	assert.NoError(t, err)
+}
+
+func TestUnitProvideTeam(t *testing.T) {
+	client := &fakeSlackAPI{
+		team: &slack.TeamInfo{
+			ID:     "T1",
+			Name:   "Acme",
+			Domain: "acme",
+			Icon: map[string]interface{}{
+				"image_34":  "https://example.com/34.png",
+				"image_132": "https://example.com/132.png",
+			},
+		},
+	}
+	ap := newTestProvider(t, client)
+	assert.Equal(t, "", ap.TeamName())
+
+	team, err := ap.ProvideTeam(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Team{ID: "T1", Name: "Acme", Domain: "acme", IconURL: "https://example.com/132.png"}, team)
+
+	_, err = ap.ProvideTeam(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.getTeamInfoCalls, "team info must be cached")
+	assert.Equal(t, "Acme", ap.TeamName())
+
+	assert.Equal(t, "", teamIconURL(map[string]interface{}{"image_default": true, "image_34": "https://example.com/default.png"}))
+}
+
+func TestUnitProfileFields(t *testing.T) {
+	client := &fakeSlackAPI{teamProfileErr: slack.SlackErrorResponse{Err: "missing_scope"}}
+	ap := newTestProvider(t, client)
+
+	_, err := ap.ProfileFields(context.Background())
+	require.Error(t, err)
+
+	client.teamProfileErr = nil
+	client.teamProfile = &slack.TeamProfile{Fields: []slack.TeamProfileField{{ID: "Xf1", Label: "Department"}}}
+	fields, err := ap.ProfileFields(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Department", fields["Xf1"].Label)
+
+	_, err = ap.ProfileFields(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.getTeamProfileCalls, "failures are retried, loaded fields are cached")
+
+	ap.profileFieldsFetched = time.Now().Add(-profileFieldsTTL)
+	client.teamProfile = &slack.TeamProfile{Fields: []slack.TeamProfileField{{ID: "Xf1", Label: "Team"}}}
+	fields, err = ap.ProfileFields(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Team", fields["Xf1"].Label, "fields older than profileFieldsTTL are refetched")
+	assert.Equal(t, 3, client.getTeamProfileCalls)
+}
+
+func TestUnitRefreshChannelsFilters(t *testing.T) {
+	newChannel := func(id, name string, private, im, archived bool) slack.Channel {
+		c := slack.Channel{}
+		c.ID, c.NameNormalized, c.IsPrivate, c.IsIM, c.IsArchived = id, name, private, im, archived
+		return c
+	}
+	fake := &fakeSlackAPI{conversations: []slack.Channel{
+		newChannel("C1", "general", false, false, false),
+		newChannel("C2", "secret", true, false, false),
+		newChannel("C3", "old", false, false, true),
+		newChannel("D1", "", false, true, false),
+	}}
+
+	ap := newTestProvider(t, fake)
+	ap.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+	ap.channelsCache = filepath.Join(t.TempDir(), "channels_cache.json")
+	ap.channelTypes = []string{PubChanType, PrivateChanType}
+
+	require.NoError(t, ap.RefreshChannels(context.Background()))
+	assert.Equal(t, []string{PubChanType, PrivateChanType}, fake.conversationParams.Types)
+	assert.True(t, fake.conversationParams.ExcludeArchived)
+	assert.ElementsMatch(t, []string{"C1", "C2"}, mapKeys(ap.channels), "archived channels and types not requested are skipped")
+	assert.Equal(t, map[string]int{PubChanType: 1, PrivateChanType: 1}, ap.CacheStats().ChannelsByType)
+
+	// a cache file written with other settings is filtered on load
+	ap = newTestProvider(t, fake)
+	ap.channelsCache = filepath.Join(t.TempDir(), "channels_cache.json")
+	ap.channelTypes = []string{PubChanType}
+	ap.includeArchived = true
+	data, err := json.Marshal([]Channel{
+		{ID: "C1", Name: "#general"},
+		{ID: "C2", Name: "#secret", IsPrivate: true},
+		{ID: "C3", Name: "#old", IsArchived: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(ap.channelsCache, data, 0644))
+
+	require.NoError(t, ap.RefreshChannels(context.Background()))
+	assert.ElementsMatch(t, []string{"C1", "C3"}, mapKeys(ap.channels))
+}
+
+func TestUnitRefreshChannelsFailureCounters(t *testing.T) {
+	fake := &fakeSlackAPI{conversationsErr: errors.New("connection reset")}
+	ap := newTestProvider(t, fake)
+	ap.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+	ap.channelsCache = filepath.Join(t.TempDir(), "channels_cache.json")
+	core, logs := observer.New(zap.WarnLevel)
+	ap.refresh = newRefreshMetrics(zap.New(core))
+	ap.refresh.failureThreshold = 2
+
+	require.Error(t, ap.RefreshChannels(context.Background()))
+	require.Error(t, ap.RefreshChannels(context.Background()))
+
+	stats := ap.refresh.Snapshot()[RefreshChannels]
+	assert.Equal(t, int64(2), stats.Attempts)
+	assert.Equal(t, int64(2), stats.Failures)
+	assert.Equal(t, int64(2), stats.ConsecutiveFailures)
+	assert.Equal(t, "connection reset", stats.LastError)
+	assert.Equal(t, 1, logs.FilterMessage("Cache refresh keeps failing, cached data is going stale").Len())
+
+	fake.conversationsErr = nil
+	require.NoError(t, ap.RefreshChannels(context.Background()))
+	assert.Zero(t, ap.refresh.Snapshot()[RefreshChannels].ConsecutiveFailures)
+}
+
+func TestUnitRefreshChannelsFailure(t *testing.T) {
+	fake := &fakeSlackAPI{conversationsErr: slack.SlackErrorResponse{Err: "ratelimited"}}
+	ap := newTestProvider(t, fake)
+	ap.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+	ap.channelsCache = filepath.Join(t.TempDir(), "channels_cache.json")
+	ap.refresh = newRefreshMetrics(zap.NewNop())
+
+	err := ap.RefreshChannels(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "ratelimited", SlackErrorCode(err))
+	assert.NoFileExists(t, ap.channelsCache, "a failed listing is not cached for the next boot")
+
+	ready, err := ap.CollectionReady(RefreshChannels)
+	assert.False(t, ready)
+	assert.ErrorIs(t, err, ErrChannelsNotReady)
+	assert.Contains(t, err.Error(), "ratelimited")
+}
+
+func TestUnitParseChannelTypes(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNEL_TYPES", "")
+	assert.Equal(t, AllChanTypes, parseChannelTypes(zap.NewNop()))
+
+	t.Setenv("SLACK_MCP_CHANNEL_TYPES", "public_channel, private_channel")
+	assert.Equal(t, []string{"public_channel", "private_channel"}, parseChannelTypes(zap.NewNop()))
+
+	t.Setenv("SLACK_MCP_CHANNEL_TYPES", "public_channel,group")
+	assert.Equal(t, AllChanTypes, parseChannelTypes(zap.NewNop()), "invalid types fall back to all")
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestUnitRefreshUsersExclusions(t *testing.T) {
+	fake := &fakeSlackAPI{users: []slack.User{
+		{ID: "U1", Name: "alice"},
+		{ID: "U2", Name: "deploybot", IsBot: true},
+		{ID: "U3", Name: "bob", Deleted: true},
+	}}
+	ap := newTestProvider(t, fake)
+	ap.excludeBots = true
+	ap.excludeDeleted = true
+
+	require.NoError(t, ap.RefreshUsers(context.Background()))
+	assert.ElementsMatch(t, []string{"U1"}, mapKeys(ap.users))
+	assert.Equal(t, 1, ap.CacheStats().Users)
+	assert.Equal(t, 2, ap.CacheStats().UsersExcluded)
+
+	// the cache file only holds included users
+	reloaded := newTestProvider(t, fake)
+	reloaded.usersCache = ap.usersCache
+	require.NoError(t, reloaded.RefreshUsers(context.Background()))
+	assert.ElementsMatch(t, []string{"U1"}, mapKeys(reloaded.users))
+
+	// excluded users are looked up on demand when referenced
+	fake.usersInfo = map[string]slack.User{"U2": {ID: "U2", Name: "deploybot", IsBot: true}}
+	require.NoError(t, ap.ResolveUsers(context.Background(), []string{"U1", "U2", "U2"}))
+	assert.Equal(t, []string{"U2"}, fake.usersInfoRequested)
+	assert.Equal(t, "deploybot", ap.ProvideUsersMap().Users["U2"].Name)
+}
+
+func TestUnitCacheMissPolicy(t *testing.T) {
+	general := slack.Channel{}
+	general.ID = "C1"
+	general.Name = "general"
+	general.NameNormalized = "general"
+	fake := &fakeSlackAPI{
+		usersInfo:        map[string]slack.User{"U1": {ID: "U1", Name: "alice"}},
+		conversationInfo: map[string]slack.Channel{"C1": general},
+	}
+
+	ap := newTestProvider(t, fake)
+	ap.cacheMissPolicy = CacheMissRaw
+	require.NoError(t, ap.ResolveUsers(context.Background(), []string{"U1"}))
+	require.NoError(t, ap.ResolveChannels(context.Background(), []string{"C1"}))
+	assert.Empty(t, fake.usersInfoRequested, "raw leaves missing IDs unresolved")
+	assert.Empty(t, ap.channels)
+
+	ap.cacheMissPolicy = CacheMissError
+	err := ap.ResolveUsers(context.Background(), []string{"U1", "U2"})
+	assert.ErrorIs(t, err, ErrCacheMiss)
+	assert.ErrorContains(t, err, "users U1, U2")
+	assert.ErrorIs(t, ap.ResolveChannels(context.Background(), []string{"C1"}), ErrCacheMiss)
+	assert.Empty(t, fake.usersInfoRequested)
+
+	ap.cacheMissPolicy = CacheMissFetch
+	require.NoError(t, ap.ResolveUsers(context.Background(), []string{"U1", "U2"}))
+	require.NoError(t, ap.ResolveChannels(context.Background(), []string{"C1", "C404"}))
+	assert.Equal(t, []string{"U1", "U2"}, fake.usersInfoRequested)
+	assert.Equal(t, "alice", ap.ProvideUsersMap().Users["U1"].Name)
+	assert.Equal(t, "#general", ap.channels["C1"].Name)
+	assert.NotContains(t, ap.channels, "C404", "failed lookups stay unresolved")
+
+	// IDs Slack did not find are not looked up again
+	fake.usersInfoRequested = nil
+	require.NoError(t, ap.ResolveUsers(context.Background(), []string{"U2"}))
+	assert.Empty(t, fake.usersInfoRequested)
+	assert.True(t, ap.channelMisses.has("C404"))
+	fake.conversationInfo["C404"] = general
+	require.NoError(t, ap.ResolveChannels(context.Background(), []string{"C404"}))
+	assert.NotContains(t, ap.channels, "C404")
+
+	// cached IDs need no lookup, even with the error policy
+	ap.cacheMissPolicy = CacheMissError
+	assert.NoError(t, ap.ResolveUsers(context.Background(), []string{"U1"}))
+	assert.NoError(t, ap.ResolveChannels(context.Background(), []string{"C1"}))
+}
+
+func TestUnitParseCacheMissPolicy(t *testing.T) {
+	for value, want := range map[string]CacheMissPolicy{
+		"":      CacheMissFetch,
+		"fetch": CacheMissFetch,
+		"ERROR": CacheMissError,
+		"skip":  CacheMissFetch,
+	} {
+		t.Setenv("SLACK_MCP_CACHE_MISS_POLICY", value)
+		assert.Equal(t, want, parseCacheMissPolicy(zap.NewNop()), value)
+	}
+}
+
+func TestUnitInvalidateChannel(t *testing.T) {
+	renamed := slack.Channel{}
+	renamed.ID = "C1"
+	renamed.Name = "release"
+	renamed.NameNormalized = "release"
+	renamed.NumMembers = 7
+	archived := slack.Channel{}
+	archived.ID = "C2"
+	archived.Name = "old"
+	archived.NameNormalized = "old"
+	archived.IsArchived = true
+
+	fake := &fakeSlackAPI{conversationInfo: map[string]slack.Channel{"C1": renamed, "C2": archived}}
+	ap := newTestProvider(t, fake)
+	ap.channels["C1"] = Channel{ID: "C1", Name: "#releases", MemberCount: 5}
+	ap.channelsInv["#releases"] = "C1"
+	ap.channels["C2"] = Channel{ID: "C2", Name: "#old"}
+	ap.channelsInv["#old"] = "C2"
+	ap.channels["C3"] = Channel{ID: "C3", Name: "#deleted"}
+	ap.channelsInv["#deleted"] = "C3"
+
+	ch, cached, err := ap.InvalidateChannel(context.Background(), "C1")
+	require.NoError(t, err)
+	assert.True(t, cached)
+	assert.Equal(t, "#release", ch.Name)
+	assert.Equal(t, 7, ap.channels["C1"].MemberCount)
+	assert.Equal(t, "C1", ap.channelsInv["#release"])
+	assert.NotContains(t, ap.channelsInv, "#releases", "the old name must not resolve anymore")
+
+	_, cached, err = ap.InvalidateChannel(context.Background(), "C2")
+	require.NoError(t, err)
+	assert.False(t, cached, "archived channels are not cached by default")
+	assert.NotContains(t, ap.channels, "C2")
+
+	_, cached, err = ap.InvalidateChannel(context.Background(), "C3")
+	require.NoError(t, err)
+	assert.False(t, cached)
+	assert.NotContains(t, ap.channels, "C3")
+	assert.NotContains(t, ap.channelsInv, "#deleted")
+}
+
+func TestUnitInvalidateChannelConcurrent(t *testing.T) {
+	general := slack.Channel{}
+	general.ID, general.NameNormalized = "C1", "general"
+	ap := newTestProvider(t, &fakeSlackAPI{conversationInfo: map[string]slack.Channel{"C1": general}})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			_, _, err := ap.InvalidateChannel(context.Background(), id)
+			assert.NoError(t, err)
+			ap.CacheStats()
+		}([]string{"C1", "C404"}[i%2])
+	}
+	wg.Wait()
+
+	channels := ap.ProvideChannelsMaps()
+	assert.Equal(t, "C1", channels.ChannelsInv["#general"])
+	assert.NotContains(t, channels.Channels, "C404")
+}
+
+func TestUnitOnChannelsChanged(t *testing.T) {
+	general := slack.Channel{}
+	general.ID, general.NameNormalized = "C1", "general"
+	ap := newTestProvider(t, &fakeSlackAPI{conversationInfo: map[string]slack.Channel{"C1": general}})
+
+	var changes int
+	ap.OnChannelsChanged(func() {
+		// the hook runs without the cache locked
+		ap.ProvideChannelsMaps()
+		changes++
+	})
+
+	random := slack.Channel{}
+	random.ID, random.NameNormalized = "C2", "random"
+	ap.AddChannel(random)
+	assert.Equal(t, 1, changes, "adding a channel")
+
+	_, ok := ap.UpdateChannel("C2", func(c *Channel) { c.Purpose = "off topic" })
+	require.True(t, ok)
+	assert.Equal(t, 2, changes, "updating a channel")
+	_, ok = ap.UpdateChannel("C404", func(c *Channel) {})
+	require.False(t, ok)
+	assert.Equal(t, 2, changes, "updating an unknown channel changes nothing")
+
+	_, _, err := ap.InvalidateChannel(context.Background(), "C1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, changes, "refetching a channel")
+	_, _, err = ap.InvalidateChannel(context.Background(), "C2")
+	require.NoError(t, err)
+	assert.Equal(t, 4, changes, "removing a channel")
+}
+
+func TestUnitInvalidateUser(t *testing.T) {
+	fake := &fakeSlackAPI{usersInfo: map[string]slack.User{
+		"U1": {ID: "U1", Name: "alice.smith"},
+		"U2": {ID: "U2", Name: "bob", Deleted: true},
+	}}
+	ap := newTestProvider(t, fake)
+	ap.excludeDeleted = true
+	for _, u := range []slack.User{{ID: "U1", Name: "alice"}, {ID: "U2", Name: "bob"}, {ID: "U3", Name: "carol"}} {
+		ap.users[u.ID] = u
+		ap.usersInv[u.Name] = u.ID
+	}
+
+	user, cached, err := ap.InvalidateUser(context.Background(), "U1")
+	require.NoError(t, err)
+	assert.True(t, cached)
+	assert.Equal(t, "alice.smith", user.Name)
+	assert.Equal(t, "U1", ap.usersInv["alice.smith"])
+	assert.NotContains(t, ap.usersInv, "alice")
+
+	_, cached, err = ap.InvalidateUser(context.Background(), "U2")
+	require.NoError(t, err)
+	assert.False(t, cached, "deactivated users are excluded")
+	assert.NotContains(t, ap.users, "U2")
+
+	_, cached, err = ap.InvalidateUser(context.Background(), "U3")
+	require.NoError(t, err)
+	assert.False(t, cached)
+	assert.NotContains(t, ap.users, "U3")
+}
+
+func TestUnitRefreshMetrics(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	client := &fakeSlackAPI{emojiErr: errors.New("boom")}
+	ap := newTestProvider(t, client)
+	ap.refresh = &refreshMetrics{stats: make(map[string]*RefreshStats), failureThreshold: 2, logger: zap.New(core)}
+
+	require.Error(t, ap.RefreshEmoji(context.Background()))
+	assert.Equal(t, 0, logs.FilterMessage("Cache refresh keeps failing, cached data is going stale").Len())
+
+	require.Error(t, ap.RefreshEmoji(context.Background()))
+	assert.Equal(t, 1, logs.FilterMessage("Cache refresh keeps failing, cached data is going stale").Len())
+
+	stats := ap.CacheStats().Refresh[RefreshEmoji]
+	assert.Equal(t, int64(2), stats.Attempts)
+	assert.Equal(t, int64(2), stats.Failures)
+	assert.Equal(t, int64(2), stats.ConsecutiveFailures)
+	assert.Equal(t, "boom", stats.LastError)
+	assert.True(t, stats.LastSuccess.IsZero())
+
+	client.emojiErr = nil
+	client.emoji = map[string]string{"party": "https://example.com/party.png"}
+	require.NoError(t, ap.RefreshEmoji(context.Background()))
+	assert.Equal(t, 1, logs.FilterMessage("Cache refresh recovered").Len())
+
+	stats = ap.CacheStats().Refresh[RefreshEmoji]
+	assert.Equal(t, int64(3), stats.Attempts)
+	assert.Equal(t, int64(1), stats.Successes)
+	assert.Equal(t, int64(0), stats.ConsecutiveFailures)
+	assert.Empty(t, stats.LastError)
+	assert.False(t, stats.LastSuccess.IsZero())
+	assert.GreaterOrEqual(t, stats.TotalDuration, stats.LastDuration)
+
+	_, ok := ap.CacheStats().Refresh[RefreshUsers]
+	assert.False(t, ok, "collections never refreshed are not reported")
+}
+
+func TestUnitIdentity(t *testing.T) {
+	client := &fakeSlackAPI{authTestErr: errors.New("invalid_auth")}
+	ap := newTestProvider(t, client)
+
+	_, err := ap.Identity(context.Background())
+	require.Error(t, err)
+	_, err = ap.Identity(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 2, client.authTestCalls, "failures are not cached")
+
+	client.authTestErr = nil
+	client.scopes = []string{"channels:history", "chat:write"}
+	identity, err := ap.Identity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Identity{UserID: "U1", User: "alice", TeamID: "T1", Team: "Acme", URL: "https://acme.slack.com/", Scopes: []string{"channels:history", "chat:write"}}, identity)
+
+	_, err = ap.Identity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, client.authTestCalls, "a successful auth.test is reused within the TTL")
+
+	ap.identityFetched = time.Now().Add(-identityTTL)
+	_, err = ap.Identity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 4, client.authTestCalls, "an expired identity is fetched again")
+}
+
+func TestUnitCollectionReady(t *testing.T) {
+	ap := newTestProvider(t, &fakeSlackAPI{})
+	ap.refresh = newRefreshMetrics(zap.NewNop())
+	ap.channelsReady = true
+
+	loadErr := errors.New("ratelimited")
+	ap.refresh.observe(RefreshUsers, time.Now(), &loadErr)
+
+	ready, err := ap.CollectionReady(RefreshChannels)
+	assert.True(t, ready)
+	assert.NoError(t, err)
+
+	ready, err = ap.CollectionReady(RefreshUsers)
+	assert.False(t, ready)
+	assert.ErrorIs(t, err, ErrUsersNotReady)
+	assert.Contains(t, err.Error(), "ratelimited", "the failed load is reported")
+
+	ready, err = ap.IsReady()
+	assert.False(t, ready, "users are required by default")
+	assert.ErrorIs(t, err, ErrUsersNotReady)
+
+	ap.readyRequires = []string{RefreshChannels}
+	ready, err = ap.IsReady()
+	assert.True(t, ready, "only channels are required")
+	assert.NoError(t, err)
+}
+
+func TestUnitParseReadyRequires(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", []string{RefreshUsers, RefreshChannels}},
+		{"all", []string{RefreshUsers, RefreshChannels}},
+		{"channels", []string{RefreshChannels}},
+		{" Users , users ", []string{RefreshUsers}},
+		{"channels,emoji", []string{RefreshUsers, RefreshChannels}},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("SLACK_MCP_READY_REQUIRES", tt.value)
+		assert.Equal(t, tt.want, parseReadyRequires(zap.NewNop()), "value %q", tt.value)
+	}
+}
+
+func TestUnitBootRetry(t *testing.T) {
+	retry := BootRetry{MaxRetries: 3, Backoff: time.Second}
+	assert.Equal(t, time.Second, retry.Delay(1))
+	assert.Equal(t, 4*time.Second, retry.Delay(3))
+	assert.Equal(t, maxBootBackoff, retry.Delay(20))
+
+	retry.Backoff = time.Millisecond
+	calls := 0
+	_, err := retryBoot(retry, "connect", zap.NewNop(), func() (int, error) {
+		calls++
+		return 0, errors.New("connection reset")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 4, calls, "the first attempt and 3 retries")
+
+	calls = 0
+	_, err = retryBoot(retry, "connect", zap.NewNop(), func() (int, error) {
+		calls++
+		return 0, slack.SlackErrorResponse{Err: "invalid_auth"}
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, calls, "rejected credentials are not retried")
+
+	t.Setenv("SLACK_MCP_BOOT_MAX_RETRIES", "0")
+	t.Setenv("SLACK_MCP_BOOT_BACKOFF", "-1s")
+	assert.Equal(t, BootRetry{MaxRetries: 0, Backoff: defaultBootBackoff}, ParseBootRetry(zap.NewNop()))
+}
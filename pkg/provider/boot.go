@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultBootMaxRetries = 10
+	defaultBootBackoff    = time.Second
+	// maxBootBackoff caps the delay between two boot attempts
+	maxBootBackoff = 5 * time.Minute
+)
+
+// BootRetry is how often connecting to Slack and the initial cache warmup are retried before the
+// server gives up, see SLACK_MCP_BOOT_MAX_RETRIES and SLACK_MCP_BOOT_BACKOFF
+type BootRetry struct {
+	MaxRetries int
+	Backoff    time.Duration
+}
+
+// Delay returns how long to wait before the given retry, starting at 1. The backoff doubles with
+// every retry up to maxBootBackoff.
+func (b BootRetry) Delay(retry int) time.Duration {
+	delay := b.Backoff
+	for i := 1; i < retry && delay < maxBootBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBootBackoff)
+}
+
+// ParseBootRetry reads SLACK_MCP_BOOT_MAX_RETRIES and SLACK_MCP_BOOT_BACKOFF, invalid values fall back to the defaults
+func ParseBootRetry(logger *zap.Logger) BootRetry {
+	retry := BootRetry{MaxRetries: defaultBootMaxRetries, Backoff: defaultBootBackoff}
+
+	if value := os.Getenv("SLACK_MCP_BOOT_MAX_RETRIES"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			logger.Warn("Invalid SLACK_MCP_BOOT_MAX_RETRIES, using default",
+				zap.String("value", value),
+				zap.Int("default", defaultBootMaxRetries))
+		} else {
+			retry.MaxRetries = n
+		}
+	}
+
+	if value := os.Getenv("SLACK_MCP_BOOT_BACKOFF"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			logger.Warn("Invalid SLACK_MCP_BOOT_BACKOFF, using default",
+				zap.String("value", value),
+				zap.Duration("default", defaultBootBackoff))
+		} else {
+			retry.Backoff = d
+		}
+	}
+
+	return retry
+}
+
+// retryBoot calls fn until it succeeds or MaxRetries retries failed and returns the last error.
+// Rejected credentials are returned right away since retrying won't help.
+func retryBoot[T any](retry BootRetry, step string, logger *zap.Logger, fn func() (T, error)) (T, error) {
+	for attempt := 0; ; attempt++ {
+		res, err := fn()
+		if err == nil || attempt >= retry.MaxRetries || authErrorCodes[SlackErrorCode(err)] || errors.Is(err, ErrAuthInvalid) {
+			return res, err
+		}
+
+		delay := retry.Delay(attempt + 1)
+		logger.Warn("Boot step failed, retrying",
+			zap.String("context", "console"),
+			zap.String("step", step),
+			zap.Int("retry", attempt+1),
+			zap.Int("max_retries", retry.MaxRetries),
+			zap.Duration("backoff", delay),
+			zap.Error(err),
+		)
+		time.Sleep(delay)
+	}
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"os"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// parseCacheMaxEntries reads SLACK_MCP_CACHE_MAX_ENTRIES, the most users and the most channels kept in
+// memory each, 0 or unset means unlimited
+func parseCacheMaxEntries(logger *zap.Logger) int {
+	value := os.Getenv("SLACK_MCP_CACHE_MAX_ENTRIES")
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		logger.Warn("Invalid SLACK_MCP_CACHE_MAX_ENTRIES, caching without limit", zap.String("value", value))
+		return 0
+	}
+	return n
+}
+
+// cacheCap applies SLACK_MCP_CACHE_MAX_ENTRIES to one load of a collection and counts the entries left out
+type cacheCap struct {
+	max     int
+	skipped int
+}
+
+// admits reports whether an entry may be added to a collection holding size entries. Entries already
+// cached are always updated, new ones are refused once the collection is full.
+func (c *cacheCap) admits(size int, cached bool) bool {
+	if c.max <= 0 || cached || size < c.max {
+		return true
+	}
+	c.skipped++
+	return false
+}
+
+// warn logs once per load that the collection hit the cap, entries left out are then looked up on
+// demand as selected by SLACK_MCP_CACHE_MISS_POLICY
+func (c *cacheCap) warn(logger *zap.Logger, collection string) {
+	if c.skipped == 0 {
+		return
+	}
+	logger.Warn("Cache reached SLACK_MCP_CACHE_MAX_ENTRIES, further entries are not cached",
+		zap.String("context", "console"),
+		zap.String("collection", collection),
+		zap.Int("max_entries", c.max),
+		zap.Int("skipped", c.skipped),
+	)
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+func TestUnitCacheMaxEntries(t *testing.T) {
+	newChannel := func(id, name string) slack.Channel {
+		c := slack.Channel{}
+		c.ID, c.NameNormalized = id, name
+		return c
+	}
+	fake := &fakeSlackAPI{
+		users: []slack.User{{ID: "U1", Name: "alice"}, {ID: "U2", Name: "bob"}, {ID: "U3", Name: "carol"}},
+		conversations: []slack.Channel{
+			newChannel("C1", "general"),
+			newChannel("C2", "random"),
+			newChannel("C3", "ops"),
+		},
+	}
+
+	ap := newTestProvider(t, fake)
+	ap.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+	ap.channelsCache = filepath.Join(t.TempDir(), "channels_cache.json")
+	ap.channelTypes = []string{PubChanType}
+	ap.cacheMaxEntries = 2
+
+	require.NoError(t, ap.RefreshUsers(context.Background()))
+	require.NoError(t, ap.RefreshChannels(context.Background()))
+	assert.Len(t, ap.users, 2)
+	assert.Len(t, ap.channels, 2)
+	stats := ap.CacheStats()
+	assert.Equal(t, 1, stats.UsersCapped)
+	assert.Equal(t, 1, stats.ChannelsCapped)
+	assert.Equal(t, 2, stats.CacheMaxEntries)
+
+	// entries left out are looked up on demand
+	missing := ""
+	for _, u := range fake.users {
+		if _, ok := ap.users[u.ID]; !ok {
+			missing = u.ID
+		}
+	}
+	ap.cacheMissPolicy = CacheMissFetch
+	fake.usersInfo = map[string]slack.User{missing: {ID: missing, Name: "late"}}
+	require.NoError(t, ap.ResolveUsers(context.Background(), []string{missing}))
+	assert.Equal(t, []string{missing}, fake.usersInfoRequested)
+	assert.Len(t, ap.users, 2, "users looked up on demand are not kept over the cap")
+	ap.AddChannel(newChannel("C4", "late"))
+	assert.Len(t, ap.channels, 2, "channels added on demand are not kept over the cap")
+
+	// merge refreshes count the users left out afresh and write only the cached users
+	fake.users[0].Updated = 100
+	for i := 0; i < 3; i++ {
+		ap.usersLastRefresh, ap.usersUpdatedSince = time.Now(), 50
+		require.NoError(t, ap.RefreshUsersMerge(context.Background()))
+		assert.Equal(t, "merge", ap.CacheStats().UsersRefreshMode)
+		assert.Equal(t, 1, ap.CacheStats().UsersCapped)
+	}
+	data, err := os.ReadFile(ap.usersCache)
+	require.NoError(t, err)
+	var written []slack.User
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.Len(t, written, 2)
+
+	// a refresh of cached entries is not capped
+	ap = newTestProvider(t, fake)
+	ap.cacheMaxEntries = 3
+	require.NoError(t, ap.RefreshUsers(context.Background()))
+	assert.Len(t, ap.users, 3)
+	assert.Zero(t, ap.CacheStats().UsersCapped)
+}
+
+func TestUnitParseCacheMaxEntries(t *testing.T) {
+	for value, want := range map[string]int{
+		"":     0,
+		"0":    0,
+		"5000": 5000,
+		"-1":   0,
+		"many": 0,
+	} {
+		t.Setenv("SLACK_MCP_CACHE_MAX_ENTRIES", value)
+		assert.Equal(t, want, parseCacheMaxEntries(zap.NewNop()), value)
+	}
+}
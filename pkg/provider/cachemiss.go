@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// cacheMissTTL is how long an ID that users.info or conversations.info could not resolve is not looked up again
+	cacheMissTTL = 10 * time.Minute
+	// maxCacheMisses bounds the remembered IDs per collection, the oldest ones are dropped first
+	maxCacheMisses = 10000
+)
+
+// missCache remembers IDs whose lookup failed, so that tool calls referencing an unknown or inaccessible
+// user or channel over and over don't repeat the same Slack call. The zero value is ready to use.
+type missCache struct {
+	mu     sync.Mutex
+	misses map[string]time.Time
+	now    func() time.Time
+}
+
+func (m *missCache) clock() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+// add remembers that the lookup of id failed
+func (m *missCache) add(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock()
+	if m.misses == nil {
+		m.misses = make(map[string]time.Time)
+	}
+	if len(m.misses) >= maxCacheMisses {
+		oldestID := ""
+		for missID, at := range m.misses {
+			if now.Sub(at) > cacheMissTTL {
+				delete(m.misses, missID)
+				continue
+			}
+			if oldestID == "" || at.Before(m.misses[oldestID]) {
+				oldestID = missID
+			}
+		}
+		if len(m.misses) >= maxCacheMisses {
+			delete(m.misses, oldestID)
+		}
+	}
+	m.misses[id] = now
+}
+
+// has reports whether the lookup of id failed less than cacheMissTTL ago
+func (m *missCache) has(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	at, ok := m.misses[id]
+	if !ok {
+		return false
+	}
+	if m.clock().Sub(at) > cacheMissTTL {
+		delete(m.misses, id)
+		return false
+	}
+	return true
+}
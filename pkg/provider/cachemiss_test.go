@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitMissCache(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	var misses missCache
+	assert.False(t, misses.has("C404"))
+
+	misses.now = func() time.Time { return now }
+	misses.add("C404")
+	assert.True(t, misses.has("C404"))
+
+	now = now.Add(cacheMissTTL + time.Second)
+	assert.False(t, misses.has("C404"), "misses expire")
+
+	for i := 0; i < maxCacheMisses+1; i++ {
+		now = now.Add(time.Millisecond)
+		misses.add(fmt.Sprintf("C%d", i))
+	}
+	assert.Len(t, misses.misses, maxCacheMisses)
+	assert.False(t, misses.has("C0"), "the oldest miss is dropped")
+	assert.True(t, misses.has(fmt.Sprintf("C%d", maxCacheMisses)))
+}
@@ -0,0 +1,723 @@
+package provider
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
+	"github.com/slack-go/slack"
+)
+
+//go:embed demo_data.json
+var defaultDemoData []byte
+
+// errDemoUnsupported is returned by demo API calls that have no fixture equivalent
+var errDemoUnsupported = errors.New("not available in demo mode")
+
+// demoData is the fixture set served in demo mode. Users, channels and messages use the
+// JSON shape of the Slack Web API, messages are keyed by channel ID.
+type demoData struct {
+	Team     slack.TeamInfo             `json:"team"`
+	Self     string                     `json:"self"`
+	Users    []slack.User               `json:"users"`
+	Channels []slack.Channel            `json:"channels"`
+	Messages map[string][]slack.Message `json:"messages"`
+	// ProfileFields are the custom profile fields returned by team.profile.get
+	ProfileFields []slack.TeamProfileField `json:"profile_fields"`
+}
+
+// IsDemoMode reports whether the demo credentials are configured
+func IsDemoMode() bool {
+	return os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo")
+}
+
+// loadDemoData reads the fixtures from path, or the built-in set when path is empty
+func loadDemoData(path string) (*demoData, error) {
+	raw := defaultDemoData
+	if path != "" {
+		var err error
+		if raw, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("reading SLACK_MCP_DEMO_DATA: %w", err)
+		}
+	}
+
+	var data demoData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("parsing demo data: %w", err)
+	}
+	if len(data.Users) == 0 {
+		return nil, errors.New("demo data must contain at least one user")
+	}
+	if data.Self == "" {
+		data.Self = data.Users[0].ID
+	}
+	for i := range data.Channels {
+		if data.Channels[i].NameNormalized == "" {
+			data.Channels[i].NameNormalized = data.Channels[i].Name
+		}
+	}
+	if data.Messages == nil {
+		data.Messages = make(map[string][]slack.Message)
+	}
+	for id, msgs := range data.Messages {
+		sort.Slice(msgs, func(i, j int) bool { return demoTs(msgs[i].Timestamp) < demoTs(msgs[j].Timestamp) })
+		for i := range msgs {
+			msgs[i].Channel = id
+		}
+	}
+	return &data, nil
+}
+
+// demoSlackAPI implements SlackAPI on top of the demo fixtures, so tools can be exercised
+// without a workspace. Posted messages, reactions, topics and statuses are kept in memory.
+type demoSlackAPI struct {
+	mu    sync.Mutex
+	data  *demoData
+	seq   int
+	prefs map[string]edge.ChannelNotificationPrefs
+}
+
+func newDemoSlackAPI(path string) (*demoSlackAPI, error) {
+	data, err := loadDemoData(path)
+	if err != nil {
+		return nil, err
+	}
+	return &demoSlackAPI{data: data, prefs: make(map[string]edge.ChannelNotificationPrefs)}, nil
+}
+
+func demoTs(ts string) float64 {
+	f, _ := strconv.ParseFloat(ts, 64)
+	return f
+}
+
+func (d *demoSlackAPI) user(id string) (*slack.User, bool) {
+	for i := range d.data.Users {
+		if d.data.Users[i].ID == id {
+			return &d.data.Users[i], true
+		}
+	}
+	return nil, false
+}
+
+func (d *demoSlackAPI) channel(id string) (*slack.Channel, bool) {
+	for i := range d.data.Channels {
+		if d.data.Channels[i].ID == id {
+			return &d.data.Channels[i], true
+		}
+	}
+	return nil, false
+}
+
+func (d *demoSlackAPI) message(channel, ts string) (*slack.Message, bool) {
+	msgs := d.data.Messages[channel]
+	for i := range msgs {
+		if msgs[i].Timestamp == ts {
+			return &msgs[i], true
+		}
+	}
+	return nil, false
+}
+
+func demoChannelType(c slack.Channel) string {
+	switch {
+	case c.IsIM:
+		return "im"
+	case c.IsMpIM:
+		return "mpim"
+	case c.IsPrivate:
+		return PrivateChanType
+	}
+	return PubChanType
+}
+
+// demoPage applies a numeric offset cursor and a limit to n items
+func demoPage(n int, cursor string, limit int) (start, end int, next string) {
+	start, _ = strconv.Atoi(cursor)
+	if start > n {
+		start = n
+	}
+	end = n
+	if limit > 0 && start+limit < n {
+		end = start + limit
+		next = strconv.Itoa(end)
+	}
+	return start, end, next
+}
+
+func (d *demoSlackAPI) AuthTest() (*slack.AuthTestResponse, error) {
+	return d.AuthTestContext(context.Background())
+}
+
+func (d *demoSlackAPI) AuthTestContext(ctx context.Context) (*slack.AuthTestResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	resp := &slack.AuthTestResponse{
+		URL:    fmt.Sprintf("https://%s.slack.com/", d.data.Team.Domain),
+		Team:   d.data.Team.Name,
+		TeamID: d.data.Team.ID,
+		UserID: d.data.Self,
+	}
+	if u, ok := d.user(d.data.Self); ok {
+		resp.User = u.Name
+	}
+	return resp, nil
+}
+
+func (d *demoSlackAPI) GetUsersPagesContext(ctx context.Context, page func([]slack.User) error, options ...slack.GetUsersOption) error {
+	d.mu.Lock()
+	users := append([]slack.User(nil), d.data.Users...)
+	d.mu.Unlock()
+
+	return page(users)
+}
+
+func (d *demoSlackAPI) GetUsersInfo(users ...string) (*[]slack.User, error) {
+	return d.GetUsersInfoContext(context.Background(), users...)
+}
+
+func (d *demoSlackAPI) GetUsersInfoContext(ctx context.Context, users ...string) (*[]slack.User, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var res []slack.User
+	for _, arg := range users {
+		for _, id := range strings.Split(arg, ",") {
+			u, ok := d.user(strings.TrimSpace(id))
+			if !ok {
+				return nil, slack.SlackErrorResponse{Err: "user_not_found"}
+			}
+			res = append(res, *u)
+		}
+	}
+	return &res, nil
+}
+
+func (d *demoSlackAPI) PostMessageContext(ctx context.Context, channel string, options ...slack.MsgOption) (string, string, error) {
+	_, values, err := slack.UnsafeApplyMsgOptions("", channel, "", options...)
+	if err != nil {
+		return "", "", err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.channel(channel); !ok {
+		return "", "", slack.SlackErrorResponse{Err: "channel_not_found"}
+	}
+
+	d.seq++
+	msg := slack.Message{}
+	msg.Type = slack.TYPE_MESSAGE
+	msg.Channel = channel
+	msg.User = d.data.Self
+	msg.Text = values.Get("text")
+	msg.Timestamp = fmt.Sprintf("%d.%06d", time.Now().Unix(), d.seq)
+
+	if threadTs := values.Get("thread_ts"); threadTs != "" {
+		parent, ok := d.message(channel, threadTs)
+		if !ok {
+			return "", "", slack.SlackErrorResponse{Err: "thread_not_found"}
+		}
+		parent.ThreadTimestamp = threadTs
+		parent.ReplyCount++
+		msg.ThreadTimestamp = threadTs
+		if values.Get("reply_broadcast") == "true" {
+			msg.SubType = slack.MsgSubTypeThreadBroadcast
+		}
+	}
+
+	d.data.Messages[channel] = append(d.data.Messages[channel], msg)
+	return channel, msg.Timestamp, nil
+}
+
+func (d *demoSlackAPI) PostEphemeralContext(ctx context.Context, channel, user string, options ...slack.MsgOption) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.channel(channel); !ok {
+		return "", slack.SlackErrorResponse{Err: "channel_not_found"}
+	}
+	d.seq++
+	return fmt.Sprintf("%d.%06d", time.Now().Unix(), d.seq), nil
+}
+
+func (d *demoSlackAPI) AddReactionContext(ctx context.Context, name string, item slack.ItemRef) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	msg, ok := d.message(item.Channel, item.Timestamp)
+	if !ok {
+		return slack.SlackErrorResponse{Err: "message_not_found"}
+	}
+	for i := range msg.Reactions {
+		if msg.Reactions[i].Name != name {
+			continue
+		}
+		for _, u := range msg.Reactions[i].Users {
+			if u == d.data.Self {
+				return slack.SlackErrorResponse{Err: "already_reacted"}
+			}
+		}
+		msg.Reactions[i].Count++
+		msg.Reactions[i].Users = append(msg.Reactions[i].Users, d.data.Self)
+		return nil
+	}
+	msg.Reactions = append(msg.Reactions, slack.ItemReaction{Name: name, Count: 1, Users: []string{d.data.Self}})
+	return nil
+}
+
+func (d *demoSlackAPI) MarkConversationContext(ctx context.Context, channel, ts string) error {
+	return nil
+}
+
+func (d *demoSlackAPI) GetScheduledMessagesContext(ctx context.Context, params *slack.GetScheduledMessagesParameters) ([]slack.ScheduledMessage, string, error) {
+	return nil, "", nil
+}
+
+func (d *demoSlackAPI) OpenConversationContext(ctx context.Context, params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(params.Users) == 1 {
+		for _, c := range d.data.Channels {
+			if c.IsIM && c.User == params.Users[0] {
+				return &c, false, true, nil
+			}
+		}
+	}
+	return nil, false, false, errDemoUnsupported
+}
+
+func (d *demoSlackAPI) GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	c, ok := d.channel(params.ChannelID)
+	if !ok {
+		return nil, "", slack.SlackErrorResponse{Err: "channel_not_found"}
+	}
+	members := c.Members
+	if c.IsIM {
+		members = []string{d.data.Self, c.User}
+	}
+	start, end, next := demoPage(len(members), params.Cursor, params.Limit)
+	return append([]string(nil), members[start:end]...), next, nil
+}
+
+func (d *demoSlackAPI) GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	c, ok := d.channel(input.ChannelID)
+	if !ok {
+		return nil, slack.SlackErrorResponse{Err: "channel_not_found"}
+	}
+	res := *c
+	return &res, nil
+}
+
+func (d *demoSlackAPI) SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	c, ok := d.channel(channelID)
+	if !ok {
+		return nil, slack.SlackErrorResponse{Err: "channel_not_found"}
+	}
+	c.Topic.Value = topic
+	res := *c
+	return &res, nil
+}
+
+func (d *demoSlackAPI) SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (*slack.Channel, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	c, ok := d.channel(channelID)
+	if !ok {
+		return nil, slack.SlackErrorResponse{Err: "channel_not_found"}
+	}
+	c.Purpose.Value = purpose
+	res := *c
+	return &res, nil
+}
+
+// JoinConversationContext succeeds for every demo channel, the demo user can read all of them already
+func (d *demoSlackAPI) JoinConversationContext(ctx context.Context, channelID string) (*slack.Channel, string, []string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	c, ok := d.channel(channelID)
+	if !ok {
+		return nil, "", nil, slack.SlackErrorResponse{Err: "channel_not_found"}
+	}
+	res := *c
+	return &res, "", nil, nil
+}
+
+func (d *demoSlackAPI) SetUserPresenceContext(ctx context.Context, presence string) error {
+	return nil
+}
+
+func (d *demoSlackAPI) SetUserCustomStatusContext(ctx context.Context, statusText, statusEmoji string, statusExpiration int64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if u, ok := d.user(d.data.Self); ok {
+		u.Profile.StatusText = statusText
+		u.Profile.StatusEmoji = statusEmoji
+		u.Profile.StatusExpiration = int(statusExpiration)
+	}
+	return nil
+}
+
+func (d *demoSlackAPI) GetUserProfileContext(ctx context.Context, params *slack.GetUserProfileParameters) (*slack.UserProfile, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	id := params.UserID
+	if id == "" {
+		id = d.data.Self
+	}
+	u, ok := d.user(id)
+	if !ok {
+		return nil, slack.SlackErrorResponse{Err: "user_not_found"}
+	}
+	profile := u.Profile
+	return &profile, nil
+}
+
+// GetConversationHistoryContext returns the top level messages of a channel, newest first
+func (d *demoSlackAPI) GetConversationHistoryContext(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.channel(params.ChannelID); !ok {
+		return nil, slack.SlackErrorResponse{Err: "channel_not_found"}
+	}
+
+	var msgs []slack.Message
+	all := d.data.Messages[params.ChannelID]
+	for i := len(all) - 1; i >= 0; i-- {
+		m := all[i]
+		// replies are left out of the history unless they were broadcast to the channel
+		if m.ThreadTimestamp != "" && m.ThreadTimestamp != m.Timestamp && m.SubType != slack.MsgSubTypeThreadBroadcast {
+			continue
+		}
+		if !demoInRange(m.Timestamp, params.Oldest, params.Latest, params.Inclusive) {
+			continue
+		}
+		msgs = append(msgs, m)
+	}
+
+	start, end, next := demoPage(len(msgs), params.Cursor, params.Limit)
+	resp := &slack.GetConversationHistoryResponse{
+		HasMore:  next != "",
+		Messages: msgs[start:end],
+	}
+	resp.Ok = true
+	resp.ResponseMetaData.NextCursor = next
+	return resp, nil
+}
+
+// GetConversationRepliesContext returns the parent message and its replies, oldest first
+func (d *demoSlackAPI) GetConversationRepliesContext(ctx context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.channel(params.ChannelID); !ok {
+		return nil, false, "", slack.SlackErrorResponse{Err: "channel_not_found"}
+	}
+	if _, ok := d.message(params.ChannelID, params.Timestamp); !ok {
+		return nil, false, "", slack.SlackErrorResponse{Err: "thread_not_found"}
+	}
+
+	var msgs []slack.Message
+	for _, m := range d.data.Messages[params.ChannelID] {
+		if m.Timestamp != params.Timestamp && m.ThreadTimestamp != params.Timestamp {
+			continue
+		}
+		if !demoInRange(m.Timestamp, params.Oldest, params.Latest, params.Inclusive) {
+			continue
+		}
+		msgs = append(msgs, m)
+	}
+
+	start, end, next := demoPage(len(msgs), params.Cursor, params.Limit)
+	return msgs[start:end], next != "", next, nil
+}
+
+func demoInRange(ts, oldest, latest string, inclusive bool) bool {
+	t := demoTs(ts)
+	if oldest != "" {
+		if o := demoTs(oldest); t < o || (t == o && !inclusive) {
+			return false
+		}
+	}
+	if latest != "" {
+		if l := demoTs(latest); t > l || (t == l && !inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchContext matches the free text of the query case-insensitively against message texts.
+// Of the search modifiers only in: and from: are applied, the others are ignored.
+func (d *demoSlackAPI) SearchContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchMessages, *slack.SearchFiles, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var terms, in, from []string
+	for _, tok := range strings.Fields(query) {
+		key, val, ok := strings.Cut(tok, ":")
+		val = strings.Trim(val, "<#@>")
+		switch {
+		case ok && key == "in":
+			in = append(in, val)
+		case ok && key == "from":
+			from = append(from, val)
+		case ok:
+		default:
+			terms = append(terms, strings.ToLower(tok))
+		}
+	}
+
+	var matches []slack.SearchMessage
+	for _, c := range d.data.Channels {
+		if len(in) > 0 && !demoMatchesAny(in, c.ID, c.Name, c.User) {
+			continue
+		}
+		msgs := d.data.Messages[c.ID]
+		for i := len(msgs) - 1; i >= 0; i-- {
+			m := msgs[i]
+			username := ""
+			if u, ok := d.user(m.User); ok {
+				username = u.Name
+			}
+			if len(from) > 0 && !demoMatchesAny(from, m.User, username) {
+				continue
+			}
+			if !demoContainsAll(strings.ToLower(m.Text), terms) {
+				continue
+			}
+			matches = append(matches, slack.SearchMessage{
+				Type:      "message",
+				Channel:   slack.CtxChannel{ID: c.ID, Name: c.Name, IsMPIM: c.IsMpIM, IsPrivate: c.IsPrivate},
+				User:      m.User,
+				Username:  username,
+				Timestamp: m.Timestamp,
+				Text:      m.Text,
+				Permalink: fmt.Sprintf("https://%s.slack.com/archives/%s/p%s", d.data.Team.Domain, c.ID, strings.ReplaceAll(m.Timestamp, ".", "")),
+			})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return demoTs(matches[i].Timestamp) > demoTs(matches[j].Timestamp) })
+
+	count, page := params.Count, params.Page
+	if count <= 0 {
+		count = slack.DEFAULT_SEARCH_COUNT
+	}
+	if page <= 0 {
+		page = 1
+	}
+	pages := (len(matches) + count - 1) / count
+	start := min((page-1)*count, len(matches))
+	end := min(start+count, len(matches))
+
+	res := &slack.SearchMessages{
+		Matches: matches[start:end],
+		Total:   len(matches),
+	}
+	res.Paging = slack.Paging{Count: count, Total: len(matches), Page: page, Pages: pages}
+	res.Pagination = slack.Pagination{TotalCount: len(matches), Page: page, PerPage: count, PageCount: pages, First: start + 1, Last: end}
+	return res, &slack.SearchFiles{}, nil
+}
+
+func demoMatchesAny(values []string, candidates ...string) bool {
+	for _, v := range values {
+		for _, c := range candidates {
+			if c != "" && strings.EqualFold(v, c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func demoContainsAll(text string, terms []string) bool {
+	for _, t := range terms {
+		if !strings.Contains(text, t) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *demoSlackAPI) ListBookmarksContext(ctx context.Context, channelID string) ([]slack.Bookmark, error) {
+	return nil, nil
+}
+
+func (d *demoSlackAPI) AddBookmarkContext(ctx context.Context, channelID string, params slack.AddBookmarkParameters) (slack.Bookmark, error) {
+	return slack.Bookmark{}, errDemoUnsupported
+}
+
+func (d *demoSlackAPI) RemoveBookmarkContext(ctx context.Context, channelID, bookmarkID string) error {
+	return errDemoUnsupported
+}
+
+func (d *demoSlackAPI) GetEmojiContext(ctx context.Context) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (d *demoSlackAPI) GetTeamInfoContext(ctx context.Context) (*slack.TeamInfo, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	team := d.data.Team
+	return &team, nil
+}
+
+func (d *demoSlackAPI) GetTeamProfileContext(ctx context.Context, teamID ...string) (*slack.TeamProfile, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return &slack.TeamProfile{Fields: d.data.ProfileFields}, nil
+}
+
+func (d *demoSlackAPI) GetFilesContext(ctx context.Context, params slack.GetFilesParameters) ([]slack.File, *slack.Paging, error) {
+	return nil, &slack.Paging{}, nil
+}
+
+// SearchFilesContext finds nothing, the demo workspace has no files
+func (d *demoSlackAPI) SearchFilesContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
+	return &slack.SearchFiles{}, nil
+}
+
+func (d *demoSlackAPI) GetFileInfoContext(ctx context.Context, fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error) {
+	return nil, nil, nil, slack.SlackErrorResponse{Err: "file_not_found"}
+}
+
+func (d *demoSlackAPI) GetFileContext(ctx context.Context, downloadURL string, writer io.Writer) error {
+	return errDemoUnsupported
+}
+
+func (d *demoSlackAPI) UploadFileV2Context(ctx context.Context, params slack.UploadFileV2Parameters) (*slack.FileSummary, error) {
+	return nil, errDemoUnsupported
+}
+
+func (d *demoSlackAPI) LookupCanvasSectionsContext(ctx context.Context, params slack.LookupCanvasSectionsParams) ([]slack.CanvasSection, error) {
+	return nil, errDemoUnsupported
+}
+
+func (d *demoSlackAPI) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var res []slack.Channel
+	for _, c := range d.data.Channels {
+		if len(params.Types) > 0 && !demoMatchesAny(params.Types, demoChannelType(c)) {
+			continue
+		}
+		if params.ExcludeArchived && c.IsArchived {
+			continue
+		}
+		res = append(res, c)
+	}
+
+	start, end, next := demoPage(len(res), params.Cursor, params.Limit)
+	return res[start:end], next, nil
+}
+
+func (d *demoSlackAPI) GetConversationsForUserContext(ctx context.Context, params *slack.GetConversationsForUserParameters) ([]slack.Channel, string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	user := params.UserID
+	if user == "" {
+		user = d.data.Self
+	}
+	if _, ok := d.user(user); !ok {
+		return nil, "", slack.SlackErrorResponse{Err: "user_not_found"}
+	}
+
+	var res []slack.Channel
+	for _, c := range d.data.Channels {
+		if len(params.Types) > 0 && !demoMatchesAny(params.Types, demoChannelType(c)) {
+			continue
+		}
+		if params.ExcludeArchived && c.IsArchived {
+			continue
+		}
+		member := demoMatchesAny(c.Members, user)
+		if c.IsIM {
+			member = user == d.data.Self || user == c.User
+		}
+		if member {
+			res = append(res, c)
+		}
+	}
+
+	start, end, next := demoPage(len(res), params.Cursor, params.Limit)
+	return res[start:end], next, nil
+}
+
+func (d *demoSlackAPI) ClientUserBoot(ctx context.Context) (*edge.ClientUserBootResponse, error) {
+	return &edge.ClientUserBootResponse{}, nil
+}
+
+// IsBrowserSession is true so that the demo serves the edge API tools
+func (d *demoSlackAPI) IsBrowserSession() bool {
+	return true
+}
+
+func (d *demoSlackAPI) ClientCounts(ctx context.Context) (edge.ClientCountsResponse, error) {
+	return edge.ClientCountsResponse{}, nil
+}
+
+func (d *demoSlackAPI) ListWorkflowTriggersContext(ctx context.Context, params WorkflowTriggersParameters) ([]WorkflowTrigger, string, error) {
+	return nil, "", nil
+}
+
+func (d *demoSlackAPI) UsersPrefsGet(ctx context.Context) (edge.NotificationPrefs, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	prefs := edge.NotificationPrefs{Channels: make(map[string]edge.ChannelNotificationPrefs, len(d.prefs))}
+	for id, p := range d.prefs {
+		prefs.Channels[id] = p
+	}
+	return prefs, nil
+}
+
+func (d *demoSlackAPI) UsersPrefsSetChannelNotifications(ctx context.Context, channelID string, muted *bool, desktop, mobile string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.channel(channelID); !ok {
+		return slack.SlackErrorResponse{Err: "channel_not_found"}
+	}
+	p := d.prefs[channelID]
+	if muted != nil {
+		p.Muted = *muted
+	}
+	if desktop != "" {
+		p.Desktop = desktop
+	}
+	if mobile != "" {
+		p.Mobile = mobile
+	}
+	d.prefs[channelID] = p
+	return nil
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitDemoProvider(t *testing.T) {
+	t.Setenv("SLACK_MCP_XOXP_TOKEN", "demo")
+	t.Setenv("SLACK_MCP_DEMO_DATA", "")
+
+	ap := New("stdio", zap.NewNop())
+	ctx := context.Background()
+
+	ready, err := ap.IsReady()
+	assert.False(t, ready)
+	assert.ErrorIs(t, err, ErrUsersNotReady)
+
+	require.NoError(t, ap.RefreshUsers(ctx))
+	require.NoError(t, ap.RefreshChannels(ctx))
+
+	ready, err = ap.IsReady()
+	require.NoError(t, err)
+	assert.True(t, ready)
+
+	assert.Contains(t, ap.ProvideUsersMap().UsersInv, "alice")
+	channels := ap.ProvideChannelsMaps()
+	assert.Equal(t, "C0DEMO0001", channels.ChannelsInv["#general"])
+	assert.Equal(t, "@bob", channels.Channels["D0DEMO0001"].Name)
+
+	ar, err := ap.Slack().AuthTest()
+	require.NoError(t, err)
+	assert.Equal(t, "U0DEMO0001", ar.UserID)
+	assert.Equal(t, "Demo Team", ar.Team)
+
+	_, ts, err := ap.Slack().PostMessageContext(ctx, "C0DEMO0001",
+		slack.MsgOptionText("hello from the demo", false),
+		slack.MsgOptionTS("1700000100.000100"),
+	)
+	require.NoError(t, err)
+
+	history, err := ap.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{ChannelID: "C0DEMO0001", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, history.Messages, 1)
+	assert.Equal(t, "1700000100.000100", history.Messages[0].Timestamp)
+	assert.Equal(t, 3, history.Messages[0].ReplyCount)
+	assert.True(t, history.HasMore)
+
+	replies, _, _, err := ap.Slack().GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{ChannelID: "C0DEMO0001", Timestamp: "1700000100.000100"})
+	require.NoError(t, err)
+	require.Len(t, replies, 4)
+	assert.Equal(t, ts, replies[3].Timestamp)
+	assert.Equal(t, "hello from the demo", replies[3].Text)
+
+	search, _, err := ap.Slack().SearchContext(ctx, "release in:#general", slack.SearchParameters{Count: 20, Page: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, search.Total)
+
+	_, err = ap.Slack().GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{ChannelID: "C404"})
+	assert.EqualError(t, err, "channel_not_found")
+
+	assert.NoFileExists(t, ".users_cache.json")
+	assert.NoFileExists(t, ".channels_cache.json")
+}
+
+func TestUnitDemoDataFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "demo.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"team": {"id": "T1", "name": "Acme", "domain": "acme"},
+		"users": [{"id": "U1", "name": "zoe"}],
+		"channels": [{"id": "C1", "name": "ops", "is_channel": true, "members": ["U1"]}],
+		"messages": {"C1": [{"user": "U1", "text": "second", "ts": "2.0"}, {"user": "U1", "text": "first", "ts": "1.0"}]}
+	}`), 0644))
+
+	api, err := newDemoSlackAPI(path)
+	require.NoError(t, err)
+
+	ar, err := api.AuthTest()
+	require.NoError(t, err)
+	assert.Equal(t, "U1", ar.UserID, "self defaults to the first user")
+	assert.Equal(t, "zoe", ar.User)
+
+	history, err := api.GetConversationHistoryContext(context.Background(), &slack.GetConversationHistoryParameters{ChannelID: "C1"})
+	require.NoError(t, err)
+	require.Len(t, history.Messages, 2)
+	assert.Equal(t, "second", history.Messages[0].Text)
+
+	_, err = newDemoSlackAPI(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"users": []}`), 0644))
+	_, err = newDemoSlackAPI(path)
+	assert.EqualError(t, err, "demo data must contain at least one user")
+}
@@ -0,0 +1,118 @@
+package edge
+
+import (
+	"context"
+	"encoding/json"
+	"runtime/trace"
+	"strconv"
+)
+
+// users.prefs.* API
+
+type usersPrefsGetForm struct {
+	BaseRequest
+	WebClientFields
+}
+
+type usersPrefsGetResponse struct {
+	baseResponse
+	Prefs struct {
+		// AllNotificationsPrefs is a JSON document encoded as a string
+		AllNotificationsPrefs string `json:"all_notifications_prefs"`
+	} `json:"prefs"`
+}
+
+// NotificationPrefs holds the notification preferences of the authenticated user
+type NotificationPrefs struct {
+	Channels map[string]ChannelNotificationPrefs `json:"channels"`
+}
+
+// ChannelNotificationPrefs are the notification preferences of a channel. Desktop and Mobile
+// are one of everything, mention, nothing or default, i.e. the global preference.
+type ChannelNotificationPrefs struct {
+	Muted   bool   `json:"muted"`
+	Desktop string `json:"desktop"`
+	Mobile  string `json:"mobile"`
+}
+
+// UsersPrefsGet returns the notification preferences of the authenticated user
+func (cl *Client) UsersPrefsGet(ctx context.Context) (NotificationPrefs, error) {
+	ctx, task := trace.NewTask(ctx, "UsersPrefsGet")
+	defer task.End()
+
+	form := usersPrefsGetForm{
+		BaseRequest:     BaseRequest{Token: cl.token},
+		WebClientFields: webclientReason("prefs-store/fetchPrefs"),
+	}
+	resp, err := cl.PostForm(ctx, "users.prefs.get", values(form, true))
+	if err != nil {
+		return NotificationPrefs{}, err
+	}
+	var r usersPrefsGetResponse
+	if err := cl.ParseResponse(&r, resp); err != nil {
+		return NotificationPrefs{}, err
+	}
+	if err := r.validate("users.prefs.get"); err != nil {
+		return NotificationPrefs{}, err
+	}
+
+	prefs := NotificationPrefs{}
+	if r.Prefs.AllNotificationsPrefs != "" {
+		if err := json.Unmarshal([]byte(r.Prefs.AllNotificationsPrefs), &prefs); err != nil {
+			return NotificationPrefs{}, err
+		}
+	}
+	if prefs.Channels == nil {
+		prefs.Channels = make(map[string]ChannelNotificationPrefs)
+	}
+	return prefs, nil
+}
+
+type usersPrefsSetNotificationsForm struct {
+	BaseRequest
+	ChannelID string `json:"channel_id"`
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	Global    bool   `json:"global"`
+	WebClientFields
+}
+
+// UsersPrefsSetChannelNotifications updates the notification preferences of a channel, nil leaves
+// muted unchanged and empty desktop or mobile levels leave them unchanged
+func (cl *Client) UsersPrefsSetChannelNotifications(ctx context.Context, channelID string, muted *bool, desktop, mobile string) error {
+	ctx, task := trace.NewTask(ctx, "UsersPrefsSetChannelNotifications")
+	defer task.End()
+
+	updates := make([][2]string, 0, 3)
+	if muted != nil {
+		updates = append(updates, [2]string{"muted", strconv.FormatBool(*muted)})
+	}
+	if desktop != "" {
+		updates = append(updates, [2]string{"desktop", desktop})
+	}
+	if mobile != "" {
+		updates = append(updates, [2]string{"mobile", mobile})
+	}
+
+	for _, u := range updates {
+		form := usersPrefsSetNotificationsForm{
+			BaseRequest:     BaseRequest{Token: cl.token},
+			ChannelID:       channelID,
+			Name:            u[0],
+			Value:           u[1],
+			WebClientFields: webclientReason("prefs-store/setChannelNotificationPrefs"),
+		}
+		resp, err := cl.PostForm(ctx, "users.prefs.setNotifications", values(form, true))
+		if err != nil {
+			return err
+		}
+		var r baseResponse
+		if err := cl.ParseResponse(&r, resp); err != nil {
+			return err
+		}
+		if err := r.validate("users.prefs.setNotifications"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
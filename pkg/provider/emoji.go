@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// EmojiKindCustom is a custom emoji uploaded to the workspace
+	EmojiKindCustom = "custom"
+	// EmojiKindAlias is an alias pointing to a custom emoji
+	EmojiKindAlias = "alias"
+	// EmojiKindStandardAlias is an alias pointing to a standard (built-in) emoji
+	EmojiKindStandardAlias = "standard_alias"
+	// EmojiKindBrokenAlias is an alias whose chain is cyclic or longer than maxEmojiAliasDepth
+	EmojiKindBrokenAlias = "broken_alias"
+)
+
+const emojiAliasPrefix = "alias:"
+
+// maxEmojiAliasDepth protects alias resolution from cycles in emoji.list responses
+const maxEmojiAliasDepth = 10
+
+type Emoji struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	AliasFor string `json:"alias_for,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// RefreshEmoji loads custom emoji from the cache file or fetches them from emoji.list
+func (ap *ApiProvider) RefreshEmoji(ctx context.Context) (err error) {
+	defer ap.refresh.observe(RefreshEmoji, time.Now(), &err)
+
+	if data, err := ioutil.ReadFile(ap.emojiCache); err == nil {
+		var cachedEmoji []Emoji
+		if err := json.Unmarshal(data, &cachedEmoji); err != nil {
+			ap.logger.Warn("Failed to unmarshal emoji cache, will refetch",
+				zap.String("cache_file", ap.emojiCache),
+				zap.Error(err))
+		} else {
+			ap.setEmoji(cachedEmoji)
+			ap.logger.Info("Loaded emoji from cache",
+				zap.Int("count", len(cachedEmoji)),
+				zap.String("cache_file", ap.emojiCache))
+			return nil
+		}
+	}
+
+	raw, err := ap.client.GetEmojiContext(ctx)
+	if err != nil {
+		ap.logger.Error("Failed to fetch emoji", zap.Error(err))
+		return err
+	}
+
+	emoji := resolveEmoji(raw)
+	ap.setEmoji(emoji)
+
+	if ap.emojiCache == "" {
+		return nil
+	}
+	if data, err := json.MarshalIndent(emoji, "", "  "); err != nil {
+		ap.logger.Error("Failed to marshal emoji for cache", zap.Error(err))
+	} else {
+		if err := ioutil.WriteFile(ap.emojiCache, data, 0644); err != nil {
+			ap.logger.Error("Failed to write cache file",
+				zap.String("cache_file", ap.emojiCache),
+				zap.Error(err))
+		} else {
+			ap.logger.Info("Wrote emoji to cache",
+				zap.Int("count", len(emoji)),
+				zap.String("cache_file", ap.emojiCache))
+		}
+	}
+
+	return nil
+}
+
+// InvalidateEmojiCache drops in-memory and on-disk emoji so the next access refetches them
+func (ap *ApiProvider) InvalidateEmojiCache() error {
+	ap.emojiMu.Lock()
+	ap.emoji = make(map[string]Emoji)
+	ap.emojiReady = false
+	ap.emojiMu.Unlock()
+
+	if err := os.Remove(ap.emojiCache); err != nil && !os.IsNotExist(err) {
+		ap.logger.Error("Failed to remove emoji cache file",
+			zap.String("cache_file", ap.emojiCache),
+			zap.Error(err))
+		return err
+	}
+
+	ap.logger.Info("Emoji cache invalidated", zap.String("cache_file", ap.emojiCache))
+	return nil
+}
+
+// ProvideEmoji returns custom emoji sorted by name, loading them on first access
+func (ap *ApiProvider) ProvideEmoji(ctx context.Context) ([]Emoji, error) {
+	ap.emojiMu.RLock()
+	ready := ap.emojiReady
+	ap.emojiMu.RUnlock()
+
+	if !ready {
+		if err := ap.RefreshEmoji(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	ap.emojiMu.RLock()
+	defer ap.emojiMu.RUnlock()
+
+	res := make([]Emoji, 0, len(ap.emoji))
+	for _, e := range ap.emoji {
+		res = append(res, e)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+
+	return res, nil
+}
+
+func (ap *ApiProvider) setEmoji(emoji []Emoji) {
+	ap.emojiMu.Lock()
+	defer ap.emojiMu.Unlock()
+
+	ap.emoji = make(map[string]Emoji, len(emoji))
+	for _, e := range emoji {
+		ap.emoji[e.Name] = e
+	}
+	ap.emojiReady = true
+	ap.emojiLastRefresh = time.Now()
+}
+
+// resolveEmoji converts an emoji.list response into emoji with alias chains resolved.
+// Aliases that end in a name missing from the response point to standard emoji, aliases that never reach
+// an emoji are broken and keep their direct target in AliasFor.
+func resolveEmoji(raw map[string]string) []Emoji {
+	res := make([]Emoji, 0, len(raw))
+	for name, value := range raw {
+		if !strings.HasPrefix(value, emojiAliasPrefix) {
+			res = append(res, Emoji{Name: name, Kind: EmojiKindCustom, URL: value})
+			continue
+		}
+
+		target := strings.TrimPrefix(value, emojiAliasPrefix)
+		e := Emoji{Name: name, Kind: EmojiKindBrokenAlias, AliasFor: target}
+		for depth := 0; depth < maxEmojiAliasDepth; depth++ {
+			next, ok := raw[target]
+			if !ok {
+				e.Kind = EmojiKindStandardAlias
+				e.AliasFor = target
+				break
+			}
+			if !strings.HasPrefix(next, emojiAliasPrefix) {
+				e.Kind = EmojiKindAlias
+				e.AliasFor = target
+				e.URL = next
+				break
+			}
+			target = strings.TrimPrefix(next, emojiAliasPrefix)
+		}
+		res = append(res, e)
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// ErrNameNotFound is returned by LookupChannel and LookupUser when no cached object has the name
+var ErrNameNotFound = errors.New("name not found")
+
+// ErrAmbiguousName is returned by LookupChannel and LookupUser when the name exists in several teams
+// of an Enterprise Grid org and no team_id was given to pick one
+var ErrAmbiguousName = errors.New("name is ambiguous across teams, pass team_id to pick one")
+
+// authTestResponse is auth.test as Slack returns it, slack-go drops is_enterprise_install
+type authTestResponse struct {
+	slack.SlackResponse
+	slack.AuthTestResponse
+	IsEnterpriseInstall bool `json:"is_enterprise_install"`
+}
+
+// isOrgScopedAuth reports whether auth.test describes a token installed on an Enterprise Grid org
+// rather than on one of its workspaces, which Slack flags with is_enterprise_install
+func isOrgScopedAuth(resp *authTestResponse) bool {
+	return resp != nil && resp.EnterpriseID != "" && resp.IsEnterpriseInstall
+}
+
+// authTest calls auth.test with the token in the form, as slack-go does, and keeps is_enterprise_install
+func authTest(ctx context.Context, httpClient *http.Client, apiURL, token string) (*authTestResponse, error) {
+	form := url.Values{"token": {token}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL+"auth.test", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return nil, &slack.RateLimitedError{RetryAfter: time.Duration(retryAfter) * time.Second}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, slack.StatusCodeError{Code: resp.StatusCode, Status: resp.Status}
+	}
+
+	var res authTestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	if err := res.Err(); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// IsBrowserSession reports whether the credentials are a browser session (xoxc/xoxd). Only those can call the
+// edge API methods of SlackAPI, such as ClientCounts, UsersPrefsGet and UsersPrefsSetChannelNotifications.
+func (ap *ApiProvider) IsBrowserSession() bool {
+	c, ok := ap.client.(interface{ IsBrowserSession() bool })
+	return ok && c.IsBrowserSession()
+}
+
+// IsOrgScoped reports whether the token is an Enterprise Grid org-level token, channels and users are
+// then fetched per team of the org and may share names across teams
+func (ap *ApiProvider) IsOrgScoped() bool {
+	c, ok := ap.client.(interface{ IsOrgScoped() bool })
+	return ok && c.IsOrgScoped()
+}
+
+// orgTeams returns the teams to list channels and users for. It is a single empty team, meaning
+// no team_id, unless the token is org-scoped and auth.teams.list returns the teams of the org.
+func (ap *ApiProvider) orgTeams(ctx context.Context) []string {
+	lister, ok := ap.client.(interface {
+		ListTeamsContext(ctx context.Context, params slack.ListTeamsParameters) ([]slack.Team, string, error)
+	})
+	if !ok || !ap.IsOrgScoped() {
+		return []string{""}
+	}
+
+	var (
+		teams  []string
+		params = slack.ListTeamsParameters{Limit: 100}
+	)
+	for {
+		list, nextCursor, err := lister.ListTeamsContext(ctx, params)
+		if err != nil {
+			ap.logger.Warn("Failed to list teams of the org, falling back to a single team", zap.Error(err))
+			return []string{""}
+		}
+		for _, team := range list {
+			teams = append(teams, team.ID)
+		}
+		if nextCursor == "" {
+			break
+		}
+		params.Cursor = nextCursor
+	}
+
+	if len(teams) == 0 {
+		return []string{""}
+	}
+	return teams
+}
+
+// listUsers pages through users.list once per team of the org and hands each page to page, users listed for
+// several teams are only handed over once
+func (ap *ApiProvider) listUsers(ctx context.Context, page func([]slack.User), options ...slack.GetUsersOption) error {
+	teams := ap.orgTeams(ctx)
+	if len(teams) == 1 && teams[0] == "" {
+		return ap.client.GetUsersPagesContext(ctx, func(users []slack.User) error {
+			page(users)
+			return nil
+		}, options...)
+	}
+
+	seen := make(map[string]bool)
+	for _, team := range teams {
+		err := ap.client.GetUsersPagesContext(ctx, func(users []slack.User) error {
+			unseen := make([]slack.User, 0, len(users))
+			for _, user := range users {
+				if seen[user.ID] {
+					continue
+				}
+				seen[user.ID] = true
+				if user.TeamID == "" {
+					user.TeamID = team
+				}
+				unseen = append(unseen, user)
+			}
+			page(unseen)
+			return nil
+		}, append(options, slack.GetUsersOptionTeamID(team))...)
+		if err != nil {
+			return fmt.Errorf("team %s: %w", team, err)
+		}
+	}
+	return nil
+}
+
+// channelTeamID returns the team a conversation belongs to, Slack reports it as context_team_id on
+// Enterprise Grid. listed is the team the conversation was listed for, used when Slack omits it.
+func channelTeamID(channel slack.Channel, listed string) string {
+	if channel.ContextTeamID != "" {
+		return channel.ContextTeamID
+	}
+	return listed
+}
+
+// channelNameKey is the key of a channel in the channels inverse map. Teams of an Enterprise Grid org may
+// share channel names, so names are prefixed with the team for org-scoped tokens.
+func channelNameKey(orgScoped bool, teamID, name string) string {
+	if !orgScoped || teamID == "" {
+		return name
+	}
+	return teamID + "/" + name
+}
+
+// channelKey returns the key of c in the channels inverse map
+func (ap *ApiProvider) channelKey(c Channel) string {
+	return channelNameKey(ap.IsOrgScoped(), c.TeamID, c.Name)
+}
+
+// LookupChannel resolves a #channel or @user DM name to its channel ID. teamID narrows the lookup to
+// one team of an Enterprise Grid org, without it a name found in several teams is ErrAmbiguousName.
+func (ap *ApiProvider) LookupChannel(name, teamID string) (string, error) {
+	orgScoped := ap.IsOrgScoped()
+
+	ap.channelsMu.RLock()
+	defer ap.channelsMu.RUnlock()
+
+	if !orgScoped || teamID != "" {
+		if id, ok := ap.channelsInv[channelNameKey(orgScoped, teamID, name)]; ok && (teamID == "" || ap.channels[id].TeamID == teamID) {
+			return id, nil
+		}
+		if !orgScoped {
+			return "", ErrNameNotFound
+		}
+	}
+
+	var (
+		id    string
+		teams []string
+	)
+	for _, c := range ap.channels {
+		if c.Name != name || (teamID != "" && c.TeamID != teamID) {
+			continue
+		}
+		id = c.ID
+		teams = append(teams, c.TeamID)
+	}
+	return lookupResult(id, teams)
+}
+
+// LookupUser resolves a user handle, without the leading @, to its user ID. teamID narrows the lookup
+// to members of one team of an Enterprise Grid org, as for LookupChannel.
+func (ap *ApiProvider) LookupUser(handle, teamID string) (string, error) {
+	ap.usersMu.RLock()
+	defer ap.usersMu.RUnlock()
+
+	if teamID == "" && !ap.IsOrgScoped() {
+		if id, ok := ap.usersInv[handle]; ok {
+			return id, nil
+		}
+		return "", ErrNameNotFound
+	}
+
+	var (
+		id    string
+		teams []string
+	)
+	for _, u := range ap.users {
+		if u.Name != handle || (teamID != "" && !userInTeam(u, teamID)) {
+			continue
+		}
+		id = u.ID
+		teams = append(teams, u.TeamID)
+	}
+	return lookupResult(id, teams)
+}
+
+func lookupResult(id string, teams []string) (string, error) {
+	switch len(teams) {
+	case 0:
+		return "", ErrNameNotFound
+	case 1:
+		return id, nil
+	}
+	sort.Strings(teams)
+	return "", fmt.Errorf("%w: found in teams %s", ErrAmbiguousName, strings.Join(teams, ", "))
+}
+
+// userInTeam reports whether u is a member of teamID, Grid users list all teams of the org they belong to
+func userInTeam(u slack.User, teamID string) bool {
+	if u.TeamID == teamID {
+		return true
+	}
+	for _, t := range u.Enterprise.Teams {
+		if t == teamID {
+			return true
+		}
+	}
+	return false
+}
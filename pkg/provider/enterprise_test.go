@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// fakeOrgSlackAPI is an org-level Enterprise Grid token listing conversations per team
+type fakeOrgSlackAPI struct {
+	*fakeSlackAPI
+
+	teams         []slack.Team
+	conversations map[string][]slack.Channel
+	requestedTeam []string
+}
+
+func (f *fakeOrgSlackAPI) IsOrgScoped() bool {
+	return true
+}
+
+func (f *fakeOrgSlackAPI) ListTeamsContext(ctx context.Context, params slack.ListTeamsParameters) ([]slack.Team, string, error) {
+	return f.teams, "", nil
+}
+
+func (f *fakeOrgSlackAPI) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	f.requestedTeam = append(f.requestedTeam, params.TeamID)
+	return f.conversations[params.TeamID], "", nil
+}
+
+func TestUnitIsOrgScopedAuth(t *testing.T) {
+	authResponse := func(teamID, enterpriseID string, enterpriseInstall bool) *authTestResponse {
+		resp := &authTestResponse{IsEnterpriseInstall: enterpriseInstall}
+		resp.TeamID, resp.EnterpriseID = teamID, enterpriseID
+		return resp
+	}
+	assert.False(t, isOrgScopedAuth(nil))
+	assert.False(t, isOrgScopedAuth(authResponse("T1", "", false)), "not an Enterprise Grid token")
+	assert.False(t, isOrgScopedAuth(authResponse("T1", "E1", false)), "workspace token of a Grid org")
+	assert.False(t, isOrgScopedAuth(authResponse("E1", "E1", false)), "the team ID is not a hint")
+	assert.True(t, isOrgScopedAuth(authResponse("E1", "E1", true)))
+	assert.True(t, isOrgScopedAuth(authResponse("T1", "E1", true)))
+}
+
+func TestUnitIsBrowserSession(t *testing.T) {
+	assert.True(t, newTestProvider(t, &MCPSlackClient{isSession: true}).IsBrowserSession())
+	assert.False(t, newTestProvider(t, &MCPSlackClient{isOAuth: true}).IsBrowserSession(), "xoxp tokens can't call the edge API")
+	assert.False(t, newTestProvider(t, &fakeSlackAPI{}).IsBrowserSession())
+}
+
+func TestUnitAuthTest(t *testing.T) {
+	body := `{"ok":true,"url":"https://acme.enterprise.slack.com/","team":"Acme","team_id":"E1","user_id":"W1","enterprise_id":"E1","is_enterprise_install":true}`
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/auth.test", r.URL.Path)
+		assert.Equal(t, "xoxp-token", r.FormValue("token"))
+		_, _ = io.WriteString(w, body)
+	}))
+	defer api.Close()
+
+	resp, err := authTest(context.Background(), api.Client(), api.URL+"/api/", "xoxp-token")
+	require.NoError(t, err)
+	assert.Equal(t, "W1", resp.UserID)
+	assert.True(t, isOrgScopedAuth(resp))
+
+	body = `{"ok":false,"error":"invalid_auth"}`
+	_, err = authTest(context.Background(), api.Client(), api.URL+"/api/", "xoxp-token")
+	assert.Equal(t, "invalid_auth", SlackErrorCode(err))
+}
+
+func TestUnitOrgScopedLookup(t *testing.T) {
+	newChannel := func(id, name, team string) slack.Channel {
+		c := slack.Channel{}
+		c.ID, c.NameNormalized = id, name
+		c.ContextTeamID = team
+		return c
+	}
+	fake := &fakeOrgSlackAPI{
+		fakeSlackAPI: &fakeSlackAPI{users: []slack.User{
+			{ID: "W1", Name: "alice", TeamID: "T1", Enterprise: slack.EnterpriseUser{Teams: []string{"T1", "T2"}}},
+			{ID: "W2", Name: "bob", TeamID: "T2"},
+		}},
+		teams: []slack.Team{{ID: "T1"}, {ID: "T2"}},
+		conversations: map[string][]slack.Channel{
+			"T1": {newChannel("C1", "general", "T1"), newChannel("C2", "eng", "")},
+			"T2": {newChannel("C3", "general", "T2")},
+		},
+	}
+
+	ap := newTestProvider(t, fake)
+	ap.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+	ap.channelsCache = filepath.Join(t.TempDir(), "channels_cache.json")
+	ap.channelTypes = AllChanTypes
+
+	require.NoError(t, ap.RefreshUsers(context.Background()))
+	require.NoError(t, ap.RefreshChannels(context.Background()))
+	assert.Equal(t, []string{"T1", "T2"}, fake.requestedTeam)
+	assert.Equal(t, 2, fake.getUsersCalls, "users are listed once per team")
+	assert.Equal(t, "T1", ap.channels["C2"].TeamID, "the listed team is used when Slack omits it")
+	assert.Equal(t, "C1", ap.channelsInv["T1/#general"], "names are kept per team")
+	assert.Equal(t, "C3", ap.channelsInv["T2/#general"])
+	channels := ap.ProvideChannelsMaps()
+	assert.NotContains(t, channels.ChannelsInv, "#general", "names shared by several teams are left out")
+	assert.Equal(t, "C2", channels.ChannelsInv["#eng"])
+
+	_, err := ap.LookupChannel("#general", "")
+	assert.ErrorIs(t, err, ErrAmbiguousName)
+	assert.ErrorContains(t, err, "T1, T2")
+
+	id, err := ap.LookupChannel("#general", "T2")
+	require.NoError(t, err)
+	assert.Equal(t, "C3", id)
+
+	id, err = ap.LookupChannel("#general", "T1")
+	require.NoError(t, err)
+	assert.Equal(t, "C1", id)
+
+	id, err = ap.LookupChannel("#eng", "")
+	require.NoError(t, err)
+	assert.Equal(t, "C2", id)
+
+	_, err = ap.LookupChannel("#eng", "T2")
+	assert.ErrorIs(t, err, ErrNameNotFound)
+
+	id, err = ap.LookupUser("alice", "T2")
+	require.NoError(t, err)
+	assert.Equal(t, "W1", id, "Grid users are members of every team they list")
+
+	_, err = ap.LookupUser("bob", "T1")
+	assert.ErrorIs(t, err, ErrNameNotFound)
+}
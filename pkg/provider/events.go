@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/webhook"
+	"github.com/slack-go/slack/slackevents"
+	"go.uber.org/zap"
+)
+
+// forwardedSubtypes are the message subtypes of new messages, edits, deletions and membership changes are not
+// forwarded
+var forwardedSubtypes = map[string]bool{
+	"":                 true,
+	"bot_message":      true,
+	"thread_broadcast": true,
+	"file_share":       true,
+	"me_message":       true,
+}
+
+// WebhookEnabled reports whether SLACK_MCP_WEBHOOK_URL is set
+func (ap *ApiProvider) WebhookEnabled() bool {
+	return ap.webhook != nil
+}
+
+// ForwardMessage sends a message to the webhook in the background when SLACK_MCP_WEBHOOK_URL is set and the
+// message passes its filters. A message is forwarded once even when it is both posted by this server and
+// delivered over Socket Mode.
+func (ap *ApiProvider) ForwardMessage(e webhook.Event) {
+	if ap.webhook == nil || !ap.webhook.Matches(e) {
+		return
+	}
+	go func() {
+		if err := ap.webhook.Send(context.Background(), e); err != nil {
+			ap.logger.Debug("Webhook delivery failed", zap.String("channel", e.ChannelID), zap.String("ts", e.Timestamp), zap.Error(err))
+		}
+	}()
+}
+
+// handleEventsAPI forwards the new messages of an Events API payload delivered over Socket Mode
+func (ap *ApiProvider) handleEventsAPI(event slackevents.EventsAPIEvent) {
+	msg, ok := event.InnerEvent.Data.(*slackevents.MessageEvent)
+	if !ok || msg == nil || !forwardedSubtypes[msg.SubType] {
+		return
+	}
+	ap.ForwardMessage(webhook.Event{
+		Type:      "message",
+		ChannelID: msg.Channel,
+		UserID:    msg.User,
+		Text:      msg.Text,
+		Timestamp: msg.TimeStamp,
+		ThreadTs:  msg.ThreadTimeStamp,
+	})
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/webhook"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func socketMessageEvent(msg *slackevents.MessageEvent) socketmode.Event {
+	return socketmode.Event{
+		Type: socketmode.EventTypeEventsAPI,
+		Data: slackevents.EventsAPIEvent{
+			Type:       slackevents.CallbackEvent,
+			InnerEvent: slackevents.EventsAPIInnerEvent{Type: "message", Data: msg},
+		},
+	}
+}
+
+func TestUnitSocketMessagesForwardedToWebhook(t *testing.T) {
+	received := make(chan webhook.Event, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var e webhook.Event
+		if assert.NoError(t, json.Unmarshal(body, &e)) {
+			received <- e
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv("SLACK_MCP_WEBHOOK_URL", srv.URL)
+	t.Setenv("SLACK_MCP_WEBHOOK_KEYWORDS", "deploy")
+	ap := newTestProvider(t, &fakeSlackAPI{})
+	ap.webhook = webhook.New(webhook.ConfigFromEnv(zap.NewNop()), zap.NewNop())
+	require.True(t, ap.WebhookEnabled())
+
+	ap.handleSocketEvent(socketMessageEvent(&slackevents.MessageEvent{Channel: "C0GENERAL", User: "U0ALICE", Text: "lunch?", TimeStamp: "1700000000.000100"}))
+	ap.handleSocketEvent(socketMessageEvent(&slackevents.MessageEvent{Channel: "C0GENERAL", SubType: "message_changed", Text: "deploy edited", TimeStamp: "1700000000.000200"}))
+	deploy := &slackevents.MessageEvent{Channel: "C0GENERAL", User: "U0ALICE", Text: "deploy finished", TimeStamp: "1700000000.000300", ThreadTimeStamp: "1700000000.000050"}
+	ap.handleSocketEvent(socketMessageEvent(deploy))
+
+	select {
+	case e := <-received:
+		assert.Equal(t, webhook.Event{Type: "message", ChannelID: "C0GENERAL", UserID: "U0ALICE", Text: "deploy finished", Timestamp: "1700000000.000300", ThreadTs: "1700000000.000050"}, e)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the matching message to be forwarded")
+	}
+
+	// a message posted by this server comes back over Socket Mode and is forwarded once
+	ap.ForwardMessage(webhook.Event{Type: "message", ChannelID: "C0GENERAL", Text: "deploy started", Timestamp: "1700000000.000400"})
+	ap.handleSocketEvent(socketMessageEvent(&slackevents.MessageEvent{Channel: "C0GENERAL", User: "U0BOT", Text: "deploy started", TimeStamp: "1700000000.000400"}))
+	ap.handleSocketEvent(socketMessageEvent(deploy))
+	select {
+	case e := <-received:
+		assert.Equal(t, "1700000000.000400", e.Timestamp)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the posted message to be forwarded")
+	}
+	select {
+	case e := <-received:
+		t.Fatalf("Expected no further events, got %+v", e)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestUnitForwardMessageWithoutWebhook(t *testing.T) {
+	ap := newTestProvider(t, &fakeSlackAPI{})
+	assert.False(t, ap.WebhookEnabled())
+	ap.ForwardMessage(webhook.Event{Type: "message", ChannelID: "C0GENERAL", Text: "hello", Timestamp: "1700000000.000100"})
+	ap.handleSocketEvent(socketMessageEvent(&slackevents.MessageEvent{Channel: "C0GENERAL", Text: "hello", TimeStamp: "1700000000.000100"}))
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// identityTTL bounds how long Identity serves a cached auth.test result
+const identityTTL = 15 * time.Second
+
+// Identity is who the configured token authenticates as
+type Identity struct {
+	UserID       string `json:"user_id"`
+	User         string `json:"user"`
+	BotID        string `json:"bot_id,omitempty"`
+	TeamID       string `json:"team_id"`
+	Team         string `json:"team"`
+	EnterpriseID string `json:"enterprise_id,omitempty"`
+	// OrgScoped is set for Enterprise Grid org-level tokens, see IsOrgScoped
+	OrgScoped bool     `json:"org_scoped"`
+	URL       string   `json:"url"`
+	Scopes    []string `json:"scopes,omitempty"`
+}
+
+// Identity calls auth.test at most once per identityTTL. Failures are not cached, so a revoked
+// token shows up on the next call. Scopes are only known for OAuth tokens.
+func (ap *ApiProvider) Identity(ctx context.Context) (*Identity, error) {
+	if ap.client == nil {
+		return nil, errors.New("slack client is not configured")
+	}
+
+	ap.identityMu.Lock()
+	defer ap.identityMu.Unlock()
+
+	if ap.identity != nil && time.Since(ap.identityFetched) < identityTTL {
+		return ap.identity, nil
+	}
+
+	started := time.Now()
+	resp, err := ap.client.AuthTestContext(ctx)
+	ap.identityLatency = time.Since(started)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		UserID:       resp.UserID,
+		User:         resp.User,
+		BotID:        resp.BotID,
+		TeamID:       resp.TeamID,
+		Team:         resp.Team,
+		EnterpriseID: resp.EnterpriseID,
+		OrgScoped:    ap.IsOrgScoped(),
+		URL:          resp.URL,
+	}
+	if c, ok := ap.client.(interface{ Scopes() []string }); ok {
+		identity.Scopes = c.Scopes()
+	}
+
+	ap.identity = identity
+	ap.identityFetched = time.Now()
+	return identity, nil
+}
+
+// IdentityLatency returns how long the last auth.test made by Identity took, cached results don't update it
+func (ap *ApiProvider) IdentityLatency() time.Duration {
+	ap.identityMu.Lock()
+	defer ap.identityMu.Unlock()
+
+	return ap.identityLatency
+}
@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+	"go.uber.org/zap"
+)
+
+const (
+	// interactiveMessageTTL is how long clicks on a posted interactive message are recorded
+	interactiveMessageTTL = 24 * time.Hour
+	// maxInteractiveMessages bounds the registered messages, the oldest one is dropped first
+	maxInteractiveMessages = 1000
+)
+
+// ButtonClick is a click on a button of an interactive message, as delivered by Socket Mode
+type ButtonClick struct {
+	ChannelID string
+	MessageTs string
+	ActionID  string
+	Value     string
+	Label     string
+	UserID    string
+	UserName  string
+	ActionTs  string
+}
+
+// Interactions records the button clicks on the interactive messages posted by this server. Messages are
+// registered when they are posted and forgotten after interactiveMessageTTL, clicks on other messages are
+// ignored. Waiters are woken up on every recorded click. A nil Interactions records nothing.
+type Interactions struct {
+	mu       sync.Mutex
+	messages map[string]*interactiveMessage
+	now      func() time.Time
+}
+
+type interactiveMessage struct {
+	posted  time.Time
+	actions map[string]bool
+	clicks  []ButtonClick
+	// changed is closed and replaced when a click is recorded
+	changed chan struct{}
+}
+
+func NewInteractions() *Interactions {
+	return &Interactions{messages: make(map[string]*interactiveMessage), now: time.Now}
+}
+
+func interactionKey(channel, ts string) string {
+	return channel + "/" + ts
+}
+
+// Register starts recording the clicks on the buttons actionIDs of message ts in channel
+func (in *Interactions) Register(channel, ts string, actionIDs []string) {
+	if in == nil {
+		return
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	now := in.now()
+	oldestKey := ""
+	for key, msg := range in.messages {
+		if now.Sub(msg.posted) > interactiveMessageTTL {
+			delete(in.messages, key)
+			continue
+		}
+		if oldestKey == "" || msg.posted.Before(in.messages[oldestKey].posted) {
+			oldestKey = key
+		}
+	}
+	if len(in.messages) >= maxInteractiveMessages {
+		delete(in.messages, oldestKey)
+	}
+
+	actions := make(map[string]bool, len(actionIDs))
+	for _, id := range actionIDs {
+		actions[id] = true
+	}
+	in.messages[interactionKey(channel, ts)] = &interactiveMessage{posted: now, actions: actions, changed: make(chan struct{})}
+}
+
+// Record stores click and reports whether it was on a button of a registered message. A click delivered
+// twice is stored once.
+func (in *Interactions) Record(click ButtonClick) bool {
+	if in == nil {
+		return false
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	msg, ok := in.messages[interactionKey(click.ChannelID, click.MessageTs)]
+	if !ok || !msg.actions[click.ActionID] || in.now().Sub(msg.posted) > interactiveMessageTTL {
+		return false
+	}
+	for _, c := range msg.clicks {
+		if c == click {
+			return true
+		}
+	}
+	msg.clicks = append(msg.clicks, click)
+	close(msg.changed)
+	msg.changed = make(chan struct{})
+	return true
+}
+
+// Clicks returns the clicks recorded on message ts in channel, oldest first, and a channel closed on the next
+// click. ok is false when the message is not registered or expired.
+func (in *Interactions) Clicks(channel, ts string) (clicks []ButtonClick, changed <-chan struct{}, ok bool) {
+	if in == nil {
+		return nil, nil, false
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	msg, ok := in.messages[interactionKey(channel, ts)]
+	if !ok || in.now().Sub(msg.posted) > interactiveMessageTTL {
+		return nil, nil, false
+	}
+	return append([]ButtonClick(nil), msg.clicks...), msg.changed, true
+}
+
+// InteractionClicks maps the button actions of a block_actions payload to clicks, other payloads have none
+func InteractionClicks(callback slack.InteractionCallback) []ButtonClick {
+	if callback.Type != slack.InteractionTypeBlockActions {
+		return nil
+	}
+	channel := callback.Container.ChannelID
+	if channel == "" {
+		channel = callback.Channel.ID
+	}
+	ts := callback.Container.MessageTs
+	if ts == "" {
+		ts = callback.Message.Timestamp
+	}
+
+	var clicks []ButtonClick
+	for _, action := range callback.ActionCallback.BlockActions {
+		if action == nil || action.Type != slack.ActionType(slack.METButton) {
+			continue
+		}
+		clicks = append(clicks, ButtonClick{
+			ChannelID: channel,
+			MessageTs: ts,
+			ActionID:  action.ActionID,
+			Value:     action.Value,
+			Label:     action.Text.Text,
+			UserID:    callback.User.ID,
+			UserName:  callback.User.Name,
+			ActionTs:  action.ActionTs,
+		})
+	}
+	return clicks
+}
+
+// ListenInteractions connects to Slack with the app-level token of SLACK_MCP_APP_TOKEN over Socket Mode,
+// records button clicks and forwards message events to the webhook until ctx is done or the connection fails
+// for good. Every request is acknowledged, Slack retries the ones that are not.
+func (ap *ApiProvider) ListenInteractions(ctx context.Context, appToken string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	client := socketmode.New(slack.New("", slack.OptionAppLevelToken(appToken)))
+	done := make(chan error, 1)
+	go func() {
+		done <- client.RunContext(ctx)
+	}()
+
+	for {
+		select {
+		case err := <-done:
+			return err
+		case evt := <-client.Events:
+			ap.handleSocketEvent(evt)
+			if evt.Request != nil {
+				client.Ack(*evt.Request)
+			}
+		}
+	}
+}
+
+func (ap *ApiProvider) handleSocketEvent(evt socketmode.Event) {
+	switch evt.Type {
+	case socketmode.EventTypeConnected:
+		ap.logger.Info("Socket Mode connected, listening for button clicks", zap.String("context", "console"))
+	case socketmode.EventTypeConnectionError, socketmode.EventTypeInvalidAuth:
+		ap.logger.Warn("Socket Mode connection failed", zap.String("context", "console"), zap.String("event", string(evt.Type)), zap.Any("data", evt.Data))
+	case socketmode.EventTypeEventsAPI:
+		event, ok := evt.Data.(slackevents.EventsAPIEvent)
+		if !ok {
+			return
+		}
+		ap.handleEventsAPI(event)
+	case socketmode.EventTypeInteractive:
+		callback, ok := evt.Data.(slack.InteractionCallback)
+		if !ok {
+			return
+		}
+		for _, click := range InteractionClicks(callback) {
+			recorded := ap.interactions.Record(click)
+			ap.logger.Debug("Button clicked",
+				zap.String("channel", click.ChannelID),
+				zap.String("ts", click.MessageTs),
+				zap.String("action_id", click.ActionID),
+				zap.String("user", click.UserID),
+				zap.Bool("recorded", recorded),
+			)
+		}
+	}
+}
+
+// Interactions returns the registry of interactive messages and their clicks
+func (ap *ApiProvider) Interactions() *Interactions {
+	return ap.interactions
+}
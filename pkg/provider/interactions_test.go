@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitInteractionsRecord(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	in := NewInteractions()
+	in.now = func() time.Time { return now }
+
+	approve := ButtonClick{ChannelID: "C0GENERAL", MessageTs: "1700000000.000100", ActionID: "button_1", Value: "approve", UserID: "U0ALICE", ActionTs: "1700000010.000001"}
+	assert.False(t, in.Record(approve), "clicks on unknown messages are ignored")
+	_, _, ok := in.Clicks("C0GENERAL", "1700000000.000100")
+	assert.False(t, ok)
+
+	in.Register("C0GENERAL", "1700000000.000100", []string{"button_1", "button_2"})
+	clicks, changed, ok := in.Clicks("C0GENERAL", "1700000000.000100")
+	require.True(t, ok)
+	assert.Empty(t, clicks)
+
+	assert.False(t, in.Record(ButtonClick{ChannelID: "C0GENERAL", MessageTs: "1700000000.000100", ActionID: "other"}), "unknown buttons are ignored")
+	assert.True(t, in.Record(approve))
+	select {
+	case <-changed:
+	default:
+		t.Fatal("waiters are woken up by a click")
+	}
+	assert.True(t, in.Record(approve), "a redelivered click is accepted")
+
+	clicks, _, _ = in.Clicks("C0GENERAL", "1700000000.000100")
+	assert.Equal(t, []ButtonClick{approve}, clicks, "and stored once")
+
+	now = now.Add(interactiveMessageTTL + time.Minute)
+	_, _, ok = in.Clicks("C0GENERAL", "1700000000.000100")
+	assert.False(t, ok, "messages expire")
+	assert.False(t, in.Record(approve))
+
+	var nilInteractions *Interactions
+	nilInteractions.Register("C0GENERAL", "1700000000.000100", []string{"button_1"})
+	assert.False(t, nilInteractions.Record(approve))
+}
+
+func TestUnitInteractionsRegisterBound(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	in := NewInteractions()
+	in.now = func() time.Time { return now }
+
+	for i := 0; i < maxInteractiveMessages+1; i++ {
+		now = now.Add(time.Second)
+		in.Register("C0GENERAL", fmt.Sprintf("1700000000.%06d", i), []string{"button_1"})
+	}
+	assert.Len(t, in.messages, maxInteractiveMessages)
+	_, _, ok := in.Clicks("C0GENERAL", "1700000000.000000")
+	assert.False(t, ok, "the oldest message is dropped")
+	_, _, ok = in.Clicks("C0GENERAL", fmt.Sprintf("1700000000.%06d", maxInteractiveMessages))
+	assert.True(t, ok)
+}
+
+func TestUnitInteractionClicks(t *testing.T) {
+	callback := slack.InteractionCallback{
+		Type:      slack.InteractionTypeBlockActions,
+		User:      slack.User{ID: "U0ALICE", Name: "alice"},
+		Container: slack.Container{ChannelID: "C0GENERAL", MessageTs: "1700000000.000100"},
+		ActionCallback: slack.ActionCallbacks{BlockActions: []*slack.BlockAction{
+			{ActionID: "button_1", Type: "button", Value: "approve", Text: slack.TextBlockObject{Text: "Approve"}, ActionTs: "1700000010.000001"},
+			{ActionID: "pick", Type: "static_select"},
+		}},
+	}
+	assert.Equal(t, []ButtonClick{{
+		ChannelID: "C0GENERAL",
+		MessageTs: "1700000000.000100",
+		ActionID:  "button_1",
+		Value:     "approve",
+		Label:     "Approve",
+		UserID:    "U0ALICE",
+		UserName:  "alice",
+		ActionTs:  "1700000010.000001",
+	}}, InteractionClicks(callback))
+
+	callback.Type = slack.InteractionTypeViewSubmission
+	assert.Empty(t, InteractionClicks(callback))
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// LifecyclePhase is a state of the server lifecycle, health responses report it as the phase detail
+type LifecyclePhase string
+
+const (
+	// PhaseStarting lasts from the start of the process until the cache warmup begins
+	PhaseStarting LifecyclePhase = "starting"
+	// PhaseCacheWarming means the users and channels caches are loading, tools depending on them wait
+	PhaseCacheWarming LifecyclePhase = "cache_warming"
+	// PhaseReady means the caches are loaded and Slack answers
+	PhaseReady LifecyclePhase = "ready"
+	// PhaseDegraded means the server is up but the warmup gave up or readiness checks of Slack fail
+	PhaseDegraded LifecyclePhase = "degraded"
+	// PhaseDraining means a shutdown signal was received and open requests are being finished
+	PhaseDraining LifecyclePhase = "draining"
+	// PhaseStopped means the server stopped serving
+	PhaseStopped LifecyclePhase = "stopped"
+)
+
+// Lifecycle is the shared lifecycle state of the server. Every change of phase is logged as a structured
+// event whose event field is the new phase. Once draining, only the move to stopped is taken, and stopped
+// is final. A nil Lifecycle stays in PhaseStarting and logs nothing.
+type Lifecycle struct {
+	mu     sync.Mutex
+	phase  LifecyclePhase
+	since  time.Time
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+// NewLifecycle returns a lifecycle in PhaseStarting, New builds one for every provider
+func NewLifecycle(logger *zap.Logger) *Lifecycle {
+	l := &Lifecycle{logger: logger, now: time.Now}
+	l.Transition(PhaseStarting, "process started")
+	return l
+}
+
+// Phase returns the current phase and when it was entered
+func (l *Lifecycle) Phase() (LifecyclePhase, time.Time) {
+	if l == nil {
+		return PhaseStarting, time.Time{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.phase, l.since
+}
+
+// Transition moves to phase and logs the event with reason, it reports whether the phase changed
+func (l *Lifecycle) Transition(phase LifecyclePhase, reason string) bool {
+	if l == nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	previous := l.phase
+	if phase == previous || previous == PhaseStopped || (previous == PhaseDraining && phase != PhaseStopped) {
+		return false
+	}
+	now := l.now()
+	fields := []zap.Field{
+		zap.String("context", "console"),
+		zap.String("event", string(phase)),
+		zap.String("reason", reason),
+	}
+	if previous != "" {
+		fields = append(fields,
+			zap.String("previous_phase", string(previous)),
+			zap.Duration("previous_phase_duration", now.Sub(l.since)),
+		)
+	}
+	l.phase, l.since = phase, now
+
+	if phase == PhaseDegraded {
+		l.logger.Warn("Lifecycle phase changed", fields...)
+	} else {
+		l.logger.Info("Lifecycle phase changed", fields...)
+	}
+	return true
+}
+
+// ObserveHealth moves between ready and degraded as readiness checks pass or fail, other phases are
+// driven by the startup and shutdown of the server and are kept
+func (l *Lifecycle) ObserveHealth(healthy bool, reason string) {
+	phase, _ := l.Phase()
+	switch {
+	case phase == PhaseReady && !healthy:
+		l.Transition(PhaseDegraded, reason)
+	case phase == PhaseDegraded && healthy:
+		l.Transition(PhaseReady, reason)
+	}
+}
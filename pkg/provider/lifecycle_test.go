@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestUnitLifecycleTransitions(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	l := NewLifecycle(zap.New(core))
+	phase, since := l.Phase()
+	assert.Equal(t, PhaseStarting, phase)
+	assert.False(t, since.IsZero())
+
+	assert.True(t, l.Transition(PhaseCacheWarming, "loading caches"))
+	assert.False(t, l.Transition(PhaseCacheWarming, "loading caches"), "staying in a phase is not an event")
+	assert.True(t, l.Transition(PhaseReady, "caches loaded"))
+
+	l.ObserveHealth(true, "healthy")
+	l.ObserveHealth(false, "slack_api failed")
+	phase, _ = l.Phase()
+	assert.Equal(t, PhaseDegraded, phase)
+	l.ObserveHealth(true, "healthy")
+	phase, _ = l.Phase()
+	assert.Equal(t, PhaseReady, phase)
+
+	assert.True(t, l.Transition(PhaseDraining, "received terminated"))
+	assert.False(t, l.Transition(PhaseReady, "caches loaded"), "a draining server doesn't become ready again")
+	l.ObserveHealth(false, "slack_api failed")
+	assert.True(t, l.Transition(PhaseStopped, "HTTP server stopped"))
+	assert.False(t, l.Transition(PhaseDraining, "received interrupt"))
+
+	var events []string
+	for _, entry := range logs.FilterMessage("Lifecycle phase changed").All() {
+		events = append(events, entry.ContextMap()["event"].(string))
+	}
+	assert.Equal(t, []string{"starting", "cache_warming", "ready", "degraded", "ready", "draining", "stopped"}, events)
+
+	last := logs.FilterMessage("Lifecycle phase changed").All()[6].ContextMap()
+	assert.Equal(t, "draining", last["previous_phase"])
+	assert.Equal(t, "HTTP server stopped", last["reason"])
+}
+
+func TestUnitLifecycleNil(t *testing.T) {
+	var l *Lifecycle
+	phase, since := l.Phase()
+	assert.Equal(t, PhaseStarting, phase)
+	assert.Equal(t, time.Time{}, since)
+	assert.False(t, l.Transition(PhaseReady, "caches loaded"))
+	l.ObserveHealth(false, "slack_api failed")
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const defaultRefreshFailureThreshold = 3
+
+// Cache collections tracked by the refresh metrics
+const (
+	RefreshUsers    = "users"
+	RefreshChannels = "channels"
+	RefreshEmoji    = "emoji"
+)
+
+// RefreshStats counts the refreshes of one cache collection, durations are in seconds
+type RefreshStats struct {
+	Attempts            int64     `json:"attempts"`
+	Successes           int64     `json:"successes"`
+	Failures            int64     `json:"failures"`
+	ConsecutiveFailures int64     `json:"consecutive_failures"`
+	LastDuration        float64   `json:"last_duration_seconds"`
+	TotalDuration       float64   `json:"total_duration_seconds"`
+	LastSuccess         time.Time `json:"last_success,omitempty"`
+	LastError           string    `json:"last_error,omitempty"`
+}
+
+// refreshMetrics records refresh attempts per collection and warns once consecutive
+// failures reach SLACK_MCP_REFRESH_FAILURE_THRESHOLD. A nil refreshMetrics records nothing.
+type refreshMetrics struct {
+	mu               sync.Mutex
+	stats            map[string]*RefreshStats
+	failureThreshold int64
+	logger           *zap.Logger
+}
+
+func newRefreshMetrics(logger *zap.Logger) *refreshMetrics {
+	return &refreshMetrics{
+		stats:            make(map[string]*RefreshStats),
+		failureThreshold: int64(parseRefreshFailureThreshold(logger)),
+		logger:           logger,
+	}
+}
+
+// observe records a refresh of collection that started at start, it is meant to be deferred with
+// a pointer to the named error result of the refresh
+func (m *refreshMetrics) observe(collection string, start time.Time, errp *error) {
+	if m == nil {
+		return
+	}
+	duration := time.Since(start)
+
+	m.mu.Lock()
+	s, ok := m.stats[collection]
+	if !ok {
+		s = &RefreshStats{}
+		m.stats[collection] = s
+	}
+	s.Attempts++
+	s.LastDuration = duration.Seconds()
+	s.TotalDuration += duration.Seconds()
+
+	var err error
+	if errp != nil {
+		err = *errp
+	}
+	recovered := int64(0)
+	if err != nil {
+		s.Failures++
+		s.ConsecutiveFailures++
+		s.LastError = err.Error()
+	} else {
+		s.Successes++
+		recovered = s.ConsecutiveFailures
+		s.ConsecutiveFailures = 0
+		s.LastSuccess = time.Now()
+		s.LastError = ""
+	}
+	consecutive := s.ConsecutiveFailures
+	m.mu.Unlock()
+
+	switch {
+	case err != nil && consecutive >= m.failureThreshold:
+		m.logger.Warn("Cache refresh keeps failing, cached data is going stale",
+			zap.String("collection", collection),
+			zap.Int64("consecutive_failures", consecutive),
+			zap.Int64("threshold", m.failureThreshold),
+			zap.Error(err),
+		)
+	case err == nil && recovered >= m.failureThreshold:
+		m.logger.Info("Cache refresh recovered",
+			zap.String("collection", collection),
+			zap.Int64("failed_attempts", recovered),
+		)
+	}
+}
+
+// Snapshot returns a copy of the stats of every collection refreshed so far
+func (m *refreshMetrics) Snapshot() map[string]RefreshStats {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]RefreshStats, len(m.stats))
+	for collection, s := range m.stats {
+		snapshot[collection] = *s
+	}
+	return snapshot
+}
+
+// RefreshCollections returns the collections of stats sorted by name
+func RefreshCollections(stats map[string]RefreshStats) []string {
+	collections := make([]string, 0, len(stats))
+	for collection := range stats {
+		collections = append(collections, collection)
+	}
+	sort.Strings(collections)
+	return collections
+}
+
+func parseRefreshFailureThreshold(logger *zap.Logger) int {
+	value := os.Getenv("SLACK_MCP_REFRESH_FAILURE_THRESHOLD")
+	if value == "" {
+		return defaultRefreshFailureThreshold
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		logger.Warn("Invalid SLACK_MCP_REFRESH_FAILURE_THRESHOLD, using default",
+			zap.String("value", value),
+			zap.Int("default", defaultRefreshFailureThreshold))
+		return defaultRefreshFailureThreshold
+	}
+	return n
+}
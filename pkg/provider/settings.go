@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Settings are the provider options resolved from the environment, with the defaults applied
+// and invalid values replaced the way New does. Durations are formatted as Go durations.
+type Settings struct {
+	// Credentials is the kind of token configured: xoxp, xoxb, xoxc/xoxd, demo or none
+	Credentials string `json:"credentials"`
+	APIBaseURL  string `json:"api_base_url"`
+	APITimeout  string `json:"api_timeout"`
+
+	MaxConcurrentCalls int    `json:"max_concurrent_calls"`
+	BreakerThreshold   int    `json:"breaker_threshold"`
+	BreakerCooldown    string `json:"breaker_cooldown"`
+
+	UsersCache    string `json:"users_cache"`
+	ChannelsCache string `json:"channels_cache"`
+	EmojiCache    string `json:"emoji_cache"`
+
+	UsersDeltaWindow        string          `json:"users_delta_window"`
+	RefreshFailureThreshold int             `json:"refresh_failure_threshold"`
+	ChannelTypes            []string        `json:"channel_types"`
+	IncludeArchived         bool            `json:"include_archived"`
+	ExcludeBots             bool            `json:"exclude_bots"`
+	ExcludeDeleted          bool            `json:"exclude_deleted"`
+	CacheMissPolicy         CacheMissPolicy `json:"cache_miss_policy"`
+	CacheMaxEntries         int             `json:"cache_max_entries"`
+}
+
+// ResolveSettings reads the provider options from the environment without connecting to Slack,
+// invalid values are logged to logger. A malformed SLACK_MCP_API_BASE_URL is an error.
+func ResolveSettings(logger *zap.Logger) (Settings, error) {
+	apiBaseURL, err := parseAPIBaseURL()
+	if err != nil {
+		return Settings{}, err
+	}
+
+	credentials := credentialsKind()
+	users, channels, emoji := cacheFiles(os.Getenv("SLACK_MCP_XOXP_TOKEN") == "")
+	if credentials == "demo" {
+		// demo mode neither reads nor writes cache files
+		users, channels, emoji = "", "", ""
+	}
+	breakerThreshold, breakerCooldown := parseBreakerSettings(logger)
+
+	return Settings{
+		Credentials: credentials,
+		APIBaseURL:  apiBaseURL,
+		APITimeout:  parseAPITimeout(logger).String(),
+
+		MaxConcurrentCalls: parseMaxConcurrentCalls(logger),
+		BreakerThreshold:   breakerThreshold,
+		BreakerCooldown:    breakerCooldown.String(),
+
+		UsersCache:    users,
+		ChannelsCache: channels,
+		EmojiCache:    emoji,
+
+		UsersDeltaWindow:        parseUsersDeltaWindow(logger).String(),
+		RefreshFailureThreshold: parseRefreshFailureThreshold(logger),
+		ChannelTypes:            parseChannelTypes(logger),
+		IncludeArchived:         parseIncludeArchived(),
+		ExcludeBots:             isEnvTrue("SLACK_MCP_EXCLUDE_BOTS"),
+		ExcludeDeleted:          isEnvTrue("SLACK_MCP_EXCLUDE_DELETED"),
+		CacheMissPolicy:         parseCacheMissPolicy(logger),
+		CacheMaxEntries:         parseCacheMaxEntries(logger),
+	}, nil
+}
+
+// credentialsKind names the configured credentials the way New picks them
+func credentialsKind() string {
+	if IsDemoMode() {
+		return "demo"
+	}
+	if xoxp := os.Getenv("SLACK_MCP_XOXP_TOKEN"); xoxp != "" {
+		if strings.HasPrefix(xoxp, "xoxb-") {
+			return "xoxb"
+		}
+		return "xoxp"
+	}
+	if os.Getenv("SLACK_MCP_XOXC_TOKEN") != "" && os.Getenv("SLACK_MCP_XOXD_TOKEN") != "" {
+		return "xoxc/xoxd"
+	}
+	return "none"
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// profileFieldsTTL bounds how long ProfileFields serves the fields of a team.profile.get call, so fields
+// added or renamed by an admin show up without a restart
+const profileFieldsTTL = time.Hour
+
+// teamIconSizes lists team.info icon keys from the largest to the smallest
+var teamIconSizes = []string{"image_original", "image_230", "image_132", "image_102", "image_88", "image_68", "image_44", "image_34"}
+
+type Team struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Domain      string `json:"domain"`
+	EmailDomain string `json:"email_domain"`
+	IconURL     string `json:"icon_url"`
+}
+
+// ProvideTeam returns the workspace of the token, team.info is called once and cached for the process lifetime
+func (ap *ApiProvider) ProvideTeam(ctx context.Context) (*Team, error) {
+	ap.teamMu.RLock()
+	team := ap.team
+	ap.teamMu.RUnlock()
+	if team != nil {
+		return team, nil
+	}
+
+	info, err := ap.client.GetTeamInfoContext(ctx)
+	if err != nil {
+		ap.logger.Error("Failed to fetch team info", zap.Error(err))
+		return nil, err
+	}
+
+	team = &Team{
+		ID:          info.ID,
+		Name:        info.Name,
+		Domain:      info.Domain,
+		EmailDomain: info.EmailDomain,
+		IconURL:     teamIconURL(info.Icon),
+	}
+
+	ap.teamMu.Lock()
+	ap.team = team
+	ap.teamMu.Unlock()
+
+	ap.logger.Info("Loaded team info", zap.String("team_id", team.ID), zap.String("team", team.Name))
+	return team, nil
+}
+
+// ProfileFields returns the custom profile fields of the workspace by ID, team.profile.get is called at most
+// once per profileFieldsTTL. Failures are not cached. Fields hidden from the token are not returned by Slack.
+func (ap *ApiProvider) ProfileFields(ctx context.Context) (map[string]slack.TeamProfileField, error) {
+	ap.teamMu.RLock()
+	fields, fetched := ap.profileFields, ap.profileFieldsFetched
+	ap.teamMu.RUnlock()
+	if fields != nil && time.Since(fetched) < profileFieldsTTL {
+		return fields, nil
+	}
+
+	profile, err := ap.client.GetTeamProfileContext(ctx)
+	if err != nil {
+		ap.logger.Error("Failed to fetch team profile fields", zap.Error(err))
+		return nil, err
+	}
+
+	fields = make(map[string]slack.TeamProfileField, len(profile.Fields))
+	for _, field := range profile.Fields {
+		fields[field.ID] = field
+	}
+
+	ap.teamMu.Lock()
+	ap.profileFields = fields
+	ap.profileFieldsFetched = time.Now()
+	ap.teamMu.Unlock()
+
+	ap.logger.Info("Loaded team profile fields", zap.Int("count", len(fields)))
+	return fields, nil
+}
+
+// TeamName returns the workspace name from team.info once loaded, or the one reported by auth.test before that
+func (ap *ApiProvider) TeamName() string {
+	ap.teamMu.RLock()
+	team := ap.team
+	ap.teamMu.RUnlock()
+	if team != nil {
+		return team.Name
+	}
+
+	if c, ok := ap.client.(interface {
+		AuthResponse() *slack.AuthTestResponse
+	}); ok {
+		if resp := c.AuthResponse(); resp != nil {
+			return resp.Team
+		}
+	}
+	return ""
+}
+
+// teamIconURL picks the largest icon of a team.info response, default icons are skipped
+func teamIconURL(icon map[string]interface{}) string {
+	if isDefault, _ := icon["image_default"].(bool); isDefault {
+		return ""
+	}
+	for _, size := range teamIconSizes {
+		if url, ok := icon[size].(string); ok && url != "" {
+			return url
+		}
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// WorkflowTrigger is a trigger of a workflow as listed by workflows.triggers.list, Type is e.g.
+// shortcut, event, scheduled or webhook
+type WorkflowTrigger struct {
+	ID          string           `json:"id"`
+	Type        string           `json:"type"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Workflow    WorkflowMetadata `json:"workflow"`
+	DateCreated int64            `json:"date_created"`
+}
+
+// WorkflowMetadata identifies the workflow a trigger starts
+type WorkflowMetadata struct {
+	ID          string `json:"id"`
+	CallbackID  string `json:"callback_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	AppID       string `json:"app_id"`
+}
+
+// WorkflowTriggersParameters select a page of workflows.triggers.list
+type WorkflowTriggersParameters struct {
+	Limit  int
+	Cursor string
+}
+
+// ListWorkflowTriggersContext returns a page of the workflow triggers the token can see and the next cursor.
+// slack-go has no binding for workflows.triggers.list, so it is called through the same HTTP client.
+func (c *MCPSlackClient) ListWorkflowTriggersContext(ctx context.Context, params WorkflowTriggersParameters) ([]WorkflowTrigger, string, error) {
+	callCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	form := url.Values{}
+	if params.Limit > 0 {
+		form.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Cursor != "" {
+		form.Set("cursor", params.Cursor)
+	}
+
+	var res struct {
+		slack.SlackResponse
+		Triggers []WorkflowTrigger `json:"triggers"`
+	}
+	err := c.postForm(callCtx, "workflows.triggers.list", form, &res)
+	if err == nil {
+		err = res.Err()
+	}
+	if err != nil {
+		return nil, "", c.callError(ctx, callCtx, err)
+	}
+	return res.Triggers, res.ResponseMetadata.Cursor, nil
+}
+
+// postForm calls a Web API method that slack-go doesn't bind and decodes the response into v
+func (c *MCPSlackClient) postForm(ctx context.Context, method string, form url.Values, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+method, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+c.authProvider.SlackToken())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return &slack.RateLimitedError{RetryAfter: time.Duration(retryAfter) * time.Second}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return slack.StatusCodeError{Code: resp.StatusCode, Status: resp.Status}
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
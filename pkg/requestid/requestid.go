@@ -0,0 +1,71 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Header carries the request ID in inbound requests and responses
+const Header = "X-Request-ID"
+
+// maxLength bounds inbound request IDs so clients can't flood logs through the header
+const maxLength = 128
+
+type requestIDKey struct{}
+
+// New returns a random request ID
+func New() string {
+	return uuid.NewString()
+}
+
+// WithID stores the request ID in the context
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext returns the request ID stored in the context or an empty string
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Logger returns logger with a request_id field when the context carries a request ID
+func Logger(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	if id := FromContext(ctx); id != "" {
+		return logger.With(zap.String("request_id", id))
+	}
+	return logger
+}
+
+// Middleware assigns a request ID to every HTTP request, honoring a valid inbound X-Request-ID,
+// stores it in the request context and echoes it in the response header
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if !isValid(id) {
+			id = New()
+		}
+
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(WithID(r.Context(), id)))
+	})
+}
+
+// isValid accepts non-empty IDs of printable ASCII without spaces, up to maxLength
+func isValid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
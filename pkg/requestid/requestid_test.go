@@ -0,0 +1,64 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestMiddleware(t *testing.T) {
+	var seen string
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = FromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name    string
+		inbound string
+		honored bool
+	}{
+		{"generated when missing", "", false},
+		{"inbound honored", "req-123", true},
+		{"too long replaced", strings.Repeat("a", maxLength+1), false},
+		{"control characters replaced", "bad\nid", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/sse", nil)
+			if tt.inbound != "" {
+				req.Header.Set(Header, tt.inbound)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			echoed := w.Header().Get(Header)
+			assert.NotEmpty(t, echoed)
+			assert.Equal(t, echoed, seen)
+			if tt.honored {
+				assert.Equal(t, tt.inbound, echoed)
+			} else {
+				assert.NotEqual(t, tt.inbound, echoed)
+			}
+		})
+	}
+}
+
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	logger := zap.New(core)
+
+	Logger(context.Background(), logger).Info("without id")
+	Logger(WithID(context.Background(), "req-1"), logger).Info("with id")
+
+	entries := logs.All()
+	assert.Len(t, entries, 2)
+	assert.NotContains(t, entries[0].ContextMap(), "request_id")
+	assert.Equal(t, "req-1", entries[1].ContextMap()["request_id"])
+}
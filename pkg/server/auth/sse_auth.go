@@ -3,16 +3,21 @@ package auth
 import (
 	"context"
 	"crypto/subtle"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
 
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"go.uber.org/zap"
 )
 
+// ErrUnauthenticated is wrapped by all authentication failures
+var ErrUnauthenticated = errors.New("unauthenticated")
+
 // authKey is a custom context key for storing the auth token.
 type authKey struct{}
 
@@ -23,6 +28,8 @@ func withAuthKey(ctx context.Context, auth string) context.Context {
 
 // Authenticate checks if the request is authenticated based on the provided context.
 func validateToken(ctx context.Context, logger *zap.Logger) (bool, error) {
+	logger = requestid.Logger(ctx, logger)
+
 	// no configured token means no authentication
 	keyA := os.Getenv("SLACK_MCP_SSE_API_KEY")
 	if keyA == "" {
@@ -37,7 +44,7 @@ func validateToken(ctx context.Context, logger *zap.Logger) (bool, error) {
 		logger.Warn("Missing auth token in context",
 			zap.String("context", "http"),
 		)
-		return false, fmt.Errorf("missing auth")
+		return false, fmt.Errorf("%w: missing auth", ErrUnauthenticated)
 	}
 
 	logger.Debug("Validating auth token",
@@ -53,7 +60,7 @@ func validateToken(ctx context.Context, logger *zap.Logger) (bool, error) {
 		logger.Warn("Invalid auth token provided",
 			zap.String("context", "http"),
 		)
-		return false, fmt.Errorf("invalid auth token")
+		return false, fmt.Errorf("%w: invalid auth token", ErrUnauthenticated)
 	}
 
 	logger.Debug("Auth token validated successfully",
@@ -74,6 +81,7 @@ func AuthFromRequest(logger *zap.Logger) func(context.Context, *http.Request) co
 func BuildMiddleware(transport string, logger *zap.Logger) server.ToolHandlerMiddleware {
 	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
 		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			logger := requestid.Logger(ctx, logger)
 			logger.Debug("Auth middleware invoked",
 				zap.String("context", "http"),
 				zap.String("transport", transport),
@@ -122,7 +130,7 @@ func IsAuthenticated(ctx context.Context, transport string, logger *zap.Logger)
 			logger.Warn("SSE unauthorized request",
 				zap.String("context", "http"),
 			)
-			return false, fmt.Errorf("unauthorized request")
+			return false, fmt.Errorf("%w: unauthorized request", ErrUnauthenticated)
 		}
 
 		return true, nil
@@ -135,3 +143,26 @@ func IsAuthenticated(ctx context.Context, transport string, logger *zap.Logger)
 		return false, fmt.Errorf("unknown transport type: %s", transport)
 	}
 }
+
+// HTTPMiddleware protects plain HTTP handlers (e.g. debug endpoints) with the same
+// API key that guards the SSE transport.
+func HTTPMiddleware(logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := AuthFromRequest(logger)(r.Context(), r)
+			logger := requestid.Logger(ctx, logger)
+			if authenticated, err := IsAuthenticated(ctx, "sse", logger); !authenticated {
+				logger.Warn("HTTP request rejected by auth middleware",
+					zap.String("context", "http"),
+					zap.String("path", r.URL.Path),
+					zap.Error(err),
+				)
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
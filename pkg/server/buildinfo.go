@@ -0,0 +1,47 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/version"
+	"go.uber.org/zap"
+)
+
+// BuildInfo identifies the deployed artifact, values are injected via ldflags at build time
+type BuildInfo struct {
+	Version    string `json:"version"`
+	Commit     string `json:"commit"`
+	BuildTime  string `json:"build_time"`
+	BinaryName string `json:"binary_name"`
+	GoVersion  string `json:"go_version"`
+}
+
+// CurrentBuildInfo returns the build information of the running binary
+func CurrentBuildInfo() BuildInfo {
+	return BuildInfo{
+		Version:    version.Version,
+		Commit:     version.CommitHash,
+		BuildTime:  version.BuildTime,
+		BinaryName: version.BinaryName,
+		GoVersion:  runtime.Version(),
+	}
+}
+
+// buildInfoHandler serves CurrentBuildInfo as JSON, it is unauthenticated like the health endpoints
+func buildInfoHandler(logger *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if err := json.NewEncoder(w).Encode(CurrentBuildInfo()); err != nil {
+			logger.Error("Failed to encode build info", zap.Error(err))
+		}
+	}
+}
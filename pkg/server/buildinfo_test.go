@@ -0,0 +1,46 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/version"
+)
+
+func TestBuildInfoEndpoint(t *testing.T) {
+	t.Setenv("SLACK_MCP_SSE_API_KEY", "secret")
+
+	origCommit, origBuildTime := version.CommitHash, version.BuildTime
+	version.CommitHash, version.BuildTime = "abc123", "2025-07-01T12:00:00Z"
+	defer func() { version.CommitHash, version.BuildTime = origCommit, origBuildTime }()
+
+	handler := newTestEnhancedSSEServer().Handler()
+
+	req := httptest.NewRequest("GET", "/build-info", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected /build-info to be served without auth, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected Content-Type application/json, got %s", ct)
+	}
+
+	var info BuildInfo
+	if err := json.NewDecoder(w.Body).Decode(&info); err != nil {
+		t.Fatalf("Failed to decode build info: %v", err)
+	}
+	if info.Commit != "abc123" || info.BuildTime != "2025-07-01T12:00:00Z" || info.Version != version.Version {
+		t.Errorf("Unexpected build info: %+v", info)
+	}
+
+	req = httptest.NewRequest("POST", "/build-info", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected POST /build-info to return 405, got %d", w.Code)
+	}
+}
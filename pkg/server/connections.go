@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultMaxConnections        = 100
+	defaultConnectionIdleTimeout = 30 * time.Minute
+)
+
+// connectionLimiter bounds the number of concurrent SSE streams and closes streams that stay idle for too long
+type connectionLimiter struct {
+	max         int
+	active      atomic.Int64
+	idleTimeout time.Duration
+	logger      *zap.Logger
+}
+
+func newConnectionLimiter(logger *zap.Logger) *connectionLimiter {
+	idleTimeout := defaultConnectionIdleTimeout
+	if value := os.Getenv("SLACK_MCP_CONNECTION_IDLE_TIMEOUT"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			logger.Warn("Invalid SLACK_MCP_CONNECTION_IDLE_TIMEOUT, using default",
+				zap.String("value", value),
+				zap.Duration("default", defaultConnectionIdleTimeout))
+		} else {
+			idleTimeout = d
+		}
+	}
+
+	return &connectionLimiter{
+		max:         parsePositiveIntEnv("SLACK_MCP_MAX_CONNECTIONS", defaultMaxConnections, logger),
+		idleTimeout: idleTimeout,
+		logger:      logger,
+	}
+}
+
+// Active returns the number of open SSE streams
+func (l *connectionLimiter) Active() int {
+	return int(l.active.Load())
+}
+
+// Stats formats the open streams against the limit for health details
+func (l *connectionLimiter) Stats() string {
+	return fmt.Sprintf("%d/%d", l.Active(), l.max)
+}
+
+func (l *connectionLimiter) acquire() bool {
+	for {
+		n := l.active.Load()
+		if n >= int64(l.max) {
+			return false
+		}
+		if l.active.CompareAndSwap(n, n+1) {
+			return true
+		}
+	}
+}
+
+func (l *connectionLimiter) release() {
+	l.active.Add(-1)
+}
+
+// Handler serves next as a long lived stream, reject is called instead when all connection slots are taken.
+// A stream without writes for longer than the idle timeout gets its request context cancelled.
+func (l *connectionLimiter) Handler(next http.Handler, reject http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := requestid.Logger(r.Context(), l.logger)
+		if !l.acquire() {
+			logger.Warn("SSE connection rejected, limit reached",
+				zap.Int("max_connections", l.max),
+				zap.String("remote_addr", r.RemoteAddr),
+			)
+			w.Header().Set("Retry-After", "5")
+			reject(w, r)
+			return
+		}
+		defer l.release()
+
+		if l.idleTimeout <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		iw := &idleResponseWriter{ResponseWriter: w}
+		iw.touch()
+		go l.watchIdle(ctx, cancel, iw, logger)
+
+		next.ServeHTTP(iw, r.WithContext(ctx))
+	})
+}
+
+// watchIdle cancels the stream once no data was written within the idle timeout
+func (l *connectionLimiter) watchIdle(ctx context.Context, cancel context.CancelFunc, iw *idleResponseWriter, logger *zap.Logger) {
+	ticker := time.NewTicker(l.idleTimeout / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if idle := iw.idleFor(); idle >= l.idleTimeout {
+				logger.Info("Closing idle SSE connection",
+					zap.Duration("idle", idle),
+					zap.Duration("idle_timeout", l.idleTimeout),
+				)
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// idleResponseWriter records the time of the last write, Flush is passed through for event streaming
+type idleResponseWriter struct {
+	http.ResponseWriter
+	lastWrite atomic.Int64
+}
+
+func (w *idleResponseWriter) touch() {
+	w.lastWrite.Store(time.Now().UnixNano())
+}
+
+func (w *idleResponseWriter) idleFor() time.Duration {
+	return time.Since(time.Unix(0, w.lastWrite.Load()))
+}
+
+func (w *idleResponseWriter) Write(b []byte) (int, error) {
+	w.touch()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idleResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func newTestConnectionServer(t *testing.T, max int, idleTimeout time.Duration) (*httptest.Server, *connectionLimiter) {
+	t.Helper()
+	e := newTestEnhancedSSEServer()
+	e.connections = &connectionLimiter{max: max, idleTimeout: idleTimeout, logger: zap.NewNop()}
+	ts := httptest.NewServer(e.Handler())
+	t.Cleanup(ts.Close)
+	return ts, e.connections
+}
+
+// openStream connects to /sse and waits for the endpoint event so the stream is known to be established
+func openStream(t *testing.T, ctx context.Context, url string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/sse", nil)
+	if err != nil {
+		t.Fatalf("Failed to build request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open SSE stream: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		t.Fatalf("Expected SSE stream to be accepted, got status %d", resp.StatusCode)
+	}
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "event: endpoint") {
+		resp.Body.Close()
+		t.Fatalf("Expected endpoint event, got %q (%v)", line, err)
+	}
+	return resp
+}
+
+func TestConnectionLimitRejectsExtraStreams(t *testing.T) {
+	ts, limiter := newTestConnectionServer(t, 2, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := openStream(t, ctx, ts.URL)
+	defer first.Body.Close()
+	second := openStream(t, ctx, ts.URL)
+	defer second.Body.Close()
+
+	if limiter.Active() != 2 {
+		t.Fatalf("Expected 2 active connections, got %d", limiter.Active())
+	}
+
+	resp, err := http.Get(ts.URL + "/sse")
+	if err != nil {
+		t.Fatalf("Failed to request SSE stream: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status 503 above the limit, got %d", resp.StatusCode)
+	}
+	if !strings.Contains(string(body), "TOO_MANY_CONNECTIONS") {
+		t.Errorf("Expected TOO_MANY_CONNECTIONS error code, got %s", body)
+	}
+	if resp.Header.Get("Retry-After") == "" {
+		t.Error("Expected Retry-After header on rejected connection")
+	}
+	if limiter.Stats() != "2/2" {
+		t.Errorf("Expected stats 2/2, got %q", limiter.Stats())
+	}
+}
+
+func TestConnectionIdleTimeoutClosesStream(t *testing.T) {
+	ts, limiter := newTestConnectionServer(t, 1, 50*time.Millisecond)
+
+	resp := openStream(t, context.Background(), ts.URL)
+	defer resp.Body.Close()
+
+	done := make(chan struct{})
+	go func() {
+		io.Copy(io.Discard, resp.Body)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected idle SSE stream to be closed")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for limiter.Active() != 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if limiter.Active() != 0 {
+		t.Errorf("Expected connection slot to be released, got %d active", limiter.Active())
+	}
+}
+
+func TestCompressedSSEStream(t *testing.T) {
+	t.Setenv("SLACK_MCP_COMPRESSION", "true")
+	ts, _ := newTestConnectionServer(t, 1, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/sse", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open SSE stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ce := resp.Header.Get("Content-Encoding"); ce != "gzip" {
+		t.Fatalf("Expected a gzip encoded stream, got %q", ce)
+	}
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to open gzip stream: %v", err)
+	}
+	line, err := bufio.NewReader(zr).ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "event: endpoint") {
+		t.Errorf("Expected endpoint event, got %q (%v)", line, err)
+	}
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/korotovsky/slack-mcp-server/pkg/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+// includeRawArg is the per-call argument that attaches the raw Slack responses to a tool result
+const includeRawArg = "include_raw"
+
+// rawCachedNote explains an empty raw list: the Web API and edge clients share the recording transport,
+// so a call recording nothing answered from the caches
+const rawCachedNote = "no Slack API call was made, the result was served from the users, channels or emoji caches"
+
+// rawPayload is the extra content a tool result gets when include_raw is set
+type rawPayload struct {
+	Raw []transport.RawResponse `json:"raw"`
+	// Dropped counts the responses over the per-call limit that were not recorded
+	Dropped int `json:"dropped,omitempty"`
+	// Note explains an empty Raw
+	Note string `json:"note,omitempty"`
+}
+
+// IsDebugRawEnabled returns true if SLACK_MCP_DEBUG_RAW lets tool calls ask for the raw Slack responses
+func IsDebugRawEnabled() bool {
+	enabled := os.Getenv("SLACK_MCP_DEBUG_RAW")
+	return enabled == "true" || enabled == "1"
+}
+
+// debugRawOption lists the include_raw argument and attaches the raw responses when SLACK_MCP_DEBUG_RAW is
+// enabled. Otherwise calls passing include_raw are rejected, so clients notice that nothing is attached.
+func debugRawOption(logger *zap.Logger) server.ServerOption {
+	if !IsDebugRawEnabled() {
+		return server.WithToolHandlerMiddleware(buildDebugRawMiddleware(false))
+	}
+
+	logger.Warn("Debug raw mode enabled, tool calls with include_raw return raw Slack API responses which may contain sensitive data, do not enable it in production",
+		zap.String("context", "console"),
+	)
+	return func(s *server.MCPServer) {
+		server.WithToolFilter(addIncludeRawArg)(s)
+		server.WithToolHandlerMiddleware(buildDebugRawMiddleware(true))(s)
+	}
+}
+
+// addIncludeRawArg declares include_raw on every tool in tools/list, the registered tools are left as is
+func addIncludeRawArg(_ context.Context, tools []mcp.Tool) []mcp.Tool {
+	listed := make([]mcp.Tool, len(tools))
+	for i, tool := range tools {
+		properties := make(map[string]any, len(tool.InputSchema.Properties)+1)
+		for name, property := range tool.InputSchema.Properties {
+			properties[name] = property
+		}
+		properties[includeRawArg] = map[string]any{
+			"type":        "boolean",
+			"description": "Debugging only: attach the raw JSON responses of the Slack API calls made by this tool call as an extra content item.",
+		}
+		tool.InputSchema.Properties = properties
+		listed[i] = tool
+	}
+	return listed
+}
+
+// buildDebugRawMiddleware records the Slack responses of calls passing include_raw=true and appends them as
+// JSON after the tool result, failed calls included. It runs outside the error middleware to see those.
+func buildDebugRawMiddleware(enabled bool) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !req.GetBool(includeRawArg, false) {
+				return next(ctx, req)
+			}
+			if !enabled {
+				return handler.ToolErrorResult(handler.NewToolError(handler.ErrCodeCapabilityUnsupported,
+					includeRawArg+" is disabled, set SLACK_MCP_DEBUG_RAW=true to attach raw Slack API responses", nil)), nil
+			}
+
+			recorder := &transport.RawRecorder{}
+			res, err := next(transport.WithRawRecorder(ctx, recorder), req)
+			if err != nil || res == nil {
+				return res, err
+			}
+
+			payload := rawPayload{}
+			payload.Raw, payload.Dropped = recorder.Responses()
+			if payload.Raw == nil {
+				payload.Raw = []transport.RawResponse{}
+				payload.Note = rawCachedNote
+			}
+			data, err := json.Marshal(payload)
+			if err != nil {
+				return res, nil
+			}
+			res.Content = append(res.Content, mcp.NewTextContent(string(data)))
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/korotovsky/slack-mcp-server/pkg/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slackCallingHandler calls a fake Slack API through RawResponseTransport with the context of the tool call
+func slackCallingHandler(t *testing.T) func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"ok":true,"channel":{"id":"C0GENERAL","name":"general"}}`)
+	}))
+	t.Cleanup(api.Close)
+	client := &http.Client{Transport: transport.NewRawResponseTransport(http.DefaultTransport)}
+
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, api.URL+"/api/conversations.info", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(httpReq)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return mcp.NewToolResultText("id,name\nC0GENERAL,general\n"), nil
+	}
+}
+
+func debugRawRequest(args map[string]any) mcp.CallToolRequest {
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "conversations_info"
+	req.Params.Arguments = args
+	return req
+}
+
+func TestDebugRawOffByDefault(t *testing.T) {
+	t.Setenv("SLACK_MCP_DEBUG_RAW", "")
+	require.False(t, IsDebugRawEnabled())
+	mw := buildDebugRawMiddleware(IsDebugRawEnabled())(slackCallingHandler(t))
+
+	res, err := mw(context.Background(), debugRawRequest(map[string]any{"channel_id": "C0GENERAL"}))
+	require.NoError(t, err)
+	require.Len(t, res.Content, 1, "the raw responses are absent by default")
+	assert.NotContains(t, res.Content[0].(mcp.TextContent).Text, `"raw"`)
+
+	res, err = mw(context.Background(), debugRawRequest(map[string]any{"channel_id": "C0GENERAL", includeRawArg: true}))
+	require.NoError(t, err)
+	require.True(t, res.IsError, "include_raw is rejected while SLACK_MCP_DEBUG_RAW is off")
+	require.Len(t, res.Content, 1)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, handler.ErrCodeCapabilityUnsupported)
+}
+
+func TestDebugRawAttachesResponses(t *testing.T) {
+	t.Setenv("SLACK_MCP_DEBUG_RAW", "true")
+	require.True(t, IsDebugRawEnabled())
+	mw := buildDebugRawMiddleware(IsDebugRawEnabled())(slackCallingHandler(t))
+
+	res, err := mw(context.Background(), debugRawRequest(map[string]any{"channel_id": "C0GENERAL"}))
+	require.NoError(t, err)
+	assert.Len(t, res.Content, 1, "calls without include_raw are unchanged")
+
+	res, err = mw(context.Background(), debugRawRequest(map[string]any{"channel_id": "C0GENERAL", includeRawArg: true}))
+	require.NoError(t, err)
+	require.Len(t, res.Content, 2)
+	assert.Equal(t, "id,name\nC0GENERAL,general\n", res.Content[0].(mcp.TextContent).Text)
+
+	var payload struct {
+		Raw []struct {
+			Method string         `json:"method"`
+			Status int            `json:"status"`
+			Body   map[string]any `json:"body"`
+		} `json:"raw"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(res.Content[1].(mcp.TextContent).Text), &payload))
+	require.Len(t, payload.Raw, 1)
+	assert.Equal(t, "conversations.info", payload.Raw[0].Method)
+	assert.Equal(t, http.StatusOK, payload.Raw[0].Status)
+	assert.Equal(t, true, payload.Raw[0].Body["ok"])
+}
+
+func TestDebugRawNotesCachedResults(t *testing.T) {
+	cached := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("id,name\nC0GENERAL,general\n"), nil
+	}
+	mw := buildDebugRawMiddleware(true)(cached)
+
+	res, err := mw(context.Background(), debugRawRequest(map[string]any{includeRawArg: true}))
+	require.NoError(t, err)
+	require.Len(t, res.Content, 2)
+
+	var payload rawPayload
+	require.NoError(t, json.Unmarshal([]byte(res.Content[1].(mcp.TextContent).Text), &payload))
+	assert.Empty(t, payload.Raw)
+	assert.Equal(t, rawCachedNote, payload.Note)
+}
+
+func TestAddIncludeRawArg(t *testing.T) {
+	tools := []mcp.Tool{mcp.NewTool("channels_list", mcp.WithString("query"))}
+
+	listed := addIncludeRawArg(context.Background(), tools)
+	require.Len(t, listed, 1)
+	assert.Contains(t, listed[0].InputSchema.Properties, "query")
+	assert.Contains(t, listed[0].InputSchema.Properties, includeRawArg)
+	assert.NotContains(t, tools[0].InputSchema.Properties, includeRawArg, "the registered tool is not changed")
+}
@@ -3,11 +3,17 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/transport"
 	"github.com/korotovsky/slack-mcp-server/pkg/version"
 	"go.uber.org/zap"
 )
@@ -18,14 +24,17 @@ type HealthStatus string
 const (
 	HealthStatusHealthy   HealthStatus = "healthy"
 	HealthStatusUnhealthy HealthStatus = "unhealthy"
+	// HealthStatusDegraded means the server works but Slack answers slowly, it is served with 200
+	HealthStatusDegraded HealthStatus = "degraded"
 )
 
 // CheckStatus represents the status of individual health checks
 type CheckStatus string
 
 const (
-	CheckStatusOK    CheckStatus = "ok"
-	CheckStatusError CheckStatus = "error"
+	CheckStatusOK       CheckStatus = "ok"
+	CheckStatusError    CheckStatus = "error"
+	CheckStatusDegraded CheckStatus = "degraded"
 )
 
 // HealthResponse represents the JSON response for health endpoints
@@ -34,42 +43,145 @@ type HealthResponse struct {
 	Timestamp time.Time              `json:"timestamp"`
 	Version   string                 `json:"version"`
 	Checks    map[string]CheckStatus `json:"checks"`
+	// Uptime is serialized in nanoseconds, MarshalJSON adds uptime_human and uptime_seconds
 	Uptime    *time.Duration         `json:"uptime,omitempty"`
 	Details   map[string]string      `json:"details,omitempty"`
 }
 
+// MarshalJSON keeps uptime in nanoseconds for existing consumers and adds it as a Go duration
+// string, e.g. 5m0s, and as seconds
+func (r HealthResponse) MarshalJSON() ([]byte, error) {
+	type plain HealthResponse
+	out := struct {
+		plain
+		UptimeHuman   string   `json:"uptime_human,omitempty"`
+		UptimeSeconds *float64 `json:"uptime_seconds,omitempty"`
+	}{plain: plain(r)}
+	if r.Uptime != nil {
+		seconds := r.Uptime.Seconds()
+		out.UptimeHuman = r.Uptime.String()
+		out.UptimeSeconds = &seconds
+	}
+	return json.Marshal(out)
+}
+
+const (
+	defaultHealthTimeout    = 10 * time.Second
+	defaultReadinessTimeout = 15 * time.Second
+	defaultDegradedLatency  = 2 * time.Second
+)
+
 // HealthChecker manages health check functionality
 type HealthChecker struct {
 	provider  *provider.ApiProvider
 	logger    *zap.Logger
 	startTime time.Time
+
+	healthTimeout    time.Duration
+	readinessTimeout time.Duration
+	// degradedLatency is the auth.test latency above which the slack_api check is degraded, 0 disables it
+	degradedLatency time.Duration
+	// slackStreak keeps one-off Slack failures from flipping readiness, see checkStreak
+	slackStreak *checkStreak
+	// proxyURL is the redacted proxy that Slack requests, auth.test of the slack_api check included, go through
+	proxyURL string
+
+	// connections is set by the SSE transport to report open streams, nil for stdio
+	connections *connectionLimiter
 }
 
 // NewHealthChecker creates a new health checker instance
 func NewHealthChecker(provider *provider.ApiProvider, logger *zap.Logger) *HealthChecker {
+	healthTimeout, readinessTimeout, err := ParseHealthTimeouts()
+	if err != nil {
+		logger.Warn("Invalid health check timeout, using defaults",
+			zap.Error(err),
+			zap.Duration("health_timeout", defaultHealthTimeout),
+			zap.Duration("readiness_timeout", defaultReadinessTimeout))
+		healthTimeout, readinessTimeout = defaultHealthTimeout, defaultReadinessTimeout
+	}
+	degradedLatency, err := ParseDegradedLatency()
+	if err != nil {
+		logger.Warn("Invalid degraded latency threshold, using default",
+			zap.Error(err),
+			zap.Duration("degraded_latency", defaultDegradedLatency))
+		degradedLatency = defaultDegradedLatency
+	}
+
 	return &HealthChecker{
-		provider:  provider,
-		logger:    logger,
-		startTime: time.Now(),
+		provider:         provider,
+		logger:           logger,
+		startTime:        time.Now(),
+		healthTimeout:    healthTimeout,
+		readinessTimeout: readinessTimeout,
+		degradedLatency:  degradedLatency,
+		slackStreak:      newCheckStreak(logger),
+		proxyURL:         healthProxyURL(logger),
+	}
+}
+
+// healthProxyURL returns the redacted proxy of Slack requests for health details, main validates it at startup
+func healthProxyURL(logger *zap.Logger) string {
+	proxyURL, err := transport.ParseProxyURL()
+	if err != nil {
+		logger.Warn("Invalid proxy configuration", zap.Error(err))
+		return ""
+	}
+	return transport.RedactProxyURL(proxyURL)
+}
+
+// ParseHealthTimeouts reads the /health and /ready check timeouts from SLACK_MCP_HEALTH_TIMEOUT
+// and SLACK_MCP_READINESS_TIMEOUT, unset values keep the defaults of 10s and 15s
+func ParseHealthTimeouts() (health, readiness time.Duration, err error) {
+	health, err = parsePositiveDurationEnv("SLACK_MCP_HEALTH_TIMEOUT", defaultHealthTimeout)
+	if err != nil {
+		return 0, 0, err
+	}
+	readiness, err = parsePositiveDurationEnv("SLACK_MCP_READINESS_TIMEOUT", defaultReadinessTimeout)
+	if err != nil {
+		return 0, 0, err
 	}
+	return health, readiness, nil
+}
+
+// ParseDegradedLatency reads SLACK_MCP_HEALTH_DEGRADED_LATENCY, the auth.test latency above which
+// /ready reports Slack as degraded instead of healthy. Unset keeps the default of 2s, 0 disables it.
+func ParseDegradedLatency() (time.Duration, error) {
+	value := os.Getenv("SLACK_MCP_HEALTH_DEGRADED_LATENCY")
+	if value == "0" {
+		return 0, nil
+	}
+	return parsePositiveDurationEnv("SLACK_MCP_HEALTH_DEGRADED_LATENCY", defaultDegradedLatency)
+}
+
+func parsePositiveDurationEnv(name string, def time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s value '%s': must be a positive duration, e.g. '10s'", name, value)
+	}
+	return d, nil
 }
 
 // HealthHandler handles the basic health check endpoint
 func (h *HealthChecker) HealthHandler(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.healthTimeout)
 	defer cancel()
 
 	response := h.performHealthChecks(ctx, false)
-	h.writeHealthResponse(w, response)
+	h.respond(w, r, response)
 }
 
 // ReadinessHandler handles the readiness check endpoint
 func (h *HealthChecker) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.readinessTimeout)
 	defer cancel()
 
 	response := h.performHealthChecks(ctx, true)
-	h.writeHealthResponse(w, response)
+	h.respond(w, r, response)
 }
 
 // LivenessHandler handles the liveness check endpoint
@@ -83,10 +195,11 @@ func (h *HealthChecker) LivenessHandler(w http.ResponseWriter, r *http.Request)
 		Checks: map[string]CheckStatus{
 			"application": CheckStatusOK,
 		},
-		Uptime: &uptime,
+		Uptime:  &uptime,
+		Details: buildDetails(map[string]string{"phase": string(h.phase())}),
 	}
 
-	h.writeHealthResponse(w, response)
+	h.respond(w, r, response)
 }
 
 // performHealthChecks executes all health checks and returns the aggregated result
@@ -101,15 +214,122 @@ func (h *HealthChecker) performHealthChecks(ctx context.Context, includeReadines
 	if cacheStatus == CheckStatusError {
 		overallStatus = HealthStatusUnhealthy
 		details["cache"] = "Cache system not ready"
+		if h.provider != nil {
+			if authErr := h.provider.AuthError(); authErr != nil {
+				details["auth"] = authErr.Error()
+			}
+		}
+	}
+
+	// Report each collection that is not loaded, one may fail while the other serves tools already
+	if h.provider != nil && h.provider.AuthError() == nil {
+		for _, collection := range []string{provider.RefreshUsers, provider.RefreshChannels} {
+			if ready, err := h.provider.CollectionReady(collection); !ready {
+				details[collection+"_cache"] = err.Error()
+			}
+		}
 	}
 
 	// Check Slack API connectivity (only for readiness checks)
 	if includeReadiness {
-		slackStatus := h.checkSlackAPI(ctx)
+		rawStatus := h.checkSlackAPI(ctx)
+		slackStatus := h.slackStreak.record(rawStatus)
 		checks["slack_api"] = slackStatus
-		if slackStatus == CheckStatusError {
+		details["slack_api_streak"] = h.slackStreak.describe()
+		if latency := h.slackAPILatency(); latency > 0 {
+			details["slack_api_latency"] = latency.String()
+		}
+		if slackStatus == CheckStatusDegraded {
+			// slow but working, probes shouldn't restart the server for it
+			if overallStatus == HealthStatusHealthy {
+				overallStatus = HealthStatusDegraded
+			}
+			details["slack_api"] = fmt.Sprintf("Slack API is slow, auth.test took longer than %s", h.degradedLatency)
+			if rawStatus == CheckStatusError {
+				details["slack_api"] = fmt.Sprintf("Slack API check failed, unhealthy after %d consecutive failures", h.slackStreak.failureThreshold)
+			}
+		}
+		if slackStatus == CheckStatusError && rawStatus != CheckStatusError {
+			overallStatus = HealthStatusUnhealthy
+			details["slack_api"] = fmt.Sprintf("Slack API is recovering, healthy after %d consecutive successes", h.slackStreak.recoveryThreshold)
+		} else if slackStatus == CheckStatusError {
 			overallStatus = HealthStatusUnhealthy
 			details["slack_api"] = "Slack API connectivity failed"
+			if h.provider != nil && h.provider.CacheStats().BreakerState == limiter.BreakerOpen {
+				details["slack_api"] = "Circuit breaker open, Slack calls fail fast until the cooldown ends"
+			}
+			if h.provider != nil {
+				if authErr := h.provider.AuthError(); authErr != nil {
+					details["slack_api"] = authErr.Error()
+				}
+			}
+			if h.proxyURL != "" && rawStatus == CheckStatusError {
+				details["slack_api"] += ", check the proxy " + h.proxyURL
+			}
+		}
+	}
+
+	// Expose outbound Slack call concurrency for tuning SLACK_MCP_MAX_CONCURRENT_CALLS
+	if h.provider != nil {
+		if team := h.provider.TeamName(); team != "" {
+			details["team"] = team
+		}
+		// Enterprise Grid org-level tokens resolve names per team, see the team_id tool argument
+		details["org_scoped"] = strconv.FormatBool(h.provider.IsOrgScoped())
+		stats := h.provider.CacheStats()
+		if stats.UsersExcluded > 0 {
+			details["users"] = fmt.Sprintf("%d cached, %d excluded", stats.Users, stats.UsersExcluded)
+		}
+		if len(stats.ChannelsByType) > 0 {
+			details["channels_by_type"] = formatCounts(stats.ChannelsByType)
+		}
+		// Entries left out by SLACK_MCP_CACHE_MAX_ENTRIES are looked up on demand instead
+		capped := make(map[string]int)
+		if stats.UsersCapped > 0 {
+			capped["users"] = stats.UsersCapped
+		}
+		if stats.ChannelsCapped > 0 {
+			capped["channels"] = stats.ChannelsCapped
+		}
+		if len(capped) > 0 {
+			details["cache_capped"] = fmt.Sprintf("%s (max %d entries)", formatCounts(capped), stats.CacheMaxEntries)
+		}
+		if stats.SlackCallsLimit > 0 {
+			details["slack_calls_in_flight"] = fmt.Sprintf("%d/%d", stats.SlackCallsInFlight, stats.SlackCallsLimit)
+		}
+		if stats.BreakerState != "" {
+			details["slack_breaker"] = stats.BreakerState
+		}
+		// Refreshes failing in a row mean the cached data is going stale
+		failing := make(map[string]int)
+		for collection, refresh := range stats.Refresh {
+			if refresh.ConsecutiveFailures > 0 {
+				failing[collection] = int(refresh.ConsecutiveFailures)
+			}
+		}
+		if len(failing) > 0 {
+			details["cache_refresh_failures"] = formatCounts(failing)
+		}
+	}
+	if h.connections != nil {
+		details["sse_connections"] = h.connections.Stats()
+	}
+	if h.proxyURL != "" {
+		details["proxy"] = h.proxyURL
+	}
+	details["read_only"] = strconv.FormatBool(IsReadOnlyEnabled())
+
+	// Readiness outcomes move the lifecycle between ready and degraded, a draining server leaves rotation
+	if h.provider != nil {
+		lifecycle := h.provider.Lifecycle()
+		if includeReadiness {
+			lifecycle.ObserveHealth(overallStatus == HealthStatusHealthy, "readiness check reported "+string(overallStatus))
+		}
+		phase := h.phase()
+		details["phase"] = string(phase)
+		if includeReadiness && (phase == provider.PhaseDraining || phase == provider.PhaseStopped) {
+			overallStatus = HealthStatusUnhealthy
+			details["lifecycle"] = "Server is shutting down"
 		}
 	}
 
@@ -120,10 +340,41 @@ func (h *HealthChecker) performHealthChecks(ctx context.Context, includeReadines
 		Version:   version.Version,
 		Checks:    checks,
 		Uptime:    &uptime,
-		Details:   details,
+		Details:   buildDetails(details),
 	}
 }
 
+// phase returns the lifecycle phase shared with the provider
+func (h *HealthChecker) phase() provider.LifecyclePhase {
+	if h.provider == nil {
+		return provider.PhaseStarting
+	}
+	phase, _ := h.provider.Lifecycle().Phase()
+	return phase
+}
+
+// formatCounts renders counters sorted by key, e.g. im=3, public_channel=10
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%d", k, counts[k]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// buildDetails adds the commit and build time of the running binary to health details
+func buildDetails(details map[string]string) map[string]string {
+	details["commit"] = version.CommitHash
+	details["build_time"] = version.BuildTime
+	return details
+}
+
 // checkCacheSystem validates the cache system status
 func (h *HealthChecker) checkCacheSystem() CheckStatus {
 	if h.provider == nil {
@@ -154,8 +405,8 @@ func (h *HealthChecker) checkSlackAPI(ctx context.Context) CheckStatus {
 		return CheckStatusOK
 	}
 
-	// Perform a lightweight API call to verify connectivity
-	_, err := h.provider.Slack().AuthTestContext(ctx)
+	// auth.test verifies connectivity, shared with auth_whoami and cached briefly
+	_, err := h.provider.Identity(ctx)
 	if err != nil {
 		h.logger.Debug("Slack API connectivity check failed",
 			zap.Error(err),
@@ -163,9 +414,80 @@ func (h *HealthChecker) checkSlackAPI(ctx context.Context) CheckStatus {
 		return CheckStatusError
 	}
 
+	if latency := h.slackAPILatency(); h.degradedLatency > 0 && latency > h.degradedLatency {
+		h.logger.Debug("Slack API is slow",
+			zap.Duration("latency", latency),
+			zap.Duration("threshold", h.degradedLatency),
+		)
+		return CheckStatusDegraded
+	}
+
 	return CheckStatusOK
 }
 
+// slackAPILatency returns the duration of the last auth.test, rounded to milliseconds
+func (h *HealthChecker) slackAPILatency() time.Duration {
+	if h.provider == nil {
+		return 0
+	}
+	return h.provider.IdentityLatency().Round(time.Millisecond)
+}
+
+// respond writes the health response in the format negotiated from the Accept header,
+// JSON unless the client prefers text/plain
+func (h *HealthChecker) respond(w http.ResponseWriter, r *http.Request, response *HealthResponse) {
+	w.Header().Add("Vary", "Accept")
+	if prefersPlainText(r.Header.Get("Accept")) {
+		h.writePlainHealthResponse(w, response)
+		return
+	}
+	h.writeHealthResponse(w, response)
+}
+
+// prefersPlainText reports whether text/plain is acceptable and ranked above an explicit
+// application/json, wildcards select the JSON default
+func prefersPlainText(accept string) bool {
+	textQ, jsonQ := -1.0, -1.0
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		q := 1.0
+		for _, param := range params[1:] {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(key) != "q" {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = parsed
+			}
+		}
+
+		switch mediaType {
+		case "text/plain", "text/*":
+			textQ = max(textQ, q)
+		case "application/json":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return textQ > 0 && textQ > jsonQ
+}
+
+// writePlainHealthResponse writes OK or UNHEALTHY for probes that only look at the status line and body
+func (h *HealthChecker) writePlainHealthResponse(w http.ResponseWriter, response *HealthResponse) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if response.Status == HealthStatusUnhealthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(w, "UNHEALTHY")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if response.Status == HealthStatusDegraded {
+		fmt.Fprintln(w, "DEGRADED")
+		return
+	}
+	fmt.Fprintln(w, "OK")
+}
+
 // writeHealthResponse writes the health response as JSON
 func (h *HealthChecker) writeHealthResponse(w http.ResponseWriter, response *HealthResponse) {
 	w.Header().Set("Content-Type", "application/json")
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/version"
 	"go.uber.org/zap"
 )
 
@@ -86,6 +87,13 @@ func TestHealthChecker_LivenessHandler(t *testing.T) {
 	if *healthResp.Uptime <= 0 {
 		t.Error("Expected uptime to be positive")
 	}
+
+	if healthResp.Details["commit"] != version.CommitHash || healthResp.Details["build_time"] != version.BuildTime {
+		t.Errorf("Expected build details in response, got %v", healthResp.Details)
+	}
+	if healthResp.Details["phase"] != "starting" {
+		t.Errorf("Expected the lifecycle phase in details, got %v", healthResp.Details)
+	}
 }
 
 func TestHealthChecker_ReadinessHandler(t *testing.T) {
@@ -378,6 +386,17 @@ func TestHealthChecker_WriteHealthResponse(t *testing.T) {
 			expectedStatus: http.StatusServiceUnavailable,
 			expectedHeader: "application/json",
 		},
+		{
+			name: "degraded response",
+			response: &HealthResponse{
+				Status:    HealthStatusDegraded,
+				Timestamp: time.Now(),
+				Version:   "1.0.0",
+				Checks:    map[string]CheckStatus{"cache": CheckStatusOK, "slack_api": CheckStatusDegraded},
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeader: "application/json",
+		},
 	}
 
 	for _, tt := range tests {
@@ -556,4 +575,175 @@ func TestHealthResponse_AllFields(t *testing.T) {
 	if len(decoded.Details) != len(response.Details) {
 		t.Errorf("Details count mismatch: expected %d, got %d", len(response.Details), len(decoded.Details))
 	}
-}
\ No newline at end of file
+}
+func TestHealthChecker_AcceptNegotiation(t *testing.T) {
+	healthChecker := NewHealthChecker(&provider.ApiProvider{}, zap.NewNop())
+
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		accept       string
+		expectStatus int
+		expectType   string
+		expectBody   string
+	}{
+		{"plain text unhealthy", healthChecker.HealthHandler, "text/plain", http.StatusServiceUnavailable, "text/plain", "UNHEALTHY\n"},
+		{"plain text healthy", healthChecker.LivenessHandler, "text/plain", http.StatusOK, "text/plain", "OK\n"},
+		{"json", healthChecker.HealthHandler, "application/json", http.StatusServiceUnavailable, "application/json", ""},
+		{"default", healthChecker.HealthHandler, "", http.StatusServiceUnavailable, "application/json", ""},
+		{"wildcard", healthChecker.LivenessHandler, "*/*", http.StatusOK, "application/json", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/health", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			tt.handler(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.expectStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectStatus, resp.StatusCode)
+			}
+			if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, tt.expectType) {
+				t.Errorf("Expected Content-Type %s, got %s", tt.expectType, contentType)
+			}
+			if resp.Header.Get("Vary") != "Accept" {
+				t.Errorf("Expected Vary: Accept, got %q", resp.Header.Get("Vary"))
+			}
+
+			if tt.expectBody != "" {
+				if body := w.Body.String(); body != tt.expectBody {
+					t.Errorf("Expected body %q, got %q", tt.expectBody, body)
+				}
+				return
+			}
+			var healthResp HealthResponse
+			if err := json.NewDecoder(resp.Body).Decode(&healthResp); err != nil {
+				t.Errorf("Expected JSON response, failed to decode: %v", err)
+			}
+		})
+	}
+}
+
+func TestPrefersPlainText(t *testing.T) {
+	tests := map[string]bool{
+		"":                                   false,
+		"*/*":                                false,
+		"application/json":                   false,
+		"text/plain":                         true,
+		"text/*":                             true,
+		"text/plain, */*":                    true,
+		"text/plain;q=0.5, application/json": false,
+		"application/json;q=0.5, text/plain": true,
+		"text/plain;q=0":                     false,
+		"TEXT/PLAIN; charset=utf-8":          true,
+	}
+
+	for accept, expected := range tests {
+		if got := prefersPlainText(accept); got != expected {
+			t.Errorf("Expected prefersPlainText(%q) = %v, got %v", accept, expected, got)
+		}
+	}
+}
+
+func TestParseDegradedLatency(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", defaultDegradedLatency, false},
+		{"500ms", 500 * time.Millisecond, false},
+		{"0", 0, false},
+		{"-1s", 0, true},
+		{"slow", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("SLACK_MCP_HEALTH_DEGRADED_LATENCY", tt.value)
+		got, err := ParseDegradedLatency()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Expected error %v for %q, got %v", tt.wantErr, tt.value, err)
+		}
+		if err == nil && got != tt.want {
+			t.Errorf("Expected %s for %q, got %s", tt.want, tt.value, got)
+		}
+	}
+}
+
+func TestHealthChecker_WritePlainDegraded(t *testing.T) {
+	healthChecker := NewHealthChecker(&provider.ApiProvider{}, zap.NewNop())
+
+	w := httptest.NewRecorder()
+	healthChecker.writePlainHealthResponse(w, &HealthResponse{Status: HealthStatusDegraded})
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d for a degraded server, got %d", http.StatusOK, w.Code)
+	}
+	if body := strings.TrimSpace(w.Body.String()); body != "DEGRADED" {
+		t.Errorf("Expected body DEGRADED, got %q", body)
+	}
+}
+
+func TestHealthResponse_UptimeRepresentations(t *testing.T) {
+	uptime := 5*time.Minute + 1500*time.Millisecond
+	response := &HealthResponse{
+		Status:    HealthStatusHealthy,
+		Timestamp: time.Now(),
+		Checks:    map[string]CheckStatus{},
+		Uptime:    &uptime,
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+
+	var decoded struct {
+		Uptime        time.Duration `json:"uptime"`
+		UptimeHuman   string        `json:"uptime_human"`
+		UptimeSeconds float64       `json:"uptime_seconds"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if decoded.Uptime != uptime {
+		t.Errorf("Expected uptime in nanoseconds %d, got %d", uptime, decoded.Uptime)
+	}
+	if decoded.UptimeHuman != "5m1.5s" {
+		t.Errorf("Expected uptime_human 5m1.5s, got %q", decoded.UptimeHuman)
+	}
+	if parsed, err := time.ParseDuration(decoded.UptimeHuman); err != nil || parsed != uptime {
+		t.Errorf("Expected uptime_human to parse back to %s, got %s (%v)", uptime, parsed, err)
+	}
+	if got := time.Duration(decoded.UptimeSeconds * float64(time.Second)); got != uptime {
+		t.Errorf("Expected uptime_seconds to convert back to %s, got %s", uptime, got)
+	}
+
+	var full HealthResponse
+	if err := json.Unmarshal(data, &full); err != nil {
+		t.Fatalf("Failed to unmarshal into HealthResponse: %v", err)
+	}
+	if full.Uptime == nil || *full.Uptime != uptime {
+		t.Error("Expected HealthResponse to keep decoding uptime")
+	}
+}
+
+func TestHealthResponse_NoUptime(t *testing.T) {
+	data, err := json.Marshal(HealthResponse{Status: HealthStatusHealthy})
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	for _, field := range []string{"uptime", "uptime_human", "uptime_seconds"} {
+		if strings.Contains(string(data), `"`+field+`"`) {
+			t.Errorf("Expected %s to be left out without uptime, got %s", field, data)
+		}
+	}
+}
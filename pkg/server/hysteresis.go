@@ -0,0 +1,103 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultReadinessFailureThreshold  = 3
+	defaultReadinessRecoveryThreshold = 2
+	defaultReadinessFailureWindow     = time.Minute
+)
+
+// checkStreak adds hysteresis to the slack_api readiness check. A single failed auth.test doesn't pull
+// the server out of rotation: it turns unhealthy after failureThreshold consecutive failures within
+// window, and healthy again only after recoveryThreshold consecutive successes.
+type checkStreak struct {
+	failureThreshold  int
+	recoveryThreshold int
+	window            time.Duration
+
+	mu           sync.Mutex
+	failures     int
+	successes    int
+	firstFailure time.Time
+	down         bool
+	now          func() time.Time
+}
+
+func newCheckStreak(logger *zap.Logger) *checkStreak {
+	window := defaultReadinessFailureWindow
+	if value := os.Getenv("SLACK_MCP_READINESS_FAILURE_WINDOW"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			logger.Warn("Invalid SLACK_MCP_READINESS_FAILURE_WINDOW, using default",
+				zap.String("value", value),
+				zap.Duration("default", defaultReadinessFailureWindow))
+		} else {
+			window = d
+		}
+	}
+
+	return &checkStreak{
+		failureThreshold:  parsePositiveIntEnv("SLACK_MCP_READINESS_FAILURE_THRESHOLD", defaultReadinessFailureThreshold, logger),
+		recoveryThreshold: parsePositiveIntEnv("SLACK_MCP_READINESS_RECOVERY_THRESHOLD", defaultReadinessRecoveryThreshold, logger),
+		window:            window,
+		now:               time.Now,
+	}
+}
+
+// record adds the outcome of a check and returns the status to report for it. Failures within the grace
+// window are reported as degraded, successes while recovering are still reported as errors.
+func (s *checkStreak) record(status CheckStatus) CheckStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if status == CheckStatusError {
+		now := s.now()
+		// failures spread wider than the window are blips, the streak starts over
+		if s.failures == 0 || now.Sub(s.firstFailure) > s.window {
+			s.failures = 0
+			s.firstFailure = now
+		}
+		s.failures++
+		s.successes = 0
+		if s.failures >= s.failureThreshold {
+			s.down = true
+		}
+		if s.down {
+			return CheckStatusError
+		}
+		return CheckStatusDegraded
+	}
+
+	s.failures = 0
+	s.successes++
+	if s.down && s.successes >= s.recoveryThreshold {
+		s.down = false
+	}
+	if s.down {
+		return CheckStatusError
+	}
+	return status
+}
+
+// describe renders the current streak for health details, e.g. "2/3 failures" or "1/2 successes, recovering"
+func (s *checkStreak) describe() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case s.failures > 0:
+		return fmt.Sprintf("%d/%d failures", s.failures, s.failureThreshold)
+	case s.down:
+		return fmt.Sprintf("%d/%d successes, recovering", s.successes, s.recoveryThreshold)
+	default:
+		return fmt.Sprintf("%d successes", s.successes)
+	}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestCheckStreak_Hysteresis(t *testing.T) {
+	t.Setenv("SLACK_MCP_READINESS_FAILURE_THRESHOLD", "3")
+	t.Setenv("SLACK_MCP_READINESS_RECOVERY_THRESHOLD", "2")
+	t.Setenv("SLACK_MCP_READINESS_FAILURE_WINDOW", "1m")
+
+	now := time.Unix(1700000000, 0)
+	streak := newCheckStreak(zap.NewNop())
+	streak.now = func() time.Time { return now }
+
+	steps := []struct {
+		status CheckStatus
+		want   CheckStatus
+	}{
+		{CheckStatusError, CheckStatusDegraded},
+		{CheckStatusOK, CheckStatusOK},
+		{CheckStatusError, CheckStatusDegraded},
+		{CheckStatusError, CheckStatusDegraded},
+		{CheckStatusError, CheckStatusError},
+		{CheckStatusOK, CheckStatusError},
+		{CheckStatusDegraded, CheckStatusDegraded},
+		{CheckStatusOK, CheckStatusOK},
+	}
+	for i, step := range steps {
+		if got := streak.record(step.status); got != step.want {
+			t.Errorf("Expected step %d to report %s, got %s", i, step.want, got)
+		}
+	}
+	if got := streak.describe(); got != "3 successes" {
+		t.Errorf("Expected streak '3 successes', got %q", got)
+	}
+}
+
+func TestCheckStreak_FailuresOutsideWindow(t *testing.T) {
+	t.Setenv("SLACK_MCP_READINESS_FAILURE_THRESHOLD", "2")
+	t.Setenv("SLACK_MCP_READINESS_FAILURE_WINDOW", "30s")
+
+	now := time.Unix(1700000000, 0)
+	streak := newCheckStreak(zap.NewNop())
+	streak.now = func() time.Time { return now }
+
+	streak.record(CheckStatusError)
+	now = now.Add(time.Minute)
+	if got := streak.record(CheckStatusError); got != CheckStatusDegraded {
+		t.Errorf("Expected a failure after the window to start a new streak, got %s", got)
+	}
+	if got := streak.describe(); got != "1/2 failures" {
+		t.Errorf("Expected streak '1/2 failures', got %q", got)
+	}
+
+	now = now.Add(10 * time.Second)
+	if got := streak.record(CheckStatusError); got != CheckStatusError {
+		t.Errorf("Expected unhealthy after 2 failures within the window, got %s", got)
+	}
+	if got := streak.describe(); got != "2/2 failures" {
+		t.Errorf("Expected streak '2/2 failures', got %q", got)
+	}
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"go.uber.org/zap"
+)
+
+// IsMetricsEnabled returns true if SLACK_MCP_METRICS_ENABLED serves the cache metrics for Prometheus
+func IsMetricsEnabled() bool {
+	enabled := os.Getenv("SLACK_MCP_METRICS_ENABLED")
+	return enabled == "true" || enabled == "1"
+}
+
+// metricsPath returns the metrics route under prefix, see SLACK_MCP_HEALTH_PATH_PREFIX
+func metricsPath(prefix string) string {
+	return prefix + "/metrics"
+}
+
+// registerMetricsHandler mounts metricsHandler on the metrics route. The metrics reveal the cache and circuit
+// breaker state, so like pprof they are guarded by the same API key as the MCP endpoints unless running in a
+// private network.
+func registerMetricsHandler(mux *http.ServeMux, stats func() provider.CacheStats, logger *zap.Logger) {
+	protect := func(h http.Handler) http.Handler { return h }
+	if !isPrivateNetworkDeployment() {
+		protect = auth.HTTPMiddleware(logger)
+	}
+
+	path := metricsPath(HealthPathPrefix())
+	mux.Handle(path, protect(metricsHandler(stats, logger)))
+	logger.Info("Metrics endpoint enabled",
+		zap.String("context", "console"),
+		zap.String("endpoint", path),
+	)
+}
+
+// metricsHandler serves the cache refresh counters in the Prometheus text exposition format,
+// stats is nil when no provider is configured
+func metricsHandler(stats func() provider.CacheStats, logger *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		if stats == nil {
+			return
+		}
+		if err := writeMetrics(w, stats()); err != nil {
+			logger.Error("Failed to write metrics", zap.Error(err))
+		}
+	}
+}
+
+type metricFamily struct {
+	name, kind, help string
+	value            func(provider.RefreshStats) float64
+}
+
+var refreshMetricFamilies = []metricFamily{
+	{"slack_mcp_cache_refresh_attempts_total", "counter", "Cache refresh attempts.",
+		func(s provider.RefreshStats) float64 { return float64(s.Attempts) }},
+	{"slack_mcp_cache_refresh_successes_total", "counter", "Successful cache refreshes.",
+		func(s provider.RefreshStats) float64 { return float64(s.Successes) }},
+	{"slack_mcp_cache_refresh_failures_total", "counter", "Failed cache refreshes.",
+		func(s provider.RefreshStats) float64 { return float64(s.Failures) }},
+	{"slack_mcp_cache_refresh_consecutive_failures", "gauge", "Cache refreshes failed in a row since the last success.",
+		func(s provider.RefreshStats) float64 { return float64(s.ConsecutiveFailures) }},
+	{"slack_mcp_cache_refresh_duration_seconds_total", "counter", "Total time spent refreshing the cache.",
+		func(s provider.RefreshStats) float64 { return s.TotalDuration }},
+	{"slack_mcp_cache_refresh_last_duration_seconds", "gauge", "Duration of the last cache refresh.",
+		func(s provider.RefreshStats) float64 { return s.LastDuration }},
+	{"slack_mcp_cache_refresh_last_success_timestamp_seconds", "gauge", "Unix time of the last successful cache refresh, 0 if none succeeded.",
+		func(s provider.RefreshStats) float64 {
+			if s.LastSuccess.IsZero() {
+				return 0
+			}
+			return float64(s.LastSuccess.UnixNano()) / 1e9
+		}},
+}
+
+// writeMetrics writes the cache sizes and one sample per collection for every refresh metric family
+func writeMetrics(w io.Writer, stats provider.CacheStats) error {
+	entries := map[string]int{
+		provider.RefreshUsers:    stats.Users,
+		provider.RefreshChannels: stats.Channels,
+		provider.RefreshEmoji:    stats.Emoji,
+	}
+	if _, err := fmt.Fprint(w, "# HELP slack_mcp_cache_entries Entries in the cache.\n# TYPE slack_mcp_cache_entries gauge\n"); err != nil {
+		return err
+	}
+	for _, collection := range []string{provider.RefreshChannels, provider.RefreshEmoji, provider.RefreshUsers} {
+		if _, err := fmt.Fprintf(w, "slack_mcp_cache_entries{collection=%q} %d\n", collection, entries[collection]); err != nil {
+			return err
+		}
+	}
+
+	collections := provider.RefreshCollections(stats.Refresh)
+	if len(collections) == 0 {
+		return nil
+	}
+	for _, family := range refreshMetricFamilies {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", family.name, family.help, family.name, family.kind); err != nil {
+			return err
+		}
+		for _, collection := range collections {
+			if _, err := fmt.Fprintf(w, "%s{collection=%q} %g\n", family.name, collection, family.value(stats.Refresh[collection])); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"go.uber.org/zap"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	stats := provider.CacheStats{
+		Users:    3,
+		Channels: 5,
+		Refresh: map[string]provider.RefreshStats{
+			provider.RefreshUsers:    {Attempts: 4, Successes: 1, Failures: 3, ConsecutiveFailures: 3, LastDuration: 0.5, TotalDuration: 2, LastSuccess: time.Unix(1700000000, 0)},
+			provider.RefreshChannels: {Attempts: 1, Successes: 1, LastDuration: 1.25, TotalDuration: 1.25},
+		},
+	}
+	handler := metricsHandler(func() provider.CacheStats { return stats }, zap.NewNop())
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Expected a text/plain Content-Type, got %s", ct)
+	}
+
+	body := w.Body.String()
+	for _, line := range []string{
+		"# TYPE slack_mcp_cache_refresh_failures_total counter",
+		`slack_mcp_cache_entries{collection="users"} 3`,
+		`slack_mcp_cache_refresh_attempts_total{collection="users"} 4`,
+		`slack_mcp_cache_refresh_failures_total{collection="users"} 3`,
+		`slack_mcp_cache_refresh_consecutive_failures{collection="users"} 3`,
+		`slack_mcp_cache_refresh_last_duration_seconds{collection="channels"} 1.25`,
+		`slack_mcp_cache_refresh_last_success_timestamp_seconds{collection="users"} 1.7e+09`,
+		`slack_mcp_cache_refresh_last_success_timestamp_seconds{collection="channels"} 0`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+
+	req = httptest.NewRequest("POST", "/metrics", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405 for POST, got %d", w.Code)
+	}
+}
+
+func TestMetricsEndpointAbsentByDefault(t *testing.T) {
+	t.Setenv("SLACK_MCP_METRICS_ENABLED", "")
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	newTestEnhancedSSEServer().Handler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected /metrics to return 404 when metrics are disabled, got %d", w.Code)
+	}
+}
+
+func TestMetricsEndpointRequiresAuth(t *testing.T) {
+	t.Setenv("SLACK_MCP_METRICS_ENABLED", "true")
+	t.Setenv("SLACK_MCP_HEALTH_PATH_PREFIX", "/ops")
+	t.Setenv("SLACK_MCP_SSE_API_KEY", "secret")
+	t.Setenv("SLACK_MCP_PRIVATE_NETWORK", "false")
+	t.Setenv("RAILWAY_ENVIRONMENT", "")
+
+	handler := newTestEnhancedSSEServer().Handler()
+
+	tests := []struct {
+		name           string
+		path           string
+		authHeader     string
+		expectedStatus int
+	}{
+		{"missing token", "/ops/metrics", "", http.StatusUnauthorized},
+		{"invalid token", "/ops/metrics", "Bearer wrong", http.StatusUnauthorized},
+		{"valid token", "/ops/metrics", "Bearer secret", http.StatusOK},
+		{"outside the prefix", "/metrics", "Bearer secret", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus == http.StatusOK && w.Body.Len() != 0 {
+				t.Errorf("Expected no metrics without a provider, got %s", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"go.uber.org/zap"
+)
+
+// IsAccessLogEnabled returns true if SLACK_MCP_ACCESS_LOG turns on logging a line per HTTP request at info level
+func IsAccessLogEnabled() bool {
+	enabled := os.Getenv("SLACK_MCP_ACCESS_LOG")
+	return enabled == "true" || enabled == "1"
+}
+
+// AccessLog logs one line per request with its method, path, status, response size, duration and client IP once
+// the response is written. clientIP resolves the address of the client, nil uses the remote address of the connection.
+// It is meant to wrap the security middleware so rate limited and rejected requests are logged too.
+func AccessLog(logger *zap.Logger, clientIP func(*http.Request) string, next http.Handler) http.Handler {
+	if clientIP == nil {
+		clientIP = remoteIP
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r)
+
+		requestid.Logger(r.Context(), logger).Info("HTTP request",
+			zap.String("event_type", "access"),
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.Int("status", sw.Status()),
+			zap.Int64("bytes", sw.bytes),
+			zap.Duration("duration", time.Since(startTime)),
+			zap.String("client_ip", formatIPAddress(clientIP(r))),
+		)
+	})
+}
+
+// remoteIP returns the address of the connection without its port
+func remoteIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// statusWriter records the status code and the number of body bytes written through it
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	// informational responses are followed by the final status
+	if sw.status == 0 && status >= http.StatusOK {
+		sw.status = status
+	}
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusWriter) Write(p []byte) (int, error) {
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+	n, err := sw.ResponseWriter.Write(p)
+	sw.bytes += int64(n)
+	return n, err
+}
+
+// Flush keeps the SSE stream flowing through the recorder
+func (sw *statusWriter) Flush() {
+	if f, ok := sw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (sw *statusWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
+
+// Status returns the status code sent to the client, 200 when the handler wrote nothing
+func (sw *statusWriter) Status() int {
+	if sw.status == 0 {
+		return http.StatusOK
+	}
+	return sw.status
+}
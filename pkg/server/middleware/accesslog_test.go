@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func serveAccessLogged(t *testing.T, clientIP func(*http.Request) string, handler http.Handler) (*httptest.ResponseRecorder, observer.LoggedEntry) {
+	t.Helper()
+	core, logs := observer.New(zap.InfoLevel)
+	req := httptest.NewRequest("POST", "/message?sessionId=abc", nil)
+	req.RemoteAddr = "192.0.2.10:54321"
+	w := httptest.NewRecorder()
+	AccessLog(zap.New(core), clientIP, handler).ServeHTTP(w, req)
+
+	entries := logs.FilterMessage("HTTP request").All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected one access log line, got %d", len(entries))
+	}
+	return w, entries[0]
+}
+
+func TestAccessLog_CapturesStatusAndBytes(t *testing.T) {
+	w, entry := serveAccessLogged(t, nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello "))
+		w.Write([]byte("world"))
+	}))
+
+	fields := entry.ContextMap()
+	if fields["status"] != int64(w.Code) || w.Code != http.StatusCreated {
+		t.Errorf("Expected logged status to match the handler's 201, got %v (response %d)", fields["status"], w.Code)
+	}
+	if fields["bytes"] != int64(11) {
+		t.Errorf("Expected 11 bytes, got %v", fields["bytes"])
+	}
+	if fields["method"] != "POST" || fields["path"] != "/message" {
+		t.Errorf("Expected POST /message, got %v %v", fields["method"], fields["path"])
+	}
+	if fields["client_ip"] != "192.0.2.10" {
+		t.Errorf("Expected client IP 192.0.2.10, got %v", fields["client_ip"])
+	}
+	if _, ok := fields["duration"].(time.Duration); !ok {
+		t.Errorf("Expected a duration field, got %T", fields["duration"])
+	}
+}
+
+func TestAccessLog_ImplicitOK(t *testing.T) {
+	_, entry := serveAccessLogged(t, nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	if status := entry.ContextMap()["status"]; status != int64(http.StatusOK) {
+		t.Errorf("Expected status 200 when the handler writes nothing, got %v", status)
+	}
+}
+
+func TestAccessLog_RecordsRateLimitedRequests(t *testing.T) {
+	t.Setenv("SLACK_MCP_RATE_LIMIT", "1h")
+	t.Setenv("SLACK_MCP_TRUSTED_PROXIES", "")
+	sm := NewSecurityMiddleware(zap.NewNop())
+	handler := sm.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// the first request spends the burst, the second one is rejected by the security middleware
+	serveAccessLogged(t, sm.ClientIP, handler)
+	w, entry := serveAccessLogged(t, sm.ClientIP, handler)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected the second request to be rate limited, got %d", w.Code)
+	}
+	if status := entry.ContextMap()["status"]; status != int64(http.StatusTooManyRequests) {
+		t.Errorf("Expected logged status 429, got %v", status)
+	}
+	if bytes := entry.ContextMap()["bytes"]; bytes != int64(w.Body.Len()) {
+		t.Errorf("Expected %d bytes, got %v", w.Body.Len(), bytes)
+	}
+}
+
+func TestAccessLog_Flush(t *testing.T) {
+	w := httptest.NewRecorder()
+	AccessLog(zap.NewNop(), nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data: ping\n\n"))
+		w.(http.Flusher).Flush()
+	})).ServeHTTP(w, httptest.NewRequest("GET", "/sse", nil))
+
+	if !w.Flushed {
+		t.Error("Expected Flush to reach the underlying writer")
+	}
+}
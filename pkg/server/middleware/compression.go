@@ -0,0 +1,212 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// compressionMinBytes is the smallest response body worth compressing, event streams are always compressed
+const compressionMinBytes = 1024
+
+// IsCompressionEnabled returns true if SLACK_MCP_COMPRESSION turns on gzip/deflate response compression
+func IsCompressionEnabled() bool {
+	enabled := os.Getenv("SLACK_MCP_COMPRESSION")
+	return enabled == "true" || enabled == "1"
+}
+
+// Compression compresses responses with gzip, or deflate for clients that only accept it.
+// Bodies below compressionMinBytes, already encoded bodies and compressed media types are sent
+// as they are. Event streams are compressed as they are written and every Flush reaches the client.
+func Compression(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.Close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header, gzip wins ties
+func negotiateEncoding(acceptEncoding string) string {
+	q := map[string]float64{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(params[0]))
+		if coding == "" {
+			continue
+		}
+		weight := 1.0
+		for _, param := range params[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if f, err := strconv.ParseFloat(value, 64); err == nil {
+					weight = f
+				}
+			}
+		}
+		q[coding] = weight
+	}
+
+	weight := func(coding string) float64 {
+		if w, ok := q[coding]; ok {
+			return w
+		}
+		if w, ok := q["*"]; ok {
+			return w
+		}
+		return 0
+	}
+	gz, deflate := weight("gzip"), weight("deflate")
+	switch {
+	case gz > 0 && gz >= deflate:
+		return "gzip"
+	case deflate > 0:
+		return "deflate"
+	}
+	return ""
+}
+
+// compressWriter holds back the status and the first compressionMinBytes of the body until it
+// knows whether compressing is worth it
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+
+	status  int
+	buf     []byte
+	decided bool
+	zw      io.WriteCloser
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.decided {
+		cw.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if cw.status != 0 {
+		return
+	}
+	cw.status = status
+	// bodyless responses have nothing to compress
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		cw.decide(false)
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if cw.decided {
+		if cw.zw != nil {
+			return cw.zw.Write(p)
+		}
+		return cw.ResponseWriter.Write(p)
+	}
+
+	cw.buf = append(cw.buf, p...)
+	if len(cw.buf) >= compressionMinBytes {
+		if err := cw.decide(cw.compressible()); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends everything written so far, compressing event streams regardless of their size
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		cw.decide(cw.compressible() && (cw.isEventStream() || len(cw.buf) >= compressionMinBytes))
+	}
+	if gz, ok := cw.zw.(interface{ Flush() error }); ok {
+		gz.Flush()
+	}
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Close writes a held back body and finishes the compressed stream
+func (cw *compressWriter) Close() error {
+	if !cw.decided {
+		if err := cw.decide(cw.compressible() && len(cw.buf) >= compressionMinBytes); err != nil {
+			return err
+		}
+	}
+	if cw.zw != nil {
+		return cw.zw.Close()
+	}
+	return nil
+}
+
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+func (cw *compressWriter) decide(compress bool) error {
+	cw.decided = true
+
+	header := cw.ResponseWriter.Header()
+	if compress {
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", cw.encoding)
+		if cw.encoding == "gzip" {
+			cw.zw = gzip.NewWriter(cw.ResponseWriter)
+		} else {
+			cw.zw = zlib.NewWriter(cw.ResponseWriter)
+		}
+	}
+	if cw.status != 0 {
+		cw.ResponseWriter.WriteHeader(cw.status)
+	}
+
+	buf := cw.buf
+	cw.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if cw.zw != nil {
+		_, err := cw.zw.Write(buf)
+		return err
+	}
+	_, err := cw.ResponseWriter.Write(buf)
+	return err
+}
+
+// compressible is false for bodies that are already encoded or compressed media
+func (cw *compressWriter) compressible() bool {
+	header := cw.ResponseWriter.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	if contentType == "" {
+		contentType = http.DetectContentType(cw.buf)
+	}
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	switch {
+	case mediaType == "image/svg+xml":
+		return true
+	case strings.HasPrefix(mediaType, "image/"), strings.HasPrefix(mediaType, "video/"), strings.HasPrefix(mediaType, "audio/"):
+		return false
+	}
+	switch mediaType {
+	case "application/zip", "application/gzip", "application/x-gzip", "application/zstd", "application/x-bzip2", "application/x-7z-compressed", "application/pdf":
+		return false
+	}
+	return true
+}
+
+func (cw *compressWriter) isEventStream() bool {
+	return strings.HasPrefix(strings.ToLower(cw.ResponseWriter.Header().Get("Content-Type")), "text/event-stream")
+}
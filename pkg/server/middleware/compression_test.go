@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func serveCompressed(t *testing.T, acceptEncoding string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest("GET", "/", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	w := httptest.NewRecorder()
+	Compression(handler).ServeHTTP(w, req)
+	return w
+}
+
+func TestCompression_Gzip(t *testing.T) {
+	body := strings.Repeat(`{"channel":"C1234567890","name":"#general"},`, 100)
+	w := serveCompressed(t, "deflate, gzip", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", "4400")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(body[:10]))
+		w.Write([]byte(body[10:]))
+	})
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d", w.Code)
+	}
+	if ce := w.Header().Get("Content-Encoding"); ce != "gzip" {
+		t.Fatalf("Expected Content-Encoding gzip, got %q", ce)
+	}
+	if w.Header().Get("Content-Length") != "" {
+		t.Error("Expected Content-Length to be dropped for a compressed body")
+	}
+	if vary := w.Header().Get("Vary"); vary != "Accept-Encoding" {
+		t.Errorf("Expected Vary: Accept-Encoding, got %q", vary)
+	}
+
+	zr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("Failed to open gzip body: %v", err)
+	}
+	decoded, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("Failed to decompress body: %v", err)
+	}
+	if string(decoded) != body {
+		t.Errorf("Expected the decompressed body to match, got %d bytes", len(decoded))
+	}
+}
+
+func TestCompression_DeflateFallback(t *testing.T) {
+	body := strings.Repeat("health ok ", 200)
+	w := serveCompressed(t, "gzip;q=0, deflate", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(body))
+	})
+
+	if ce := w.Header().Get("Content-Encoding"); ce != "deflate" {
+		t.Fatalf("Expected Content-Encoding deflate, got %q", ce)
+	}
+	zr, err := zlib.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("Failed to open deflate body: %v", err)
+	}
+	decoded, _ := io.ReadAll(zr)
+	if string(decoded) != body {
+		t.Errorf("Expected the decompressed body to match, got %d bytes", len(decoded))
+	}
+}
+
+func TestCompression_SkipsUncompressibleResponses(t *testing.T) {
+	large := strings.Repeat("x", 2*compressionMinBytes)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		encoding       string
+		body           string
+	}{
+		{"tiny payload", "gzip", "application/json", "", `{"status":"healthy"}`},
+		{"no Accept-Encoding", "", "application/json", "", large},
+		{"unsupported encoding", "br", "application/json", "", large},
+		{"already encoded", "gzip", "application/json", "br", large},
+		{"compressed media", "gzip", "image/png", "", large},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveCompressed(t, tt.acceptEncoding, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				w.Write([]byte(tt.body))
+			})
+
+			if ce := w.Header().Get("Content-Encoding"); ce != tt.encoding {
+				t.Errorf("Expected Content-Encoding %q, got %q", tt.encoding, ce)
+			}
+			if w.Body.String() != tt.body {
+				t.Errorf("Expected the body to pass through unchanged, got %d bytes", w.Body.Len())
+			}
+		})
+	}
+}
+
+func TestCompression_EventStream(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(Compression(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+
+		w.Write([]byte("event: endpoint\ndata: /message?sessionId=1\n\n"))
+		w.(http.Flusher).Flush()
+		<-release
+	})))
+	defer ts.Close()
+	defer close(release)
+
+	req, _ := http.NewRequest("GET", ts.URL, nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ce := resp.Header.Get("Content-Encoding"); ce != "gzip" {
+		t.Fatalf("Expected the event stream to be gzip encoded, got %q", ce)
+	}
+
+	// the handler is still blocked, so the event must arrive through Flush and not at the end of the response
+	lines := make(chan string, 1)
+	go func() {
+		zr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			lines <- "error: " + err.Error()
+			return
+		}
+		line, _ := bufio.NewReader(zr).ReadString('\n')
+		lines <- line
+	}()
+
+	select {
+	case line := <-lines:
+		if line != "event: endpoint\n" {
+			t.Errorf("Expected the first event line, got %q", line)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the flushed event to reach the client while the stream is open")
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := map[string]string{
+		"":                      "",
+		"gzip":                  "gzip",
+		"deflate":               "deflate",
+		"deflate, gzip":         "gzip",
+		"gzip;q=0.5, deflate":   "deflate",
+		"gzip;q=0, deflate;q=0": "",
+		"*":                     "gzip",
+		"br, *;q=0.1":           "gzip",
+		"identity":              "",
+		" GZIP ; q=0.8 , br":    "gzip",
+	}
+
+	for accept, expected := range tests {
+		if got := negotiateEncoding(accept); got != expected {
+			t.Errorf("negotiateEncoding(%q) = %q, expected %q", accept, got, expected)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"fmt"
+	"math"
 	"net"
 	"net/http"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 )
@@ -19,6 +21,8 @@ type SecurityConfig struct {
 	CORSOrigins           []string
 	EnableSecurityHeaders bool
 	RateLimit             time.Duration
+	RateLimitBurst        int
+	TrustedProxies        []*net.IPNet
 	Logger                *zap.Logger
 }
 
@@ -35,9 +39,17 @@ func NewSecurityMiddleware(logger *zap.Logger) *SecurityMiddleware {
 		CORSOrigins:           parseCORSOrigins(),
 		EnableSecurityHeaders: parseSecurityHeaders(),
 		RateLimit:             parseRateLimit(),
+		RateLimitBurst:        parseRateLimitBurst(),
+		TrustedProxies:        parseTrustedProxies(logger),
 		Logger:                logger,
 	}
 
+	if len(config.TrustedProxies) == 0 {
+		logger.Warn("No trusted proxies configured, X-Forwarded-For and X-Real-IP headers are trusted from any client. Set SLACK_MCP_TRUSTED_PROXIES to restrict them",
+			zap.String("context", "console"),
+		)
+	}
+
 	return &SecurityMiddleware{
 		config:       config,
 		rateLimiters: make(map[string]*rate.Limiter),
@@ -48,10 +60,11 @@ func NewSecurityMiddleware(logger *zap.Logger) *SecurityMiddleware {
 func (sm *SecurityMiddleware) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		startTime := time.Now()
-		clientIP := formatIPAddress(getClientIP(r))
+		clientIP := formatIPAddress(getClientIP(r, sm.config.TrustedProxies))
+		logger := requestid.Logger(r.Context(), sm.config.Logger)
 
 		// Log incoming request with IPv6-formatted address
-		sm.config.Logger.Debug("Security middleware processing request",
+		logger.Debug("Security middleware processing request",
 			zap.String("event_type", "request_start"),
 			zap.String("client_ip", clientIP),
 			zap.String("method", r.Method),
@@ -72,7 +85,7 @@ func (sm *SecurityMiddleware) Handler(next http.Handler) http.Handler {
 		if sm.config.EnableSecurityHeaders {
 			sm.applySecurityHeaders(w)
 
-			sm.config.Logger.Debug("Security headers applied",
+			logger.Debug("Security headers applied",
 				zap.String("event_type", "security_headers_applied"),
 				zap.String("client_ip", clientIP),
 			)
@@ -80,7 +93,7 @@ func (sm *SecurityMiddleware) Handler(next http.Handler) http.Handler {
 
 		// Handle preflight requests
 		if r.Method == http.MethodOptions {
-			sm.config.Logger.Debug("CORS preflight request handled",
+			logger.Debug("CORS preflight request handled",
 				zap.String("event_type", "cors_preflight"),
 				zap.String("client_ip", clientIP),
 				zap.String("origin", r.Header.Get("Origin")),
@@ -94,7 +107,7 @@ func (sm *SecurityMiddleware) Handler(next http.Handler) http.Handler {
 
 		// Log request completion
 		duration := time.Since(startTime)
-		sm.config.Logger.Debug("Security middleware request completed",
+		logger.Debug("Security middleware request completed",
 			zap.String("event_type", "request_completed"),
 			zap.String("client_ip", clientIP),
 			zap.String("method", r.Method),
@@ -104,38 +117,49 @@ func (sm *SecurityMiddleware) Handler(next http.Handler) http.Handler {
 	})
 }
 
+// ClientIP returns the address of the client of r, honoring proxy headers from trusted proxies only
+func (sm *SecurityMiddleware) ClientIP(r *http.Request) string {
+	return getClientIP(r, sm.config.TrustedProxies)
+}
+
 // checkRateLimit checks if the request should be rate limited
 func (sm *SecurityMiddleware) checkRateLimit(r *http.Request, w http.ResponseWriter) bool {
-	if sm.config.RateLimit == 0 {
+	_, rateLimit := sm.Policy()
+	if rateLimit == 0 {
 		return true // Rate limiting disabled
 	}
 
-	clientIP := getClientIP(r)
+	clientIP := getClientIP(r, sm.config.TrustedProxies)
+	logger := requestid.Logger(r.Context(), sm.config.Logger)
 	formattedIP := formatIPAddress(clientIP)
 	limiter := sm.getRateLimiter(clientIP)
 
-	if !limiter.Allow() {
+	allowed := limiter.Allow()
+	setRateLimitHeaders(w, limiter, time.Now())
+
+	if !allowed {
 		// Structured logging for rate limiting events
-		sm.config.Logger.Warn("Rate limit exceeded",
+		logger.Warn("Rate limit exceeded",
 			zap.String("event_type", "rate_limit_exceeded"),
 			zap.String("client_ip", formattedIP),
 			zap.String("client_ip_raw", clientIP),
 			zap.String("path", r.URL.Path),
 			zap.String("method", r.Method),
 			zap.String("user_agent", r.Header.Get("User-Agent")),
-			zap.Float64("rate_limit_rpm", 60.0/sm.config.RateLimit.Minutes()),
+			zap.Float64("rate_limit_rpm", 60.0/rateLimit.Minutes()),
+			zap.Int("rate_limit_burst", limiter.Burst()),
 			zap.String("x_forwarded_for", r.Header.Get("X-Forwarded-For")),
 			zap.String("x_real_ip", r.Header.Get("X-Real-IP")),
 		)
 
 		sm.writeErrorResponse(w, r, http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED",
 			"Too many requests from this client",
-			fmt.Sprintf("Rate limit of %.0f requests per minute exceeded", 60.0/sm.config.RateLimit.Minutes()))
+			fmt.Sprintf("Rate limit of %.0f requests per minute with bursts of %d exceeded", 60.0/rateLimit.Minutes(), limiter.Burst()))
 		return false
 	}
 
 	// Log successful rate limit check for debugging (at debug level)
-	sm.config.Logger.Debug("Rate limit check passed",
+	logger.Debug("Rate limit check passed",
 		zap.String("event_type", "rate_limit_check"),
 		zap.String("client_ip", formattedIP),
 		zap.String("path", r.URL.Path),
@@ -145,6 +169,45 @@ func (sm *SecurityMiddleware) checkRateLimit(r *http.Request, w http.ResponseWri
 	return true
 }
 
+// setRateLimitHeaders reports the state of a client's token bucket: the burst size as limit,
+// the whole tokens left and the seconds until the bucket is full again
+func setRateLimitHeaders(w http.ResponseWriter, limiter *rate.Limiter, now time.Time) {
+	burst := limiter.Burst()
+	tokens := limiter.TokensAt(now)
+
+	remaining := int(math.Floor(tokens))
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	reset := 0
+	if missing := float64(burst) - tokens; missing > 0 && limiter.Limit() > 0 {
+		reset = int(math.Ceil(missing / float64(limiter.Limit())))
+	}
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(burst))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.Itoa(reset))
+}
+
+// Policy returns the CORS origins and the rate limit interval, UpdatePolicy may replace them at any time
+func (sm *SecurityMiddleware) Policy() ([]string, time.Duration) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.config.CORSOrigins, sm.config.RateLimit
+}
+
+// UpdatePolicy replaces the CORS origins, the rate limit interval and the burst of a running middleware,
+// clients start over with a full bucket at the new rate
+func (sm *SecurityMiddleware) UpdatePolicy(corsOrigins []string, rateLimit time.Duration, rateLimitBurst int) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.config.CORSOrigins = corsOrigins
+	sm.config.RateLimit = rateLimit
+	sm.config.RateLimitBurst = rateLimitBurst
+	sm.rateLimiters = make(map[string]*rate.Limiter)
+}
+
 // getRateLimiter gets or creates a rate limiter for the given IP
 func (sm *SecurityMiddleware) getRateLimiter(ip string) *rate.Limiter {
 	sm.mu.RLock()
@@ -157,7 +220,11 @@ func (sm *SecurityMiddleware) getRateLimiter(ip string) *rate.Limiter {
 		if limiter, exists = sm.rateLimiters[ip]; !exists {
 			// Create new rate limiter: requests per minute converted to requests per second
 			rps := 1.0 / sm.config.RateLimit.Seconds()
-			limiter = rate.NewLimiter(rate.Limit(rps), 1) // Burst of 1
+			burst := sm.config.RateLimitBurst
+			if burst < 1 {
+				burst = 1
+			}
+			limiter = rate.NewLimiter(rate.Limit(rps), burst)
 			sm.rateLimiters[ip] = limiter
 		}
 		sm.mu.Unlock()
@@ -169,14 +236,16 @@ func (sm *SecurityMiddleware) getRateLimiter(ip string) *rate.Limiter {
 // applyCORS applies CORS headers to the response
 func (sm *SecurityMiddleware) applyCORS(w http.ResponseWriter, r *http.Request) {
 	origin := r.Header.Get("Origin")
-	clientIP := formatIPAddress(getClientIP(r))
+	clientIP := formatIPAddress(getClientIP(r, sm.config.TrustedProxies))
+	logger := requestid.Logger(r.Context(), sm.config.Logger)
+	corsOrigins, _ := sm.Policy()
 
 	// If no origins configured, allow all origins for private network deployment
-	if len(sm.config.CORSOrigins) == 0 {
+	if len(corsOrigins) == 0 {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 
 		// Log CORS policy application
-		sm.config.Logger.Debug("CORS policy applied - allow all origins",
+		logger.Debug("CORS policy applied - allow all origins",
 			zap.String("event_type", "cors_applied"),
 			zap.String("client_ip", clientIP),
 			zap.String("origin", origin),
@@ -185,7 +254,7 @@ func (sm *SecurityMiddleware) applyCORS(w http.ResponseWriter, r *http.Request)
 	} else {
 		// Check if origin is in allowed list
 		allowed := false
-		for _, allowedOrigin := range sm.config.CORSOrigins {
+		for _, allowedOrigin := range corsOrigins {
 			if allowedOrigin == "*" || allowedOrigin == origin {
 				w.Header().Set("Access-Control-Allow-Origin", origin)
 				allowed = true
@@ -195,20 +264,20 @@ func (sm *SecurityMiddleware) applyCORS(w http.ResponseWriter, r *http.Request)
 
 		// Log CORS policy application with structured data
 		if allowed {
-			sm.config.Logger.Debug("CORS policy applied - origin allowed",
+			logger.Debug("CORS policy applied - origin allowed",
 				zap.String("event_type", "cors_applied"),
 				zap.String("client_ip", clientIP),
 				zap.String("origin", origin),
 				zap.String("policy", "origin_allowed"),
-				zap.Strings("allowed_origins", sm.config.CORSOrigins),
+				zap.Strings("allowed_origins", corsOrigins),
 			)
 		} else if origin != "" {
-			sm.config.Logger.Info("CORS policy blocked origin",
+			logger.Info("CORS policy blocked origin",
 				zap.String("event_type", "cors_blocked"),
 				zap.String("client_ip", clientIP),
 				zap.String("origin", origin),
 				zap.String("policy", "origin_blocked"),
-				zap.Strings("allowed_origins", sm.config.CORSOrigins),
+				zap.Strings("allowed_origins", corsOrigins),
 			)
 		}
 	}
@@ -216,6 +285,7 @@ func (sm *SecurityMiddleware) applyCORS(w http.ResponseWriter, r *http.Request)
 	// Set other CORS headers
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
+	w.Header().Set("Access-Control-Expose-Headers", requestid.Header+", X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset")
 	w.Header().Set("Access-Control-Allow-Credentials", "true")
 	w.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
 }
@@ -275,13 +345,40 @@ func formatIPAddress(ip string) string {
 	return ip
 }
 
-// getClientIP extracts the client IP address from the request
-func getClientIP(r *http.Request) string {
+// getClientIP extracts the client IP address from the request. Proxy headers are honored
+// only when the request comes from a trusted proxy, an empty list trusts any source.
+func getClientIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	// Fall back to RemoteAddr
+	ip := r.RemoteAddr
+	if strings.Contains(ip, ":") {
+		// Remove port if present
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	if len(trustedProxies) > 0 && !isTrustedProxy(ip, trustedProxies) {
+		return ip
+	}
+
 	// Check X-Forwarded-For header first (for proxies/load balancers)
 	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		// Take the first IP in the chain
 		ips := strings.Split(xff, ",")
-		return strings.TrimSpace(ips[0])
+		if len(trustedProxies) == 0 {
+			// Take the first IP in the chain
+			return strings.TrimSpace(ips[0])
+		}
+
+		// Walk the chain from the right and take the first hop not added by a trusted proxy
+		for i := len(ips) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(ips[i])
+			if hop == "" {
+				continue
+			}
+			if i == 0 || !isTrustedProxy(hop, trustedProxies) {
+				return hop
+			}
+		}
 	}
 
 	// Check X-Real-IP header
@@ -289,21 +386,32 @@ func getClientIP(r *http.Request) string {
 		return xri
 	}
 
-	// Fall back to RemoteAddr
-	ip := r.RemoteAddr
-	if strings.Contains(ip, ":") {
-		// Remove port if present
-		if host, _, err := net.SplitHostPort(ip); err == nil {
-			ip = host
+	return ip
+}
+
+// isTrustedProxy checks if the IP address belongs to one of the trusted proxy ranges
+func isTrustedProxy(ip string, trustedProxies []*net.IPNet) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+
+	for _, network := range trustedProxies {
+		if network.Contains(parsedIP) {
+			return true
 		}
 	}
 
-	return ip
+	return false
 }
 
 // parseCORSOrigins parses CORS origins from environment variable
 func parseCORSOrigins() []string {
-	corsOrigins := os.Getenv("SLACK_MCP_CORS_ORIGINS")
+	return SplitCORSOrigins(os.Getenv("SLACK_MCP_CORS_ORIGINS"))
+}
+
+// SplitCORSOrigins splits a SLACK_MCP_CORS_ORIGINS value, an empty list allows all origins
+func SplitCORSOrigins(corsOrigins string) []string {
 	if corsOrigins == "" {
 		return []string{} // Empty means allow all origins
 	}
@@ -336,27 +444,134 @@ func parseSecurityHeaders() bool {
 
 // parseRateLimit parses rate limit configuration from environment
 func parseRateLimit() time.Duration {
-	value := os.Getenv("SLACK_MCP_RATE_LIMIT")
+	interval, err := RateLimitInterval(os.Getenv("SLACK_MCP_RATE_LIMIT"))
+	if err != nil {
+		return time.Minute // Default on parse error
+	}
+	return interval
+}
+
+// RateLimitInterval converts a SLACK_MCP_RATE_LIMIT value in requests per minute to the interval
+// between requests, empty defaults to 1 request per minute and 0 disables rate limiting
+func RateLimitInterval(value string) (time.Duration, error) {
 	if value == "" {
-		return time.Minute // Default: 1 request per minute (60 requests per hour)
+		return time.Minute, nil // Default: 1 request per minute (60 requests per hour)
 	}
 
 	// Parse as requests per minute
 	requestsPerMinute, err := strconv.Atoi(value)
-	if err != nil {
-		return time.Minute // Default on parse error
+	if err != nil || requestsPerMinute < 0 {
+		return 0, fmt.Errorf("invalid SLACK_MCP_RATE_LIMIT value '%s': must be a non-negative integer", value)
 	}
 
 	// Handle special case: 0 means no rate limiting
 	if requestsPerMinute == 0 {
-		return 0 // Disabled
+		return 0, nil // Disabled
 	}
 
-	// Handle negative values
-	if requestsPerMinute < 0 {
-		return time.Minute // Default on invalid value
+	// Convert to duration between requests
+	return time.Minute / time.Duration(requestsPerMinute), nil
+}
+
+// parseRateLimitBurst parses the rate limit burst from environment
+func parseRateLimitBurst() int {
+	burst, err := RateLimitBurst(os.Getenv("SLACK_MCP_RATE_LIMIT_BURST"))
+	if err != nil {
+		return 1 // Default on parse error
 	}
+	return burst
+}
 
-	// Convert to duration between requests
-	return time.Minute / time.Duration(requestsPerMinute)
+// RateLimitBurst parses a SLACK_MCP_RATE_LIMIT_BURST value, the number of requests a client may send
+// back to back before SLACK_MCP_RATE_LIMIT spaces them out, empty defaults to 1
+func RateLimitBurst(value string) (int, error) {
+	if value == "" {
+		return 1, nil
+	}
+
+	burst, err := strconv.Atoi(value)
+	if err != nil || burst < 1 {
+		return 0, fmt.Errorf("invalid SLACK_MCP_RATE_LIMIT_BURST value '%s': must be a positive integer", value)
+	}
+	return burst, nil
+}
+
+// noTrustedProxies trusts no source: an empty network contains no address, so forwarded headers are never honored
+var noTrustedProxies = []*net.IPNet{{}}
+
+// TrustedProxies parses a SLACK_MCP_TRUSTED_PROXIES value, comma-separated CIDRs or single IP addresses,
+// and fails on the first invalid entry
+func TrustedProxies(value string) ([]*net.IPNet, error) {
+	var result []*net.IPNet
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		network, err := parseTrustedProxy(item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, network)
+	}
+	return result, nil
+}
+
+// parseTrustedProxy parses a trusted proxy CIDR or single IP address
+func parseTrustedProxy(item string) (*net.IPNet, error) {
+	if !strings.Contains(item, "/") {
+		ip := net.ParseIP(item)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid SLACK_MCP_TRUSTED_PROXIES entry '%s': must be an IP address or CIDR", item)
+		}
+		bits := 128
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 32
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, network, err := net.ParseCIDR(item)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SLACK_MCP_TRUSTED_PROXIES entry '%s': %w", item, err)
+	}
+	return network, nil
+}
+
+// parseTrustedProxies parses trusted proxy CIDRs or single IP addresses from environment. Invalid entries
+// are skipped, and when none is valid no proxy is trusted rather than every client
+func parseTrustedProxies(logger *zap.Logger) []*net.IPNet {
+	value := os.Getenv("SLACK_MCP_TRUSTED_PROXIES")
+	if value == "" {
+		return nil
+	}
+
+	var result []*net.IPNet
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		network, err := parseTrustedProxy(item)
+		if err != nil {
+			logger.Warn("Invalid trusted proxy ignored",
+				zap.String("context", "console"),
+				zap.String("proxy", item),
+				zap.Error(err),
+			)
+			continue
+		}
+		result = append(result, network)
+	}
+
+	if len(result) == 0 {
+		logger.Warn("No valid trusted proxy in SLACK_MCP_TRUSTED_PROXIES, X-Forwarded-For and X-Real-IP headers are not trusted",
+			zap.String("context", "console"),
+		)
+		return noTrustedProxies
+	}
+	return result
 }
@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -170,7 +172,7 @@ func TestGetClientIP(t *testing.T) {
 				req.Header.Set("X-Real-IP", tt.xRealIP)
 			}
 
-			ip := getClientIP(req)
+			ip := getClientIP(req, nil)
 			if ip != tt.expectedIP {
 				t.Errorf("Expected IP %s, got %s", tt.expectedIP, ip)
 			}
@@ -488,7 +490,7 @@ func TestGetClientIP_XForwardedForMultiple(t *testing.T) {
 	req.RemoteAddr = "192.168.1.1:12345"
 	req.Header.Set("X-Forwarded-For", "203.0.113.1, 203.0.113.2, 192.168.1.1")
 
-	ip := getClientIP(req)
+	ip := getClientIP(req, nil)
 	expected := "203.0.113.1" // Should take the first IP
 
 	if ip != expected {
@@ -501,7 +503,7 @@ func TestGetClientIP_XForwardedForWithSpaces(t *testing.T) {
 	req.RemoteAddr = "192.168.1.1:12345"
 	req.Header.Set("X-Forwarded-For", "  203.0.113.1  , 192.168.1.1")
 
-	ip := getClientIP(req)
+	ip := getClientIP(req, nil)
 	expected := "203.0.113.1" // Should trim spaces
 
 	if ip != expected {
@@ -516,7 +518,7 @@ func TestGetClientIP_Precedence(t *testing.T) {
 	req.Header.Set("X-Forwarded-For", "203.0.113.1")
 	req.Header.Set("X-Real-IP", "203.0.113.2")
 
-	ip := getClientIP(req)
+	ip := getClientIP(req, nil)
 	expected := "203.0.113.1" // X-Forwarded-For should take precedence
 
 	if ip != expected {
@@ -524,6 +526,155 @@ func TestGetClientIP_Precedence(t *testing.T) {
 	}
 }
 
+func TestGetClientIP_TrustedProxies(t *testing.T) {
+	_, proxyNet, _ := net.ParseCIDR("10.0.0.0/8")
+	trusted := []*net.IPNet{proxyNet}
+
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		xForwardedFor string
+		xRealIP       string
+		expectedIP    string
+	}{
+		{
+			name:          "spoofed X-Forwarded-For from untrusted client",
+			remoteAddr:    "198.51.100.7:12345",
+			xForwardedFor: "203.0.113.1",
+			expectedIP:    "198.51.100.7",
+		},
+		{
+			name:       "spoofed X-Real-IP from untrusted client",
+			remoteAddr: "198.51.100.7:12345",
+			xRealIP:    "203.0.113.2",
+			expectedIP: "198.51.100.7",
+		},
+		{
+			name:          "X-Forwarded-For from trusted proxy",
+			remoteAddr:    "10.1.2.3:12345",
+			xForwardedFor: "203.0.113.1",
+			expectedIP:    "203.0.113.1",
+		},
+		{
+			name:          "spoofed hop prepended before trusted proxy chain",
+			remoteAddr:    "10.1.2.3:12345",
+			xForwardedFor: "1.2.3.4, 203.0.113.1, 10.0.0.5",
+			expectedIP:    "203.0.113.1",
+		},
+		{
+			name:       "X-Real-IP from trusted proxy",
+			remoteAddr: "10.1.2.3:12345",
+			xRealIP:    "203.0.113.2",
+			expectedIP: "203.0.113.2",
+		},
+		{
+			name:       "trusted proxy without headers",
+			remoteAddr: "10.1.2.3:12345",
+			expectedIP: "10.1.2.3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/test", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.xForwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.xForwardedFor)
+			}
+			if tt.xRealIP != "" {
+				req.Header.Set("X-Real-IP", tt.xRealIP)
+			}
+
+			ip := getClientIP(req, trusted)
+			if ip != tt.expectedIP {
+				t.Errorf("Expected IP %s, got %s", tt.expectedIP, ip)
+			}
+		})
+	}
+}
+
+func TestSecurityMiddleware_RateLimitSpoofedIP(t *testing.T) {
+	logger := zap.NewNop()
+	_, proxyNet, _ := net.ParseCIDR("10.0.0.0/8")
+	middleware := &SecurityMiddleware{
+		config: SecurityConfig{
+			RateLimit:      time.Minute,
+			TrustedProxies: []*net.IPNet{proxyNet},
+			Logger:         logger,
+		},
+		rateLimiters: make(map[string]*rate.Limiter),
+	}
+
+	handler := middleware.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// An untrusted client rotating X-Forwarded-For must still be limited by its RemoteAddr
+	for i, spoofed := range []string{"203.0.113.1", "203.0.113.2"} {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.RemoteAddr = "198.51.100.7:12345"
+		req.Header.Set("X-Forwarded-For", spoofed)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		expected := http.StatusOK
+		if i > 0 {
+			expected = http.StatusTooManyRequests
+		}
+		if w.Code != expected {
+			t.Errorf("Request %d: expected status %d, got %d", i+1, expected, w.Code)
+		}
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	os.Setenv("SLACK_MCP_TRUSTED_PROXIES", "10.0.0.0/8, 192.168.1.1, 2001:db8::/32, invalid")
+	defer os.Unsetenv("SLACK_MCP_TRUSTED_PROXIES")
+
+	proxies := parseTrustedProxies(zap.NewNop())
+	if len(proxies) != 3 {
+		t.Fatalf("Expected 3 trusted proxies, got %d", len(proxies))
+	}
+
+	for ip, expected := range map[string]bool{
+		"10.20.30.40": true,
+		"192.168.1.1": true,
+		"192.168.1.2": false,
+		"2001:db8::1": true,
+		"2001:db9::1": false,
+	} {
+		if got := isTrustedProxy(ip, proxies); got != expected {
+			t.Errorf("isTrustedProxy(%s): expected %v, got %v", ip, expected, got)
+		}
+	}
+}
+
+func TestParseTrustedProxiesAllInvalid(t *testing.T) {
+	t.Setenv("SLACK_MCP_TRUSTED_PROXIES", "proxy.internal, 10.0.0.0/33")
+
+	proxies := parseTrustedProxies(zap.NewNop())
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "203.0.113.7:4321"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	req.Header.Set("X-Real-IP", "198.51.100.2")
+	if got := getClientIP(req, proxies); got != "203.0.113.7" {
+		t.Errorf("Expected forwarded headers to be ignored, got client IP %s", got)
+	}
+}
+
+func TestTrustedProxies(t *testing.T) {
+	proxies, err := TrustedProxies("10.0.0.0/8, 192.168.1.1")
+	if err != nil || len(proxies) != 2 {
+		t.Fatalf("Expected 2 trusted proxies, got %d (%v)", len(proxies), err)
+	}
+	if proxies, err := TrustedProxies(""); err != nil || proxies != nil {
+		t.Errorf("Expected no trusted proxies for an empty value, got %v (%v)", proxies, err)
+	}
+	if _, err := TrustedProxies("10.0.0.0/8, invalid"); err == nil {
+		t.Error("Expected an error for an invalid entry")
+	}
+}
+
 func TestParseCORSOrigins(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -680,6 +831,69 @@ func TestParseRateLimit(t *testing.T) {
 	}
 }
 
+func TestParseRateLimitBurst(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		expected int
+	}{
+		{name: "empty (default)", envValue: "", expected: 1},
+		{name: "five", envValue: "5", expected: 5},
+		{name: "zero value (default)", envValue: "0", expected: 1},
+		{name: "negative value (default)", envValue: "-2", expected: 1},
+		{name: "invalid value (default)", envValue: "many", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SLACK_MCP_RATE_LIMIT_BURST", tt.envValue)
+
+			if result := parseRateLimitBurst(); result != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := RateLimitBurst("0"); err == nil {
+		t.Error("Expected an error for a burst of 0")
+	}
+}
+
+func TestSecurityMiddleware_RateLimitBurst(t *testing.T) {
+	t.Setenv("SLACK_MCP_RATE_LIMIT", "1")
+	t.Setenv("SLACK_MCP_RATE_LIMIT_BURST", "3")
+
+	middleware := NewSecurityMiddleware(zap.NewNop())
+	handler := middleware.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// a burst of 3 lets 3 quick requests through, the 4th is limited
+	for i := 1; i <= 3; i++ {
+		if w := serve(); w.Code != http.StatusOK {
+			t.Errorf("Request %d: Expected status 200, got %d", i, w.Code)
+		}
+	}
+	w := serve()
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("Request 4: Expected status 429, got %d", w.Code)
+	}
+	if got := w.Header().Get("X-RateLimit-Limit"); got != "3" {
+		t.Errorf("Expected X-RateLimit-Limit 3, got %q", got)
+	}
+	if !strings.Contains(w.Body.String(), "with bursts of 3") {
+		t.Errorf("Expected the error to mention the burst, got %s", w.Body.String())
+	}
+}
+
 func TestSecurityMiddleware_IntegrationTest(t *testing.T) {
 	// Integration test that combines multiple middleware features
 	os.Setenv("SLACK_MCP_CORS_ORIGINS", "https://allowed.com")
@@ -726,4 +940,61 @@ func TestSecurityMiddleware_IntegrationTest(t *testing.T) {
 	if w.Body.String() != "Success" {
 		t.Errorf("Expected response body 'Success', got %s", w.Body.String())
 	}
-}
\ No newline at end of file
+}
+func TestSecurityMiddleware_RateLimitHeaders(t *testing.T) {
+	middleware := &SecurityMiddleware{
+		config: SecurityConfig{
+			RateLimit: time.Minute,
+			Logger:    zap.NewNop(),
+		},
+		rateLimiters: map[string]*rate.Limiter{
+			"192.168.1.1": rate.NewLimiter(rate.Every(time.Minute), 3),
+		},
+	}
+
+	handler := middleware.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i, expected := range []string{"2", "1", "0", "0"} {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if got := w.Header().Get("X-RateLimit-Limit"); got != "3" {
+			t.Errorf("Request %d: Expected X-RateLimit-Limit 3, got %q", i+1, got)
+		}
+		if got := w.Header().Get("X-RateLimit-Remaining"); got != expected {
+			t.Errorf("Request %d: Expected X-RateLimit-Remaining %s, got %q", i+1, expected, got)
+		}
+		reset, err := strconv.Atoi(w.Header().Get("X-RateLimit-Reset"))
+		if err != nil || reset <= 0 || reset > 180 {
+			t.Errorf("Request %d: Expected X-RateLimit-Reset between 1 and 180 seconds, got %q", i+1, w.Header().Get("X-RateLimit-Reset"))
+		}
+	}
+}
+
+func TestSecurityMiddleware_RateLimitHeadersDisabled(t *testing.T) {
+	middleware := &SecurityMiddleware{
+		config: SecurityConfig{
+			RateLimit: 0,
+			Logger:    zap.NewNop(),
+		},
+		rateLimiters: make(map[string]*rate.Limiter),
+	}
+
+	handler := middleware.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	for _, header := range []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"} {
+		if got := w.Header().Get(header); got != "" {
+			t.Errorf("Expected no %s header when rate limiting is disabled, got %q", header, got)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"os"
+	"strings"
+)
+
+// HealthPathPrefix returns SLACK_MCP_HEALTH_PATH_PREFIX normalized to a leading slash and no trailing slash,
+// empty keeps the health routes at /health
+func HealthPathPrefix() string {
+	return normalizePathPrefix(os.Getenv("SLACK_MCP_HEALTH_PATH_PREFIX"))
+}
+
+// SSEPathPrefix returns SLACK_MCP_SSE_PATH_PREFIX normalized like HealthPathPrefix, empty keeps the MCP
+// routes at /sse and /message
+func SSEPathPrefix() string {
+	return normalizePathPrefix(os.Getenv("SLACK_MCP_SSE_PATH_PREFIX"))
+}
+
+// normalizePathPrefix turns "mcp/", "/mcp" or " /mcp/ " into "/mcp", a lone slash into ""
+func normalizePathPrefix(prefix string) string {
+	prefix = strings.Trim(strings.TrimSpace(prefix), "/")
+	if prefix == "" {
+		return ""
+	}
+	return "/" + prefix
+}
+
+// healthPaths returns the health, readiness and liveness routes under prefix
+func healthPaths(prefix string) (health, ready, live string) {
+	health = prefix + "/health"
+	return health, health + "/ready", health + "/live"
+}
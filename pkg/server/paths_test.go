@@ -0,0 +1,103 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+func TestNormalizePathPrefix(t *testing.T) {
+	for input, expected := range map[string]string{
+		"":           "",
+		"/":          "",
+		"ops":        "/ops",
+		"/ops/":      "/ops",
+		" /a/b/ ":    "/a/b",
+		"//slack//":  "/slack",
+		"/slack/mcp": "/slack/mcp",
+	} {
+		if got := normalizePathPrefix(input); got != expected {
+			t.Errorf("normalizePathPrefix(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func serveHealthPath(t *testing.T, path string) int {
+	t.Helper()
+	e := newTestEnhancedSSEServer()
+	e.healthChecker = NewHealthChecker(&provider.ApiProvider{}, zap.NewNop())
+
+	w := httptest.NewRecorder()
+	e.Handler().ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	return w.Code
+}
+
+func TestHealthRoutesDefaultPaths(t *testing.T) {
+	t.Setenv("SLACK_MCP_HEALTH_PATH_PREFIX", "")
+
+	if code := serveHealthPath(t, "/health/live"); code != http.StatusOK {
+		t.Errorf("Expected /health/live to return 200, got %d", code)
+	}
+}
+
+func TestHealthRoutesUnderPrefix(t *testing.T) {
+	t.Setenv("SLACK_MCP_HEALTH_PATH_PREFIX", "/ops/")
+
+	if code := serveHealthPath(t, "/ops/health/live"); code != http.StatusOK {
+		t.Errorf("Expected /ops/health/live to return 200, got %d", code)
+	}
+	if code := serveHealthPath(t, "/health/live"); code == http.StatusOK {
+		t.Error("Expected /health/live to no longer be served by the health checker")
+	}
+}
+
+func TestSSEPathPrefix(t *testing.T) {
+	t.Setenv("SLACK_MCP_SSE_PATH_PREFIX", "slack")
+	t.Setenv("SLACK_MCP_BASE_URL", "")
+	t.Setenv("RAILWAY_PUBLIC_DOMAIN", "")
+	s := &MCPServer{logger: zap.NewNop(), server: server.NewMCPServer("test", "0.0.0")}
+
+	sse := s.ServeSSE("127.0.0.1:8080")
+	if got := sse.CompleteSsePath(); got != "/slack/sse" {
+		t.Errorf("Expected SSE path /slack/sse, got %q", got)
+	}
+	if got := sse.CompleteMessagePath(); got != "/slack/message" {
+		t.Errorf("Expected message path /slack/message, got %q", got)
+	}
+
+	t.Setenv("SLACK_MCP_SSE_PATH_PREFIX", "")
+	if got := s.ServeSSE("127.0.0.1:8080").CompleteSsePath(); got != "/sse" {
+		t.Errorf("Expected default SSE path /sse, got %q", got)
+	}
+}
+
+func TestDetermineBaseURLTrimsSSEPathPrefix(t *testing.T) {
+	t.Setenv("SLACK_MCP_SSE_PATH_PREFIX", "/slack")
+	t.Setenv("RAILWAY_PUBLIC_DOMAIN", "")
+	s := &MCPServer{logger: zap.NewNop(), server: server.NewMCPServer("test", "0.0.0")}
+
+	for baseURL, expected := range map[string]string{
+		"https://example.com/slack":      "https://example.com",
+		"https://example.com/slack/":     "https://example.com",
+		"https://example.com/api/slack":  "https://example.com/api",
+		"https://example.com":            "https://example.com",
+		"https://example.com/slackbot":   "https://example.com/slackbot",
+		"https://slack":                  "https://slack",
+		"https://example.com/api/slack/": "https://example.com/api",
+	} {
+		t.Setenv("SLACK_MCP_BASE_URL", baseURL)
+		if got := s.determineBaseURL(":8080"); got != expected {
+			t.Errorf("determineBaseURL with base URL %q = %q, expected %q", baseURL, got, expected)
+		}
+	}
+
+	// the SSE server adds the prefix back once
+	t.Setenv("SLACK_MCP_BASE_URL", "https://example.com/slack")
+	if got := s.ServeSSE(":8080").CompleteSsePath(); got != "/slack/sse" {
+		t.Errorf("Expected SSE path /slack/sse, got %q", got)
+	}
+}
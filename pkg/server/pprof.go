@@ -0,0 +1,40 @@
+package server
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"os"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"go.uber.org/zap"
+)
+
+const pprofPathPrefix = "/debug/pprof/"
+
+// IsPprofEnabled returns true if pprof endpoints are explicitly enabled via environment variable
+func IsPprofEnabled() bool {
+	enabled := os.Getenv("SLACK_MCP_PPROF_ENABLED")
+	return enabled == "true" || enabled == "1"
+}
+
+// registerPprofHandlers mounts net/http/pprof handlers under /debug/pprof/. The handlers are
+// registered explicitly on the given mux so nothing leaks through http.DefaultServeMux, and they
+// are guarded by the same API key as the MCP endpoints unless running in a private network.
+func registerPprofHandlers(mux *http.ServeMux, logger *zap.Logger) {
+	protect := func(h http.Handler) http.Handler { return h }
+	if !isPrivateNetworkDeployment() {
+		protect = auth.HTTPMiddleware(logger)
+	}
+
+	mux.Handle(pprofPathPrefix, protect(http.HandlerFunc(pprof.Index)))
+	mux.Handle(pprofPathPrefix+"cmdline", protect(http.HandlerFunc(pprof.Cmdline)))
+	mux.Handle(pprofPathPrefix+"profile", protect(http.HandlerFunc(pprof.Profile)))
+	mux.Handle(pprofPathPrefix+"symbol", protect(http.HandlerFunc(pprof.Symbol)))
+	mux.Handle(pprofPathPrefix+"trace", protect(http.HandlerFunc(pprof.Trace)))
+
+	logger.Warn("pprof endpoints enabled, do not expose them publicly",
+		zap.String("context", "console"),
+		zap.String("path", pprofPathPrefix),
+		zap.Bool("auth_required", !isPrivateNetworkDeployment()),
+	)
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+func newTestEnhancedSSEServer() *EnhancedSSEServer {
+	mcpServer := server.NewMCPServer("test", "0.0.0")
+	return &EnhancedSSEServer{
+		sseServer: server.NewSSEServer(mcpServer),
+		logger:    zap.NewNop(),
+	}
+}
+
+func TestPprofRoutesAbsentByDefault(t *testing.T) {
+	t.Setenv("SLACK_MCP_PPROF_ENABLED", "")
+
+	handler := newTestEnhancedSSEServer().Handler()
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/cmdline", "/debug/pprof/goroutine"} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected %s to return 404 when pprof is disabled, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestPprofRoutesRequireAuth(t *testing.T) {
+	t.Setenv("SLACK_MCP_PPROF_ENABLED", "true")
+	t.Setenv("SLACK_MCP_SSE_API_KEY", "secret")
+	t.Setenv("SLACK_MCP_PRIVATE_NETWORK", "false")
+	t.Setenv("RAILWAY_ENVIRONMENT", "")
+
+	handler := newTestEnhancedSSEServer().Handler()
+
+	tests := []struct {
+		name           string
+		authHeader     string
+		expectedStatus int
+	}{
+		{"missing token", "", http.StatusUnauthorized},
+		{"invalid token", "Bearer wrong", http.StatusUnauthorized},
+		{"valid token", "Bearer secret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/debug/pprof/cmdline", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestIsPprofEnabled(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"", false},
+		{"false", false},
+		{"yes", false},
+		{"true", true},
+		{"1", true},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("SLACK_MCP_PPROF_ENABLED", tt.value)
+		if got := IsPprofEnabled(); got != tt.expected {
+			t.Errorf("IsPprofEnabled() with %q = %v, want %v", tt.value, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultReadinessQueueDepth   = 100
+	defaultReadinessQueueTimeout = 30 * time.Second
+	readinessPollInterval        = 250 * time.Millisecond
+	// readinessRetryAfter is the Retry-After of tool call posts rejected by the readiness queue
+	readinessRetryAfter = 5 * time.Second
+)
+
+// maxReadinessRetries bounds how often a tool call is run again after waiting, once per cache collection
+const maxReadinessRetries = 2
+
+// IsQueueUntilReadyEnabled returns true if tool calls should wait for the caches instead of failing during warmup
+func IsQueueUntilReadyEnabled() bool {
+	enabled := os.Getenv("SLACK_MCP_QUEUE_UNTIL_READY")
+	return enabled == "true" || enabled == "1"
+}
+
+// readinessGate holds tool calls that failed with CACHE_NOT_READY in a bounded queue until the cache they miss
+// is loaded. Handlers report which cache they need, so there is no list of cache dependent tools to keep in sync.
+type readinessGate struct {
+	isReady func() (bool, error)
+	// collectionReady checks a single collection when a handler reports which one it misses, nil uses isReady
+	collectionReady func(collection string) (bool, error)
+	slots           chan struct{}
+	timeout         time.Duration
+	poll            time.Duration
+	logger          *zap.Logger
+}
+
+func newReadinessGate(isReady func() (bool, error), collectionReady func(collection string) (bool, error), logger *zap.Logger) *readinessGate {
+	depth := parsePositiveIntEnv("SLACK_MCP_QUEUE_MAX_DEPTH", defaultReadinessQueueDepth, logger)
+	timeout := defaultReadinessQueueTimeout
+	if value := os.Getenv("SLACK_MCP_QUEUE_TIMEOUT"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			logger.Warn("Invalid SLACK_MCP_QUEUE_TIMEOUT, using default",
+				zap.String("value", value),
+				zap.Duration("default", defaultReadinessQueueTimeout))
+		} else {
+			timeout = d
+		}
+	}
+
+	return &readinessGate{
+		isReady:         isReady,
+		collectionReady: collectionReady,
+		slots:           make(chan struct{}, depth),
+		timeout:         timeout,
+		poll:            readinessPollInterval,
+		logger:          logger,
+	}
+}
+
+// wait returns once isReady reports the caches tool needs are loaded, or a CACHE_NOT_READY error when the queue
+// is full or the deadline passes
+func (g *readinessGate) wait(ctx context.Context, tool string, isReady func() (bool, error)) error {
+	if ready, err := g.ready(isReady); ready || err != nil {
+		return err
+	}
+
+	logger := requestid.Logger(ctx, g.logger)
+
+	select {
+	case g.slots <- struct{}{}:
+		defer func() { <-g.slots }()
+	default:
+		logger.Warn("Readiness queue is full, rejecting tool call",
+			zap.String("tool", tool),
+			zap.Int("max_depth", cap(g.slots)))
+		err := handler.NewToolError(handler.ErrCodeCacheNotReady, "server is warming up its caches and the request queue is full, retry later", nil)
+		err.Details = fmt.Sprintf("queue depth %d reached", cap(g.slots))
+		return err
+	}
+
+	logger.Info("Tool call queued until caches are ready", zap.String("tool", tool), zap.Int("queued", len(g.slots)))
+
+	timer := time.NewTimer(g.timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(g.poll)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if ready, err := g.ready(isReady); ready || err != nil {
+				return err
+			}
+		case <-timer.C:
+			_, readyErr := isReady()
+			logger.Warn("Timed out waiting for caches", zap.String("tool", tool), zap.Duration("timeout", g.timeout))
+			err := handler.NewToolError(handler.ErrCodeCacheNotReady, "server is still warming up its caches, retry later", readyErr)
+			err.Details = fmt.Sprintf("not ready after %s", g.timeout)
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// ready reports whether isReady is satisfied, rejected credentials fail right away since waiting won't help
+func (g *readinessGate) ready(isReady func() (bool, error)) (bool, error) {
+	ready, err := isReady()
+	if errors.Is(err, provider.ErrAuthInvalid) {
+		return false, handler.AsToolError(err)
+	}
+	return ready, nil
+}
+
+// missingCache returns the readiness check of the cache a handler reported missing with CACHE_NOT_READY, ok is
+// false for other results
+func (g *readinessGate) missingCache(err error) (isReady func() (bool, error), ok bool) {
+	var te *handler.ToolError
+	if !errors.As(err, &te) || te.Code != handler.ErrCodeCacheNotReady {
+		return nil, false
+	}
+	if g.collectionReady != nil {
+		switch {
+		case errors.Is(err, provider.ErrUsersNotReady):
+			return func() (bool, error) { return g.collectionReady(provider.RefreshUsers) }, true
+		case errors.Is(err, provider.ErrChannelsNotReady):
+			return func() (bool, error) { return g.collectionReady(provider.RefreshChannels) }, true
+		}
+	}
+	return g.isReady, true
+}
+
+// buildReadinessMiddleware queues tool calls whose handler failed with CACHE_NOT_READY during warmup and runs them
+// again once the missing cache is loaded, see SLACK_MCP_QUEUE_UNTIL_READY. Handlers check the caches before they
+// change anything in Slack, so running them again is safe. Tools that don't need the caches are never queued.
+func buildReadinessMiddleware(gate *readinessGate) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			res, err := next(ctx, req)
+			for retry := 0; retry < maxReadinessRetries; retry++ {
+				isReady, ok := gate.missingCache(err)
+				if !ok {
+					break
+				}
+				if err := gate.wait(ctx, req.Params.Name, isReady); err != nil {
+					heldCallFrom(ctx).resolve(err)
+					return nil, err
+				}
+				res, err = next(ctx, req)
+			}
+			return res, err
+		}
+	}
+}
+
+func parsePositiveIntEnv(name string, def int, logger *zap.Logger) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		logger.Warn("Invalid "+name+", using default", zap.String("value", value), zap.Int("default", def))
+		return def
+	}
+	return n
+}
+
+// newReadinessGateFromEnv returns the readiness queue when SLACK_MCP_QUEUE_UNTIL_READY is enabled and nil otherwise
+func newReadinessGateFromEnv(ap *provider.ApiProvider, logger *zap.Logger) *readinessGate {
+	if !IsQueueUntilReadyEnabled() {
+		return nil
+	}
+
+	gate := newReadinessGate(ap.IsReady, ap.CollectionReady, logger)
+	logger.Info("Tool calls are queued until caches are ready",
+		zap.String("context", "console"),
+		zap.Int("max_depth", cap(gate.slots)),
+		zap.Duration("timeout", gate.timeout),
+	)
+	return gate
+}
+
+// readinessMiddlewareOption registers the readiness queue of gate and the hooks answering held SSE posts, it is a
+// no-op when gate is nil
+func readinessMiddlewareOption(gate *readinessGate) server.ServerOption {
+	if gate == nil {
+		return func(*server.MCPServer) {}
+	}
+
+	// every tool call ends in one of the hooks, the ones rejected by the queue are resolved before
+	hooks := &server.Hooks{}
+	hooks.AddAfterCallTool(func(ctx context.Context, id any, message *mcp.CallToolRequest, result *mcp.CallToolResult) {
+		heldCallFrom(ctx).resolve(nil)
+	})
+	hooks.AddOnError(func(ctx context.Context, id any, method mcp.MCPMethod, message any, err error) {
+		heldCallFrom(ctx).resolve(nil)
+	})
+	return func(s *server.MCPServer) {
+		server.WithToolHandlerMiddleware(buildReadinessMiddleware(gate))(s)
+		server.WithHooks(hooks)(s)
+	}
+}
+
+// heldCall is the outcome of a tool call posted over SSE during warmup. The post is answered once the call
+// passed or was rejected by the readiness queue, so that a rejection is a 503 rather than a 202.
+type heldCall struct {
+	once sync.Once
+	done chan struct{}
+	err  error
+}
+
+type heldCallKey struct{}
+
+func withHeldCall(ctx context.Context, h *heldCall) context.Context {
+	return context.WithValue(ctx, heldCallKey{}, h)
+}
+
+func heldCallFrom(ctx context.Context) *heldCall {
+	h, _ := ctx.Value(heldCallKey{}).(*heldCall)
+	return h
+}
+
+// resolve records the outcome, err is the rejection of the readiness queue, the first outcome wins
+func (h *heldCall) resolve(err error) {
+	if h == nil {
+		return
+	}
+	h.once.Do(func() {
+		h.err = err
+		close(h.done)
+	})
+}
+
+// heldWriter defers the 202 Accepted the SSE server answers a message post with, other statuses pass through
+type heldWriter struct {
+	http.ResponseWriter
+	accepted bool
+}
+
+func (w *heldWriter) WriteHeader(status int) {
+	if status == http.StatusAccepted {
+		w.accepted = true
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// holdWarmupToolCalls answers tool calls posted while the caches are not ready only once the readiness queue
+// admitted them. A call rejected because the queue is full or timed out gets reject, a 503 with Retry-After.
+// Posts of other messages, and all posts once the caches are ready, are answered right away.
+func holdWarmupToolCalls(isReady func() (bool, error), next http.Handler, reject func(w http.ResponseWriter, r *http.Request, err error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ready, _ := isReady(); ready || r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		var message struct {
+			Method string `json:"method"`
+		}
+		if json.Unmarshal(body, &message) != nil || message.Method != string(mcp.MethodToolsCall) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		held := &heldCall{done: make(chan struct{})}
+		hw := &heldWriter{ResponseWriter: w}
+		next.ServeHTTP(hw, r.WithContext(withHeldCall(r.Context(), held)))
+		if !hw.accepted {
+			return
+		}
+
+		select {
+		case <-held.done:
+			if held.err != nil {
+				reject(w, r, held.err)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+		case <-r.Context().Done():
+		}
+	})
+}
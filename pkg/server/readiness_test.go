@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+func newTestReadinessGate(ready *atomic.Bool, depth int, timeout time.Duration) *readinessGate {
+	return &readinessGate{
+		isReady: func() (bool, error) {
+			if ready.Load() {
+				return true, nil
+			}
+			return false, errors.New("channels cache is not ready yet")
+		},
+		slots:   make(chan struct{}, depth),
+		timeout: timeout,
+		poll:    time.Millisecond,
+		logger:  zap.NewNop(),
+	}
+}
+
+func callTool(mw func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error), name string) error {
+	var req mcp.CallToolRequest
+	req.Params.Name = name
+	_, err := mw(context.Background(), req)
+	return err
+}
+
+// cacheHandler fails like the handlers do while the cache it needs is missing
+func cacheHandler(ready func() bool, notReady error, calls *atomic.Int32) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls.Add(1)
+		if !ready() {
+			return nil, handler.NewToolError(handler.ErrCodeCacheNotReady, "", notReady)
+		}
+		return mcp.NewToolResultText("ok"), nil
+	}
+}
+
+func TestReadinessMiddlewareWaitsForCaches(t *testing.T) {
+	var ready atomic.Bool
+	gate := newTestReadinessGate(&ready, 10, time.Second)
+
+	var calls atomic.Int32
+	mw := buildReadinessMiddleware(gate)(cacheHandler(ready.Load, provider.ErrChannelsNotReady, &calls))
+
+	time.AfterFunc(20*time.Millisecond, func() { ready.Store(true) })
+	if err := callTool(mw, "conversations_post_ephemeral"); err != nil {
+		t.Fatalf("Expected queued call to succeed once ready, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected handler to run again once ready, got %d calls", calls.Load())
+	}
+}
+
+func TestReadinessMiddlewareBypassesCacheFreeTools(t *testing.T) {
+	var ready atomic.Bool
+	gate := newTestReadinessGate(&ready, 0, time.Hour)
+
+	var calls atomic.Int32
+	mw := buildReadinessMiddleware(gate)(cacheHandler(func() bool { return true }, nil, &calls))
+
+	if err := callTool(mw, "emoji_list"); err != nil {
+		t.Errorf("Expected cache free tool to bypass the queue, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected handler to be called once, got %d", calls.Load())
+	}
+}
+
+func TestReadinessMiddlewareQueueFull(t *testing.T) {
+	var ready atomic.Bool
+	gate := newTestReadinessGate(&ready, 1, time.Hour)
+	gate.slots <- struct{}{}
+
+	err := gate.wait(context.Background(), "channels_list", gate.isReady)
+	if te := handler.AsToolError(err); te.Code != handler.ErrCodeCacheNotReady {
+		t.Errorf("Expected %s when queue is full, got %v", handler.ErrCodeCacheNotReady, err)
+	}
+}
+
+func TestReadinessMiddlewareTimeout(t *testing.T) {
+	var ready atomic.Bool
+	gate := newTestReadinessGate(&ready, 1, 10*time.Millisecond)
+
+	err := gate.wait(context.Background(), "channels_list", gate.isReady)
+	te := handler.AsToolError(err)
+	if te.Code != handler.ErrCodeCacheNotReady || te.Details != "not ready after 10ms" {
+		t.Errorf("Expected timeout error, got %v (%s)", err, te.Details)
+	}
+	if len(gate.slots) != 0 {
+		t.Errorf("Expected queue slot to be released, %d still taken", len(gate.slots))
+	}
+}
+
+func TestReadinessMiddlewareAuthInvalid(t *testing.T) {
+	gate := &readinessGate{
+		isReady: func() (bool, error) {
+			return false, fmt.Errorf("%w: invalid_auth", provider.ErrAuthInvalid)
+		},
+		slots:   make(chan struct{}, 1),
+		timeout: time.Hour,
+		poll:    time.Millisecond,
+		logger:  zap.NewNop(),
+	}
+
+	err := gate.wait(context.Background(), "channels_list", gate.isReady)
+	if te := handler.AsToolError(err); te.Code != handler.ErrCodeAuthInvalid {
+		t.Errorf("Expected %s without queueing, got %v", handler.ErrCodeAuthInvalid, err)
+	}
+}
+
+func TestReadinessMiddlewareWaitsForMissingCollection(t *testing.T) {
+	var ready atomic.Bool
+	gate := newTestReadinessGate(&ready, 0, time.Hour)
+	gate.collectionReady = func(collection string) (bool, error) {
+		if collection == provider.RefreshChannels {
+			return true, nil
+		}
+		return false, provider.ErrUsersNotReady
+	}
+
+	// the channels are loaded by the time the call would be queued
+	var channelsLoaded atomic.Bool
+	var calls atomic.Int32
+	mw := buildReadinessMiddleware(gate)(cacheHandler(func() bool { return channelsLoaded.Swap(true) }, provider.ErrChannelsNotReady, &calls))
+	if err := callTool(mw, "conversations_history"); err != nil {
+		t.Errorf("Expected conversations_history to run once the channels are loaded, got %v", err)
+	}
+
+	mw = buildReadinessMiddleware(gate)(cacheHandler(func() bool { return false }, provider.ErrUsersNotReady, &calls))
+	if err := callTool(mw, "users_profile_get"); handler.AsToolError(err).Code != handler.ErrCodeCacheNotReady {
+		t.Errorf("Expected users_profile_get to wait for the users cache, got %v", err)
+	}
+}
+
+func TestHoldWarmupToolCalls(t *testing.T) {
+	var ready atomic.Bool
+	var outcome atomic.Value
+	// like the SSE server, the post is accepted and the call is handled in the background
+	sse := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		held := heldCallFrom(r.Context())
+		go func() {
+			if err, ok := outcome.Load().(error); ok {
+				held.resolve(err)
+				return
+			}
+			held.resolve(nil)
+		}()
+	})
+	hold := holdWarmupToolCalls(func() (bool, error) { return ready.Load(), nil }, sse, func(w http.ResponseWriter, r *http.Request, err error) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	post := func(body string) int {
+		rec := httptest.NewRecorder()
+		hold.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/message?sessionId=s1", strings.NewReader(body)))
+		return rec.Code
+	}
+	toolCall := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"conversations_history"}}`
+
+	if code := post(toolCall); code != http.StatusAccepted {
+		t.Errorf("Expected an admitted call to be accepted, got %d", code)
+	}
+	outcome.Store(handler.NewToolError(handler.ErrCodeCacheNotReady, "server is still warming up its caches, retry later", nil))
+	if code := post(toolCall); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected a rejected call to get 503, got %d", code)
+	}
+	if code := post(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`); code != http.StatusAccepted {
+		t.Errorf("Expected other messages to be accepted right away, got %d", code)
+	}
+	ready.Store(true)
+	if code := post(toolCall); code != http.StatusAccepted {
+		t.Errorf("Expected posts to pass through once ready, got %d", code)
+	}
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+// writeTools change workspace state, they are hidden and rejected in read-only mode
+var writeTools = map[string]bool{
+	"conversations_add_message":      true,
+	"conversations_post_ephemeral":   true,
+	"conversations_acknowledge":      true,
+	"conversations_await_response":   true,
+	"conversations_post_interactive": true,
+	"conversations_broadcast":        true,
+	"conversations_post_with_file":   true,
+	"conversations_set_topic":        true,
+	"conversations_set_purpose":      true,
+	"conversations_mark":             true,
+	"conversations_set_prefs":        true,
+	"conversations_open":             true,
+	"bookmarks_add":                  true,
+	"bookmarks_remove":               true,
+	"users_set_presence":             true,
+	"users_profile_set_status":       true,
+}
+
+// IsReadOnlyEnabled returns true if SLACK_MCP_READ_ONLY disables every tool that writes to Slack, handlers
+// check the same setting for side effects such as auto-join
+func IsReadOnlyEnabled() bool {
+	return handler.IsReadOnlyEnabled()
+}
+
+// filterWriteTools drops write tools from tools/list
+func filterWriteTools(_ context.Context, tools []mcp.Tool) []mcp.Tool {
+	filtered := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if !writeTools[tool.Name] {
+			filtered = append(filtered, tool)
+		}
+	}
+	return filtered
+}
+
+// buildReadOnlyMiddleware rejects calls to write tools, e.g. from clients that cached an older tool list
+func buildReadOnlyMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if writeTools[req.Params.Name] {
+				return nil, handler.NewToolError(handler.ErrCodeReadOnly,
+					fmt.Sprintf("%s tool is disabled, the server runs in read-only mode (SLACK_MCP_READ_ONLY)", req.Params.Name), nil)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// readOnlyOption hides and rejects write tools when SLACK_MCP_READ_ONLY is enabled and is a no-op otherwise
+func readOnlyOption(logger *zap.Logger) server.ServerOption {
+	if !IsReadOnlyEnabled() {
+		return func(*server.MCPServer) {}
+	}
+
+	logger.Info("Read-only mode enabled, write tools are disabled",
+		zap.String("context", "console"),
+		zap.Int("disabled_tools", len(writeTools)),
+	)
+	return func(s *server.MCPServer) {
+		server.WithToolFilter(filterWriteTools)(s)
+		server.WithToolHandlerMiddleware(buildReadOnlyMiddleware())(s)
+	}
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestReadOnlyMiddlewareRejectsWriteTools(t *testing.T) {
+	var calls int
+	mw := buildReadOnlyMiddleware()(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	err := callTool(mw, "conversations_add_message")
+	if code := handler.AsToolError(err).Code; code != handler.ErrCodeReadOnly {
+		t.Fatalf("Expected %s for a write tool, got %s (%v)", handler.ErrCodeReadOnly, code, err)
+	}
+	if calls != 0 {
+		t.Errorf("Expected write tool handler not to be called, got %d calls", calls)
+	}
+
+	if err := callTool(mw, "conversations_history"); err != nil {
+		t.Errorf("Expected read tool to pass in read-only mode, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected read tool handler to be called once, got %d", calls)
+	}
+}
+
+func TestReadOnlyFilterHidesWriteTools(t *testing.T) {
+	tools := []mcp.Tool{
+		mcp.NewTool("channels_list"),
+		mcp.NewTool("bookmarks_add"),
+		mcp.NewTool("users_set_presence"),
+		mcp.NewTool("conversations_replies"),
+	}
+
+	filtered := filterWriteTools(context.Background(), tools)
+	if len(filtered) != 2 {
+		t.Fatalf("Expected 2 tools after filtering, got %d", len(filtered))
+	}
+	for _, tool := range filtered {
+		if writeTools[tool.Name] {
+			t.Errorf("Expected write tool %s to be hidden", tool.Name)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/middleware"
+	"go.uber.org/zap"
+)
+
+const reloadPath = "/reload"
+
+// reloadableEnvVars are the settings Reload applies without a restart
+var reloadableEnvVars = []string{
+	"SLACK_MCP_ADD_MESSAGE_TOOL",
+	"SLACK_MCP_READ_CHANNELS",
+	"SLACK_MCP_ENABLED_TOOLS",
+	"SLACK_MCP_CORS_ORIGINS",
+	"SLACK_MCP_RATE_LIMIT",
+	"SLACK_MCP_RATE_LIMIT_BURST",
+}
+
+// ConfigChange is a setting changed by Reload
+type ConfigChange struct {
+	Name string `json:"name"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// IsReloadEndpointEnabled returns true if POST /reload is explicitly enabled via environment variable
+func IsReloadEndpointEnabled() bool {
+	enabled := os.Getenv("SLACK_MCP_RELOAD_ENDPOINT")
+	return enabled == "true" || enabled == "1"
+}
+
+// reloadableEnv returns the current values of reloadableEnvVars
+func reloadableEnv() map[string]string {
+	env := make(map[string]string, len(reloadableEnvVars))
+	for _, name := range reloadableEnvVars {
+		env[name] = os.Getenv(name)
+	}
+	return env
+}
+
+// Reload re-reads the reloadable settings from the file named by SLACK_MCP_RELOAD_FILE, settings the
+// file leaves out fall back to the environment the server started with. All settings are validated
+// before any of them is applied, an invalid file is rejected and the running configuration is kept.
+// It returns the settings that changed.
+func (s *MCPServer) Reload() ([]ConfigChange, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	changes, err := s.reload(os.Getenv("SLACK_MCP_RELOAD_FILE"))
+	if err != nil {
+		s.logger.Warn("Configuration reload rejected, keeping the running configuration",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (s *MCPServer) reload(path string) ([]ConfigChange, error) {
+	if path == "" {
+		return nil, fmt.Errorf("SLACK_MCP_RELOAD_FILE is not set, there is no file to reload the configuration from")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading SLACK_MCP_RELOAD_FILE: %w", err)
+	}
+	defer f.Close()
+
+	overrides, err := parseReloadFile(f)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	values := make(map[string]string, len(reloadableEnvVars))
+	for _, name := range reloadableEnvVars {
+		values[name] = s.startupEnv[name]
+		if value, ok := overrides[name]; ok {
+			values[name] = value
+		}
+	}
+
+	if err := ValidateAddMessageTool(values["SLACK_MCP_ADD_MESSAGE_TOOL"]); err != nil {
+		return nil, fmt.Errorf("invalid SLACK_MCP_ADD_MESSAGE_TOOL: %w", err)
+	}
+	if err := ValidateReadChannels(values["SLACK_MCP_READ_CHANNELS"]); err != nil {
+		return nil, fmt.Errorf("invalid SLACK_MCP_READ_CHANNELS: %w", err)
+	}
+	enabled, err := ParseEnabledTools(values["SLACK_MCP_ENABLED_TOOLS"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid SLACK_MCP_ENABLED_TOOLS: %w", err)
+	}
+	rateLimit, err := middleware.RateLimitInterval(values["SLACK_MCP_RATE_LIMIT"])
+	if err != nil {
+		return nil, err
+	}
+	rateLimitBurst, err := middleware.RateLimitBurst(values["SLACK_MCP_RATE_LIMIT_BURST"])
+	if err != nil {
+		return nil, err
+	}
+	corsOrigins := middleware.SplitCORSOrigins(values["SLACK_MCP_CORS_ORIGINS"])
+
+	var changes []ConfigChange
+	for _, name := range reloadableEnvVars {
+		if old := os.Getenv(name); old != values[name] {
+			changes = append(changes, ConfigChange{Name: name, Old: old, New: values[name]})
+		}
+	}
+	if len(changes) == 0 {
+		s.logger.Info("Configuration reloaded, nothing changed",
+			zap.String("context", "console"),
+			zap.String("file", path),
+		)
+		return nil, nil
+	}
+
+	// Handlers read the channel policy from the environment on every call
+	for _, change := range changes {
+		if err := os.Setenv(change.Name, change.New); err != nil {
+			return nil, fmt.Errorf("applying %s: %w", change.Name, err)
+		}
+	}
+	if s.tools != nil {
+		added, removed := s.tools.SetEnabled(enabled)
+		if len(added) > 0 || len(removed) > 0 {
+			s.logger.Info("Tool list changed by reload",
+				zap.String("context", "console"),
+				zap.Strings("added", added),
+				zap.Strings("removed", removed),
+			)
+		}
+	}
+	if s.security != nil {
+		s.security.UpdatePolicy(corsOrigins, rateLimit, rateLimitBurst)
+	}
+
+	for _, change := range changes {
+		s.logger.Info("Configuration setting reloaded",
+			zap.String("context", "console"),
+			zap.String("name", change.Name),
+			zap.String("old", change.Old),
+			zap.String("new", change.New),
+		)
+	}
+	return changes, nil
+}
+
+// parseReloadFile parses KEY=VALUE lines, blank lines and # comments are skipped and values may be
+// quoted. Only reloadableEnvVars may appear, any other setting requires a restart.
+func parseReloadFile(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", n)
+		}
+		name = strings.TrimSpace(strings.TrimPrefix(name, "export "))
+		if !slices.Contains(reloadableEnvVars, name) {
+			return nil, fmt.Errorf("line %d: %s can't be reloaded, reloadable settings: %s", n, name, strings.Join(reloadableEnvVars, ", "))
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// registerReloadHandler mounts POST /reload. It requires SLACK_MCP_SSE_API_KEY even in a private
+// network, without a key the endpoint stays disabled.
+func (e *EnhancedSSEServer) registerReloadHandler(mux *http.ServeMux) {
+	if os.Getenv("SLACK_MCP_SSE_API_KEY") == "" {
+		e.logger.Warn("SLACK_MCP_RELOAD_ENDPOINT requires SLACK_MCP_SSE_API_KEY, the reload endpoint is disabled",
+			zap.String("context", "console"),
+		)
+		return
+	}
+
+	mux.Handle(reloadPath, auth.HTTPMiddleware(e.logger)(http.HandlerFunc(e.reloadHandler)))
+	e.logger.Info("Reload endpoint enabled",
+		zap.String("context", "console"),
+		zap.String("path", reloadPath),
+	)
+}
+
+// reloadHandler applies Reload and responds with the changed settings
+func (e *EnhancedSSEServer) reloadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		e.writeStandardErrorResponse(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Method not allowed", "The reload endpoint only accepts POST")
+		return
+	}
+
+	changes, err := e.reload()
+	if err != nil {
+		e.writeStandardErrorResponse(w, r, http.StatusUnprocessableEntity, "INVALID_CONFIGURATION",
+			"Configuration reload rejected, the running configuration is kept", err.Error())
+		return
+	}
+
+	if changes == nil {
+		changes = []ConfigChange{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(struct {
+		Changes []ConfigChange `json:"changes"`
+	}{changes}); err != nil {
+		requestid.Logger(r.Context(), e.logger).Error("Failed to encode reload response", zap.Error(err))
+	}
+}
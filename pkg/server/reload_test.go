@@ -0,0 +1,245 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/server/middleware"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+// newTestReloadServer registers channels_list and files_download with SLACK_MCP_ENABLED_TOOLS
+// limited to channels_list and points SLACK_MCP_RELOAD_FILE to a file with the given content
+func newTestReloadServer(t *testing.T, content string) (*MCPServer, string) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "")
+	t.Setenv("SLACK_MCP_ENABLED_TOOLS", "channels_list")
+	t.Setenv("SLACK_MCP_CORS_ORIGINS", "")
+	t.Setenv("SLACK_MCP_RATE_LIMIT", "")
+	t.Setenv("SLACK_MCP_RATE_LIMIT_BURST", "")
+
+	path := filepath.Join(t.TempDir(), "reload.env")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SLACK_MCP_RELOAD_FILE", path)
+
+	s := server.NewMCPServer("test", "0.0.0")
+	tools := &toolRegistrar{s: s, enabled: map[string]bool{"channels_list": true}, logger: zap.NewNop()}
+	handler := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	tools.AddTool(mcp.NewTool("channels_list"), handler)
+	tools.AddTool(mcp.NewTool("files_download"), handler)
+
+	return &MCPServer{
+		server:     s,
+		logger:     zap.NewNop(),
+		tools:      tools,
+		security:   middleware.NewSecurityMiddleware(zap.NewNop()),
+		startupEnv: reloadableEnv(),
+	}, path
+}
+
+func listToolNames(t *testing.T, s *server.MCPServer) []string {
+	t.Helper()
+	resp, ok := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatal("Expected a JSON-RPC response to tools/list")
+	}
+	var names []string
+	for _, tool := range resp.Result.(mcp.ListToolsResult).Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestReloadAppliesFile(t *testing.T) {
+	s, _ := newTestReloadServer(t, `# tools and channels
+SLACK_MCP_ENABLED_TOOLS="files_download"
+export SLACK_MCP_ADD_MESSAGE_TOOL=C123,C456
+SLACK_MCP_RATE_LIMIT=0
+`)
+
+	changes, err := s.Reload()
+	if err != nil {
+		t.Fatalf("Expected the reload to succeed, got %v", err)
+	}
+	if len(changes) != 3 {
+		t.Errorf("Expected 3 changed settings, got %+v", changes)
+	}
+	if got := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL"); got != "C123,C456" {
+		t.Errorf("Expected SLACK_MCP_ADD_MESSAGE_TOOL to be applied, got %q", got)
+	}
+	if got := listToolNames(t, s.server); len(got) != 1 || got[0] != "files_download" {
+		t.Errorf("Expected only files_download to be registered, got %v", got)
+	}
+	if _, rateLimit := s.security.Policy(); rateLimit != 0 {
+		t.Errorf("Expected rate limiting to be disabled, got %v", rateLimit)
+	}
+
+	changes, err = s.Reload()
+	if err != nil || len(changes) != 0 {
+		t.Errorf("Expected a second reload to change nothing, got %+v, %v", changes, err)
+	}
+}
+
+func TestReloadRevertsToStartupEnv(t *testing.T) {
+	s, path := newTestReloadServer(t, "SLACK_MCP_RATE_LIMIT=30\n")
+	if _, err := s.Reload(); err != nil {
+		t.Fatalf("Expected the reload to succeed, got %v", err)
+	}
+	if _, rateLimit := s.security.Policy(); rateLimit != 2*time.Second {
+		t.Errorf("Expected a 2s rate limit interval, got %v", rateLimit)
+	}
+
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	changes, err := s.Reload()
+	if err != nil {
+		t.Fatalf("Expected the reload to succeed, got %v", err)
+	}
+	if len(changes) != 1 || changes[0].Name != "SLACK_MCP_RATE_LIMIT" || changes[0].Old != "30" || changes[0].New != "" {
+		t.Errorf("Expected SLACK_MCP_RATE_LIMIT to revert to its startup value, got %+v", changes)
+	}
+	if _, rateLimit := s.security.Policy(); rateLimit != time.Minute {
+		t.Errorf("Expected the default rate limit interval, got %v", rateLimit)
+	}
+}
+
+func TestReloadAppliesRateLimitBurst(t *testing.T) {
+	s, _ := newTestReloadServer(t, "SLACK_MCP_RATE_LIMIT_BURST=3\n")
+
+	changes, err := s.Reload()
+	if err != nil {
+		t.Fatalf("Expected the reload to succeed, got %v", err)
+	}
+	if len(changes) != 1 || changes[0].Name != "SLACK_MCP_RATE_LIMIT_BURST" || changes[0].New != "3" {
+		t.Errorf("Expected SLACK_MCP_RATE_LIMIT_BURST to be reported as changed, got %+v", changes)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/sse", nil)
+	req.RemoteAddr = "203.0.113.7:4321"
+	w := httptest.NewRecorder()
+	s.security.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, req)
+	if got := w.Header().Get("X-RateLimit-Limit"); got != "3" {
+		t.Errorf("Expected new clients to get a burst of 3, got X-RateLimit-Limit %q", got)
+	}
+}
+
+func TestReloadRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"mixed channels", "SLACK_MCP_ADD_MESSAGE_TOOL=C123,!C456\nSLACK_MCP_RATE_LIMIT=30\n"},
+		{"unknown tool", "SLACK_MCP_ENABLED_TOOLS=files_donwload\n"},
+		{"negative rate limit", "SLACK_MCP_RATE_LIMIT=-1\n"},
+		{"zero rate limit burst", "SLACK_MCP_RATE_LIMIT_BURST=0\n"},
+		{"not reloadable", "SLACK_MCP_XOXP_TOKEN=xoxp-1\n"},
+		{"missing value", "SLACK_MCP_RATE_LIMIT\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestReloadServer(t, tt.content)
+
+			if _, err := s.Reload(); err == nil {
+				t.Fatal("Expected the reload to be rejected")
+			}
+			if got := os.Getenv("SLACK_MCP_RATE_LIMIT"); got != "" {
+				t.Errorf("Expected the environment to be kept, got SLACK_MCP_RATE_LIMIT=%q", got)
+			}
+			if got := listToolNames(t, s.server); len(got) != 1 || got[0] != "channels_list" {
+				t.Errorf("Expected the tool list to be kept, got %v", got)
+			}
+			if _, rateLimit := s.security.Policy(); rateLimit != time.Minute {
+				t.Errorf("Expected the rate limit to be kept, got %v", rateLimit)
+			}
+		})
+	}
+}
+
+func TestReloadWithoutFile(t *testing.T) {
+	s, _ := newTestReloadServer(t, "")
+	t.Setenv("SLACK_MCP_RELOAD_FILE", "")
+
+	if _, err := s.Reload(); err == nil || !strings.Contains(err.Error(), "SLACK_MCP_RELOAD_FILE") {
+		t.Errorf("Expected an error naming SLACK_MCP_RELOAD_FILE, got %v", err)
+	}
+}
+
+func TestReloadEndpoint(t *testing.T) {
+	s, _ := newTestReloadServer(t, "SLACK_MCP_ENABLED_TOOLS=!channels_list\n")
+	t.Setenv("SLACK_MCP_RELOAD_ENDPOINT", "true")
+	t.Setenv("SLACK_MCP_SSE_API_KEY", "secret")
+
+	e := newTestEnhancedSSEServer()
+	e.reload = s.Reload
+	handler := e.Handler()
+
+	tests := []struct {
+		name           string
+		method         string
+		authHeader     string
+		expectedStatus int
+	}{
+		{"missing token", http.MethodPost, "", http.StatusUnauthorized},
+		{"invalid token", http.MethodPost, "Bearer wrong", http.StatusUnauthorized},
+		{"wrong method", http.MethodGet, "Bearer secret", http.StatusMethodNotAllowed},
+		{"valid token", http.MethodPost, "Bearer secret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/reload", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Changes []ConfigChange `json:"changes"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("Expected a JSON body, got %v", err)
+			}
+			if len(body.Changes) != 1 || body.Changes[0].New != "!channels_list" {
+				t.Errorf("Expected the SLACK_MCP_ENABLED_TOOLS change, got %+v", body.Changes)
+			}
+		})
+	}
+}
+
+func TestReloadEndpointRequiresAPIKey(t *testing.T) {
+	t.Setenv("SLACK_MCP_RELOAD_ENDPOINT", "true")
+	t.Setenv("SLACK_MCP_SSE_API_KEY", "")
+
+	e := newTestEnhancedSSEServer()
+	e.reload = func() ([]ConfigChange, error) { return nil, nil }
+
+	req := httptest.NewRequest(http.MethodPost, "/reload", nil)
+	w := httptest.NewRecorder()
+	e.Handler().ServeHTTP(w, req)
+
+	if w.Code == http.StatusOK {
+		t.Error("Expected the reload endpoint to stay disabled without an API key")
+	}
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"os"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+// defaultListPageSize is how many resources, tools or prompts a list request returns before a cursor is needed
+const defaultListPageSize = 100
+
+// IsChannelResourcesEnabled returns false if SLACK_MCP_CHANNEL_RESOURCES disables listing every cached channel as a resource
+func IsChannelResourcesEnabled() bool {
+	enabled := os.Getenv("SLACK_MCP_CHANNEL_RESOURCES")
+	return enabled != "false" && enabled != "0"
+}
+
+// listPaginationOption pages the resources, tools and prompts lists by SLACK_MCP_LIST_PAGE_SIZE
+func listPaginationOption(logger *zap.Logger) server.ServerOption {
+	return server.WithPaginationLimit(parsePositiveIntEnv("SLACK_MCP_LIST_PAGE_SIZE", defaultListPageSize, logger))
+}
+
+// channelResources returns a resource per cached channel readable by SLACK_MCP_READ_CHANNELS. Names carry the
+// channel ID since lists are paged by name and channels of different teams of an Enterprise Grid org may share
+// a name.
+func channelResources(ws string, channels map[string]provider.Channel) []mcp.Resource {
+	resources := make([]mcp.Resource, 0, len(channels))
+	for _, c := range channels {
+		if !handler.IsChannelReadable(c.ID) {
+			continue
+		}
+		description := "Recent messages of " + c.Name
+		if c.Purpose != "" {
+			description += ": " + c.Purpose
+		}
+		resources = append(resources, mcp.NewResource(
+			handler.ChannelResourceURI(ws, c.ID),
+			c.Name+" ("+c.ID+")",
+			mcp.WithResourceDescription(description),
+			mcp.WithMIMEType("text/csv"),
+		))
+	}
+	return resources
+}
+
+// SyncChannelResources lists the channels of the channels cache as resources, channels no longer cached are
+// removed. It runs after every change of the channels cache once the cache is loaded, channels read through
+// the URI template don't need to be listed.
+func (s *MCPServer) SyncChannelResources() {
+	if !IsChannelResourcesEnabled() || s.channelResource == nil {
+		return
+	}
+	if ready, _ := s.provider.CollectionReady(provider.RefreshChannels); !ready {
+		return
+	}
+
+	s.channelResourcesMu.Lock()
+	defer s.channelResourcesMu.Unlock()
+
+	first := s.channelResources == nil
+	changed := s.syncChannelResources(s.provider.ProvideChannelsMaps().Channels)
+	if !changed {
+		return
+	}
+
+	// the resources capability doesn't declare listChanged to avoid a notification per channel, clients
+	// are told when the listed channels changed
+	s.server.SendNotificationToAllClients(mcp.MethodNotificationResourcesListChanged, nil)
+	if first {
+		s.logger.Info("Channels listed as resources",
+			zap.String("context", "console"),
+			zap.Int("count", len(s.channelResources)),
+		)
+		return
+	}
+	s.logger.Debug("Channel resources resynced",
+		zap.String("context", "console"),
+		zap.Int("count", len(s.channelResources)),
+	)
+}
+
+// syncChannelResources adds or updates a resource per channel and removes the resources of channels no
+// longer cached. It reports whether the listed URIs changed, an update of a listed channel refreshes its
+// name and description without a notification.
+func (s *MCPServer) syncChannelResources(channels map[string]provider.Channel) bool {
+	resources := channelResources(s.workspace, channels)
+	listed := make(map[string]bool, len(resources))
+	changed := s.channelResources == nil
+	for _, r := range resources {
+		listed[r.URI] = true
+		if !s.channelResources[r.URI] {
+			changed = true
+		}
+		s.server.AddResource(r, s.channelResource)
+	}
+	for uri := range s.channelResources {
+		if !listed[uri] {
+			changed = true
+			s.server.RemoveResource(uri)
+		}
+	}
+	s.channelResources = listed
+	return changed
+}
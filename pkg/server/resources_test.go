@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+func TestChannelResources(t *testing.T) {
+	resources := channelResources("acme", map[string]provider.Channel{
+		"C1": {ID: "C1", Name: "#general", Purpose: "Company wide"},
+	})
+
+	if len(resources) != 1 {
+		t.Fatalf("Expected 1 resource, got %d", len(resources))
+	}
+	r := resources[0]
+	if r.URI != "slack://acme/channel/C1" {
+		t.Errorf("Expected the channel resource URI, got %q", r.URI)
+	}
+	if r.Name != "#general (C1)" {
+		t.Errorf("Expected the name to carry the channel ID, got %q", r.Name)
+	}
+	if r.Description != "Recent messages of #general: Company wide" {
+		t.Errorf("Expected the purpose in the description, got %q", r.Description)
+	}
+}
+
+func TestChannelResourcesSkipUnreadable(t *testing.T) {
+	t.Setenv("SLACK_MCP_READ_CHANNELS", "!C2")
+	resources := channelResources("acme", map[string]provider.Channel{
+		"C1": {ID: "C1", Name: "#general"},
+		"C2": {ID: "C2", Name: "#secret"},
+	})
+
+	if len(resources) != 1 || resources[0].URI != "slack://acme/channel/C1" {
+		t.Errorf("Expected only the readable channel, got %v", resources)
+	}
+}
+
+func TestListPaginationOption(t *testing.T) {
+	t.Setenv("SLACK_MCP_LIST_PAGE_SIZE", "2")
+
+	s := server.NewMCPServer("test", "0.0.0", listPaginationOption(zap.NewNop()))
+	read := func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return nil, nil
+	}
+	for _, r := range channelResources("acme", map[string]provider.Channel{
+		"C1": {ID: "C1", Name: "#a"},
+		"C2": {ID: "C2", Name: "#b"},
+		"C3": {ID: "C3", Name: "#c"},
+	}) {
+		s.AddResource(r, read)
+	}
+
+	var names []string
+	cursor := ""
+	for pages := 0; pages < 3; pages++ {
+		msg, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      pages,
+			"method":  "resources/list",
+			"params":  map[string]any{"cursor": cursor},
+		})
+		resp, ok := s.HandleMessage(context.Background(), msg).(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatal("Expected a JSON-RPC response to resources/list")
+		}
+		result := resp.Result.(mcp.ListResourcesResult)
+		for _, r := range result.Resources {
+			names = append(names, r.Name)
+		}
+		if cursor = string(result.NextCursor); cursor == "" {
+			break
+		}
+	}
+
+	if len(names) != 3 || names[0] != "#a (C1)" || names[2] != "#c (C3)" {
+		t.Errorf("Expected all channels over two pages, got %v", names)
+	}
+}
+
+func TestSyncChannelResourcesReportsChanges(t *testing.T) {
+	read := func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return nil, nil
+	}
+	s := &MCPServer{logger: zap.NewNop(), server: server.NewMCPServer("test", "0.0.0"), workspace: "acme", channelResource: read}
+
+	channels := map[string]provider.Channel{"C1": {ID: "C1", Name: "#general"}}
+	if !s.syncChannelResources(channels) {
+		t.Error("Expected the first sync to change the list")
+	}
+	channels["C1"] = provider.Channel{ID: "C1", Name: "#general", Purpose: "Company wide"}
+	if s.syncChannelResources(channels) {
+		t.Error("Expected an updated channel to keep the list")
+	}
+	channels["C2"] = provider.Channel{ID: "C2", Name: "#random"}
+	if !s.syncChannelResources(channels) {
+		t.Error("Expected an added channel to change the list")
+	}
+	delete(channels, "C1")
+	if !s.syncChannelResources(channels) {
+		t.Error("Expected a removed channel to change the list")
+	}
+	if len(s.channelResources) != 1 || !s.channelResources["slack://acme/channel/C2"] {
+		t.Errorf("Expected only C2 to be listed, got %v", s.channelResources)
+	}
+}
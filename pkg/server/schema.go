@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// argumentError names the tool argument that failed validation and why
+type argumentError struct {
+	field  string
+	reason string
+}
+
+func (e *argumentError) Error() string {
+	return fmt.Sprintf("invalid argument %q: %s", e.field, e.reason)
+}
+
+// validatedHandler checks the arguments of every call against the input schema the tool advertises
+// in tools/list before next runs, failures are returned as INVALID_ARGUMENT naming the field
+func validatedHandler(tool mcp.Tool, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := validateArguments(tool.InputSchema, req.GetArguments())
+		if err != nil {
+			te := handler.NewToolError(handler.ErrCodeInvalidArgument, "", err)
+			te.Details = err.field
+			return nil, te
+		}
+		req.Params.Arguments = args
+		return next(ctx, req)
+	}
+}
+
+// validateArguments returns a copy of args in which missing arguments got their schema default and
+// values were converted to the declared type. Numbers and booleans sent as strings, and numbers sent
+// for string arguments, are converted since clients differ in how strictly they follow the schema.
+// Arguments the schema doesn't declare are passed through, see continuesListing for the limit default.
+func validateArguments(schema mcp.ToolInputSchema, args map[string]any) (map[string]any, *argumentError) {
+	res := make(map[string]any, len(args))
+	for k, v := range args {
+		// JSON null is treated like a missing argument
+		if v != nil {
+			res[k] = v
+		}
+	}
+
+	for _, field := range schema.Required {
+		if _, ok := res[field]; !ok {
+			return nil, &argumentError{field: field, reason: "is required"}
+		}
+	}
+
+	fields := make([]string, 0, len(schema.Properties))
+	for field := range schema.Properties {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		prop, ok := schema.Properties[field].(map[string]any)
+		if !ok {
+			continue
+		}
+		value, ok := res[field]
+		if !ok {
+			if def, ok := prop["default"]; ok && !continuesListing(field, res) {
+				res[field] = def
+			}
+			continue
+		}
+
+		converted, err := convertArgument(prop, value)
+		if err != nil {
+			return nil, &argumentError{field: field, reason: err.Error()}
+		}
+		res[field] = converted
+	}
+	return res, nil
+}
+
+// continuesListing reports whether field is the limit of a call passing a cursor. The cursor carries the
+// range of the listing it continues and handlers reject a limit next to it, so the default isn't applied.
+func continuesListing(field string, args map[string]any) bool {
+	cursor, _ := args["cursor"].(string)
+	return field == "limit" && cursor != ""
+}
+
+// convertArgument checks value against the type, enum and range constraints of prop
+func convertArgument(prop map[string]any, value any) (any, error) {
+	typ, _ := prop["type"].(string)
+	switch typ {
+	case "string":
+		var s string
+		switch v := value.(type) {
+		case string:
+			s = v
+		case float64:
+			s = strconv.FormatFloat(v, 'f', -1, 64)
+		case int:
+			s = strconv.Itoa(v)
+		case bool:
+			s = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("must be a string, got %s", jsonType(value))
+		}
+		if max, ok := numberValue(prop["maxLength"]); ok && float64(utf8.RuneCountInString(s)) > max {
+			return nil, fmt.Errorf("must be at most %v characters", max)
+		}
+		if min, ok := numberValue(prop["minLength"]); ok && float64(utf8.RuneCountInString(s)) < min {
+			return nil, fmt.Errorf("must be at least %v characters", min)
+		}
+		value = s
+	case "number", "integer":
+		n, ok := numberValue(value)
+		if !ok {
+			if s, isString := value.(string); isString {
+				f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+				n, ok = f, err == nil
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("must be a number, got %s", jsonType(value))
+		}
+		if typ == "integer" && n != math.Trunc(n) {
+			return nil, fmt.Errorf("must be an integer, got %v", n)
+		}
+		if min, ok := numberValue(prop["minimum"]); ok && n < min {
+			return nil, fmt.Errorf("must be at least %v, got %v", min, n)
+		}
+		if max, ok := numberValue(prop["maximum"]); ok && n > max {
+			return nil, fmt.Errorf("must be at most %v, got %v", max, n)
+		}
+		value = n
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("must be a boolean, got %q", v)
+			}
+			value = b
+		default:
+			return nil, fmt.Errorf("must be a boolean, got %s", jsonType(value))
+		}
+	case "array":
+		if _, ok := value.([]any); !ok {
+			return nil, fmt.Errorf("must be an array, got %s", jsonType(value))
+		}
+	case "object":
+		if _, ok := value.(map[string]any); !ok {
+			return nil, fmt.Errorf("must be an object, got %s", jsonType(value))
+		}
+	}
+
+	if enum, ok := prop["enum"].([]string); ok && !enumContains(enum, value) {
+		return nil, fmt.Errorf("must be one of %s, got %v", strings.Join(enum, ", "), value)
+	}
+	return value, nil
+}
+
+func enumContains(enum []string, value any) bool {
+	s := fmt.Sprint(value)
+	for _, e := range enum {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func numberValue(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// jsonType names the JSON type of a decoded value for error messages
+func jsonType(v any) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case float64, int:
+		return "number"
+	case bool:
+		return "boolean"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func newSchemaTestTool() mcp.Tool {
+	return mcp.NewTool("test_tool",
+		mcp.WithString("channel_id", mcp.Required()),
+		mcp.WithString("limit", mcp.DefaultString("1d")),
+		mcp.WithNumber("count", mcp.DefaultNumber(20), mcp.Min(1), mcp.Max(100)),
+		mcp.WithBoolean("render", mcp.DefaultBool(true)),
+		mcp.WithString("sort", mcp.Enum("popularity", "none")),
+	)
+}
+
+func TestValidateArgumentsDefaultsAndConversions(t *testing.T) {
+	args, err := validateArguments(newSchemaTestTool().InputSchema, map[string]any{
+		"channel_id": "C123",
+		"count":      "50",
+		"render":     "false",
+		"sort":       nil,
+		"extra":      "kept",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if args["limit"] != "1d" {
+		t.Errorf("Expected the default limit, got %v", args["limit"])
+	}
+	if args["count"] != float64(50) {
+		t.Errorf("Expected count to be converted to a number, got %#v", args["count"])
+	}
+	if args["render"] != false {
+		t.Errorf("Expected render to be converted to a boolean, got %#v", args["render"])
+	}
+	if _, ok := args["sort"]; ok {
+		t.Errorf("Expected a null argument to be dropped, got %v", args["sort"])
+	}
+	if args["extra"] != "kept" {
+		t.Errorf("Expected undeclared arguments to be passed through, got %v", args["extra"])
+	}
+
+	args, err = validateArguments(newSchemaTestTool().InputSchema, map[string]any{"channel_id": "C123", "limit": float64(50)})
+	if err != nil || args["limit"] != "50" {
+		t.Errorf("Expected a numeric limit to be converted to a string, got %#v (%v)", args["limit"], err)
+	}
+}
+
+func TestValidateArgumentsCursorSkipsLimitDefault(t *testing.T) {
+	args, err := validateArguments(newSchemaTestTool().InputSchema, map[string]any{"channel_id": "C123", "cursor": "MTIzNC41Njc4"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := args["limit"]; ok {
+		t.Errorf("Expected no default limit next to a cursor, got %v", args["limit"])
+	}
+	if args["render"] != true {
+		t.Errorf("Expected the other defaults to be applied, got %v", args["render"])
+	}
+
+	args, err = validateArguments(newSchemaTestTool().InputSchema, map[string]any{"channel_id": "C123", "cursor": ""})
+	if err != nil || args["limit"] != "1d" {
+		t.Errorf("Expected the default limit with an empty cursor, got %#v (%v)", args["limit"], err)
+	}
+}
+
+func TestValidateArgumentsErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		args  map[string]any
+		field string
+		want  string
+	}{
+		{"missing required", map[string]any{}, "channel_id", "is required"},
+		{"null required", map[string]any{"channel_id": nil}, "channel_id", "is required"},
+		{"wrong type", map[string]any{"channel_id": []any{"C1"}}, "channel_id", "must be a string, got array"},
+		{"not a number", map[string]any{"channel_id": "C1", "count": "many"}, "count", "must be a number"},
+		{"below minimum", map[string]any{"channel_id": "C1", "count": float64(0)}, "count", "must be at least 1"},
+		{"above maximum", map[string]any{"channel_id": "C1", "count": float64(500)}, "count", "must be at most 100"},
+		{"not a boolean", map[string]any{"channel_id": "C1", "render": "maybe"}, "render", "must be a boolean"},
+		{"not in enum", map[string]any{"channel_id": "C1", "sort": "name"}, "sort", "must be one of popularity, none"},
+	}
+
+	for _, tc := range cases {
+		_, err := validateArguments(newSchemaTestTool().InputSchema, tc.args)
+		if err == nil {
+			t.Errorf("%s: Expected an error", tc.name)
+			continue
+		}
+		if err.field != tc.field || !strings.Contains(err.reason, tc.want) {
+			t.Errorf("%s: Expected %s to fail with %q, got %v", tc.name, tc.field, tc.want, err)
+		}
+	}
+}
+
+func TestValidatedHandler(t *testing.T) {
+	var got map[string]any
+	next := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		got = req.GetArguments()
+		return mcp.NewToolResultText("ok"), nil
+	}
+	h := validatedHandler(newSchemaTestTool(), next)
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "test_tool"
+	req.Params.Arguments = map[string]any{"channel_id": "C123"}
+	if _, err := h(context.Background(), req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got["render"] != true {
+		t.Errorf("Expected the handler to see defaults, got %v", got)
+	}
+
+	got = nil
+	req.Params.Arguments = map[string]any{"channel_id": "C123", "sort": "name"}
+	_, err := h(context.Background(), req)
+	var te *handler.ToolError
+	if !errors.As(err, &te) || te.Code != handler.ErrCodeInvalidArgument || te.Details != "sort" {
+		t.Fatalf("Expected INVALID_ARGUMENT for sort, got %v", err)
+	}
+	if !strings.Contains(te.Message, `invalid argument "sort"`) {
+		t.Errorf("Expected the message to name the field, got %q", te.Message)
+	}
+	if got != nil {
+		t.Error("Expected the handler not to run for invalid arguments")
+	}
+}
@@ -3,15 +3,20 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/korotovsky/slack-mcp-server/pkg/handler"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
 	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
 	"github.com/korotovsky/slack-mcp-server/pkg/server/middleware"
 	"github.com/korotovsky/slack-mcp-server/pkg/text"
@@ -26,11 +31,28 @@ type MCPServer struct {
 	logger       *zap.Logger
 	provider     *provider.ApiProvider
 	healthChecker *HealthChecker
+
+	// tools and security are updated by Reload, reloadMu serializes reloads
+	tools    *toolRegistrar
+	security *middleware.SecurityMiddleware
+	reloadMu sync.Mutex
+	// startupEnv holds the reloadable settings the process started with, see Reload
+	startupEnv map[string]string
+
+	// workspace and channelResource list cached channels as resources, see SyncChannelResources
+	workspace          string
+	channelResource    server.ResourceHandlerFunc
+	channelResourcesMu sync.Mutex
+	channelResources   map[string]bool
+
+	// readiness queues tool calls during warmup, nil unless SLACK_MCP_QUEUE_UNTIL_READY is enabled
+	readiness *readinessGate
 }
 
 func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer {
 	// Create base server with logging and recovery
 	var s *server.MCPServer
+	readiness := newReadinessGateFromEnv(provider, logger)
 	
 	// Only add authentication middleware if not in private network deployment mode
 	if !isPrivateNetworkDeployment() {
@@ -39,8 +61,13 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 			version.Version,
 			server.WithLogging(),
 			server.WithRecovery(),
+			debugRawOption(logger),
+			server.WithToolHandlerMiddleware(buildErrorMiddleware(logger)),
 			server.WithToolHandlerMiddleware(buildLoggerMiddleware(logger)),
 			server.WithToolHandlerMiddleware(auth.BuildMiddleware(provider.ServerTransport(), logger)),
+			readOnlyOption(logger),
+			readinessMiddlewareOption(readiness),
+			listPaginationOption(logger),
 		)
 		logger.Info("Authentication middleware enabled",
 			zap.String("context", "console"),
@@ -52,7 +79,12 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 			version.Version,
 			server.WithLogging(),
 			server.WithRecovery(),
+			debugRawOption(logger),
+			server.WithToolHandlerMiddleware(buildErrorMiddleware(logger)),
 			server.WithToolHandlerMiddleware(buildLoggerMiddleware(logger)),
+			readOnlyOption(logger),
+			readinessMiddlewareOption(readiness),
+			listPaginationOption(logger),
 		)
 		logger.Info("Authentication middleware disabled for private network deployment",
 			zap.String("context", "console"),
@@ -60,9 +92,25 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 		)
 	}
 
+	enabledTools, err := ParseEnabledTools(os.Getenv("SLACK_MCP_ENABLED_TOOLS"))
+	if err != nil {
+		logger.Fatal("error in SLACK_MCP_ENABLED_TOOLS",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+	if enabledTools != nil {
+		logger.Info("Registering a subset of tools",
+			zap.String("context", "console"),
+			zap.Int("enabled", len(enabledTools)),
+			zap.Int("total", len(ToolNames)),
+		)
+	}
+	tools := &toolRegistrar{s: s, enabled: enabledTools, logger: logger}
+
 	conversationsHandler := handler.NewConversationsHandler(provider, logger)
 
-	s.AddTool(mcp.NewTool("conversations_history",
+	tools.AddTool(mcp.NewTool("conversations_history",
 		mcp.WithDescription("Get messages from the channel (or DM) by channel_id, the last row/column in the response is used as 'cursor' parameter for pagination if not empty"),
 		mcp.WithString("channel_id",
 			mcp.Required(),
@@ -72,6 +120,10 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 			mcp.Description("If true, the response will include activity messages such as 'channel_join' or 'channel_leave'. Default is boolean false."),
 			mcp.DefaultBool(false),
 		),
+		mcp.WithBoolean("exclude_bots",
+			mcp.Description("If true, messages posted by bots and apps are left out. The limit counts messages before they are left out, so a page may hold fewer; the cursor is kept. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
 		mcp.WithString("cursor",
 			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
 		),
@@ -79,9 +131,20 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 			mcp.DefaultString("1d"),
 			mcp.Description("Limit of messages to fetch in format of maximum ranges of time (e.g. 1d - 1 day, 1w - 1 week, 30d - 30 days, 90d - 90 days which is a default limit for free tier history) or number of messages (e.g. 50). Must be empty when 'cursor' is provided."),
 		),
+		mcp.WithBoolean("render",
+			mcp.Description("If true, Slack markup in message text is expanded: user mentions become @displayname, channel references #name and links their labels. Set to false to get the raw Slack markup. Default is boolean true."),
+			mcp.DefaultBool(true),
+		),
+		mcp.WithBoolean("include_edits",
+			mcp.Description("If true, edited messages are marked with '(edited)' and carry who edited them last and when in editedBy and editedTs. Default is boolean true."),
+			mcp.DefaultBool(true),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Team ID in format Txxxxxxxxxx used to resolve a channel name when it exists in several teams of an Enterprise Grid org. Only needed with org-level tokens."),
+		),
 	), conversationsHandler.ConversationsHistoryHandler)
 
-	s.AddTool(mcp.NewTool("conversations_replies",
+	tools.AddTool(mcp.NewTool("conversations_replies",
 		mcp.WithDescription("Get a thread of messages posted to a conversation by channelID and thread_ts, the last row/column in the response is used as 'cursor' parameter for pagination if not empty"),
 		mcp.WithString("channel_id",
 			mcp.Required(),
@@ -95,6 +158,10 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 			mcp.Description("If true, the response will include activity messages such as 'channel_join' or 'channel_leave'. Default is boolean false."),
 			mcp.DefaultBool(false),
 		),
+		mcp.WithBoolean("exclude_bots",
+			mcp.Description("If true, messages posted by bots and apps are left out. The limit counts messages before they are left out, so a page may hold fewer; the cursor is kept. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
 		mcp.WithString("cursor",
 			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
 		),
@@ -102,27 +169,357 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 			mcp.DefaultString("1d"),
 			mcp.Description("Limit of messages to fetch in format of maximum ranges of time (e.g. 1d - 1 day, 30d - 30 days, 90d - 90 days which is a default limit for free tier history) or number of messages (e.g. 50). Must be empty when 'cursor' is provided."),
 		),
+		mcp.WithBoolean("render",
+			mcp.Description("If true, Slack markup in message text is expanded: user mentions become @displayname, channel references #name and links their labels. Set to false to get the raw Slack markup. Default is boolean true."),
+			mcp.DefaultBool(true),
+		),
+		mcp.WithBoolean("include_edits",
+			mcp.Description("If true, edited messages are marked with '(edited)' and carry who edited them last and when in editedBy and editedTs. Default is boolean true."),
+			mcp.DefaultBool(true),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Team ID in format Txxxxxxxxxx used to resolve a channel name when it exists in several teams of an Enterprise Grid org. Only needed with org-level tokens."),
+		),
 	), conversationsHandler.ConversationsRepliesHandler)
 
-	s.AddTool(mcp.NewTool("conversations_add_message",
+	tools.AddTool(mcp.NewTool("conversations_add_message",
 		mcp.WithDescription("Add a message to a public channel, private channel, or direct message (DM, or IM) conversation by channel_id and thread_ts."),
 		mcp.WithString("channel_id",
-			mcp.Required(),
-			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm. Optional when SLACK_MCP_DEFAULT_CHANNEL is set, which is used when it is omitted."),
 		),
 		mcp.WithString("thread_ts",
 			mcp.Description("Unique identifier of either a thread's parent message or a message in the thread_ts must be the timestamp in format 1234567890.123456 of an existing message with 0 or more replies. Optional, if not provided the message will be added to the channel itself, otherwise it will be added to the thread."),
 		),
 		mcp.WithString("payload",
-			mcp.Description("Message payload in specified content_type format. Example: 'Hello, world!' for text/plain or '# Hello, world!' for text/markdown."),
+			mcp.Description("Message payload in specified content_type format. Example: 'Hello, world!' for text/plain or '# Hello, world!' for text/markdown. Required unless blocks are given, empty or whitespace-only messages are rejected."),
 		),
 		mcp.WithString("content_type",
 			mcp.DefaultString("text/markdown"),
+			mcp.Enum("text/markdown", "text/plain"),
 			mcp.Description("Content type of the message. Default is 'text/markdown'. Allowed values: 'text/markdown', 'text/plain'."),
 		),
+		mcp.WithString("username",
+			mcp.Description("Display name to post the message as, overriding the bot's name. Only available for bot tokens."),
+		),
+		mcp.WithString("icon_emoji",
+			mcp.Description("Emoji to use as the message icon in format :emoji_name:, e.g. ':rotating_light:'. Only available for bot tokens, can't be combined with icon_url."),
+		),
+		mcp.WithString("icon_url",
+			mcp.Description("Absolute https URL of an image to use as the message icon. Only available for bot tokens, can't be combined with icon_emoji."),
+		),
+		mcp.WithString("blocks",
+			mcp.Description("Optional Block Kit blocks as a JSON array, posted instead of the formatted payload which becomes the notification fallback text."),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Team ID in format Txxxxxxxxxx used to resolve a channel name when it exists in several teams of an Enterprise Grid org. Only needed with org-level tokens."),
+		),
+		mcp.WithBoolean("allow_broadcast",
+			mcp.DefaultBool(false),
+			mcp.Description("Keep @channel, @here and @everyone mentions so they notify the channel. By default they are escaped, see SLACK_MCP_ESCAPE_MENTIONS."),
+		),
+		mcp.WithBoolean("reply_broadcast",
+			mcp.DefaultBool(false),
+			mcp.Description("Also show the thread reply in the channel, like 'Also send to #channel' in Slack. Requires thread_ts."),
+		),
 	), conversationsHandler.ConversationsAddMessageHandler)
 
-	s.AddTool(mcp.NewTool("conversations_search_messages",
+	tools.AddTool(mcp.NewTool("conversations_post_ephemeral",
+		mcp.WithDescription("Post a message to a channel that is visible only to one user, e.g. for interactive help. Requires a bot token that is a member of the channel. Ephemeral messages are not stored in the history, so no timestamp is returned."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx."),
+		),
+		mcp.WithString("user",
+			mcp.Required(),
+			mcp.Description("User who will see the message, user ID in format Uxxxxxxxxxx or handle in format @username. The user must be a member of the channel."),
+		),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("Message text in Slack mrkdwn, also used as the notification fallback when blocks are given."),
+		),
+		mcp.WithString("blocks",
+			mcp.Description("Optional Block Kit blocks as a JSON array, e.g. '[{\"type\":\"section\",\"text\":{\"type\":\"mrkdwn\",\"text\":\"Hi\"}}]'."),
+		),
+	), conversationsHandler.ConversationsPostEphemeralHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_acknowledge",
+		mcp.WithDescription("Acknowledge a message in one call: add a reaction to it and post a reply in its thread. Both steps are attempted, the result lists the outcome of each, a failed step is not rolled back. Gated by SLACK_MCP_ADD_MESSAGE_TOOL like conversations_add_message."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or Dxxxxxxxxxx."),
+		),
+		mcp.WithString("ts",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message to acknowledge in format 1234567890.123456."),
+		),
+		mcp.WithString("reaction",
+			mcp.Required(),
+			mcp.Description("Emoji name of the reaction without colons, e.g. eyes or white_check_mark."),
+		),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("Text of the threaded reply in Slack mrkdwn."),
+		),
+	), conversationsHandler.ConversationsAcknowledgeHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_await_response",
+		mcp.WithDescription("Post a message and wait until someone reacts to it or replies in its thread, e.g. to ask a human for an approval. Returns the first accepted response, or status 'timeout' when nobody responded in time. Gated by SLACK_MCP_ADD_MESSAGE_TOOL like conversations_add_message."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or Dxxxxxxxxxx."),
+		),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("Text of the message in Slack mrkdwn, e.g. 'Deploy release 1.2 to production? React with :white_check_mark: to approve'."),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Timestamp of a thread's parent message in format 1234567890.123456 to post the message in that thread."),
+		),
+		mcp.WithString("accept",
+			mcp.DefaultString("any"),
+			mcp.Enum("any", "reaction", "reply"),
+			mcp.Description("Kind of response to wait for: 'reaction' to the message, threaded 'reply' after it, or 'any'."),
+		),
+		mcp.WithString("users",
+			mcp.Description("Comma-separated user IDs or @handles allowed to respond. Defaults to anyone except the authenticated user."),
+		),
+		mcp.WithString("reactions",
+			mcp.Description("Comma-separated emoji names that count as a response, e.g. 'white_check_mark,x'. Defaults to any reaction."),
+		),
+		mcp.WithString("timeout",
+			mcp.Description("How long to wait as a duration, e.g. 90s or 10m. Defaults to and is capped at SLACK_MCP_AWAIT_TIMEOUT (5m)."),
+		),
+	), conversationsHandler.ConversationsAwaitResponseHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_post_interactive",
+		mcp.WithDescription("Post a message with Block Kit buttons, e.g. Approve and Reject for an approval flow. Returns one row per button with the message timestamp and the button's action ID, pass channelID and messageTs to conversations_await_interaction to wait for a click. Clicks are received over Socket Mode and need SLACK_MCP_APP_TOKEN. Gated by SLACK_MCP_ADD_MESSAGE_TOOL like conversations_add_message."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or Dxxxxxxxxxx."),
+		),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("Text of the message in Slack mrkdwn shown above the buttons, e.g. 'Deploy release 1.2 to production?'."),
+		),
+		mcp.WithString("buttons",
+			mcp.Required(),
+			mcp.Description(`Buttons as a JSON array of 1 to 5 objects with 'text', optional 'value' returned on click (defaults to the text) and optional 'style' 'primary' or 'danger', e.g. [{"text":"Approve","value":"approve","style":"primary"},{"text":"Reject","value":"reject","style":"danger"}].`),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Timestamp of a thread's parent message in format 1234567890.123456 to post the message in that thread."),
+		),
+	), conversationsHandler.ConversationsPostInteractiveHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_await_interaction",
+		mcp.WithDescription("Wait until someone clicks a button of a message posted by conversations_post_interactive. Returns the first accepted click with the button's action ID, text and value and who clicked, or status 'timeout' when nobody clicked in time. Clicks made before the call count too."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel the message was posted in, the channelID returned by conversations_post_interactive."),
+		),
+		mcp.WithString("ts",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message in format 1234567890.123456, the messageTs returned by conversations_post_interactive."),
+		),
+		mcp.WithString("users",
+			mcp.Description("Comma-separated user IDs or @handles whose clicks count. Defaults to anyone."),
+		),
+		mcp.WithString("timeout",
+			mcp.Description("How long to wait as a duration, e.g. 90s or 10m. Defaults to and is capped at SLACK_MCP_AWAIT_TIMEOUT (5m)."),
+		),
+	), conversationsHandler.ConversationsAwaitInteractionHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_broadcast",
+		mcp.WithDescription("Post the same message to several channels, e.g. for announcements. Returns one row per channel with the message timestamp or the error, so partial failures are visible. Every channel is gated by SLACK_MCP_ADD_MESSAGE_TOOL like conversations_add_message."),
+		mcp.WithString("channel_ids",
+			mcp.Required(),
+			mcp.Description("Comma-separated channel IDs in format Cxxxxxxxxxx or Dxxxxxxxxxx, at most 50."),
+		),
+		mcp.WithString("payload",
+			mcp.Required(),
+			mcp.Description("Message payload in specified content_type format. Example: 'Hello, world!' for text/plain or '# Hello, world!' for text/markdown."),
+		),
+		mcp.WithString("content_type",
+			mcp.DefaultString("text/markdown"),
+			mcp.Enum("text/markdown", "text/plain"),
+			mcp.Description("Content type of the message. Default is 'text/markdown'. Allowed values: 'text/markdown', 'text/plain'."),
+		),
+		mcp.WithBoolean("stop_on_error",
+			mcp.DefaultBool(false),
+			mcp.Description("Stop after the first failed channel, remaining channels are reported as skipped. Default is false, which posts to every channel."),
+		),
+		mcp.WithBoolean("allow_broadcast",
+			mcp.DefaultBool(false),
+			mcp.Description("Keep @channel, @here and @everyone mentions so they notify the channel. By default they are escaped, see SLACK_MCP_ESCAPE_MENTIONS."),
+		),
+	), conversationsHandler.ConversationsBroadcastHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_post_with_file",
+		mcp.WithDescription("Upload a file and post it with a message to a channel in one call, e.g. a generated report with its summary. Returns the file ID and permalink and the message timestamp. If the upload succeeds but the message fails, the row has status 'uploaded' with the error and the file, which is not shared anywhere yet. Gated by SLACK_MCP_ADD_MESSAGE_TOOL like conversations_add_message."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("filename",
+			mcp.Required(),
+			mcp.Description("Name of the file including its extension, which sets its type. Example: 'report.csv'."),
+		),
+		mcp.WithString("content",
+			mcp.Description("Content of a text file. Exactly one of content and content_base64 is required."),
+		),
+		mcp.WithString("content_base64",
+			mcp.Description("Content of a binary file encoded as standard base64, e.g. an image or a PDF."),
+		),
+		mcp.WithString("payload",
+			mcp.Description("Message text posted with the file, in Slack mrkdwn. Optional, without it the message only shows the file."),
+		),
+		mcp.WithString("title",
+			mcp.Description("Title of the file shown in Slack, defaults to the file name."),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Timestamp of a thread's parent message in format 1234567890.123456 to post the file as a reply. Optional."),
+		),
+	), conversationsHandler.ConversationsPostWithFileHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_set_topic",
+		mcp.WithDescription("Set the topic of a channel, an empty topic clears it. Returns the updated channel."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx."),
+		),
+		mcp.WithString("topic",
+			mcp.Required(),
+			mcp.MaxLength(250),
+			mcp.Description("New topic of the channel, at most 250 characters."),
+		),
+	), conversationsHandler.ConversationsSetTopicHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_set_purpose",
+		mcp.WithDescription("Set the purpose (description) of a channel, an empty purpose clears it. Returns the updated channel."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx."),
+		),
+		mcp.WithString("purpose",
+			mcp.Required(),
+			mcp.MaxLength(250),
+			mcp.Description("New purpose of the channel, at most 250 characters."),
+		),
+	), conversationsHandler.ConversationsSetPurposeHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_mark",
+		mcp.WithDescription("Mark a channel or direct message (DM, or IM) conversation as read up to the given message. Requires a user token, not available for bot tokens."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or Dxxxxxxxxxx."),
+		),
+		mcp.WithString("ts",
+			mcp.Required(),
+			mcp.Description("Timestamp of the most recently seen message in format 1234567890.123456, the read cursor is moved to it."),
+		),
+	), conversationsHandler.ConversationsMarkHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_get_prefs",
+		mcp.WithDescription("Get the notification preferences of a channel for the authenticated user: whether it is muted and its desktop and mobile notification levels. Requires browser session credentials (xoxc/xoxd), not available for xoxp and xoxb tokens."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or Dxxxxxxxxxx."),
+		),
+	), conversationsHandler.ConversationsGetPrefsHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_set_prefs",
+		mcp.WithDescription("Mute or unmute a channel or change its notification levels for the authenticated user, e.g. to quiet a noisy channel. Returns the resulting preferences. Requires browser session credentials (xoxc/xoxd), not available for xoxp and xoxb tokens."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or Dxxxxxxxxxx."),
+		),
+		mcp.WithBoolean("muted",
+			mcp.Description("Mute (true) or unmute (false) the channel. Left unchanged when omitted."),
+		),
+		mcp.WithString("desktop",
+			mcp.Enum("everything", "mention", "nothing", "default"),
+			mcp.Description("Desktop notification level: everything, mention, nothing or default to follow the global preference. Left unchanged when omitted."),
+		),
+		mcp.WithString("mobile",
+			mcp.Enum("everything", "mention", "nothing", "default"),
+			mcp.Description("Mobile notification level: everything, mention, nothing or default to follow the global preference. Left unchanged when omitted."),
+		),
+	), conversationsHandler.ConversationsSetPrefsHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_open",
+		mcp.WithDescription("Open or resume a direct message (DM) with one user or a group DM (MPIM) with several users and return its channel ID, creating the conversation if needed. Use the channel ID to post messages with conversations_add_message."),
+		mcp.WithString("users",
+			mcp.Required(),
+			mcp.Description("Comma-separated user IDs in format Uxxxxxxxxxx or handles in format @username. One user opens a DM, 2 to 8 users open a group DM."),
+		),
+	), conversationsHandler.ConversationsOpenHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_members",
+		mcp.WithDescription("List members of a channel by channel ID, one page at a time, with user IDs resolved to names. Use it for access reviews of who is in a channel."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or Gxxxxxxxxxx."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(100),
+			mcp.Min(1),
+			mcp.Max(1000),
+			mcp.Description("The maximum number of members to return per page, up to 1000."),
+		),
+	), conversationsHandler.ConversationsMembersHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_list_dms",
+		mcp.WithDescription("List direct messages (DMs) and group DMs of the authenticated user, most recent first, with the other participants resolved to names and the timestamp of the latest message. Use it to triage an inbox. With a bot token only DMs with the bot are listed."),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(50),
+			mcp.Min(1),
+			mcp.Max(200),
+			mcp.Description("The maximum number of DMs to return per page, up to 200."),
+		),
+	), conversationsHandler.ConversationsListDMsHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_unread_counts",
+		mcp.WithDescription("Get unread message counts of the authenticated user's channels, DMs and group DMs with their last read timestamps, most unread first. Use it to find out what the user missed. Requires browser session credentials (xoxc/xoxd), not available for xoxp and xoxb tokens."),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(20),
+			mcp.Min(1),
+			mcp.Max(100),
+			mcp.Description("The maximum number of conversations with unread messages to return, the most recently active ones are counted, up to 100."),
+		),
+	), conversationsHandler.ConversationsUnreadCountsHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_scheduled_messages_list",
+		mcp.WithDescription("List messages scheduled to be posted that are still pending, with their target channels, post times and text previews. Optionally filtered by channel and post time range."),
+		mcp.WithString("channel_id",
+			mcp.Description("Only list messages scheduled in this channel, in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+		mcp.WithString("date_from",
+			mcp.Description("Only list messages scheduled to be posted on or after this date. Example: '2025-07-01', 'July 1, 2025'."),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("Only list messages scheduled to be posted on or before this date, inclusive. Example: '2025-07-31'."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(50),
+			mcp.Min(1),
+			mcp.Max(1000),
+			mcp.Description("The maximum number of scheduled messages to return per page, up to 1000."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithBoolean("render",
+			mcp.Description("If true, Slack markup in message text is expanded: user mentions become @displayname, channel references #name and links their labels. Set to false to get the raw Slack markup. Default is boolean true."),
+			mcp.DefaultBool(true),
+		),
+	), conversationsHandler.ConversationsScheduledListHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_search_messages",
 		mcp.WithDescription("Search messages in a public channel, private channel, or direct message (DM, or IM) conversation using filters. All filters are optional, if not provided then search_query is required."),
 		mcp.WithString("search_query",
 			mcp.Description("Search query to filter messages. Example: 'marketing report' or full URL of Slack message e.g. 'https://slack.com/archives/C1234567890/p1234567890123456', then the tool will return a single message matching given URL, herewith all other parameters will be ignored."),
@@ -160,30 +557,529 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 		),
 		mcp.WithNumber("limit",
 			mcp.DefaultNumber(20),
+			mcp.Min(1),
+			mcp.Max(100),
 			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 100."),
 		),
+		mcp.WithBoolean("render",
+			mcp.Description("If true, Slack markup in message text is expanded: user mentions become @displayname, channel references #name and links their labels. Set to false to get the raw Slack markup. Default is boolean true."),
+			mcp.DefaultBool(true),
+		),
 	), conversationsHandler.ConversationsSearchHandler)
 
+	tools.AddTool(mcp.NewTool("chat_resolve_permalink",
+		mcp.WithDescription("Fetch the message a Slack permalink points to, e.g. a message URL pasted by the user. Thread permalinks return the linked reply. Only permalinks of the authenticated workspace are accepted."),
+		mcp.WithString("permalink",
+			mcp.Required(),
+			mcp.Description("Slack message URL, e.g. 'https://acme.slack.com/archives/C1234567890/p1234567890123456' or a reply with '?thread_ts=1234567890.123456'."),
+		),
+		mcp.WithBoolean("render",
+			mcp.Description("If true, Slack markup in message text is expanded: user mentions become @displayname, channel references #name and links their labels. Set to false to get the raw Slack markup. Default is boolean true."),
+			mcp.DefaultBool(true),
+		),
+	), conversationsHandler.ChatResolvePermalinkHandler)
+
+	tools.AddTool(mcp.NewTool("chat_unfurl_preview",
+		mcp.WithDescription("Get the title, description and image of a link, e.g. to understand a URL shared in a message without opening it. With channel_id and ts the unfurl Slack stored on that message is used, otherwise the OpenGraph tags of the page are fetched by the server when SLACK_MCP_UNFURL_FETCH is enabled."),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("Absolute http or https URL, Slack markup such as '<https://example.com/post|label>' is accepted. Example: 'https://example.com/blog/launch'."),
+		),
+		mcp.WithString("channel_id",
+			mcp.Description("ID of the channel of a message that contains the link, in format Cxxxxxxxxxx. Requires ts."),
+		),
+		mcp.WithString("ts",
+			mcp.Description("Timestamp of the message that contains the link, in format 1234567890.123456. Requires channel_id."),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Timestamp of the thread parent when the message is a reply, in format 1234567890.123456."),
+		),
+	), conversationsHandler.ChatUnfurlPreviewHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_canvas_get",
+		mcp.WithDescription("Read the canvas attached to a channel, or a canvas by ID, converted to markdown, e.g. a team handbook or project brief pinned as the channel canvas. Requires the files:read scope for OAuth tokens."),
+		mcp.WithString("channel_id",
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general. Its attached canvas is returned. Required unless canvas_id is given."),
+		),
+		mcp.WithString("canvas_id",
+			mcp.Description("ID of a canvas in format Fxxxxxxxxxx, e.g. from search_files. Takes precedence over channel_id."),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Team ID in format Txxxxxxxxxx used to resolve a channel name when it exists in several teams of an Enterprise Grid org. Only needed with org-level tokens."),
+		),
+	), conversationsHandler.ConversationsCanvasGetHandler)
+
+	tools.AddTool(mcp.NewTool("canvases_sections_list",
+		mcp.WithDescription("Find the sections of a canvas by heading type or text, returns their section IDs. Requires the canvases:read scope for OAuth tokens."),
+		mcp.WithString("channel_id",
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general. Its attached canvas is searched. Required unless canvas_id is given."),
+		),
+		mcp.WithString("canvas_id",
+			mcp.Description("ID of a canvas in format Fxxxxxxxxxx. Takes precedence over channel_id."),
+		),
+		mcp.WithString("section_types",
+			mcp.Description("Comma separated heading types to match: any_header, h1, h2 or h3. At least one of section_types and contains_text is required."),
+		),
+		mcp.WithString("contains_text",
+			mcp.Description("Only sections containing this text are returned."),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Team ID in format Txxxxxxxxxx used to resolve a channel name when it exists in several teams of an Enterprise Grid org. Only needed with org-level tokens."),
+		),
+	), conversationsHandler.CanvasesSectionsListHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_summary",
+		mcp.WithDescription("Get a pulse of a channel: message counts per user, top reactions, the most active threads and the latest messages of a recent window, aggregated server-side. Returns one CSV row per entry, the 'section' column tells totals, user, reaction, thread and recent rows apart."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+		mcp.WithString("lookback",
+			mcp.Description("How far back to look, e.g. 12h, 3d or 2w. Defaults to and is capped at SLACK_MCP_SUMMARY_LOOKBACK (7d)."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(500),
+			mcp.Min(1),
+			mcp.Max(1000),
+			mcp.Description("Maximum number of messages to scan, newest first, between 1 and 1000."),
+		),
+		mcp.WithNumber("top",
+			mcp.DefaultNumber(5),
+			mcp.Min(1),
+			mcp.Max(20),
+			mcp.Description("Number of rows per user, reaction, thread and recent section, between 1 and 20."),
+		),
+		mcp.WithBoolean("exclude_bots",
+			mcp.Description("If true, messages posted by bots and apps are not counted, a totals row tells how many were left out. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("render",
+			mcp.Description("If true, Slack markup in message text is expanded, see conversations_history. Default is boolean true."),
+			mcp.DefaultBool(true),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Team ID in format Txxxxxxxxxx used to resolve a channel name when it exists in several teams of an Enterprise Grid org. Only needed with org-level tokens."),
+		),
+	), conversationsHandler.ConversationsSummaryHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_latest",
+		mcp.WithDescription("Get the most recent message of each of several channels, e.g. for a last activity overview. Much cheaper than conversations_history as only a few messages are fetched per channel, join, leave and other activity messages are skipped. Returns one CSV row per channel, with empty message columns for channels without messages and errorCode and error for channels that could not be read."),
+		mcp.WithString("channel_ids",
+			mcp.Required(),
+			mcp.Description("Comma-separated channel IDs in format Cxxxxxxxxxx or Dxxxxxxxxxx, at most 50."),
+		),
+		mcp.WithBoolean("render",
+			mcp.Description("If true, Slack markup in message text is expanded, see conversations_history. Default is boolean true."),
+			mcp.DefaultBool(true),
+		),
+	), conversationsHandler.ConversationsLatestHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_analytics",
+		mcp.WithDescription("Get engagement analytics of a channel for charting: one CSV row per UTC day of the date range with message count, unique active users, reaction total, threads and their replies, followed by a 'total' row over the whole range. Days without messages are included. Scanning is expensive, so the range is capped at 90 days, the scan at 'limit' messages, and results are reused for 5 minutes. Rows scanned only partly because the limit was reached have complete=false."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+		mcp.WithString("start_date",
+			mcp.Description("First day of the range, e.g. 2025-06-01. Defaults to 29 days before end_date."),
+		),
+		mcp.WithString("end_date",
+			mcp.Description("Last day of the range, e.g. 2025-06-30. Defaults to today (UTC)."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(5000),
+			mcp.Min(1),
+			mcp.Max(20000),
+			mcp.Description("Maximum number of messages to scan, newest first, between 1 and 20000."),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Team ID in format Txxxxxxxxxx used to resolve a channel name when it exists in several teams of an Enterprise Grid org. Only needed with org-level tokens."),
+		),
+	), conversationsHandler.ConversationsAnalyticsHandler)
+
+	tools.AddTool(mcp.NewTool("reactions_leaderboard",
+		mcp.WithDescription("Rank the most used reaction emoji and the users who react most in a channel over a recent window, e.g. for team-culture bots. History is paged and the reactions of every message are tallied server-side. Returns one CSV row per entry, the 'section' column tells totals, emoji and user rows apart, ties share a rank."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+		mcp.WithString("lookback",
+			mcp.Description("How far back to look, e.g. 12h, 3d or 2w. Default is 7d, at most 30d."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(1000),
+			mcp.Min(1),
+			mcp.Max(5000),
+			mcp.Description("Maximum number of messages to scan, newest first, between 1 and 5000."),
+		),
+		mcp.WithNumber("top",
+			mcp.DefaultNumber(10),
+			mcp.Min(1),
+			mcp.Max(50),
+			mcp.Description("Number of rows in the emoji and user sections, between 1 and 50."),
+		),
+		mcp.WithBoolean("include_replies",
+			mcp.Description("If true, reactions on thread replies are counted too, the replies of up to 100 threads are fetched. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Team ID in format Txxxxxxxxxx used to resolve a channel name when it exists in several teams of an Enterprise Grid org. Only needed with org-level tokens."),
+		),
+	), conversationsHandler.ReactionsLeaderboardHandler)
+
+	tools.AddTool(mcp.NewTool("mentions_recent",
+		mcp.WithDescription("List recent messages that mention the authenticated user, newest first, with their channel, author and permalink. User tokens with search access use search.messages, other tokens scan the recent history of up to 100 cached channels. Channels denied by SLACK_MCP_READ_CHANNELS are left out. The 'note' column of the first row tells the source used and what was not scanned."),
+		mcp.WithString("lookback",
+			mcp.Description("How far back to look, e.g. 12h, 3d or 2w. Default is 7d, at most 30d."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(50),
+			mcp.Min(1),
+			mcp.Max(100),
+			mcp.Description("Maximum number of mentions to return, between 1 and 100."),
+		),
+		mcp.WithBoolean("render",
+			mcp.Description("If true, Slack markup in message text is expanded: user mentions become @displayname, channel references #name and links their labels. Set to false to get the raw Slack markup. Default is boolean true."),
+			mcp.DefaultBool(true),
+		),
+	), conversationsHandler.MentionsRecentHandler)
+
+	tools.AddTool(mcp.NewTool("conversations_open_threads",
+		mcp.WithDescription("Triage helper: list top-level messages of a channel that are still waiting for an answer, oldest first. A message is waiting when it has no replies, or when responders are given, no reply from any of them."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #support."),
+		),
+		mcp.WithString("lookback",
+			mcp.Description("How far back to look for messages, e.g. 12h, 3d or 2w. Default is 7d, at most 30d."),
+		),
+		mcp.WithString("responders",
+			mcp.Description("Comma-separated user IDs or @handles of the people expected to answer, e.g. the support team. Their own messages are never waiting."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(20),
+			mcp.Min(1),
+			mcp.Max(100),
+			mcp.Description("Maximum number of messages to return, between 1 and 100."),
+		),
+		mcp.WithBoolean("render",
+			mcp.Description("If true, Slack markup in message text is expanded, see conversations_history. Default is boolean true."),
+			mcp.DefaultBool(true),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Team ID in format Txxxxxxxxxx used to resolve channel names and handles that exist in several teams of an Enterprise Grid org. Only needed with org-level tokens."),
+		),
+	), conversationsHandler.ConversationsOpenThreadsHandler)
+
 	channelsHandler := handler.NewChannelsHandler(provider, logger)
 
-	s.AddTool(mcp.NewTool("channels_list",
+	tools.AddTool(mcp.NewTool("channels_list",
 		mcp.WithDescription("Get list of channels"),
 		mcp.WithString("channel_types",
 			mcp.Required(),
 			mcp.Description("Comma-separated channel types. Allowed values: 'mpim', 'im', 'public_channel', 'private_channel'. Example: 'public_channel,private_channel,im'"),
 		),
 		mcp.WithString("sort",
-			mcp.Description("Type of sorting. Allowed values: 'popularity' - sort by number of members/participants in each channel."),
+			mcp.DefaultString("popularity"),
+			mcp.Enum("popularity", "none", "members", "name", "created"),
+			mcp.Description("Type of sorting. Allowed values: 'popularity' - sort the channels of each page by number of members/participants, 'none' - keep the cache order, 'members' - most members first across all pages, 'name' - alphabetically across all pages, 'created' - newest channels first across all pages."),
+		),
+		mcp.WithNumber("min_members",
+			mcp.Min(0),
+			mcp.Description("Only list channels with at least this many members. Filters the cached channels without extra Slack calls."),
+		),
+		mcp.WithNumber("max_members",
+			mcp.Min(0),
+			mcp.Description("Only list channels with at most this many members."),
+		),
+		mcp.WithString("name_contains",
+			mcp.Description("Only list channels whose name contains this text, case-insensitive. Example: 'eng' matches #engineering and #eng-oncall."),
 		),
 		mcp.WithNumber("limit",
 			mcp.DefaultNumber(100),
+			mcp.Min(1),
+			mcp.Max(1000),
 			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 1000 (maximum 999)."), // context fix for cursor: https://github.com/korotovsky/slack-mcp-server/issues/7
 		),
 		mcp.WithString("cursor",
 			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
 		),
+		mcp.WithString("team_id",
+			mcp.Description("Only list channels of this team of an Enterprise Grid org, in format Txxxxxxxxxx. Only useful with org-level tokens."),
+		),
 	), channelsHandler.ChannelsHandler)
 
+	filesHandler := handler.NewFilesHandler(provider, logger)
+
+	tools.AddTool(mcp.NewTool("channels_resolve",
+		mcp.WithDescription("Resolve channel names to channel IDs using the synced channels cache. Returns one row per name with status 'found' or 'not_found', or 'candidate' rows when fuzzy matching is enabled."),
+		mcp.WithString("names",
+			mcp.Required(),
+			mcp.Description("Comma-separated channel names with or without leading #, or DM names starting with @. Example: '#engineering,random,@username_dm'."),
+		),
+		mcp.WithBoolean("fuzzy",
+			mcp.Description("If true, names without an exact match return up to 5 candidate channels whose name starts with or contains the given text. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+	), channelsHandler.ChannelsResolveHandler)
+
+	tools.AddTool(mcp.NewTool("resolve_ids",
+		mcp.WithDescription("Resolve many user and channel IDs to names in one call, e.g. the IDs of a message. Users (U..., W...) and channels (C..., G..., D...) are told apart by prefix, served from the caches and looked up in Slack when missing. Returns one row per ID with status 'found', 'not_found' or 'invalid', the call never fails for a single ID."),
+		mcp.WithString("ids",
+			mcp.Required(),
+			mcp.Description("User and channel IDs separated by commas or spaces, at most 200. Slack markup such as <@U0123ABCD> or <#C0123ABCD|general> is accepted. Example: 'U0123ABCD,C0123ABCD,D0123ABCD'."),
+		),
+	), channelsHandler.ResolveIDsHandler)
+
+	tools.AddTool(mcp.NewTool("files_list",
+		mcp.WithDescription("List files shared in a channel, the last row/column in the response is used as 'cursor' parameter for pagination if not empty"),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with # aka #general."),
+		),
+		mcp.WithString("user",
+			mcp.Description("Only return files uploaded by this user. User ID in format Uxxxxxxxxxx or its handle aka @username."),
+		),
+		mcp.WithString("types",
+			mcp.Description("Comma-separated file types. Allowed values: 'all', 'spaces', 'snippets', 'images', 'gdocs', 'zips', 'pdfs'. Default is 'all'."),
+		),
+		mcp.WithString("date_from",
+			mcp.Description("Only return files created on or after this date. Example: '2025-07-01', 'July 1, 2025'."),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("Only return files created on or before this date. Example: '2025-07-31', 'July 31, 2025'."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(20),
+			mcp.Min(1),
+			mcp.Max(100),
+			mcp.Description("The maximum number of files to return per page. Must be an integer between 1 and 100."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Team ID in format Txxxxxxxxxx used to resolve channel and user names when they exist in several teams of an Enterprise Grid org. Only needed with org-level tokens."),
+		),
+	), filesHandler.FilesListHandler)
+
+	tools.AddTool(mcp.NewTool("files_download",
+		mcp.WithDescription("Get a file's metadata and, for text files such as snippets, CSV or source code, its content. Binary files return metadata and the permalink only."),
+		mcp.WithString("file_id",
+			mcp.Required(),
+			mcp.Description("ID of the file in format Fxxxxxxxxxx, e.g. the fileID column of files_list."),
+		),
+	), filesHandler.FilesDownloadHandler)
+
+	tools.AddTool(mcp.NewTool("search_files",
+		mcp.WithDescription("Search files of the workspace by name, title or content, e.g. to find a document to read with files_download. Returns file metadata and permalinks, the last row/column in the response is used as 'cursor' parameter for pagination if not empty. Requires a user token (xoxp or xoxc/xoxd)."),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("Search query, Slack search modifiers such as in:#channel, from:@user or after:2025-07-01 are supported. Example: 'roadmap' or 'report in:#finance'."),
+		),
+		mcp.WithString("types",
+			mcp.Description("Only return files of these comma-separated file types as shown in the fileType column. Example: 'pdf,csv' or 'png'. The filter applies to each page, so a page may hold fewer files than limit."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(20),
+			mcp.Min(1),
+			mcp.Max(100),
+			mcp.Description("The maximum number of files to return per page. Must be an integer between 1 and 100."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+	), filesHandler.FilesSearchHandler)
+
+	formatHandler := handler.NewFormatHandler(provider, logger)
+
+	tools.AddTool(mcp.NewTool("format_render",
+		mcp.WithDescription("Expand Slack-formatted text into readable text: <@U123> becomes @displayname, <#C123|name> becomes #name, <!here> becomes @here and <https://...|label> becomes label. Names come from the users and channels caches, IDs that can't be resolved are kept as the raw token. Message tools already do this unless called with render=false."),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("Text with Slack markup, e.g. 'ping <@U1234567890> in <#C1234567890>'."),
+		),
+	), formatHandler.FormatRenderHandler)
+
+	emojiHandler := handler.NewEmojiHandler(provider, logger)
+
+	tools.AddTool(mcp.NewTool("emoji_list",
+		mcp.WithDescription("List custom emoji of the workspace. Each row has a kind: 'custom' - uploaded emoji with its URL, 'alias' - alias of a custom emoji with the resolved URL, 'standard_alias' - alias of a standard emoji in aliasFor, 'broken_alias' - alias whose chain is cyclic or never reaches an emoji. Standard emoji themselves are not listed."),
+		mcp.WithString("query",
+			mcp.Description("Only return emoji whose name contains this text. Example: 'party'."),
+		),
+		mcp.WithBoolean("refresh",
+			mcp.Description("If true, invalidate the emoji cache and fetch emoji from Slack again. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+	), emojiHandler.EmojiListHandler)
+
+	teamHandler := handler.NewTeamHandler(provider, logger)
+
+	tools.AddTool(mcp.NewTool("team_info",
+		mcp.WithDescription("Get the Slack workspace (team) the server is connected to: team ID, name, domain, email domain and icon. Use it to confirm which workspace the configured token authenticates to."),
+	), teamHandler.TeamInfoHandler)
+
+	tools.AddTool(mcp.NewTool("auth_whoami",
+		mcp.WithDescription("Get the identity the configured token authenticates as via auth.test: user ID and name, bot ID for bot tokens, team, workspace URL and, for OAuth tokens, the granted scopes. Use it to learn who you act as and to confirm the token works. The result is cached for a few seconds."),
+	), teamHandler.AuthWhoamiHandler)
+
+	cacheHandler := handler.NewCacheHandler(provider, logger)
+
+	tools.AddTool(mcp.NewTool("cache_invalidate",
+		mcp.WithDescription("Refetch a single channel and/or user from Slack and update the cached entry, e.g. after a channel was renamed or a user changed their name. Use it after mutations instead of waiting for a full cache refresh."),
+		mcp.WithString("channel_id",
+			mcp.Description("ID of the channel to refetch in format Cxxxxxxxxxx, Gxxxxxxxxxx or Dxxxxxxxxxx."),
+		),
+		mcp.WithString("user_id",
+			mcp.Description("ID of the user to refetch in format Uxxxxxxxxxx."),
+		),
+	), cacheHandler.CacheInvalidateHandler)
+
+	bookmarksHandler := handler.NewBookmarksHandler(provider, logger)
+
+	tools.AddTool(mcp.NewTool("bookmarks_list",
+		mcp.WithDescription("List bookmarks of a channel."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx."),
+		),
+	), bookmarksHandler.BookmarksListHandler)
+
+	tools.AddTool(mcp.NewTool("bookmarks_add",
+		mcp.WithDescription("Add a link bookmark to a channel and return it."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx."),
+		),
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("Title of the bookmark."),
+		),
+		mcp.WithString("link",
+			mcp.Required(),
+			mcp.Description("Absolute http or https URL of the bookmark."),
+		),
+		mcp.WithString("emoji",
+			mcp.Description("Emoji of the bookmark in format :emoji_name:. Example: ':link:'."),
+		),
+	), bookmarksHandler.BookmarksAddHandler)
+
+	tools.AddTool(mcp.NewTool("bookmarks_remove",
+		mcp.WithDescription("Remove a bookmark from a channel."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx."),
+		),
+		mcp.WithString("bookmark_id",
+			mcp.Required(),
+			mcp.Description("ID of the bookmark as returned by bookmarks_list, e.g. Bk1234567890."),
+		),
+	), bookmarksHandler.BookmarksRemoveHandler)
+
+	usersHandler := handler.NewUsersHandler(provider, logger)
+
+	tools.AddTool(mcp.NewTool("users_set_presence",
+		mcp.WithDescription("Set presence of the authenticated user. Requires a user token, not available for bot tokens."),
+		mcp.WithString("presence",
+			mcp.Required(),
+			mcp.Enum("auto", "away"),
+			mcp.Description("Presence to set. Allowed values: 'auto' - let Slack determine presence based on activity, 'away' - force away presence."),
+		),
+	), usersHandler.UsersSetPresenceHandler)
+
+	tools.AddTool(mcp.NewTool("users_profile_set_status",
+		mcp.WithDescription("Set custom status of the authenticated user and return the resulting status. Requires a user token, not available for bot tokens. Empty status_text and status_emoji clear the status."),
+		mcp.WithString("status_text",
+			mcp.MaxLength(100),
+			mcp.Description("Status text to display, up to 100 characters. Example: 'In a meeting'."),
+		),
+		mcp.WithString("status_emoji",
+			mcp.Description("Status emoji in format :emoji_name:. Example: ':calendar:'."),
+		),
+		mcp.WithString("expiration",
+			mcp.Description("When the status expires. Either a duration (e.g. 30m, 2h, 1d), a unix timestamp or an RFC3339 timestamp. If not provided, the status never expires."),
+		),
+	), usersHandler.UsersProfileSetStatusHandler)
+
+	tools.AddTool(mcp.NewTool("users_local_time",
+		mcp.WithDescription("Get the current local time and UTC offset of a user from the timezone of their Slack profile. Use it before proposing meeting times. If the profile has no timezone, timezoneKnown is false and no local time is returned."),
+		mcp.WithString("user",
+			mcp.Required(),
+			mcp.Description("User ID in format Uxxxxxxxxxx or handle in format @username."),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Team ID in format Txxxxxxxxxx used to resolve a handle within one team of an Enterprise Grid org. Only needed with org-level tokens."),
+		),
+	), usersHandler.UsersLocalTimeHandler)
+
+	tools.AddTool(mcp.NewTool("users_profile_get",
+		mcp.WithDescription("Get the full profile of a user, one row per field. Standard fields such as title, email and phone come first with section 'standard', custom profile fields such as department, location or manager follow with section 'custom', their field ID and label. The alt of a user field, e.g. a manager, holds the @handle of that user. Fields hidden from the token, common with bot tokens, are left out."),
+		mcp.WithString("user",
+			mcp.Required(),
+			mcp.Description("User ID in format Uxxxxxxxxxx or handle in format @username."),
+		),
+		mcp.WithBoolean("include_labels",
+			mcp.DefaultBool(true),
+			mcp.Description("Label custom fields with the names of the workspace profile fields. Without it, or when the token can't read the workspace profile, custom fields are identified by their field ID only."),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Team ID in format Txxxxxxxxxx used to resolve a handle within one team of an Enterprise Grid org. Only needed with org-level tokens."),
+		),
+	), usersHandler.UsersProfileGetHandler)
+
+	tools.AddTool(mcp.NewTool("users_conversations",
+		mcp.WithDescription("List the channels a user is a member of, one page at a time, with channel names resolved. Use it for access audits. Listing the channels of another user may need extra scopes, with a bot token only channels the bot can see are listed."),
+		mcp.WithString("user",
+			mcp.Description("User ID in format Uxxxxxxxxxx or handle in format @username. Defaults to the authenticated user."),
+		),
+		mcp.WithString("channel_types",
+			mcp.DefaultString("public_channel,private_channel"),
+			mcp.Description("Comma-separated channel types. Allowed values: 'mpim', 'im', 'public_channel', 'private_channel'."),
+		),
+		mcp.WithBoolean("include_archived",
+			mcp.DefaultBool(false),
+			mcp.Description("Include archived channels."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(100),
+			mcp.Min(1),
+			mcp.Max(999),
+			mcp.Description("The maximum number of channels to return per page, up to 999."),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Only list channels of this team of an Enterprise Grid org, in format Txxxxxxxxxx. Also used to resolve the handle. Only needed with org-level tokens."),
+		),
+	), usersHandler.UsersConversationsHandler)
+
+	tools.AddTool(mcp.NewTool("users_list_admins",
+		mcp.WithDescription("List the admins and owners of the workspace with their highest role, e.g. to find who to escalate to. Read from the users cache, no Slack API is called. Deactivated users and bots are left out."),
+		mcp.WithString("role",
+			mcp.Description("Comma-separated roles to list: 'admin', 'owner' or 'primary_owner'. Users holding any of them are returned, owners usually hold admin too. Defaults to all roles."),
+		),
+		mcp.WithString("team_id",
+			mcp.Description("Only list users of this team of an Enterprise Grid org, in format Txxxxxxxxxx. Only needed with org-level tokens."),
+		),
+	), usersHandler.UsersListAdminsHandler)
+
+	workflowsHandler := handler.NewWorkflowsHandler(provider, logger)
+
+	tools.AddTool(mcp.NewTool("workflows_list",
+		mcp.WithDescription("List the Slack workflows the token can see with their triggers, one row per trigger: workflow ID and name, trigger ID, name and type (shortcut, event, scheduled, webhook). Requires the triggers:read scope, read-only."),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(100),
+			mcp.Min(1),
+			mcp.Max(1000),
+			mcp.Description("The maximum number of triggers to return per page, up to 1000."),
+		),
+	), workflowsHandler.WorkflowsListHandler)
+
 	logger.Info("Authenticating with Slack API...",
 		zap.String("context", "console"),
 	)
@@ -226,18 +1122,76 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 		mcp.WithMIMEType("text/csv"),
 	), conversationsHandler.UsersResource)
 
+	s.AddResourceTemplate(mcp.NewResourceTemplate(
+		handler.ChannelResourceURITemplate(ws),
+		"Slack channel history",
+		mcp.WithTemplateDescription("This resource provides the recent messages of a Slack channel, DM or group DM by its ID."),
+		mcp.WithTemplateMIMEType("text/csv"),
+	), conversationsHandler.ChannelResource)
+
+	// read-only mode is applied once at startup by readOnlyOption, the enabled tools can change on reload
+	readOnly := IsReadOnlyEnabled()
+	promptsHandler := handler.NewPromptsHandler(provider, func(name string) bool {
+		return !(readOnly && writeTools[name]) && tools.Enabled(name)
+	}, logger)
+
+	s.AddPrompt(mcp.NewPrompt("summarize_channel",
+		mcp.WithPromptDescription("Summarize the recent activity of a channel: topics, decisions, open questions and action items."),
+		mcp.WithArgument("channel",
+			mcp.ArgumentDescription("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+			mcp.RequiredArgument(),
+		),
+		mcp.WithArgument("lookback",
+			mcp.ArgumentDescription("How far back to look in whole days or weeks, e.g. 1d, 3d or 2w. Default is 1d."),
+		),
+	), promptsHandler.SummarizeChannelPrompt)
+
+	s.AddPrompt(mcp.NewPrompt("summarize_thread",
+		mcp.WithPromptDescription("Summarize a thread: what was asked, the answers and decisions, and what is still open."),
+		mcp.WithArgument("channel",
+			mcp.ArgumentDescription("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+			mcp.RequiredArgument(),
+		),
+		mcp.WithArgument("thread_ts",
+			mcp.ArgumentDescription("Timestamp of the thread parent in format 1234567890.123456."),
+			mcp.RequiredArgument(),
+		),
+	), promptsHandler.SummarizeThreadPrompt)
+
+	s.AddPrompt(mcp.NewPrompt("draft_thread_reply",
+		mcp.WithPromptDescription("Draft a reply to a thread for review, it is only posted once approved."),
+		mcp.WithArgument("channel",
+			mcp.ArgumentDescription("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+			mcp.RequiredArgument(),
+		),
+		mcp.WithArgument("thread_ts",
+			mcp.ArgumentDescription("Timestamp of the thread parent in format 1234567890.123456."),
+			mcp.RequiredArgument(),
+		),
+		mcp.WithArgument("intent",
+			mcp.ArgumentDescription("What the reply should say or achieve, e.g. 'agree and propose Friday for the release'."),
+		),
+	), promptsHandler.DraftThreadReplyPrompt)
+
 	// Initialize health checker if enabled
 	var healthChecker *HealthChecker
 	if IsHealthCheckEnabled() {
 		healthChecker = NewHealthChecker(provider, logger)
 	}
 
-	return &MCPServer{
-		server:        s,
-		logger:        logger,
-		provider:      provider,
-		healthChecker: healthChecker,
+	ms := &MCPServer{
+		server:          s,
+		logger:          logger,
+		provider:        provider,
+		healthChecker:   healthChecker,
+		tools:           tools,
+		startupEnv:      reloadableEnv(),
+		workspace:       ws,
+		channelResource: conversationsHandler.ChannelResource,
+		readiness:       readiness,
 	}
+	provider.OnChannelsChanged(ms.SyncChannelResources)
+	return ms
 }
 
 func (s *MCPServer) ServeSSE(addr string) *server.SSEServer {
@@ -275,6 +1229,7 @@ func (s *MCPServer) ServeSSE(addr string) *server.SSEServer {
 	
 	return server.NewSSEServer(s.server,
 		server.WithBaseURL(baseURL),
+		server.WithStaticBasePath(SSEPathPrefix()),
 		server.WithSSEContextFunc(contextFunc),
 	)
 }
@@ -283,12 +1238,22 @@ func (s *MCPServer) ServeSSE(addr string) *server.SSEServer {
 func (s *MCPServer) ServeSSEWithHealthChecks(addr string) *EnhancedSSEServer {
 	sseServer := s.ServeSSE(addr)
 	securityMiddleware := middleware.NewSecurityMiddleware(s.logger)
+	s.reloadMu.Lock()
+	s.security = securityMiddleware
+	s.reloadMu.Unlock()
+	connections := newConnectionLimiter(s.logger)
+	if s.healthChecker != nil {
+		s.healthChecker.connections = connections
+	}
 	
 	return &EnhancedSSEServer{
 		sseServer:          sseServer,
 		healthChecker:      s.healthChecker,
 		logger:             s.logger,
 		securityMiddleware: securityMiddleware,
+		connections:        connections,
+		reload:             s.Reload,
+		readiness:          s.readiness,
 	}
 }
 
@@ -298,6 +1263,15 @@ type EnhancedSSEServer struct {
 	healthChecker    *HealthChecker
 	logger           *zap.Logger
 	securityMiddleware *middleware.SecurityMiddleware
+	connections      *connectionLimiter
+	// reload serves POST /reload when SLACK_MCP_RELOAD_ENDPOINT is enabled
+	reload func() ([]ConfigChange, error)
+	// readiness holds tool call posts during warmup, nil unless SLACK_MCP_QUEUE_UNTIL_READY is enabled
+	readiness *readinessGate
+
+	// httpServer is set by Start and stopped by Shutdown
+	httpMu     sync.Mutex
+	httpServer *http.Server
 }
 
 // Start starts the enhanced SSE server with health check endpoints
@@ -337,25 +1311,139 @@ func (e *EnhancedSSEServer) Start(addr string) error {
 		)
 	}
 
+	// Create HTTP server with enhanced configuration
+	server := &http.Server{
+		Addr:    addr,
+		Handler: e.Handler(),
+		// Add timeouts for better security and resource management
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+		IdleTimeout:  120 * time.Second,
+	}
+	e.httpMu.Lock()
+	e.httpServer = server
+	e.httpMu.Unlock()
+
+	// Log server startup with detailed configuration
+	e.logger.Info("HTTP server starting",
+		zap.String("context", "console"),
+		zap.String("address", addr),
+		zap.Duration("read_timeout", server.ReadTimeout),
+		zap.Duration("write_timeout", server.WriteTimeout),
+		zap.Duration("idle_timeout", server.IdleTimeout),
+	)
+
+	// Start the server and handle potential binding errors, ErrServerClosed means Shutdown was called
+	err = server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	if err != nil {
+		// Enhanced error logging for network binding issues
+		if strings.Contains(err.Error(), "bind") || strings.Contains(err.Error(), "address already in use") {
+			e.logger.Error("Failed to bind to address - port may be in use or IPv6 unavailable",
+				zap.String("context", "console"),
+				zap.String("address", addr),
+				zap.String("host", host),
+				zap.String("port", port),
+				zap.Error(err),
+			)
+		} else {
+			e.logger.Error("HTTP server error",
+				zap.String("context", "console"),
+				zap.String("address", addr),
+				zap.Error(err),
+			)
+		}
+	}
+	
+	return err
+}
+
+// Shutdown stops accepting connections and waits for open requests until ctx is done. Open SSE streams
+// never finish on their own, they are cut when ctx is done.
+func (e *EnhancedSSEServer) Shutdown(ctx context.Context) error {
+	e.httpMu.Lock()
+	server := e.httpServer
+	e.httpMu.Unlock()
+	if server == nil {
+		return nil
+	}
+
+	err := server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		e.logger.Warn("Shutdown timed out, closing open connections",
+			zap.String("context", "console"),
+		)
+		return server.Close()
+	}
+	return err
+}
+
+// Handler builds the HTTP handler with health check routes, optional pprof routes,
+// the SSE server and the security middleware applied on top
+func (e *EnhancedSSEServer) Handler() http.Handler {
 	// Create a custom HTTP server with health check routes and security middleware
 	mux := http.NewServeMux()
 	
-	// Add health check endpoints if enabled
+	// Add health check endpoints if enabled, under SLACK_MCP_HEALTH_PATH_PREFIX
+	healthPath, readyPath, livePath := healthPaths(HealthPathPrefix())
 	if e.healthChecker != nil {
-		mux.HandleFunc("/health", e.healthChecker.HealthHandler)
-		mux.HandleFunc("/health/ready", e.healthChecker.ReadinessHandler)
-		mux.HandleFunc("/health/live", e.healthChecker.LivenessHandler)
+		mux.HandleFunc(healthPath, e.healthChecker.HealthHandler)
+		mux.HandleFunc(readyPath, e.healthChecker.ReadinessHandler)
+		mux.HandleFunc(livePath, e.healthChecker.LivenessHandler)
 		
 		e.logger.Info("Health check endpoints enabled",
 			zap.String("context", "console"),
-			zap.Strings("endpoints", []string{"/health", "/health/ready", "/health/live"}),
+			zap.Strings("endpoints", []string{healthPath, readyPath, livePath}),
 		)
 	}
 	
+	// Build information is always available to identify the deployed artifact
+	mux.HandleFunc("/build-info", buildInfoHandler(e.logger))
+
+	// Cache refresh counters for Prometheus are opt-in, empty without a provider
+	if IsMetricsEnabled() {
+		var cacheStats func() provider.CacheStats
+		if e.healthChecker != nil && e.healthChecker.provider != nil {
+			cacheStats = e.healthChecker.provider.CacheStats
+		}
+		registerMetricsHandler(mux, cacheStats, e.logger)
+	}
+
+	// Reloading the configuration over HTTP is opt-in and always requires the API key
+	if IsReloadEndpointEnabled() && e.reload != nil {
+		e.registerReloadHandler(mux)
+	}
+
+	// Add pprof endpoints only when explicitly enabled
+	if IsPprofEnabled() {
+		registerPprofHandlers(mux, e.logger)
+	}
+
+	// SSE streams count against SLACK_MCP_MAX_CONNECTIONS, message posts are not limited here
+	var streamHandler http.Handler
+	if e.connections != nil {
+		streamHandler = e.connections.Handler(e.sseServer, func(w http.ResponseWriter, r *http.Request) {
+			e.writeStandardErrorResponse(w, r, http.StatusServiceUnavailable, "TOO_MANY_CONNECTIONS",
+				"Too many connections", fmt.Sprintf("The server accepts at most %d concurrent SSE connections, see SLACK_MCP_MAX_CONNECTIONS", e.connections.max))
+		})
+	}
+
+	// Tool calls posted during warmup are answered once the readiness queue admitted them, or with 503
+	var messageHandler http.Handler
+	if e.readiness != nil {
+		messageHandler = holdWarmupToolCalls(e.readiness.isReady, e.sseServer, func(w http.ResponseWriter, r *http.Request, err error) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(readinessRetryAfter.Seconds())))
+			e.writeStandardErrorResponse(w, r, http.StatusServiceUnavailable, handler.ErrCodeCacheNotReady,
+				"Server is warming up its caches", handler.AsToolError(err).Message)
+		})
+	}
+
 	// Add the SSE server handler for all other routes with error handling
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		// Check if this is a health check endpoint
-		if e.healthChecker != nil && (r.URL.Path == "/health" || r.URL.Path == "/health/ready" || r.URL.Path == "/health/live") {
+		if e.healthChecker != nil && (r.URL.Path == healthPath || r.URL.Path == readyPath || r.URL.Path == livePath) {
 			// These are handled by the specific handlers above
 			return
 		}
@@ -363,7 +1451,7 @@ func (e *EnhancedSSEServer) Start(addr string) error {
 		// Wrap the SSE server with error handling
 		defer func() {
 			if err := recover(); err != nil {
-				e.logger.Error("SSE server panic recovered",
+				requestid.Logger(r.Context(), e.logger).Error("SSE server panic recovered",
 					zap.Any("error", err),
 					zap.String("path", r.URL.Path),
 					zap.String("method", r.Method),
@@ -374,11 +1462,26 @@ func (e *EnhancedSSEServer) Start(addr string) error {
 		}()
 		
 		// For all other requests, delegate to the SSE server
+		if streamHandler != nil && r.Method == http.MethodGet && r.URL.Path == e.sseServer.CompleteSsePath() {
+			streamHandler.ServeHTTP(w, r)
+			return
+		}
+		if messageHandler != nil && r.URL.Path == e.sseServer.CompleteMessagePath() {
+			messageHandler.ServeHTTP(w, r)
+			return
+		}
 		e.sseServer.ServeHTTP(w, r)
 	})
 
 	// Apply security middleware to the entire handler chain
 	var handler http.Handler = mux
+	if middleware.IsCompressionEnabled() {
+		handler = middleware.Compression(handler)
+		e.logger.Info("Response compression enabled",
+			zap.String("context", "console"),
+			zap.Strings("encodings", []string{"gzip", "deflate"}),
+		)
+	}
 	if e.securityMiddleware != nil {
 		handler = e.securityMiddleware.Handler(handler)
 		e.logger.Info("Security middleware enabled",
@@ -389,47 +1492,22 @@ func (e *EnhancedSSEServer) Start(addr string) error {
 		)
 	}
 
-	// Create HTTP server with enhanced configuration
-	server := &http.Server{
-		Addr:    addr,
-		Handler: handler,
-		// Add timeouts for better security and resource management
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
-		IdleTimeout:  120 * time.Second,
+	// Log requests outside the security middleware so rate limited and rejected requests are logged too
+	if middleware.IsAccessLogEnabled() {
+		var clientIP func(*http.Request) string
+		if e.securityMiddleware != nil {
+			clientIP = e.securityMiddleware.ClientIP
+		}
+		handler = middleware.AccessLog(e.logger, clientIP, handler)
+		e.logger.Info("Access log enabled",
+			zap.String("context", "console"),
+		)
 	}
 
-	// Log server startup with detailed configuration
-	e.logger.Info("HTTP server starting",
-		zap.String("context", "console"),
-		zap.String("address", addr),
-		zap.Duration("read_timeout", server.ReadTimeout),
-		zap.Duration("write_timeout", server.WriteTimeout),
-		zap.Duration("idle_timeout", server.IdleTimeout),
-	)
+	// Assign request IDs outermost so every log line of the request, including the security middleware, carries it
+	handler = requestid.Middleware(handler)
 
-	// Start the server and handle potential binding errors
-	err = server.ListenAndServe()
-	if err != nil {
-		// Enhanced error logging for network binding issues
-		if strings.Contains(err.Error(), "bind") || strings.Contains(err.Error(), "address already in use") {
-			e.logger.Error("Failed to bind to address - port may be in use or IPv6 unavailable",
-				zap.String("context", "console"),
-				zap.String("address", addr),
-				zap.String("host", host),
-				zap.String("port", port),
-				zap.Error(err),
-			)
-		} else {
-			e.logger.Error("HTTP server error",
-				zap.String("context", "console"),
-				zap.String("address", addr),
-				zap.Error(err),
-			)
-		}
-	}
-	
-	return err
+	return handler
 }
 
 func (s *MCPServer) ServeStdio() error {
@@ -439,6 +1517,10 @@ func (s *MCPServer) ServeStdio() error {
 		zap.String("commit_hash", version.CommitHash),
 	)
 	err := server.ServeStdio(s.server)
+	if errors.Is(err, context.Canceled) {
+		// stopped by SIGINT or SIGTERM
+		return nil
+	}
 	if err != nil {
 		s.logger.Error("STDIO server error", zap.Error(err))
 	}
@@ -448,6 +1530,7 @@ func (s *MCPServer) ServeStdio() error {
 func buildLoggerMiddleware(logger *zap.Logger) server.ToolHandlerMiddleware {
 	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
 		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			logger := requestid.Logger(ctx, logger)
 			logger.Info("Request received",
 				zap.String("tool", req.Params.Name),
 				zap.Any("params", req.Params),
@@ -469,11 +1552,40 @@ func buildLoggerMiddleware(logger *zap.Logger) server.ToolHandlerMiddleware {
 	}
 }
 
+// buildErrorMiddleware turns tool errors into results carrying a stable error code,
+// serialized with the same JSON shape as HTTP error responses
+func buildErrorMiddleware(logger *zap.Logger) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			res, err := next(ctx, req)
+			if err == nil {
+				return res, nil
+			}
+
+			toolErr := handler.AsToolError(err)
+			requestid.Logger(ctx, logger).Warn("Tool call failed",
+				zap.String("tool", req.Params.Name),
+				zap.String("code", toolErr.Code),
+				zap.Error(err),
+			)
+
+			return handler.ToolErrorResult(err), nil
+		}
+	}
+}
+
 // determineBaseURL determines the appropriate base URL for the SSE server
-// considering Railway deployment and IPv6 address formatting
+// considering Railway deployment and IPv6 address formatting. The SSE path prefix
+// is added by the SSE server, so a base URL already ending with it is trimmed.
 func (s *MCPServer) determineBaseURL(addr string) string {
 	// Check for Railway-specific base URL configuration
 	if baseURL := os.Getenv("SLACK_MCP_BASE_URL"); baseURL != "" {
+		if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+			if path := strings.TrimSuffix(u.Path, "/"); SSEPathPrefix() != "" && strings.HasSuffix(path, SSEPathPrefix()) {
+				u.Path = strings.TrimSuffix(path, SSEPathPrefix())
+				return u.String()
+			}
+		}
 		return baseURL
 	}
 
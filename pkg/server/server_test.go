@@ -1,9 +1,12 @@
 package server
 
 import (
+	"net/http/httptest"
 	"os"
 	"testing"
 
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+
 	"go.uber.org/zap"
 )
 
@@ -87,4 +90,24 @@ func TestDetermineBaseURL(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestHandlerEchoesRequestID(t *testing.T) {
+	handler := newTestEnhancedSSEServer().Handler()
+
+	req := httptest.NewRequest("GET", "/build-info", nil)
+	req.Header.Set(requestid.Header, "trace-42")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get(requestid.Header); got != "trace-42" {
+		t.Errorf("Expected inbound request ID to be echoed, got %q", got)
+	}
+
+	req = httptest.NewRequest("GET", "/build-info", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get(requestid.Header); got == "" {
+		t.Error("Expected a generated request ID in the response")
+	}
+}
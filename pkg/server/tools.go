@@ -0,0 +1,243 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+// ToolNames lists every tool NewMCPServer can register, SLACK_MCP_ENABLED_TOOLS is validated against it
+var ToolNames = []string{
+	"conversations_history",
+	"conversations_replies",
+	"conversations_add_message",
+	"conversations_post_ephemeral",
+	"conversations_acknowledge",
+	"conversations_await_response",
+	"conversations_post_interactive",
+	"conversations_await_interaction",
+	"conversations_broadcast",
+	"conversations_post_with_file",
+	"conversations_set_topic",
+	"conversations_set_purpose",
+	"conversations_mark",
+	"conversations_get_prefs",
+	"conversations_set_prefs",
+	"conversations_open",
+	"conversations_members",
+	"conversations_list_dms",
+	"conversations_unread_counts",
+	"conversations_scheduled_messages_list",
+	"conversations_search_messages",
+	"chat_resolve_permalink",
+	"chat_unfurl_preview",
+	"conversations_canvas_get",
+	"canvases_sections_list",
+	"conversations_summary",
+	"conversations_latest",
+	"conversations_analytics",
+	"reactions_leaderboard",
+	"mentions_recent",
+	"conversations_open_threads",
+	"channels_list",
+	"channels_resolve",
+	"resolve_ids",
+	"files_list",
+	"files_download",
+	"search_files",
+	"format_render",
+	"emoji_list",
+	"team_info",
+	"auth_whoami",
+	"cache_invalidate",
+	"bookmarks_list",
+	"bookmarks_add",
+	"bookmarks_remove",
+	"users_set_presence",
+	"users_profile_set_status",
+	"users_local_time",
+	"users_profile_get",
+	"users_conversations",
+	"users_list_admins",
+	"workflows_list",
+}
+
+// ParseEnabledTools parses SLACK_MCP_ENABLED_TOOLS, a comma separated list of tool names to register,
+// or of ! prefixed names to leave out. An empty value enables every tool and returns nil.
+func ParseEnabledTools(config string) (map[string]bool, error) {
+	if strings.TrimSpace(config) == "" {
+		return nil, nil
+	}
+
+	known := make(map[string]bool, len(ToolNames))
+	for _, name := range ToolNames {
+		known[name] = true
+	}
+
+	listed := make(map[string]bool)
+	var unknown []string
+	hasNegated, hasPositive := false, false
+	for _, item := range strings.Split(config, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name := strings.TrimPrefix(item, "!")
+		if name != item {
+			hasNegated = true
+		} else {
+			hasPositive = true
+		}
+		if !known[name] {
+			unknown = append(unknown, name)
+			continue
+		}
+		listed[name] = true
+	}
+
+	if hasNegated && hasPositive {
+		return nil, fmt.Errorf("cannot mix enabled and disabled (! prefixed) tools")
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown tools %s, known tools: %s", strings.Join(unknown, ", "), strings.Join(ToolNames, ", "))
+	}
+
+	enabled := make(map[string]bool, len(ToolNames))
+	for _, name := range ToolNames {
+		if listed[name] != hasNegated {
+			enabled[name] = true
+		}
+	}
+	return enabled, nil
+}
+
+// toolRegistrar adds tools to the MCP server unless SLACK_MCP_ENABLED_TOOLS leaves them out,
+// calls are validated against the input schema of the tool before its handler runs
+type toolRegistrar struct {
+	s       *server.MCPServer
+	mu      sync.RWMutex
+	enabled map[string]bool
+	logger  *zap.Logger
+	// all keeps every tool in registration order, disabled ones included, so that SetEnabled can add them later
+	all []server.ServerTool
+}
+
+func (r *toolRegistrar) AddTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	entry := server.ServerTool{Tool: tool, Handler: validatedHandler(tool, handler)}
+	r.all = append(r.all, entry)
+	if !r.Enabled(tool.Name) {
+		r.logger.Debug("Tool disabled by SLACK_MCP_ENABLED_TOOLS", zap.String("tool", tool.Name))
+		return
+	}
+	r.s.AddTools(entry)
+}
+
+// Enabled reports whether SLACK_MCP_ENABLED_TOOLS registers the tool name
+func (r *toolRegistrar) Enabled(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.enabled == nil || r.enabled[name]
+}
+
+// SetEnabled applies a new set of enabled tools as returned by ParseEnabledTools and returns the tools
+// it added and removed, the MCP server notifies connected clients that the tool list changed
+func (r *toolRegistrar) SetEnabled(enabled map[string]bool) (added, removed []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var entries []server.ServerTool
+	for _, entry := range r.all {
+		name := entry.Tool.Name
+		was := r.enabled == nil || r.enabled[name]
+		is := enabled == nil || enabled[name]
+		switch {
+		case is && !was:
+			entries = append(entries, entry)
+			added = append(added, name)
+		case was && !is:
+			removed = append(removed, name)
+		}
+	}
+	r.enabled = enabled
+
+	if len(removed) > 0 {
+		r.s.DeleteTools(removed...)
+	}
+	if len(entries) > 0 {
+		r.s.AddTools(entries...)
+	}
+	return added, removed
+}
+
+// ValidateDefaultChannel validates SLACK_MCP_DEFAULT_CHANNEL, a single channel ID such as C1234567890
+// or a name starting with # or @
+func ValidateDefaultChannel(channel string) error {
+	channel = strings.TrimSpace(channel)
+	if channel == "" {
+		return nil
+	}
+	if strings.ContainsAny(channel, ", ") {
+		return fmt.Errorf("must be a single channel, got %q", channel)
+	}
+	if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
+		if len(channel) == 1 {
+			return fmt.Errorf("channel name is empty, got %q", channel)
+		}
+		return nil
+	}
+	if len(channel) < 2 || !strings.ContainsRune("CDG", rune(channel[0])) || strings.ToUpper(channel) != channel {
+		return fmt.Errorf("must be a channel ID such as C1234567890 or a name such as #general, got %q", channel)
+	}
+	return nil
+}
+
+// ValidateAppToken validates SLACK_MCP_APP_TOKEN, an app-level token for Socket Mode starting with xapp-
+func ValidateAppToken(token string) error {
+	token = strings.TrimSpace(token)
+	if token != "" && !strings.HasPrefix(token, "xapp-") {
+		return errors.New("must be an app-level token starting with xapp-")
+	}
+	return nil
+}
+
+// ValidateReadChannels validates SLACK_MCP_READ_CHANNELS, a channel list like SLACK_MCP_ADD_MESSAGE_TOOL
+func ValidateReadChannels(config string) error {
+	return ValidateAddMessageTool(config)
+}
+
+// ValidateAddMessageTool validates SLACK_MCP_ADD_MESSAGE_TOOL, a list of channels must not mix allowed
+// and ! prefixed disallowed channels
+func ValidateAddMessageTool(config string) error {
+	if config == "" || config == "true" || config == "1" {
+		return nil
+	}
+
+	items := strings.Split(config, ",")
+	hasNegated := false
+	hasPositive := false
+
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if strings.HasPrefix(item, "!") {
+			hasNegated = true
+		} else {
+			hasPositive = true
+		}
+	}
+
+	if hasNegated && hasPositive {
+		return fmt.Errorf("cannot mix allowed and disallowed (! prefixed) channels")
+	}
+
+	return nil
+}
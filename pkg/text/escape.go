@@ -0,0 +1,23 @@
+package text
+
+import (
+	"regexp"
+)
+
+// specialMentionRe matches <!...> control sequences such as <!here>, <!channel|channel> or <!subteam^S123>
+var specialMentionRe = regexp.MustCompile(`<(![^<>]*)>`)
+
+// bareBroadcastRe matches @channel, @here and @everyone written as plain words, which Slack links when link_names is set
+var bareBroadcastRe = regexp.MustCompile(`(?i)(^|[^\w@])@(channel|here|everyone)\b`)
+
+// zeroWidthSpace breaks a bare broadcast mention without changing how the text looks
+const zeroWidthSpace = "\u200b"
+
+// EscapeMentions neutralizes text that would notify a whole channel or group when posted: <!...> control
+// sequences get their angle brackets escaped so Slack shows them literally, and bare @channel, @here and
+// @everyone get a zero width space after the @. User mentions <@U123>, channel links <#C123> and URLs
+// are kept.
+func EscapeMentions(s string) string {
+	s = specialMentionRe.ReplaceAllString(s, "&lt;$1&gt;")
+	return bareBroadcastRe.ReplaceAllString(s, "$1@"+zeroWidthSpace+"$2")
+}
@@ -0,0 +1,52 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestEscapeMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "special mention sequences",
+			text: "<!here> deploy is done, <!channel|channel> and <!everyone>",
+			want: "&lt;!here&gt; deploy is done, &lt;!channel|channel&gt; and &lt;!everyone&gt;",
+		},
+		{
+			name: "user group mention",
+			text: "ping <!subteam^S0123|@oncall>",
+			want: "ping &lt;!subteam^S0123|@oncall&gt;",
+		},
+		{
+			name: "bare broadcast words",
+			text: "@here the build is red, @Channel please look. (@everyone)",
+			want: "@\u200bhere the build is red, @\u200bChannel please look. (@\u200beveryone)",
+		},
+		{
+			name: "adjacent mentions",
+			text: "@here @channel",
+			want: "@\u200bhere @\u200bchannel",
+		},
+		{
+			name: "user and channel references are kept",
+			text: "<@U0123> see <#C0123|general> and <https://example.com|the docs>",
+			want: "<@U0123> see <#C0123|general> and <https://example.com|the docs>",
+		},
+		{
+			name: "look-alikes are kept",
+			text: "mail ops@here.example.com or @channels and @hereafter",
+			want: "mail ops@here.example.com or @channels and @hereafter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EscapeMentions(tt.text); got != tt.want {
+				t.Errorf("EscapeMentions(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package text
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Resolver returns the name of a Slack user or channel ID, ok is false when the ID is unknown
+type Resolver func(id string) (name string, ok bool)
+
+var markupRe = regexp.MustCompile(`<([^<>\s][^<>]*)>`)
+
+var markupEntities = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+
+// RenderMarkup expands Slack markup into readable text: <@U123> becomes @displayname, <#C123|name>
+// becomes #name, <!here> becomes @here and <https://example.com|label> becomes label. Mentions
+// that neither resolve nor carry a label are kept as the raw token.
+func RenderMarkup(s string, users, channels Resolver) string {
+	rendered := markupRe.ReplaceAllStringFunc(s, func(token string) string {
+		inner := token[1 : len(token)-1]
+		target, label, _ := strings.Cut(inner, "|")
+
+		switch {
+		case strings.HasPrefix(target, "@"):
+			if users != nil {
+				if name, ok := users(target[1:]); ok && name != "" {
+					return "@" + strings.TrimPrefix(name, "@")
+				}
+			}
+			if label != "" {
+				return "@" + strings.TrimPrefix(label, "@")
+			}
+		case strings.HasPrefix(target, "#"):
+			if channels != nil {
+				if name, ok := channels(target[1:]); ok && name != "" {
+					if strings.HasPrefix(name, "#") || strings.HasPrefix(name, "@") {
+						return name
+					}
+					return "#" + name
+				}
+			}
+			if label != "" {
+				return "#" + strings.TrimPrefix(label, "#")
+			}
+		case strings.HasPrefix(target, "!"):
+			switch target {
+			case "!here", "!channel", "!everyone":
+				return "@" + target[1:]
+			}
+			// e.g. <!subteam^S123|@team> or <!date^1392734382^{date}|fallback>
+			if label != "" {
+				return label
+			}
+		default:
+			if label != "" {
+				return label
+			}
+			return target
+		}
+		return token
+	})
+	return markupEntities.Replace(rendered)
+}
+
+// ProcessRenderedText is ProcessText with the Slack markup tokens rendered by RenderMarkup. The tokens are set
+// aside while the rest of the text is cleaned up, so text without markup comes out exactly as ProcessText returns it.
+func ProcessRenderedText(s string, users, channels Resolver) string {
+	var rendered []string
+	protected := markupRe.ReplaceAllStringFunc(s, func(token string) string {
+		rendered = append(rendered, strings.Join(strings.Fields(RenderMarkup(token, users, channels)), " "))
+		return markupPlaceholder(len(rendered) - 1)
+	})
+
+	cleaned := ProcessText(protected)
+	for i, token := range rendered {
+		cleaned = strings.Replace(cleaned, markupPlaceholder(i), token, 1)
+	}
+	return cleaned
+}
+
+func markupPlaceholder(i int) string {
+	return "___MARKUP_PLACEHOLDER_" + strconv.Itoa(i) + "___"
+}
+
+// MarkupReferences returns the user IDs mentioned in s and the channel IDs referenced without a label,
+// i.e. the IDs RenderMarkup needs to resolve
+func MarkupReferences(s string) (users, channels []string) {
+	for _, m := range markupRe.FindAllStringSubmatch(s, -1) {
+		target, label, _ := strings.Cut(m[1], "|")
+		switch {
+		case strings.HasPrefix(target, "@") && len(target) > 1:
+			users = append(users, target[1:])
+		case strings.HasPrefix(target, "#") && len(target) > 1 && label == "":
+			channels = append(channels, target[1:])
+		}
+	}
+	return users, channels
+}
@@ -0,0 +1,106 @@
+package text
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenderMarkup(t *testing.T) {
+	users := func(id string) (string, bool) {
+		if id == "U123" {
+			return "alice", true
+		}
+		return "", false
+	}
+	channels := func(id string) (string, bool) {
+		switch id {
+		case "C123":
+			return "#general", true
+		case "D123":
+			return "@alice", true
+		}
+		return "", false
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"user mention", "hi <@U123>!", "hi @alice!"},
+		{"user mention with label", "hi <@U123|al>", "hi @alice"},
+		{"unknown user", "hi <@U999>", "hi <@U999>"},
+		{"unknown user with label", "hi <@U999|bob>", "hi @bob"},
+		{"channel reference", "see <#C123|old-name>", "see #general"},
+		{"dm reference", "see <#D123>", "see @alice"},
+		{"unknown channel with label", "see <#C999|random>", "see #random"},
+		{"unknown channel", "see <#C999>", "see <#C999>"},
+		{"link with label", "read <https://example.com/a?b=1|the docs>", "read the docs"},
+		{"bare link", "read <https://example.com>", "read https://example.com"},
+		{"special mention", "<!here> deploy", "@here deploy"},
+		{"user group", "ping <!subteam^S123|@oncall>", "ping @oncall"},
+		{"unknown special", "<!foo>", "<!foo>"},
+		{"entities", "a &lt; b &amp;&amp; c &gt; d", "a < b && c > d"},
+		{"plain text", "nothing to do", "nothing to do"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkup(tt.input, users, channels); got != tt.expected {
+				t.Errorf("RenderMarkup(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderMarkupWithoutResolvers(t *testing.T) {
+	if got := RenderMarkup("<@U123> in <#C123>", nil, nil); got != "<@U123> in <#C123>" {
+		t.Errorf("Expected raw tokens without resolvers, got %q", got)
+	}
+}
+
+func TestMarkupReferences(t *testing.T) {
+	users, channels := MarkupReferences("<@U123> asked <@U456|bob> about <#C123> and <#C456|general>, see <!here> <https://example.com|docs>")
+	if !reflect.DeepEqual(users, []string{"U123", "U456"}) {
+		t.Errorf("Expected mentioned users, got %v", users)
+	}
+	if !reflect.DeepEqual(channels, []string{"C123"}) {
+		t.Errorf("Expected unlabeled channel references only, got %v", channels)
+	}
+}
+
+func TestProcessRenderedText(t *testing.T) {
+	users := func(id string) (string, bool) { return "alice", id == "U123" }
+
+	// text without markup is cleaned up byte for byte like ProcessText
+	for _, input := range []string{
+		"plain text",
+		"issue #42 for @bob, costs $5 (approx.) & more!",
+		"a &lt; b &amp;&amp; c &gt; d",
+		"see [the docs](https://example.com/docs) and https://example.com/x?y=1",
+		"  spaced\n\nout\ttext  ",
+		"émoji 🎉 and ünïcode",
+	} {
+		if got, want := ProcessRenderedText(input, users, nil), ProcessText(input); got != want {
+			t.Errorf("ProcessRenderedText(%q) = %q, expected the ProcessText output %q", input, got, want)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"user mention", "hi <@U123>!", "hi @alice"},
+		{"unknown user", "hi <@U999> there", "hi <@U999> there"},
+		{"link with label", "read <https://example.com|the docs> now", "read the docs now"},
+		{"several tokens", "<@U123> see <#C1|ops> (today)", "@alice see #ops today"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ProcessRenderedText(tt.input, users, nil); got != tt.expected {
+				t.Errorf("ProcessRenderedText(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
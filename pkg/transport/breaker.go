@@ -0,0 +1,58 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"go.uber.org/zap"
+)
+
+// BreakerTransport wraps another RoundTripper with a circuit breaker. Network errors and
+// 5xx responses count as failures, any other response proves Slack is reachable.
+type BreakerTransport struct {
+	roundTripper http.RoundTripper
+	breaker      *limiter.Breaker
+	logger       *zap.Logger
+}
+
+// NewBreakerTransport creates a new BreakerTransport
+func NewBreakerTransport(roundTripper http.RoundTripper, breaker *limiter.Breaker, logger *zap.Logger) *BreakerTransport {
+	return &BreakerTransport{
+		roundTripper: roundTripper,
+		breaker:      breaker,
+		logger:       logger,
+	}
+}
+
+// RoundTrip implements the RoundTripper interface
+func (t *BreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.roundTripper.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		t.breaker.Cancel()
+	case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+		if t.breaker.Failure() {
+			fields := []zap.Field{
+				zap.String("url", req.URL.String()),
+				zap.Int("threshold", t.breaker.Threshold()),
+				zap.Duration("cooldown", t.breaker.Cooldown()),
+			}
+			if err != nil {
+				fields = append(fields, zap.Error(err))
+			} else {
+				fields = append(fields, zap.Int("status", resp.StatusCode))
+			}
+			requestid.Logger(req.Context(), t.logger).Error("Slack API circuit breaker opened, calls fail fast", fields...)
+		}
+	default:
+		if t.breaker.Success() {
+			requestid.Logger(req.Context(), t.logger).Info("Slack API circuit breaker closed, Slack is reachable again")
+		}
+	}
+	return resp, err
+}
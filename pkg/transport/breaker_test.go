@@ -0,0 +1,55 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
+	"go.uber.org/zap"
+)
+
+func TestBreakerTransport(t *testing.T) {
+	var calls atomic.Int64
+	status := http.StatusServiceUnavailable
+	inner := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	})
+
+	breaker := limiter.NewBreaker(2, 20*time.Millisecond)
+	client := &http.Client{Transport: NewBreakerTransport(inner, breaker, zap.NewNop())}
+
+	get := func() error {
+		resp, err := client.Get("http://slack.test/api/auth.test")
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := get(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := get(); !errors.Is(err, limiter.ErrBreakerOpen) {
+		t.Fatalf("expected ErrBreakerOpen after 2 failures, got %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected open breaker to skip Slack, got %d calls", got)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	status = http.StatusOK
+	if err := get(); err != nil {
+		t.Fatalf("expected probe to pass after the cooldown, got %v", err)
+	}
+	if breaker.State() != limiter.BreakerClosed {
+		t.Errorf("expected breaker to close after a successful probe, got %s", breaker.State())
+	}
+}
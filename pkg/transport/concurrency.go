@@ -0,0 +1,62 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"go.uber.org/zap"
+)
+
+// ConcurrencyLimitTransport wraps another RoundTripper and bounds the number of
+// concurrent requests with a shared semaphore. A slot is held until the response
+// body is closed, so slow body reads count as in-flight as well.
+type ConcurrencyLimitTransport struct {
+	roundTripper http.RoundTripper
+	semaphore    *limiter.Semaphore
+	logger       *zap.Logger
+}
+
+// NewConcurrencyLimitTransport creates a new ConcurrencyLimitTransport
+func NewConcurrencyLimitTransport(roundTripper http.RoundTripper, semaphore *limiter.Semaphore, logger *zap.Logger) *ConcurrencyLimitTransport {
+	return &ConcurrencyLimitTransport{
+		roundTripper: roundTripper,
+		semaphore:    semaphore,
+		logger:       logger,
+	}
+}
+
+// RoundTrip implements the RoundTripper interface
+func (t *ConcurrencyLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.semaphore.Acquire(req.Context()); err != nil {
+		requestid.Logger(req.Context(), t.logger).Warn("Gave up waiting for a free Slack API call slot",
+			zap.String("url", req.URL.String()),
+			zap.Int("in_flight", t.semaphore.InFlight()),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	resp, err := t.roundTripper.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		t.semaphore.Release()
+		return resp, err
+	}
+
+	resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: t.semaphore.Release}
+	return resp, nil
+}
+
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,64 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
+	"go.uber.org/zap"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestConcurrencyLimitTransport(t *testing.T) {
+	const limit = 2
+
+	var current, peak atomic.Int64
+	inner := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		n := current.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		current.Add(-1)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+	})
+
+	sem := limiter.NewSemaphore(limit)
+	client := &http.Client{Transport: NewConcurrencyLimitTransport(inner, sem, zap.NewNop())}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get("http://slack.test/api/auth.test")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			_, _ = io.ReadAll(resp.Body)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > limit {
+		t.Errorf("expected at most %d concurrent requests, got %d", limit, got)
+	}
+	if got := sem.InFlight(); got != 0 {
+		t.Errorf("expected all slots to be released, got %d in flight", got)
+	}
+}
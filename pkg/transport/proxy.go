@@ -0,0 +1,73 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// proxyProbeURL is the request used to resolve HTTPS_PROXY and NO_PROXY for Slack API calls
+const proxyProbeURL = "https://slack.com/api/auth.test"
+
+// ParseProxyURL returns the proxy for outgoing Slack requests: SLACK_MCP_PROXY_URL, its older name
+// SLACK_MCP_PROXY, or else HTTPS_PROXY as net/http reads it, honoring NO_PROXY. SLACK_MCP_PROXY_USER and
+// SLACK_MCP_PROXY_PASSWORD set the proxy credentials instead of the user info of the URL.
+// nil means requests connect directly, a malformed proxy URL is an error.
+func ParseProxyURL() (*url.URL, error) {
+	name, raw := explicitProxy()
+
+	var (
+		proxyURL *url.URL
+		err      error
+	)
+	if raw != "" {
+		proxyURL, err = url.Parse(raw)
+	} else {
+		name = "HTTPS_PROXY"
+		req, _ := http.NewRequest(http.MethodGet, proxyProbeURL, nil)
+		proxyURL, err = http.ProxyFromEnvironment(req)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if proxyURL == nil {
+		return nil, nil
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid %s %q: scheme must be http, https or socks5", name, RedactProxyURL(proxyURL))
+	}
+	if proxyURL.Hostname() == "" {
+		return nil, fmt.Errorf("invalid %s %q: proxy host is missing", name, RedactProxyURL(proxyURL))
+	}
+
+	if user := os.Getenv("SLACK_MCP_PROXY_USER"); user != "" {
+		if password, ok := os.LookupEnv("SLACK_MCP_PROXY_PASSWORD"); ok {
+			proxyURL.User = url.UserPassword(user, password)
+		} else {
+			proxyURL.User = url.User(user)
+		}
+	}
+	return proxyURL, nil
+}
+
+// explicitProxy returns the name and value of the proxy variable set for this server, if any
+func explicitProxy() (name, value string) {
+	for _, name := range []string{"SLACK_MCP_PROXY_URL", "SLACK_MCP_PROXY"} {
+		if value := os.Getenv(name); value != "" {
+			return name, value
+		}
+	}
+	return "", ""
+}
+
+// RedactProxyURL returns the proxy URL without its password, for logs and health details
+func RedactProxyURL(proxyURL *url.URL) string {
+	if proxyURL == nil {
+		return ""
+	}
+	return proxyURL.Redacted()
+}
@@ -0,0 +1,108 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"sync"
+)
+
+const (
+	// maxRawResponses bounds the responses recorded for one tool call, later ones are only counted
+	maxRawResponses = 20
+	// maxRawBodyBytes bounds the recorded body of one response, the response itself is passed on whole
+	maxRawBodyBytes = 256 << 10
+)
+
+// RawResponse is a Slack Web API response as received, before slack-go decodes it
+type RawResponse struct {
+	Method string `json:"method"`
+	Status int    `json:"status"`
+	// Body is the JSON payload, Text holds bodies that are not JSON or were truncated
+	Body      json.RawMessage `json:"body,omitempty"`
+	Text      string          `json:"text,omitempty"`
+	Truncated bool            `json:"truncated,omitempty"`
+}
+
+// RawRecorder collects the raw Slack responses of the requests made with its context, see WithRawRecorder
+type RawRecorder struct {
+	mu        sync.Mutex
+	responses []RawResponse
+	dropped   int
+}
+
+type rawRecorderKey struct{}
+
+// WithRawRecorder makes RawResponseTransport record the responses of requests made with the returned context
+func WithRawRecorder(ctx context.Context, recorder *RawRecorder) context.Context {
+	return context.WithValue(ctx, rawRecorderKey{}, recorder)
+}
+
+// RawRecorderFromContext returns the recorder stored in the context or nil
+func RawRecorderFromContext(ctx context.Context) *RawRecorder {
+	if ctx == nil {
+		return nil
+	}
+	recorder, _ := ctx.Value(rawRecorderKey{}).(*RawRecorder)
+	return recorder
+}
+
+func (r *RawRecorder) record(resp RawResponse) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.responses) >= maxRawResponses {
+		r.dropped++
+		return
+	}
+	r.responses = append(r.responses, resp)
+}
+
+// Responses returns the recorded responses in request order and how many were dropped over maxRawResponses
+func (r *RawRecorder) Responses() ([]RawResponse, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RawResponse(nil), r.responses...), r.dropped
+}
+
+// RawResponseTransport copies the responses of requests whose context carries a RawRecorder into it.
+// Requests without a recorder, which is every request unless SLACK_MCP_DEBUG_RAW is on, pass through untouched.
+type RawResponseTransport struct {
+	roundTripper http.RoundTripper
+}
+
+// NewRawResponseTransport creates a new RawResponseTransport
+func NewRawResponseTransport(roundTripper http.RoundTripper) *RawResponseTransport {
+	return &RawResponseTransport{roundTripper: roundTripper}
+}
+
+// RoundTrip implements the RoundTripper interface
+func (t *RawResponseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.roundTripper.RoundTrip(req)
+	recorder := RawRecorderFromContext(req.Context())
+	if err != nil || recorder == nil || resp.Body == nil {
+		return resp, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	raw := RawResponse{Method: path.Base(req.URL.Path), Status: resp.StatusCode}
+	switch {
+	case len(data) > maxRawBodyBytes:
+		raw.Text = string(data[:maxRawBodyBytes])
+		raw.Truncated = true
+	case json.Valid(data):
+		raw.Body = json.RawMessage(data)
+	default:
+		raw.Text = string(data)
+	}
+	recorder.record(raw)
+	return resp, nil
+}
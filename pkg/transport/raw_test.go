@@ -0,0 +1,61 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRawResponseTransport(t *testing.T) {
+	body := `{"ok":true,"channel":{"id":"C0GENERAL"}}`
+	inner := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	client := &http.Client{Transport: NewRawResponseTransport(inner)}
+
+	get := func(ctx context.Context, url string) string {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return string(data)
+	}
+
+	if got := get(context.Background(), "https://slack.com/api/conversations.info"); got != body {
+		t.Errorf("expected the body to pass through without a recorder, got %q", got)
+	}
+
+	recorder := &RawRecorder{}
+	ctx := WithRawRecorder(context.Background(), recorder)
+	if got := get(ctx, "https://slack.com/api/conversations.info"); got != body {
+		t.Errorf("expected the recorded body to still reach the caller, got %q", got)
+	}
+	body = "<html>rate limited</html>"
+	get(ctx, "https://slack.com/api/users.info")
+
+	responses, dropped := recorder.Responses()
+	if len(responses) != 2 || dropped != 0 {
+		t.Fatalf("expected 2 recorded responses, got %d and %d dropped", len(responses), dropped)
+	}
+	if responses[0].Method != "conversations.info" || string(responses[0].Body) != `{"ok":true,"channel":{"id":"C0GENERAL"}}` {
+		t.Errorf("expected the JSON payload of conversations.info, got %+v", responses[0])
+	}
+	if responses[1].Method != "users.info" || responses[1].Body != nil || responses[1].Text != body {
+		t.Errorf("expected a body that is not JSON as text, got %+v", responses[1])
+	}
+
+	for i := 0; i < maxRawResponses; i++ {
+		get(ctx, "https://slack.com/api/users.info")
+	}
+	if responses, dropped = recorder.Responses(); len(responses) != maxRawResponses || dropped != 2 {
+		t.Errorf("expected %d responses and 2 dropped, got %d and %d", maxRawResponses, len(responses), dropped)
+	}
+}
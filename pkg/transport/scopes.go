@@ -0,0 +1,48 @@
+package transport
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ScopesTransport remembers the OAuth scopes Slack reports in the X-OAuth-Scopes header of
+// Web API responses. Browser session tokens (xoxc) get no such header.
+type ScopesTransport struct {
+	roundTripper http.RoundTripper
+
+	mu     sync.RWMutex
+	scopes []string
+}
+
+// NewScopesTransport creates a new ScopesTransport
+func NewScopesTransport(roundTripper http.RoundTripper) *ScopesTransport {
+	return &ScopesTransport{roundTripper: roundTripper}
+}
+
+// RoundTrip implements the RoundTripper interface
+func (t *ScopesTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.roundTripper.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if header := resp.Header.Get("X-OAuth-Scopes"); header != "" {
+		var scopes []string
+		for _, scope := range strings.Split(header, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+		t.mu.Lock()
+		t.scopes = scopes
+		t.mu.Unlock()
+	}
+	return resp, nil
+}
+
+// Scopes returns the scopes of the last response that reported them, nil if none did
+func (t *ScopesTransport) Scopes() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return append([]string(nil), t.scopes...)
+}
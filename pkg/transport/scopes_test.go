@@ -0,0 +1,44 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestScopesTransport(t *testing.T) {
+	header := ""
+	inner := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}"))}
+		if header != "" {
+			resp.Header.Set("X-OAuth-Scopes", header)
+		}
+		return resp, nil
+	})
+	st := NewScopesTransport(inner)
+	client := &http.Client{Transport: st}
+
+	if _, err := client.Get("https://slack.com/api/auth.test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scopes := st.Scopes(); scopes != nil {
+		t.Errorf("expected no scopes before Slack reports them, got %v", scopes)
+	}
+
+	header = "channels:history, chat:write,users:read"
+	if _, err := client.Get("https://slack.com/api/auth.test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(st.Scopes(), " "); got != "channels:history chat:write users:read" {
+		t.Errorf("expected the reported scopes, got %q", got)
+	}
+
+	header = ""
+	if _, err := client.Get("https://slack.com/api/conversations.list"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(st.Scopes()) != 3 {
+		t.Errorf("expected a response without the header to keep the scopes, got %v", st.Scopes())
+	}
+}
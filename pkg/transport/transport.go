@@ -15,7 +15,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
 	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/korotovsky/slack-mcp-server/pkg/version"
 	utls "github.com/refraction-networking/utls"
 	"go.uber.org/zap"
 	"golang.org/x/net/http2"
@@ -70,11 +72,12 @@ func (t *UserAgentTransport) RoundTrip(req *http.Request) (*http.Response, error
 		clonedReq.AddCookie(cookie)
 	}
 
-	t.logger.Debug("Making request", zap.String("url", clonedReq.URL.String()))
+	logger := requestid.Logger(req.Context(), t.logger)
+	logger.Debug("Making request", zap.String("url", clonedReq.URL.String()))
 
 	resp, err := t.roundTripper.RoundTrip(clonedReq)
 	if err != nil {
-		t.logger.Error("Request failed", zap.Error(err))
+		logger.Error("Request failed", zap.Error(err))
 	}
 	return resp, err
 }
@@ -333,22 +336,34 @@ func detectBrowserFromUserAgent(userAgent string) utls.ClientHelloID {
 	return utls.HelloChrome_Auto
 }
 
+// provideUserAgent returns SLACK_MCP_USER_AGENT if set. Otherwise browser session
+// tokens, which are sent with cookies, keep a browser User-Agent and all other
+// tokens identify the server as slack-mcp-server/<version>.
+func provideUserAgent(cookies []*http.Cookie) string {
+	if ua := os.Getenv("SLACK_MCP_USER_AGENT"); ua != "" {
+		return ua
+	}
+	if len(cookies) > 0 {
+		return defaultUA
+	}
+	return version.BinaryName + "/" + version.Version
+}
+
 // ProvideHTTPClient creates an HTTP client with optional uTLS support
 func ProvideHTTPClient(cookies []*http.Cookie, logger *zap.Logger) *http.Client {
-	if os.Getenv("SLACK_MCP_PROXY") != "" && os.Getenv("SLACK_MCP_CUSTOM_TLS") != "" {
-		logger.Fatal("SLACK_MCP_PROXY and SLACK_MCP_CUSTOM_TLS cannot be used together",
+	if name, _ := explicitProxy(); name != "" && os.Getenv("SLACK_MCP_CUSTOM_TLS") != "" {
+		logger.Fatal(name+" and SLACK_MCP_CUSTOM_TLS cannot be used together",
 			zap.String("reason", "Custom TLS fingerprinting has no effect when using a proxy, as the target server sees the proxy's TLS handshake"))
 	}
 
 	var proxy func(*http.Request) (*url.URL, error)
-	if proxyURL := os.Getenv("SLACK_MCP_PROXY"); proxyURL != "" {
-		parsed, err := url.Parse(proxyURL)
-		if err != nil {
-			logger.Fatal("Failed to parse proxy URL",
-				zap.String("proxy_url", proxyURL),
-				zap.Error(err))
-		}
-		proxy = http.ProxyURL(parsed)
+	proxyURL, err := ParseProxyURL()
+	if err != nil {
+		logger.Fatal("Failed to parse proxy URL", zap.Error(err))
+	}
+	if proxyURL != nil {
+		logger.Debug("Slack requests go through a proxy", zap.String("proxy_url", RedactProxyURL(proxyURL)))
+		proxy = http.ProxyURL(proxyURL)
 	}
 
 	rootCAs, _ := x509.SystemCertPool()
@@ -382,10 +397,7 @@ func ProvideHTTPClient(cookies []*http.Cookie, logger *zap.Logger) *http.Client
 		insecure = true
 	}
 
-	userAgent := defaultUA
-	if ua := os.Getenv("SLACK_MCP_USER_AGENT"); ua != "" {
-		userAgent = ua
-	}
+	userAgent := provideUserAgent(cookies)
 
 	var transport http.RoundTripper
 
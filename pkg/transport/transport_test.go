@@ -0,0 +1,27 @@
+package transport
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/version"
+)
+
+func TestProvideUserAgent(t *testing.T) {
+	cookies := []*http.Cookie{{Name: "d", Value: "xoxd-test"}}
+
+	t.Setenv("SLACK_MCP_USER_AGENT", "")
+	if got, want := provideUserAgent(nil), version.BinaryName+"/"+version.Version; got != want {
+		t.Errorf("expected %q for tokens without cookies, got %q", want, got)
+	}
+	if got := provideUserAgent(cookies); got != defaultUA {
+		t.Errorf("expected browser User-Agent for session tokens, got %q", got)
+	}
+
+	t.Setenv("SLACK_MCP_USER_AGENT", "acme-bot/1.0")
+	for _, c := range [][]*http.Cookie{nil, cookies} {
+		if got := provideUserAgent(c); got != "acme-bot/1.0" {
+			t.Errorf("expected SLACK_MCP_USER_AGENT to win, got %q", got)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with SLACK_MCP_WEBHOOK_SECRET
+	SignatureHeader = "X-Slack-MCP-Signature"
+	// TimestampHeader carries the unix time the signature was computed at, receivers should reject stale requests
+	TimestampHeader = "X-Slack-MCP-Timestamp"
+
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultTimeout        = 10 * time.Second
+	// dedupWindow is how long a sent event is remembered, a message posted by this server also comes back
+	// over Socket Mode and is forwarded once
+	dedupWindow = 10 * time.Minute
+)
+
+// Event is a Slack event forwarded to the webhook as JSON
+type Event struct {
+	Type      string `json:"type"`
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id,omitempty"`
+	Text      string `json:"text"`
+	Timestamp string `json:"ts"`
+	ThreadTs  string `json:"thread_ts,omitempty"`
+}
+
+type Config struct {
+	URL    string
+	Secret string
+	// Channels limits forwarded events to these channel IDs, empty forwards all channels
+	Channels []string
+	// Keywords limits forwarded events to texts containing any of them case-insensitively, empty forwards all texts
+	Keywords       []string
+	MaxAttempts    int
+	InitialBackoff time.Duration
+}
+
+// ConfigFromEnv reads the SLACK_MCP_WEBHOOK_* variables
+func ConfigFromEnv(logger *zap.Logger) Config {
+	config := Config{
+		URL:            strings.TrimSpace(os.Getenv("SLACK_MCP_WEBHOOK_URL")),
+		Secret:         os.Getenv("SLACK_MCP_WEBHOOK_SECRET"),
+		Channels:       splitList(os.Getenv("SLACK_MCP_WEBHOOK_CHANNELS")),
+		Keywords:       splitList(os.Getenv("SLACK_MCP_WEBHOOK_KEYWORDS")),
+		MaxAttempts:    defaultMaxAttempts,
+		InitialBackoff: defaultInitialBackoff,
+	}
+
+	if value := os.Getenv("SLACK_MCP_WEBHOOK_MAX_ATTEMPTS"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			logger.Warn("Invalid SLACK_MCP_WEBHOOK_MAX_ATTEMPTS, using default",
+				zap.String("value", value),
+				zap.Int("default", defaultMaxAttempts))
+		} else {
+			config.MaxAttempts = n
+		}
+	}
+
+	return config
+}
+
+// Sink posts matching events to an external HTTP endpoint
+type Sink struct {
+	config Config
+	client *http.Client
+	logger *zap.Logger
+	sleep  func(ctx context.Context, d time.Duration) error
+
+	mu   sync.Mutex
+	sent map[string]time.Time
+	now  func() time.Time
+}
+
+// New creates a sink, it returns nil when no URL is configured so callers can skip forwarding
+func New(config Config, logger *zap.Logger) *Sink {
+	if config.URL == "" {
+		return nil
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = defaultInitialBackoff
+	}
+	if config.Secret == "" {
+		logger.Warn("SLACK_MCP_WEBHOOK_SECRET is not set, webhook requests will not be signed")
+	}
+
+	return &Sink{
+		config: config,
+		client: &http.Client{Timeout: defaultTimeout},
+		logger: logger,
+		sleep:  sleepContext,
+		sent:   make(map[string]time.Time),
+		now:    time.Now,
+	}
+}
+
+// Matches reports whether the event passes the channel and keyword filters
+func (s *Sink) Matches(e Event) bool {
+	if len(s.config.Channels) > 0 && !contains(s.config.Channels, e.ChannelID) {
+		return false
+	}
+	if len(s.config.Keywords) == 0 {
+		return true
+	}
+	text := strings.ToLower(e.Text)
+	for _, k := range s.config.Keywords {
+		if strings.Contains(text, strings.ToLower(k)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Send posts the event if it matches the filters and was not sent within dedupWindow. Transport errors and
+// 5xx responses are retried with exponential backoff, the event is dropped with a logged error after MaxAttempts.
+func (s *Sink) Send(ctx context.Context, e Event) error {
+	if !s.Matches(e) || !s.claim(e) {
+		return nil
+	}
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+
+	backoff := s.config.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := s.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= s.config.MaxAttempts {
+			s.logger.Error("Dropping webhook event",
+				zap.String("channel", e.ChannelID),
+				zap.String("ts", e.Timestamp),
+				zap.Int("attempts", attempt),
+				zap.Error(err))
+			return err
+		}
+
+		s.logger.Warn("Webhook delivery failed, retrying",
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+		if err := s.sleep(ctx, backoff); err != nil {
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// claim reports whether e was not sent within dedupWindow and remembers it as sent
+func (s *Sink) claim(e Event) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, at := range s.sent {
+		if now.Sub(at) > dedupWindow {
+			delete(s.sent, key)
+		}
+	}
+	key := e.Type + "/" + e.ChannelID + "/" + e.Timestamp
+	if _, ok := s.sent[key]; ok {
+		return false
+	}
+	s.sent[key] = now
+	return true
+}
+
+// post delivers body once and reports whether a failure is worth retrying
+func (s *Sink) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.config.Secret != "" {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, ts)
+		req.Header.Set(SignatureHeader, Sign(s.config.Secret, ts, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook responded with %s", resp.Status)
+	case resp.StatusCode >= 300:
+		return false, fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return false, nil
+}
+
+// Sign returns the value of SignatureHeader for a body sent at timestamp ts
+func Sign(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func splitList(raw string) []string {
+	var res []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+func contains(items []string, v string) bool {
+	for _, item := range items {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
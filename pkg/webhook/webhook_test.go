@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestSink(t *testing.T, config Config) *Sink {
+	t.Helper()
+	s := New(config, zap.NewNop())
+	require.NotNil(t, s)
+	s.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+	return s
+}
+
+func TestNewWithoutURL(t *testing.T) {
+	assert.Nil(t, New(Config{}, zap.NewNop()))
+}
+
+func TestMatches(t *testing.T) {
+	s := newTestSink(t, Config{URL: "http://example.invalid", Channels: []string{"C1"}, Keywords: []string{"Deploy", "outage"}})
+
+	assert.True(t, s.Matches(Event{ChannelID: "C1", Text: "deploy finished"}))
+	assert.True(t, s.Matches(Event{ChannelID: "C1", Text: "OUTAGE in eu"}))
+	assert.False(t, s.Matches(Event{ChannelID: "C1", Text: "lunch?"}))
+	assert.False(t, s.Matches(Event{ChannelID: "C2", Text: "deploy finished"}))
+
+	all := newTestSink(t, Config{URL: "http://example.invalid"})
+	assert.True(t, all.Matches(Event{ChannelID: "C2", Text: "anything"}))
+}
+
+func TestSendSignsAndPosts(t *testing.T) {
+	var received Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, Sign("secret", r.Header.Get(TimestampHeader), body), r.Header.Get(SignatureHeader))
+		require.NoError(t, json.Unmarshal(body, &received))
+	}))
+	defer srv.Close()
+
+	s := newTestSink(t, Config{URL: srv.URL, Secret: "secret"})
+	event := Event{Type: "message", ChannelID: "C1", UserID: "U1", Text: "hello", Timestamp: "1.000001"}
+	require.NoError(t, s.Send(context.Background(), event))
+	assert.Equal(t, event, received)
+}
+
+func TestSendRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	s := newTestSink(t, Config{URL: srv.URL, MaxAttempts: 5})
+	require.NoError(t, s.Send(context.Background(), Event{ChannelID: "C1"}))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestSendDropsAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	s := newTestSink(t, Config{URL: srv.URL, MaxAttempts: 3})
+	assert.Error(t, s.Send(context.Background(), Event{ChannelID: "C1"}))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestSendDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	s := newTestSink(t, Config{URL: srv.URL, MaxAttempts: 3})
+	assert.Error(t, s.Send(context.Background(), Event{ChannelID: "C1"}))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("SLACK_MCP_WEBHOOK_URL", " https://hooks.example.com/slack ")
+	t.Setenv("SLACK_MCP_WEBHOOK_SECRET", "s3cret")
+	t.Setenv("SLACK_MCP_WEBHOOK_CHANNELS", "C1, C2,")
+	t.Setenv("SLACK_MCP_WEBHOOK_KEYWORDS", "deploy")
+	t.Setenv("SLACK_MCP_WEBHOOK_MAX_ATTEMPTS", "nope")
+
+	config := ConfigFromEnv(zap.NewNop())
+	assert.Equal(t, "https://hooks.example.com/slack", config.URL)
+	assert.Equal(t, []string{"C1", "C2"}, config.Channels)
+	assert.Equal(t, []string{"deploy"}, config.Keywords)
+	assert.Equal(t, defaultMaxAttempts, config.MaxAttempts)
+}
+
+func TestSendSkipsDuplicates(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	now := time.Unix(1700000000, 0)
+	s := newTestSink(t, Config{URL: srv.URL})
+	s.now = func() time.Time { return now }
+
+	event := Event{Type: "message", ChannelID: "C1", Text: "hello", Timestamp: "1.000001"}
+	require.NoError(t, s.Send(context.Background(), event))
+	require.NoError(t, s.Send(context.Background(), event))
+	assert.Equal(t, int32(1), calls.Load(), "an event sent twice is delivered once")
+
+	now = now.Add(dedupWindow + time.Second)
+	require.NoError(t, s.Send(context.Background(), event))
+	assert.Equal(t, int32(2), calls.Load(), "the event is delivered again after the window")
+}